@@ -0,0 +1,159 @@
+// Evaluates feed rankings offline against a database.
+//
+// Take a snapshot of the feed as it was at a point in time, along with the interactions that followed:
+//
+//	go run ./cmd/feedeval snapshot --as-of 2024-03-01T00:00:00Z --horizon 24h --out feed.json
+//
+// Then rank the snapshot with each scorer and report how well the rankings predicted those interactions:
+//
+//	go run ./cmd/feedeval eval feed.json -k 20
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/persist/postgres"
+	"github.com/mikeydub/go-gallery/service/recommend/feedeval"
+	"github.com/mikeydub/go-gallery/service/recommend/feedrank"
+	"github.com/mikeydub/go-gallery/service/recommend/userpref"
+)
+
+var (
+	asOf        string
+	horizon     time.Duration
+	out         string
+	k           int
+	maxViewers  int
+	personalize bool
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "feedeval",
+	Short: "Evaluate feed rankings offline",
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save the feed as it was at a point in time and the interactions that followed",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		at := time.Now().Add(-horizon)
+		if asOf != "" {
+			var err error
+			at, err = time.Parse(time.RFC3339, asOf)
+			if err != nil {
+				return fmt.Errorf("invalid --as-of: %w", err)
+			}
+		}
+
+		q := db.New(postgres.NewPgxClient())
+
+		logger.For(ctx).Infof("taking snapshot as of %s with a %s horizon", at.Format(time.RFC3339), horizon)
+		s, err := feedeval.TakeSnapshot(ctx, q, at, horizon)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if err := s.Write(f); err != nil {
+			return err
+		}
+
+		logger.For(ctx).Infof("wrote %d posts and %d interactions to %s", len(s.Entities), len(s.Interactions), out)
+		return nil
+	},
+}
+
+var evalCmd = &cobra.Command{
+	Use:   "eval [SNAPSHOT]",
+	Short: "Rank a snapshot with each scorer and report offline metrics",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		s, err := feedeval.ReadSnapshot(f)
+		if err != nil {
+			return err
+		}
+
+		var relevance feedrank.RelevanceFunc
+		if personalize {
+			// The matrices are built from the database as it is now, so they may include follows and displays
+			// made after the snapshot
+			logger.For(ctx).Info("building personalization matrices")
+			relevance = userpref.NewPersonalizationFromDB(ctx, db.New(postgres.NewPgxClient())).RelevanceTo
+		}
+
+		// Evaluate every ranker that's in an experiment
+		scorers := []feedeval.Scorer{feedeval.RecencyScorer}
+		seen := make(map[string]bool)
+		for _, e := range []feedrank.Experiment{feedrank.TrendingExperiment, feedrank.ForYouExperiment} {
+			for _, v := range e.Variants {
+				if !seen[v.Ranker.Name()] {
+					seen[v.Ranker.Name()] = true
+					scorers = append(scorers, feedeval.RankerScorer{Ranker: v.Ranker, Relevance: relevance, Diversity: feedrank.FeedDiversity})
+				}
+			}
+		}
+
+		results := feedeval.Evaluate(s, scorers, feedeval.Options{K: k, MaxViewers: maxViewers})
+
+		fmt.Printf("snapshot as of %s, %d posts, %d interactions over %s\n\n", s.AsOf.Format(time.RFC3339), len(s.Entities), len(s.Interactions), s.Horizon)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "scorer\tviewers\tndcg@%d\tglobal ndcg@%d\tdiversity@%d\tcreator coverage@%d\n", k, k, k, k)
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%d\t%.4f\t%.4f\t%.4f\t%.4f\n", r.Scorer, r.Viewers, r.NDCG, r.GlobalNDCG, r.Diversity, r.CreatorCoverage)
+		}
+		return w.Flush()
+	},
+}
+
+func main() {
+	setDefaults()
+
+	snapshotCmd.Flags().StringVar(&asOf, "as-of", "", "RFC 3339 time to rebuild the feed at (default now minus the horizon)")
+	snapshotCmd.Flags().DurationVar(&horizon, "horizon", 24*time.Hour, "how long after the snapshot to collect interactions for")
+	snapshotCmd.Flags().StringVarP(&out, "out", "o", "feed-snapshot.json", "file to write the snapshot to")
+
+	evalCmd.Flags().IntVarP(&k, "k", "k", 20, "number of posts at the top of each ranking to measure")
+	evalCmd.Flags().IntVar(&maxViewers, "max-viewers", 1000, "most viewers to evaluate, or 0 for all")
+	evalCmd.Flags().BoolVar(&personalize, "personalize", false, "build personalization matrices from the database for the for you scorer")
+
+	rootCmd.AddCommand(snapshotCmd, evalCmd)
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func setDefaults() {
+	viper.SetDefault("ENV", "local")
+	viper.SetDefault("POSTGRES_HOST", "0.0.0.0")
+	viper.SetDefault("POSTGRES_PORT", 5432)
+	viper.SetDefault("POSTGRES_USER", "gallery_backend")
+	viper.SetDefault("POSTGRES_PASSWORD", "")
+	viper.SetDefault("POSTGRES_DB", "postgres")
+	viper.AutomaticEnv()
+}
@@ -84,7 +84,7 @@ func (q *Queries) CreateTokenAdmire(ctx context.Context, arg CreateTokenAdmirePa
 }
 
 const deleteAdmireByID = `-- name: DeleteAdmireByID :exec
-update admires set deleted = true where id = $1
+update admires set deleted = true, last_updated = now() where id = $1
 `
 
 func (q *Queries) DeleteAdmireByID(ctx context.Context, id persist.DBID) error {
@@ -482,6 +482,69 @@ func (b *CountInteractionsByPostIDBatchBatchResults) Close() error {
 	return b.br.Close()
 }
 
+const countPollVotesByOptionBatch = `-- name: CountPollVotesByOptionBatch :batchmany
+select option_index, count(*) from poll_votes where attachment_id = $1 and not deleted group by option_index
+`
+
+type CountPollVotesByOptionBatchBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type CountPollVotesByOptionBatchRow struct {
+	OptionIndex int32 `db:"option_index" json:"option_index"`
+	Count       int64 `db:"count" json:"count"`
+}
+
+func (q *Queries) CountPollVotesByOptionBatch(ctx context.Context, attachmentID []persist.DBID) *CountPollVotesByOptionBatchBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range attachmentID {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(countPollVotesByOptionBatch, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &CountPollVotesByOptionBatchBatchResults{br, len(attachmentID), false}
+}
+
+func (b *CountPollVotesByOptionBatchBatchResults) Query(f func(int, []CountPollVotesByOptionBatchRow, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var items []CountPollVotesByOptionBatchRow
+		if b.closed {
+			if f != nil {
+				f(t, items, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		err := func() error {
+			rows, err := b.br.Query()
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var i CountPollVotesByOptionBatchRow
+				if err := rows.Scan(&i.OptionIndex, &i.Count); err != nil {
+					return err
+				}
+				items = append(items, i)
+			}
+			return rows.Err()
+		}()
+		if f != nil {
+			f(t, items, err)
+		}
+	}
+}
+
+func (b *CountPollVotesByOptionBatchBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const countRepliesByCommentIDBatch = `-- name: CountRepliesByCommentIDBatch :batchone
 SELECT count(*) FROM comments c 
 WHERE 
@@ -534,6 +597,55 @@ func (b *CountRepliesByCommentIDBatchBatchResults) Close() error {
 	return b.br.Close()
 }
 
+const countRepostsByPostIDBatch = `-- name: CountRepostsByPostIDBatch :batchone
+select count(*) from (
+  select id from reposts where reposts.post_id = $1 and not reposts.deleted
+  union all
+  select id from posts where posts.quoted_post_id = $1 and not posts.deleted
+) r
+`
+
+type CountRepostsByPostIDBatchBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+func (q *Queries) CountRepostsByPostIDBatch(ctx context.Context, postID []persist.DBID) *CountRepostsByPostIDBatchBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range postID {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(countRepostsByPostIDBatch, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &CountRepostsByPostIDBatchBatchResults{br, len(postID), false}
+}
+
+func (b *CountRepostsByPostIDBatchBatchResults) QueryRow(f func(int, int64, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var count int64
+		if b.closed {
+			if f != nil {
+				f(t, count, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		row := b.br.QueryRow()
+		err := row.Scan(&count)
+		if f != nil {
+			f(t, count, err)
+		}
+	}
+}
+
+func (b *CountRepostsByPostIDBatchBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const getAdmireByActorIDAndCommentID = `-- name: GetAdmireByActorIDAndCommentID :batchone
 SELECT id, version, feed_event_id, actor_id, deleted, created_at, last_updated, post_id, token_id, comment_id FROM admires WHERE actor_id = $1 AND comment_id = $2 AND deleted = false
 `
@@ -2520,7 +2632,7 @@ func (b *GetGalleryByIdBatchBatchResults) Close() error {
 }
 
 const getGalleryTokenMediasByGalleryIDBatch = `-- name: GetGalleryTokenMediasByGalleryIDBatch :batchmany
-select tm.id, tm.created_at, tm.last_updated, tm.version, tm.active, tm.media, tm.processing_job_id, tm.deleted, tm.chain, tm.contract_address, tm.token_id
+select tm.id, tm.created_at, tm.last_updated, tm.version, tm.active, tm.media, tm.processing_job_id, tm.deleted, tm.chain, tm.contract_address, tm.token_id, tm.pipeline_trace
 from galleries g, collections c, tokens t, token_medias tm, token_definitions td
 where
 	g.id = $1
@@ -2591,6 +2703,7 @@ func (b *GetGalleryTokenMediasByGalleryIDBatchBatchResults) Query(f func(int, []
 					&i.Chain,
 					&i.ContractAddress,
 					&i.TokenID,
+					&i.PipelineTrace,
 				); err != nil {
 					return err
 				}
@@ -2610,7 +2723,7 @@ func (b *GetGalleryTokenMediasByGalleryIDBatchBatchResults) Close() error {
 }
 
 const getMediaByMediaIdIgnoringStatusBatch = `-- name: GetMediaByMediaIdIgnoringStatusBatch :batchone
-select m.id, m.created_at, m.last_updated, m.version, m.active, m.media, m.processing_job_id, m.deleted, m.chain, m.contract_address, m.token_id from token_medias m where m.id = $1 and not deleted
+select m.id, m.created_at, m.last_updated, m.version, m.active, m.media, m.processing_job_id, m.deleted, m.chain, m.contract_address, m.token_id, m.pipeline_trace from token_medias m where m.id = $1 and not deleted
 `
 
 type GetMediaByMediaIdIgnoringStatusBatchBatchResults struct {
@@ -2654,6 +2767,7 @@ func (b *GetMediaByMediaIdIgnoringStatusBatchBatchResults) QueryRow(f func(int,
 			&i.Chain,
 			&i.ContractAddress,
 			&i.TokenID,
+			&i.PipelineTrace,
 		)
 		if f != nil {
 			f(t, i, err)
@@ -2666,6 +2780,58 @@ func (b *GetMediaByMediaIdIgnoringStatusBatchBatchResults) Close() error {
 	return b.br.Close()
 }
 
+const getMediaPreferencesByUserIDBatch = `-- name: GetMediaPreferencesByUserIDBatch :batchone
+select id, created_at, last_updated, deleted, user_id, flagged_media_display from user_media_preferences where user_id = $1 and not deleted
+`
+
+type GetMediaPreferencesByUserIDBatchBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+func (q *Queries) GetMediaPreferencesByUserIDBatch(ctx context.Context, userID []persist.DBID) *GetMediaPreferencesByUserIDBatchBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range userID {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(getMediaPreferencesByUserIDBatch, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &GetMediaPreferencesByUserIDBatchBatchResults{br, len(userID), false}
+}
+
+func (b *GetMediaPreferencesByUserIDBatchBatchResults) QueryRow(f func(int, UserMediaPreference, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var i UserMediaPreference
+		if b.closed {
+			if f != nil {
+				f(t, i, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		row := b.br.QueryRow()
+		err := row.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.UserID,
+			&i.FlaggedMediaDisplay,
+		)
+		if f != nil {
+			f(t, i, err)
+		}
+	}
+}
+
+func (b *GetMediaPreferencesByUserIDBatchBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const getMembershipByMembershipIdBatch = `-- name: GetMembershipByMembershipIdBatch :batchone
 SELECT id, deleted, version, created_at, last_updated, token_id, name, asset_url, owners FROM membership WHERE id = $1 AND deleted = false
 `
@@ -3117,8 +3283,128 @@ func (b *GetOwnersByContractIdBatchPaginateBatchResults) Close() error {
 	return b.br.Close()
 }
 
+const getPinnedCommentByPostIDBatch = `-- name: GetPinnedCommentByPostIDBatch :batchone
+select c.id, c.version, c.feed_event_id, c.actor_id, c.reply_to, c.comment, c.deleted, c.created_at, c.last_updated, c.post_id, c.removed, c.top_level_comment_id from pinned_comments pc
+    join comments c on c.id = pc.comment_id and not c.deleted
+where pc.post_id = $1 and not pc.deleted
+`
+
+type GetPinnedCommentByPostIDBatchBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+func (q *Queries) GetPinnedCommentByPostIDBatch(ctx context.Context, postID []persist.DBID) *GetPinnedCommentByPostIDBatchBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range postID {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(getPinnedCommentByPostIDBatch, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &GetPinnedCommentByPostIDBatchBatchResults{br, len(postID), false}
+}
+
+func (b *GetPinnedCommentByPostIDBatchBatchResults) QueryRow(f func(int, Comment, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var i Comment
+		if b.closed {
+			if f != nil {
+				f(t, i, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		row := b.br.QueryRow()
+		err := row.Scan(
+			&i.ID,
+			&i.Version,
+			&i.FeedEventID,
+			&i.ActorID,
+			&i.ReplyTo,
+			&i.Comment,
+			&i.Deleted,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.PostID,
+			&i.Removed,
+			&i.TopLevelCommentID,
+		)
+		if f != nil {
+			f(t, i, err)
+		}
+	}
+}
+
+func (b *GetPinnedCommentByPostIDBatchBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const getPostAttachmentByPostIDBatch = `-- name: GetPostAttachmentByPostIDBatch :batchone
+select id, created_at, last_updated, deleted, post_id, attachment_type, poll_options, poll_closes_at, link_url, link_title, link_description, link_image_url, link_site_name, community_id from post_attachments where post_id = $1 and not deleted
+`
+
+type GetPostAttachmentByPostIDBatchBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+func (q *Queries) GetPostAttachmentByPostIDBatch(ctx context.Context, postID []persist.DBID) *GetPostAttachmentByPostIDBatchBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range postID {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(getPostAttachmentByPostIDBatch, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &GetPostAttachmentByPostIDBatchBatchResults{br, len(postID), false}
+}
+
+func (b *GetPostAttachmentByPostIDBatchBatchResults) QueryRow(f func(int, PostAttachment, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var i PostAttachment
+		if b.closed {
+			if f != nil {
+				f(t, i, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		row := b.br.QueryRow()
+		err := row.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.PostID,
+			&i.AttachmentType,
+			&i.PollOptions,
+			&i.PollClosesAt,
+			&i.LinkUrl,
+			&i.LinkTitle,
+			&i.LinkDescription,
+			&i.LinkImageUrl,
+			&i.LinkSiteName,
+			&i.CommunityID,
+		)
+		if f != nil {
+			f(t, i, err)
+		}
+	}
+}
+
+func (b *GetPostAttachmentByPostIDBatchBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const getPostByIdBatch = `-- name: GetPostByIdBatch :batchone
-SELECT id, version, token_ids, contract_ids, actor_id, caption, created_at, last_updated, deleted, is_first_post, user_mint_url FROM posts WHERE id = $1 AND deleted = false
+SELECT id, version, token_ids, contract_ids, actor_id, caption, created_at, last_updated, deleted, is_first_post, user_mint_url, quoted_post_id, edited_at FROM posts WHERE id = $1 AND deleted = false
 `
 
 type GetPostByIdBatchBatchResults struct {
@@ -3162,6 +3448,8 @@ func (b *GetPostByIdBatchBatchResults) QueryRow(f func(int, Post, error)) {
 			&i.Deleted,
 			&i.IsFirstPost,
 			&i.UserMintUrl,
+			&i.QuotedPostID,
+			&i.EditedAt,
 		)
 		if f != nil {
 			f(t, i, err)
@@ -3174,8 +3462,72 @@ func (b *GetPostByIdBatchBatchResults) Close() error {
 	return b.br.Close()
 }
 
+const getPostRevisionsByPostID = `-- name: GetPostRevisionsByPostID :batchmany
+select id, created_at, post_id, caption, written_at from post_revisions where post_id = $1 order by created_at desc
+`
+
+type GetPostRevisionsByPostIDBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+func (q *Queries) GetPostRevisionsByPostID(ctx context.Context, postID []persist.DBID) *GetPostRevisionsByPostIDBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range postID {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(getPostRevisionsByPostID, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &GetPostRevisionsByPostIDBatchResults{br, len(postID), false}
+}
+
+func (b *GetPostRevisionsByPostIDBatchResults) Query(f func(int, []PostRevision, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var items []PostRevision
+		if b.closed {
+			if f != nil {
+				f(t, items, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		err := func() error {
+			rows, err := b.br.Query()
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var i PostRevision
+				if err := rows.Scan(
+					&i.ID,
+					&i.CreatedAt,
+					&i.PostID,
+					&i.Caption,
+					&i.WrittenAt,
+				); err != nil {
+					return err
+				}
+				items = append(items, i)
+			}
+			return rows.Err()
+		}()
+		if f != nil {
+			f(t, items, err)
+		}
+	}
+}
+
+func (b *GetPostRevisionsByPostIDBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const getPostsByIdsPaginateBatch = `-- name: GetPostsByIdsPaginateBatch :batchmany
-select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url
+select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url, posts.quoted_post_id, posts.edited_at
 from posts
 join unnest($1::varchar[]) with ordinality t(id, pos) using(id)
 where not posts.deleted and t.pos > $2::int and t.pos < $3::int
@@ -3238,6 +3590,8 @@ func (b *GetPostsByIdsPaginateBatchBatchResults) Query(f func(int, []Post, error
 					&i.Deleted,
 					&i.IsFirstPost,
 					&i.UserMintUrl,
+					&i.QuotedPostID,
+					&i.EditedAt,
 				); err != nil {
 					return err
 				}
@@ -3330,6 +3684,58 @@ func (b *GetProfileImageByIdBatchBatchResults) Close() error {
 	return b.br.Close()
 }
 
+const getRepostByIdBatch = `-- name: GetRepostByIdBatch :batchone
+select id, created_at, last_updated, deleted, actor_id, post_id from reposts where id = $1 and not deleted
+`
+
+type GetRepostByIdBatchBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+func (q *Queries) GetRepostByIdBatch(ctx context.Context, id []persist.DBID) *GetRepostByIdBatchBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range id {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(getRepostByIdBatch, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &GetRepostByIdBatchBatchResults{br, len(id), false}
+}
+
+func (b *GetRepostByIdBatchBatchResults) QueryRow(f func(int, Repost, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var i Repost
+		if b.closed {
+			if f != nil {
+				f(t, i, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		row := b.br.QueryRow()
+		err := row.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.ActorID,
+			&i.PostID,
+		)
+		if f != nil {
+			f(t, i, err)
+		}
+	}
+}
+
+func (b *GetRepostByIdBatchBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const getSharedCommunitiesBatchPaginate = `-- name: GetSharedCommunitiesBatchPaginate :batchmany
 select communities.id, communities.version, communities.community_type, communities.key1, communities.key2, communities.key3, communities.key4, communities.name, communities.override_name, communities.description, communities.override_description, communities.profile_image_url, communities.override_profile_image_url, communities.badge_url, communities.override_badge_url, communities.contract_id, communities.created_at, communities.last_updated, communities.deleted, communities.website_url, communities.override_website_url, communities.mint_url, communities.override_mint_url, a.displayed as displayed_by_user_a, b.displayed as displayed_by_user_b, a.owned_count
 from owned_communities a, owned_communities b, communities
@@ -3899,7 +4305,7 @@ func (b *GetTokenByUserTokenIdentifiersBatchBatchResults) Close() error {
 }
 
 const getTokenByUserTokenIdentifiersIgnoreDisplayableBatch = `-- name: GetTokenByUserTokenIdentifiersIgnoreDisplayableBatch :batchone
-select t.id, t.deleted, t.version, t.created_at, t.last_updated, t.collectors_note, t.quantity, t.block_number, t.owner_user_id, t.owned_by_wallets, t.contract_id, t.is_user_marked_spam, t.last_synced, t.is_creator_token, t.token_definition_id, t.is_holder_token, t.displayable, td.id, td.created_at, td.last_updated, td.deleted, td.name, td.description, td.token_type, td.token_id, td.external_url, td.chain, td.metadata, td.fallback_media, td.contract_address, td.contract_id, td.token_media_id, td.is_fxhash, c.id, c.deleted, c.version, c.created_at, c.last_updated, c.name, c.symbol, c.address, c.creator_address, c.chain, c.profile_banner_url, c.profile_image_url, c.badge_url, c.description, c.owner_address, c.is_provider_marked_spam, c.parent_id, c.override_creator_user_id, c.l1_chain, tm.id, tm.created_at, tm.last_updated, tm.version, tm.active, tm.media, tm.processing_job_id, tm.deleted, tm.chain, tm.contract_address, tm.token_id
+select t.id, t.deleted, t.version, t.created_at, t.last_updated, t.collectors_note, t.quantity, t.block_number, t.owner_user_id, t.owned_by_wallets, t.contract_id, t.is_user_marked_spam, t.last_synced, t.is_creator_token, t.token_definition_id, t.is_holder_token, t.displayable, td.id, td.created_at, td.last_updated, td.deleted, td.name, td.description, td.token_type, td.token_id, td.external_url, td.chain, td.metadata, td.fallback_media, td.contract_address, td.contract_id, td.token_media_id, td.is_fxhash, c.id, c.deleted, c.version, c.created_at, c.last_updated, c.name, c.symbol, c.address, c.creator_address, c.chain, c.profile_banner_url, c.profile_image_url, c.badge_url, c.description, c.owner_address, c.is_provider_marked_spam, c.parent_id, c.override_creator_user_id, c.l1_chain, tm.id, tm.created_at, tm.last_updated, tm.version, tm.active, tm.media, tm.processing_job_id, tm.deleted, tm.chain, tm.contract_address, tm.token_id, tm.pipeline_trace
 from tokens t, token_definitions td, contracts c, token_medias tm
 where t.token_definition_id = td.id
     and td.contract_id = c.id
@@ -4024,6 +4430,7 @@ func (b *GetTokenByUserTokenIdentifiersIgnoreDisplayableBatchBatchResults) Query
 			&i.TokenMedia.Chain,
 			&i.TokenMedia.ContractAddress,
 			&i.TokenMedia.TokenID,
+			&i.TokenMedia.PipelineTrace,
 		)
 		if f != nil {
 			f(t, i, err)
@@ -4165,6 +4572,156 @@ func (b *GetTokenDefinitionByTokenDbidBatchBatchResults) Close() error {
 	return b.br.Close()
 }
 
+const getTokenDefinitionRarityBatch = `-- name: GetTokenDefinitionRarityBatch :batchone
+select tdr.community_id, tdr.statistical_score, tdr.normalized_score, tdr.rank, cti.token_count as total from token_definition_rarities tdr
+    join communities c on c.id = tdr.community_id
+    join community_trait_indexes cti on cti.community_id = tdr.community_id
+where tdr.token_definition_id = $1 and ($2::dbid = '' or tdr.community_id = $2) and not c.deleted
+order by c.community_type = 0, c.id
+limit 1
+`
+
+type GetTokenDefinitionRarityBatchBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type GetTokenDefinitionRarityBatchParams struct {
+	TokenDefinitionID persist.DBID `db:"token_definition_id" json:"token_definition_id"`
+	CommunityID       persist.DBID `db:"community_id" json:"community_id"`
+}
+
+type GetTokenDefinitionRarityBatchRow struct {
+	CommunityID      persist.DBID `db:"community_id" json:"community_id"`
+	StatisticalScore float64      `db:"statistical_score" json:"statistical_score"`
+	NormalizedScore  float64      `db:"normalized_score" json:"normalized_score"`
+	Rank             int32        `db:"rank" json:"rank"`
+	Total            int32        `db:"total" json:"total"`
+}
+
+func (q *Queries) GetTokenDefinitionRarityBatch(ctx context.Context, arg []GetTokenDefinitionRarityBatchParams) *GetTokenDefinitionRarityBatchBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.TokenDefinitionID,
+			a.CommunityID,
+		}
+		batch.Queue(getTokenDefinitionRarityBatch, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &GetTokenDefinitionRarityBatchBatchResults{br, len(arg), false}
+}
+
+func (b *GetTokenDefinitionRarityBatchBatchResults) QueryRow(f func(int, GetTokenDefinitionRarityBatchRow, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var i GetTokenDefinitionRarityBatchRow
+		if b.closed {
+			if f != nil {
+				f(t, i, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		row := b.br.QueryRow()
+		err := row.Scan(
+			&i.CommunityID,
+			&i.StatisticalScore,
+			&i.NormalizedScore,
+			&i.Rank,
+			&i.Total,
+		)
+		if f != nil {
+			f(t, i, err)
+		}
+	}
+}
+
+func (b *GetTokenDefinitionRarityBatchBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const getTokenDefinitionTraitsBatch = `-- name: GetTokenDefinitionTraitsBatch :batchmany
+select tdt.trait_type, tdt.value, ct.token_count, cti.token_count as total from token_definition_traits tdt
+    join community_traits ct on ct.community_id = tdt.community_id and ct.trait_type = tdt.trait_type and ct.value = tdt.value
+    join community_trait_indexes cti on cti.community_id = tdt.community_id
+where tdt.community_id = $1 and tdt.token_definition_id = $2
+order by tdt.trait_type, tdt.value
+`
+
+type GetTokenDefinitionTraitsBatchBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type GetTokenDefinitionTraitsBatchParams struct {
+	CommunityID       persist.DBID `db:"community_id" json:"community_id"`
+	TokenDefinitionID persist.DBID `db:"token_definition_id" json:"token_definition_id"`
+}
+
+type GetTokenDefinitionTraitsBatchRow struct {
+	TraitType  string `db:"trait_type" json:"trait_type"`
+	Value      string `db:"value" json:"value"`
+	TokenCount int32  `db:"token_count" json:"token_count"`
+	Total      int32  `db:"total" json:"total"`
+}
+
+func (q *Queries) GetTokenDefinitionTraitsBatch(ctx context.Context, arg []GetTokenDefinitionTraitsBatchParams) *GetTokenDefinitionTraitsBatchBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.CommunityID,
+			a.TokenDefinitionID,
+		}
+		batch.Queue(getTokenDefinitionTraitsBatch, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &GetTokenDefinitionTraitsBatchBatchResults{br, len(arg), false}
+}
+
+func (b *GetTokenDefinitionTraitsBatchBatchResults) Query(f func(int, []GetTokenDefinitionTraitsBatchRow, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var items []GetTokenDefinitionTraitsBatchRow
+		if b.closed {
+			if f != nil {
+				f(t, items, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		err := func() error {
+			rows, err := b.br.Query()
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var i GetTokenDefinitionTraitsBatchRow
+				if err := rows.Scan(
+					&i.TraitType,
+					&i.Value,
+					&i.TokenCount,
+					&i.Total,
+				); err != nil {
+					return err
+				}
+				items = append(items, i)
+			}
+			return rows.Err()
+		}()
+		if f != nil {
+			f(t, items, err)
+		}
+	}
+}
+
+func (b *GetTokenDefinitionTraitsBatchBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const getTokensByCollectionIdBatch = `-- name: GetTokensByCollectionIdBatch :batchmany
 select t.id, t.deleted, t.version, t.created_at, t.last_updated, t.collectors_note, t.quantity, t.block_number, t.owner_user_id, t.owned_by_wallets, t.contract_id, t.is_user_marked_spam, t.last_synced, t.is_creator_token, t.token_definition_id, t.is_holder_token, t.displayable from collections c,
     unnest(c.nfts) with ordinality as u(nft_id, nft_ord)
@@ -5708,12 +6265,26 @@ func (b *PaginateCommentsByFeedEventIDBatchBatchResults) Close() error {
 }
 
 const paginateCommentsByPostIDBatch = `-- name: PaginateCommentsByPostIDBatch :batchmany
-SELECT id, version, feed_event_id, actor_id, reply_to, comment, deleted, created_at, last_updated, post_id, removed, top_level_comment_id FROM comments WHERE post_id = $1 AND reply_to is null AND deleted = false
-    AND (created_at, id) < ($2, $3::dbid)
-    AND (created_at, id) > ($4, $5::dbid)
-    ORDER BY CASE WHEN $6::bool THEN (created_at, id) END ASC,
-             CASE WHEN NOT $6::bool THEN (created_at, id) END DESC
-    LIMIT $7
+select c.id, c.version, c.feed_event_id, c.actor_id, c.reply_to, c.comment, c.deleted, c.created_at, c.last_updated, c.post_id, c.removed, c.top_level_comment_id, (pc.id is null)::bool as not_pinned, k.sort_key::float8 as sort_key
+from comments c
+    left join pinned_comments pc on pc.comment_id = c.id and pc.post_id = c.post_id and not pc.deleted
+    cross join lateral (
+        select case
+            when $1::varchar = 'TOP' then -(
+                select count(*) from admires a
+                where a.comment_id = c.id and a.created_at <= $2::timestamptz
+                    and (not a.deleted or a.last_updated > $2::timestamptz)
+            )
+            when $1::varchar = 'NEWEST' then -extract(epoch from c.created_at)
+            else extract(epoch from c.created_at)
+        end::float8 as sort_key
+    ) k
+where c.post_id = $3 and c.reply_to is null and not c.deleted
+    and (pc.id is null, c.post_id, k.sort_key, c.id) < ($4::bool, $3, $5::float8, $6::dbid)
+    and (pc.id is null, c.post_id, k.sort_key, c.id) > ($7::bool, $3, $8::float8, $9::dbid)
+order by case when $10::bool then (pc.id is null, c.post_id, k.sort_key, c.id) end asc,
+         case when not $10::bool then (pc.id is null, c.post_id, k.sort_key, c.id) end desc
+limit $11
 `
 
 type PaginateCommentsByPostIDBatchBatchResults struct {
@@ -5723,23 +6294,37 @@ type PaginateCommentsByPostIDBatchBatchResults struct {
 }
 
 type PaginateCommentsByPostIDBatchParams struct {
-	PostID        persist.DBID `db:"post_id" json:"post_id"`
-	CurBeforeTime time.Time    `db:"cur_before_time" json:"cur_before_time"`
-	CurBeforeID   persist.DBID `db:"cur_before_id" json:"cur_before_id"`
-	CurAfterTime  time.Time    `db:"cur_after_time" json:"cur_after_time"`
-	CurAfterID    persist.DBID `db:"cur_after_id" json:"cur_after_id"`
-	PagingForward bool         `db:"paging_forward" json:"paging_forward"`
-	Limit         int32        `db:"limit" json:"limit"`
+	SortBy             string       `db:"sort_by" json:"sort_by"`
+	SnapshotTime       time.Time    `db:"snapshot_time" json:"snapshot_time"`
+	PostID             persist.DBID `db:"post_id" json:"post_id"`
+	CurBeforeNotPinned bool         `db:"cur_before_not_pinned" json:"cur_before_not_pinned"`
+	CurBeforeSortKey   float64      `db:"cur_before_sort_key" json:"cur_before_sort_key"`
+	CurBeforeID        persist.DBID `db:"cur_before_id" json:"cur_before_id"`
+	CurAfterNotPinned  bool         `db:"cur_after_not_pinned" json:"cur_after_not_pinned"`
+	CurAfterSortKey    float64      `db:"cur_after_sort_key" json:"cur_after_sort_key"`
+	CurAfterID         persist.DBID `db:"cur_after_id" json:"cur_after_id"`
+	PagingForward      bool         `db:"paging_forward" json:"paging_forward"`
+	Limit              int32        `db:"limit" json:"limit"`
+}
+
+type PaginateCommentsByPostIDBatchRow struct {
+	Comment   Comment `db:"comment" json:"comment"`
+	NotPinned bool    `db:"not_pinned" json:"not_pinned"`
+	SortKey   float64 `db:"sort_key" json:"sort_key"`
 }
 
 func (q *Queries) PaginateCommentsByPostIDBatch(ctx context.Context, arg []PaginateCommentsByPostIDBatchParams) *PaginateCommentsByPostIDBatchBatchResults {
 	batch := &pgx.Batch{}
 	for _, a := range arg {
 		vals := []interface{}{
+			a.SortBy,
+			a.SnapshotTime,
 			a.PostID,
-			a.CurBeforeTime,
+			a.CurBeforeNotPinned,
+			a.CurBeforeSortKey,
 			a.CurBeforeID,
-			a.CurAfterTime,
+			a.CurAfterNotPinned,
+			a.CurAfterSortKey,
 			a.CurAfterID,
 			a.PagingForward,
 			a.Limit,
@@ -5750,10 +6335,10 @@ func (q *Queries) PaginateCommentsByPostIDBatch(ctx context.Context, arg []Pagin
 	return &PaginateCommentsByPostIDBatchBatchResults{br, len(arg), false}
 }
 
-func (b *PaginateCommentsByPostIDBatchBatchResults) Query(f func(int, []Comment, error)) {
+func (b *PaginateCommentsByPostIDBatchBatchResults) Query(f func(int, []PaginateCommentsByPostIDBatchRow, error)) {
 	defer b.br.Close()
 	for t := 0; t < b.tot; t++ {
-		var items []Comment
+		var items []PaginateCommentsByPostIDBatchRow
 		if b.closed {
 			if f != nil {
 				f(t, items, ErrBatchAlreadyClosed)
@@ -5767,20 +6352,22 @@ func (b *PaginateCommentsByPostIDBatchBatchResults) Query(f func(int, []Comment,
 			}
 			defer rows.Close()
 			for rows.Next() {
-				var i Comment
+				var i PaginateCommentsByPostIDBatchRow
 				if err := rows.Scan(
-					&i.ID,
-					&i.Version,
-					&i.FeedEventID,
-					&i.ActorID,
-					&i.ReplyTo,
-					&i.Comment,
-					&i.Deleted,
-					&i.CreatedAt,
-					&i.LastUpdated,
-					&i.PostID,
-					&i.Removed,
-					&i.TopLevelCommentID,
+					&i.Comment.ID,
+					&i.Comment.Version,
+					&i.Comment.FeedEventID,
+					&i.Comment.ActorID,
+					&i.Comment.ReplyTo,
+					&i.Comment.Comment,
+					&i.Comment.Deleted,
+					&i.Comment.CreatedAt,
+					&i.Comment.LastUpdated,
+					&i.Comment.PostID,
+					&i.Comment.Removed,
+					&i.Comment.TopLevelCommentID,
+					&i.NotPinned,
+					&i.SortKey,
 				); err != nil {
 					return err
 				}
@@ -6136,23 +6723,24 @@ with community_data as (
 )
 
 (
-select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url
+select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url, posts.quoted_post_id, posts.edited_at
     from community_data, posts
     where community_data.community_type = 0
         and community_data.contract_id = any(posts.contract_ids)
         and posts.deleted = false
         and (posts.created_at, posts.id) < ($2, $3::dbid)
         and (posts.created_at, posts.id) > ($4, $5::dbid)
+        and not exists (select 1 from muted_posts where muted_posts.user_id = $6 and muted_posts.post_id = posts.id)
     order by
-        case when $6::bool then (posts.created_at, posts.id) end asc,
-        case when not $6::bool then (posts.created_at, posts.id) end desc
-    limit $7
+        case when $7::bool then (posts.created_at, posts.id) end asc,
+        case when not $7::bool then (posts.created_at, posts.id) end desc
+    limit $8
 )
 
 union all
 
 (
-select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url
+select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url, posts.quoted_post_id, posts.edited_at
     from community_data, posts
         join tokens on tokens.id = any(posts.token_ids) and not tokens.deleted
         join token_community_memberships on tokens.token_definition_id = token_community_memberships.token_definition_id
@@ -6162,10 +6750,11 @@ select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor
       and posts.deleted = false
       and (posts.created_at, posts.id) < ($2, $3::dbid)
       and (posts.created_at, posts.id) > ($4, $5::dbid)
+      and not exists (select 1 from muted_posts where muted_posts.user_id = $6 and muted_posts.post_id = posts.id)
     order by
-        case when $6::bool then (posts.created_at, posts.id) end asc,
-        case when not $6::bool then (posts.created_at, posts.id) end desc
-    limit $7
+        case when $7::bool then (posts.created_at, posts.id) end asc,
+        case when not $7::bool then (posts.created_at, posts.id) end desc
+    limit $8
 )
 `
 
@@ -6181,6 +6770,7 @@ type PaginatePostsByCommunityIDParams struct {
 	CurBeforeID   persist.DBID `db:"cur_before_id" json:"cur_before_id"`
 	CurAfterTime  time.Time    `db:"cur_after_time" json:"cur_after_time"`
 	CurAfterID    persist.DBID `db:"cur_after_id" json:"cur_after_id"`
+	ViewerID      persist.DBID `db:"viewer_id" json:"viewer_id"`
 	PagingForward bool         `db:"paging_forward" json:"paging_forward"`
 	Limit         int32        `db:"limit" json:"limit"`
 }
@@ -6194,6 +6784,7 @@ func (q *Queries) PaginatePostsByCommunityID(ctx context.Context, arg []Paginate
 			a.CurBeforeID,
 			a.CurAfterTime,
 			a.CurAfterID,
+			a.ViewerID,
 			a.PagingForward,
 			a.Limit,
 		}
@@ -6233,6 +6824,8 @@ func (b *PaginatePostsByCommunityIDBatchResults) Query(f func(int, []Post, error
 					&i.Deleted,
 					&i.IsFirstPost,
 					&i.UserMintUrl,
+					&i.QuotedPostID,
+					&i.EditedAt,
 				); err != nil {
 					return err
 				}
@@ -6252,7 +6845,7 @@ func (b *PaginatePostsByCommunityIDBatchResults) Close() error {
 }
 
 const paginatePostsByContractID = `-- name: PaginatePostsByContractID :batchmany
-SELECT posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url
+SELECT posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url, posts.quoted_post_id, posts.edited_at
 FROM posts
 WHERE $1::dbid = ANY(posts.contract_ids)
 AND posts.deleted = false
@@ -6328,6 +6921,8 @@ func (b *PaginatePostsByContractIDBatchResults) Query(f func(int, []Post, error)
 					&i.Deleted,
 					&i.IsFirstPost,
 					&i.UserMintUrl,
+					&i.QuotedPostID,
+					&i.EditedAt,
 				); err != nil {
 					return err
 				}
@@ -6347,24 +6942,31 @@ func (b *PaginatePostsByContractIDBatchResults) Close() error {
 }
 
 const paginateRepliesByCommentIDBatch = `-- name: PaginateRepliesByCommentIDBatch :batchmany
-SELECT id, version, feed_event_id, actor_id, reply_to, comment, deleted, created_at, last_updated, post_id, removed, top_level_comment_id FROM comments c 
-WHERE 
-    CASE 
-        WHEN (SELECT reply_to FROM comments cc WHERE cc.id = $1) IS NULL 
-        THEN c.top_level_comment_id = $1 
-        ELSE c.reply_to = $1 
-    END
-    AND c.deleted = false
-    AND (c.created_at, c.id) < ($2, $3::dbid)
-    AND (c.created_at, c.id) > ($4, $5::dbid)
-ORDER BY 
-    CASE 
-        WHEN $6::bool THEN (c.created_at, c.id) 
-    END ASC,
-    CASE 
-        WHEN NOT $6::bool THEN (c.created_at, c.id) 
-    END DESC
-LIMIT $7
+select c.id, c.version, c.feed_event_id, c.actor_id, c.reply_to, c.comment, c.deleted, c.created_at, c.last_updated, c.post_id, c.removed, c.top_level_comment_id, k.sort_key::float8 as sort_key
+from comments c
+    cross join lateral (
+        select case
+            when $1::varchar = 'TOP' then -(
+                select count(*) from admires a
+                where a.comment_id = c.id and a.created_at <= $2::timestamptz
+                    and (not a.deleted or a.last_updated > $2::timestamptz)
+            )
+            when $1::varchar = 'NEWEST' then -extract(epoch from c.created_at)
+            else extract(epoch from c.created_at)
+        end::float8 as sort_key
+    ) k
+where
+    case
+        when (select reply_to from comments cc where cc.id = $3) is null
+        then c.top_level_comment_id = $3
+        else c.reply_to = $3
+    end
+    and c.deleted = false
+    and (k.sort_key, c.id) < ($4::float8, $5::dbid)
+    and (k.sort_key, c.id) > ($6::float8, $7::dbid)
+order by case when $8::bool then (k.sort_key, c.id) end asc,
+         case when not $8::bool then (k.sort_key, c.id) end desc
+limit $9
 `
 
 type PaginateRepliesByCommentIDBatchBatchResults struct {
@@ -6374,23 +6976,32 @@ type PaginateRepliesByCommentIDBatchBatchResults struct {
 }
 
 type PaginateRepliesByCommentIDBatchParams struct {
-	CommentID     persist.DBID `db:"comment_id" json:"comment_id"`
-	CurBeforeTime time.Time    `db:"cur_before_time" json:"cur_before_time"`
-	CurBeforeID   persist.DBID `db:"cur_before_id" json:"cur_before_id"`
-	CurAfterTime  time.Time    `db:"cur_after_time" json:"cur_after_time"`
-	CurAfterID    persist.DBID `db:"cur_after_id" json:"cur_after_id"`
-	PagingForward bool         `db:"paging_forward" json:"paging_forward"`
-	Limit         int32        `db:"limit" json:"limit"`
+	SortBy           string       `db:"sort_by" json:"sort_by"`
+	SnapshotTime     time.Time    `db:"snapshot_time" json:"snapshot_time"`
+	CommentID        persist.DBID `db:"comment_id" json:"comment_id"`
+	CurBeforeSortKey float64      `db:"cur_before_sort_key" json:"cur_before_sort_key"`
+	CurBeforeID      persist.DBID `db:"cur_before_id" json:"cur_before_id"`
+	CurAfterSortKey  float64      `db:"cur_after_sort_key" json:"cur_after_sort_key"`
+	CurAfterID       persist.DBID `db:"cur_after_id" json:"cur_after_id"`
+	PagingForward    bool         `db:"paging_forward" json:"paging_forward"`
+	Limit            int32        `db:"limit" json:"limit"`
+}
+
+type PaginateRepliesByCommentIDBatchRow struct {
+	Comment Comment `db:"comment" json:"comment"`
+	SortKey float64 `db:"sort_key" json:"sort_key"`
 }
 
 func (q *Queries) PaginateRepliesByCommentIDBatch(ctx context.Context, arg []PaginateRepliesByCommentIDBatchParams) *PaginateRepliesByCommentIDBatchBatchResults {
 	batch := &pgx.Batch{}
 	for _, a := range arg {
 		vals := []interface{}{
+			a.SortBy,
+			a.SnapshotTime,
 			a.CommentID,
-			a.CurBeforeTime,
+			a.CurBeforeSortKey,
 			a.CurBeforeID,
-			a.CurAfterTime,
+			a.CurAfterSortKey,
 			a.CurAfterID,
 			a.PagingForward,
 			a.Limit,
@@ -6401,10 +7012,10 @@ func (q *Queries) PaginateRepliesByCommentIDBatch(ctx context.Context, arg []Pag
 	return &PaginateRepliesByCommentIDBatchBatchResults{br, len(arg), false}
 }
 
-func (b *PaginateRepliesByCommentIDBatchBatchResults) Query(f func(int, []Comment, error)) {
+func (b *PaginateRepliesByCommentIDBatchBatchResults) Query(f func(int, []PaginateRepliesByCommentIDBatchRow, error)) {
 	defer b.br.Close()
 	for t := 0; t < b.tot; t++ {
-		var items []Comment
+		var items []PaginateRepliesByCommentIDBatchRow
 		if b.closed {
 			if f != nil {
 				f(t, items, ErrBatchAlreadyClosed)
@@ -6418,20 +7029,21 @@ func (b *PaginateRepliesByCommentIDBatchBatchResults) Query(f func(int, []Commen
 			}
 			defer rows.Close()
 			for rows.Next() {
-				var i Comment
+				var i PaginateRepliesByCommentIDBatchRow
 				if err := rows.Scan(
-					&i.ID,
-					&i.Version,
-					&i.FeedEventID,
-					&i.ActorID,
-					&i.ReplyTo,
-					&i.Comment,
-					&i.Deleted,
-					&i.CreatedAt,
-					&i.LastUpdated,
-					&i.PostID,
-					&i.Removed,
-					&i.TopLevelCommentID,
+					&i.Comment.ID,
+					&i.Comment.Version,
+					&i.Comment.FeedEventID,
+					&i.Comment.ActorID,
+					&i.Comment.ReplyTo,
+					&i.Comment.Comment,
+					&i.Comment.Deleted,
+					&i.Comment.CreatedAt,
+					&i.Comment.LastUpdated,
+					&i.Comment.PostID,
+					&i.Comment.Removed,
+					&i.Comment.TopLevelCommentID,
+					&i.SortKey,
 				); err != nil {
 					return err
 				}
@@ -7,6 +7,7 @@ package coredb
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgtype"
 	"github.com/mikeydub/go-gallery/service/persist"
@@ -60,7 +61,7 @@ with community_data as (
 
 community_posts as (
     (
-        select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url
+        select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url, posts.quoted_post_id, posts.edited_at
             from community_data, posts
             where community_data.community_type = 0
                 and community_data.contract_id = any(posts.contract_ids)
@@ -70,7 +71,7 @@ community_posts as (
     union all
 
     (
-        select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url
+        select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url, posts.quoted_post_id, posts.edited_at
             from community_data, posts
                 join tokens on tokens.id = any(posts.token_ids) and not tokens.deleted
                 join token_community_memberships on tokens.token_definition_id = token_community_memberships.token_definition_id
@@ -82,10 +83,16 @@ community_posts as (
 )
 
 select count(*) from community_posts
+where not exists (select 1 from muted_posts where muted_posts.user_id = $2 and muted_posts.post_id = community_posts.id)
 `
 
-func (q *Queries) CountPostsByCommunityID(ctx context.Context, communityID persist.DBID) (int64, error) {
-	row := q.db.QueryRow(ctx, countPostsByCommunityID, communityID)
+type CountPostsByCommunityIDParams struct {
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	ViewerID    persist.DBID `db:"viewer_id" json:"viewer_id"`
+}
+
+func (q *Queries) CountPostsByCommunityID(ctx context.Context, arg CountPostsByCommunityIDParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPostsByCommunityID, arg.CommunityID, arg.ViewerID)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -139,6 +146,78 @@ func (q *Queries) CountTokensByCommunityID(ctx context.Context, communityID pers
 	return sum, err
 }
 
+const countTokensByCommunityIDWithTraits = `-- name: CountTokensByCommunityIDWithTraits :one
+with community_data as (
+    select id as community_id, community_type, contract_id
+    from communities
+    where communities.id = $1 and not deleted
+    limit 1
+),
+community_definitions as (
+    (select td.id as token_definition_id from community_data cd
+        join token_definitions td on td.contract_id = cd.contract_id
+    where cd.community_type = 0 and not td.deleted)
+
+    union all
+
+    (select tcm.token_definition_id from community_data cd
+        join token_community_memberships tcm on tcm.community_id = cd.community_id
+    where cd.community_type != 0 and not tcm.deleted)
+),
+matching_definitions as (
+    select cdef.token_definition_id from community_definitions cdef
+    where cardinality($2::varchar[]) = 0
+        or (select count(distinct tdt.trait_type) from token_definition_traits tdt
+            where tdt.community_id = $1
+                and tdt.token_definition_id = cdef.token_definition_id
+                and (tdt.trait_type, tdt.value) in (select unnest($2::varchar[]), unnest($3::varchar[]))
+        ) = (select count(distinct trait_type) from unnest($2::varchar[]) trait_type)
+)
+select count(t.*) from matching_definitions md
+    join tokens t on t.token_definition_id = md.token_definition_id
+    join token_definitions td on td.id = t.token_definition_id
+    join users u on u.id = t.owner_user_id
+    join contracts c on t.contract_id = c.id
+where t.displayable
+    and t.deleted = false
+    and c.deleted = false
+    and td.deleted = false
+    and u.deleted = false
+    and u.universal = false
+`
+
+type CountTokensByCommunityIDWithTraitsParams struct {
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	TraitTypes  []string     `db:"trait_types" json:"trait_types"`
+	TraitValues []string     `db:"trait_values" json:"trait_values"`
+}
+
+func (q *Queries) CountTokensByCommunityIDWithTraits(ctx context.Context, arg CountTokensByCommunityIDWithTraitsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTokensByCommunityIDWithTraits, arg.CommunityID, arg.TraitTypes, arg.TraitValues)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const followCommunity = `-- name: FollowCommunity :one
+with community_to_follow as (select id from communities where communities.id = $1 and not deleted)
+insert into community_follows (id, user_id, community_id) (select $2, $3, community_to_follow.id from community_to_follow)
+on conflict(user_id, community_id) where not deleted do update set last_updated = now() returning id
+`
+
+type FollowCommunityParams struct {
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	ID          persist.DBID `db:"id" json:"id"`
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) FollowCommunity(ctx context.Context, arg FollowCommunityParams) (persist.DBID, error) {
+	row := q.db.QueryRow(ctx, followCommunity, arg.CommunityID, arg.ID, arg.UserID)
+	var id persist.DBID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getCommunitiesByKeys = `-- name: GetCommunitiesByKeys :many
 with keys as (
     select unnest ($1::int[]) as type
@@ -296,6 +375,117 @@ func (q *Queries) GetCommunityContractProviders(ctx context.Context, contractIds
 	return items, nil
 }
 
+const getCommunityFollowsByCommunityIDs = `-- name: GetCommunityFollowsByCommunityIDs :many
+select id, created_at, last_updated, deleted, user_id, community_id from community_follows where community_id = any($1::varchar[]) and not deleted
+`
+
+func (q *Queries) GetCommunityFollowsByCommunityIDs(ctx context.Context, communityIds []string) ([]CommunityFollow, error) {
+	rows, err := q.db.Query(ctx, getCommunityFollowsByCommunityIDs, communityIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CommunityFollow
+	for rows.Next() {
+		var i CommunityFollow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.UserID,
+			&i.CommunityID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFollowedCommunityPosts = `-- name: GetFollowedCommunityPosts :many
+with followed_communities as (
+    select communities.id, communities.community_type, communities.contract_id
+    from community_follows
+        join communities on communities.id = community_follows.community_id and not communities.deleted
+    where community_follows.user_id = $1 and not community_follows.deleted
+)
+select posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url, posts.quoted_post_id, posts.edited_at from posts
+where not posts.deleted
+    and posts.created_at > $2
+    and (
+        exists (select 1 from followed_communities fc
+            where fc.community_type = 0 and fc.contract_id = any(posts.contract_ids))
+        or exists (select 1 from followed_communities fc
+            join token_community_memberships tcm on tcm.community_id = fc.id and not tcm.deleted
+            join tokens on tokens.token_definition_id = tcm.token_definition_id and not tokens.deleted
+            where fc.community_type = 1 and tokens.id = any(posts.token_ids))
+    )
+    and not exists (select 1 from muted_posts where muted_posts.user_id = $1 and muted_posts.post_id = posts.id)
+    and not exists (select 1 from feed_blocklist fb where fb.user_id = posts.actor_id and fb.active and not fb.deleted)
+order by posts.created_at desc, posts.id desc
+limit $3
+`
+
+type GetFollowedCommunityPostsParams struct {
+	UserID    persist.DBID `db:"user_id" json:"user_id"`
+	WindowEnd time.Time    `db:"window_end" json:"window_end"`
+	Limit     int32        `db:"limit" json:"limit"`
+}
+
+func (q *Queries) GetFollowedCommunityPosts(ctx context.Context, arg GetFollowedCommunityPostsParams) ([]Post, error) {
+	rows, err := q.db.Query(ctx, getFollowedCommunityPosts, arg.UserID, arg.WindowEnd, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
+			&i.TokenIds,
+			&i.ContractIds,
+			&i.ActorID,
+			&i.Caption,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.IsFirstPost,
+			&i.UserMintUrl,
+			&i.QuotedPostID,
+			&i.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isFollowingCommunity = `-- name: IsFollowingCommunity :one
+select exists(select 1 from community_follows where user_id = $1 and community_id = $2 and not deleted)
+`
+
+type IsFollowingCommunityParams struct {
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+}
+
+func (q *Queries) IsFollowingCommunity(ctx context.Context, arg IsFollowingCommunityParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isFollowingCommunity, arg.UserID, arg.CommunityID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const isMemberOfCommunity = `-- name: IsMemberOfCommunity :one
 with community_data as (
     select community_type, contract_id
@@ -343,6 +533,140 @@ func (q *Queries) IsMemberOfCommunity(ctx context.Context, arg IsMemberOfCommuni
 	return exists, err
 }
 
+const paginateTokensByCommunityIDWithTraits = `-- name: PaginateTokensByCommunityIDWithTraits :many
+with community_data as (
+    select id as community_id, community_type, contract_id
+    from communities
+    where communities.id = $1 and not deleted
+    limit 1
+),
+community_definitions as (
+    (select td.id as token_definition_id from community_data cd
+        join token_definitions td on td.contract_id = cd.contract_id
+    where cd.community_type = 0 and not td.deleted)
+
+    union all
+
+    (select tcm.token_definition_id from community_data cd
+        join token_community_memberships tcm on tcm.community_id = cd.community_id
+    where cd.community_type != 0 and not tcm.deleted)
+),
+matching_definitions as (
+    select cdef.token_definition_id, coalesce(tdr.rank, 2147483647)::int as rarity_rank from community_definitions cdef
+        left join token_definition_rarities tdr on tdr.community_id = $1 and tdr.token_definition_id = cdef.token_definition_id
+    where cardinality($2::varchar[]) = 0
+        or (select count(distinct tdt.trait_type) from token_definition_traits tdt
+            where tdt.community_id = $1
+                and tdt.token_definition_id = cdef.token_definition_id
+                and (tdt.trait_type, tdt.value) in (select unnest($2::varchar[]), unnest($3::varchar[]))
+        ) = (select count(distinct trait_type) from unnest($2::varchar[]) trait_type)
+)
+select t.id, t.deleted, t.version, t.created_at, t.last_updated, t.collectors_note, t.quantity, t.block_number, t.owner_user_id, t.owned_by_wallets, t.contract_id, t.is_user_marked_spam, t.last_synced, t.is_creator_token, t.token_definition_id, t.is_holder_token, t.displayable, md.rarity_rank from matching_definitions md
+    join tokens t on t.token_definition_id = md.token_definition_id
+    join token_definitions td on td.id = t.token_definition_id
+    join users u on u.id = t.owner_user_id
+    join contracts c on t.contract_id = c.id
+where t.displayable
+    and t.deleted = false
+    and c.deleted = false
+    and td.deleted = false
+    and u.deleted = false
+    and u.universal = false
+    and (case when $4::bool then md.rarity_rank else 0 end, t.created_at, t.id) < ($5::int, $6::timestamptz, $7::dbid)
+    and (case when $4::bool then md.rarity_rank else 0 end, t.created_at, t.id) > ($8::int, $9::timestamptz, $10::dbid)
+order by case when $11::bool then (case when $4::bool then md.rarity_rank else 0 end, t.created_at, t.id) end asc,
+         case when not $11::bool then (case when $4::bool then md.rarity_rank else 0 end, t.created_at, t.id) end desc
+limit $12
+`
+
+type PaginateTokensByCommunityIDWithTraitsParams struct {
+	CommunityID   persist.DBID `db:"community_id" json:"community_id"`
+	TraitTypes    []string     `db:"trait_types" json:"trait_types"`
+	TraitValues   []string     `db:"trait_values" json:"trait_values"`
+	SortByRarity  bool         `db:"sort_by_rarity" json:"sort_by_rarity"`
+	CurBeforeInt  int32        `db:"cur_before_int" json:"cur_before_int"`
+	CurBeforeTime time.Time    `db:"cur_before_time" json:"cur_before_time"`
+	CurBeforeID   persist.DBID `db:"cur_before_id" json:"cur_before_id"`
+	CurAfterInt   int32        `db:"cur_after_int" json:"cur_after_int"`
+	CurAfterTime  time.Time    `db:"cur_after_time" json:"cur_after_time"`
+	CurAfterID    persist.DBID `db:"cur_after_id" json:"cur_after_id"`
+	PagingForward bool         `db:"paging_forward" json:"paging_forward"`
+	Limit         int32        `db:"limit" json:"limit"`
+}
+
+type PaginateTokensByCommunityIDWithTraitsRow struct {
+	Token      Token `db:"token" json:"token"`
+	RarityRank int32 `db:"rarity_rank" json:"rarity_rank"`
+}
+
+// Pages through a community's tokens that have all of the given traits. Tokens match a trait type if they have any of the
+// values given for it. Tokens are ordered by when they were created, or by rarity rank (rarest first) if sort_by_rarity is set.
+func (q *Queries) PaginateTokensByCommunityIDWithTraits(ctx context.Context, arg PaginateTokensByCommunityIDWithTraitsParams) ([]PaginateTokensByCommunityIDWithTraitsRow, error) {
+	rows, err := q.db.Query(ctx, paginateTokensByCommunityIDWithTraits,
+		arg.CommunityID,
+		arg.TraitTypes,
+		arg.TraitValues,
+		arg.SortByRarity,
+		arg.CurBeforeInt,
+		arg.CurBeforeTime,
+		arg.CurBeforeID,
+		arg.CurAfterInt,
+		arg.CurAfterTime,
+		arg.CurAfterID,
+		arg.PagingForward,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PaginateTokensByCommunityIDWithTraitsRow
+	for rows.Next() {
+		var i PaginateTokensByCommunityIDWithTraitsRow
+		if err := rows.Scan(
+			&i.Token.ID,
+			&i.Token.Deleted,
+			&i.Token.Version,
+			&i.Token.CreatedAt,
+			&i.Token.LastUpdated,
+			&i.Token.CollectorsNote,
+			&i.Token.Quantity,
+			&i.Token.BlockNumber,
+			&i.Token.OwnerUserID,
+			&i.Token.OwnedByWallets,
+			&i.Token.ContractID,
+			&i.Token.IsUserMarkedSpam,
+			&i.Token.LastSynced,
+			&i.Token.IsCreatorToken,
+			&i.Token.TokenDefinitionID,
+			&i.Token.IsHolderToken,
+			&i.Token.Displayable,
+			&i.RarityRank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unfollowCommunity = `-- name: UnfollowCommunity :exec
+update community_follows set deleted = true, last_updated = now() where user_id = $1 and community_id = $2 and not deleted
+`
+
+type UnfollowCommunityParams struct {
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+}
+
+func (q *Queries) UnfollowCommunity(ctx context.Context, arg UnfollowCommunityParams) error {
+	_, err := q.db.Exec(ctx, unfollowCommunity, arg.UserID, arg.CommunityID)
+	return err
+}
+
 const upsertCommunities = `-- name: UpsertCommunities :many
 insert into communities(id, version, name, description, community_type, key1, key2, key3, key4, profile_image_url, badge_url, website_url, contract_id, created_at, last_updated, deleted) (
     select unnest($1::varchar[])
@@ -116,6 +116,15 @@ type CommunityCreator struct {
 	Deleted               bool                         `db:"deleted" json:"deleted"`
 }
 
+type CommunityFollow struct {
+	ID          persist.DBID `db:"id" json:"id"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	LastUpdated time.Time    `db:"last_updated" json:"last_updated"`
+	Deleted     bool         `db:"deleted" json:"deleted"`
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+}
+
 type CommunityGallery struct {
 	UserID                persist.DBID      `db:"user_id" json:"user_id"`
 	CommunityID           persist.DBID      `db:"community_id" json:"community_id"`
@@ -132,6 +141,20 @@ type CommunityRelevance struct {
 	Score int32        `db:"score" json:"score"`
 }
 
+type CommunityTrait struct {
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	TraitType   string       `db:"trait_type" json:"trait_type"`
+	Value       string       `db:"value" json:"value"`
+	TokenCount  int32        `db:"token_count" json:"token_count"`
+}
+
+type CommunityTraitIndex struct {
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	LastUpdated time.Time    `db:"last_updated" json:"last_updated"`
+	TokenCount  int32        `db:"token_count" json:"token_count"`
+}
+
 type Contract struct {
 	ID                    persist.DBID    `db:"id" json:"id"`
 	Deleted               bool            `db:"deleted" json:"deleted"`
@@ -171,6 +194,55 @@ type ContractCreator struct {
 	CreatorAddress persist.Address `db:"creator_address" json:"creator_address"`
 }
 
+type ContractEnrichment struct {
+	ID             persist.DBID                 `db:"id" json:"id"`
+	CreatedAt      time.Time                    `db:"created_at" json:"created_at"`
+	LastUpdated    time.Time                    `db:"last_updated" json:"last_updated"`
+	Chain          persist.Chain                `db:"chain" json:"chain"`
+	Address        persist.Address              `db:"address" json:"address"`
+	ContractUri    sql.NullString               `db:"contract_uri" json:"contract_uri"`
+	UriName        sql.NullString               `db:"uri_name" json:"uri_name"`
+	UriSymbol      sql.NullString               `db:"uri_symbol" json:"uri_symbol"`
+	UriDescription sql.NullString               `db:"uri_description" json:"uri_description"`
+	UriImageUrl    sql.NullString               `db:"uri_image_url" json:"uri_image_url"`
+	RpcName        sql.NullString               `db:"rpc_name" json:"rpc_name"`
+	RpcSymbol      sql.NullString               `db:"rpc_symbol" json:"rpc_symbol"`
+	Sources        persist.ContractFieldSources `db:"sources" json:"sources"`
+	OwnerEnsName   sql.NullString               `db:"owner_ens_name" json:"owner_ens_name"`
+}
+
+type ContractRefreshPolicy struct {
+	ID                     persist.DBID  `db:"id" json:"id"`
+	CreatedAt              time.Time     `db:"created_at" json:"created_at"`
+	LastUpdated            time.Time     `db:"last_updated" json:"last_updated"`
+	Deleted                bool          `db:"deleted" json:"deleted"`
+	ContractID             persist.DBID  `db:"contract_id" json:"contract_id"`
+	RefreshIntervalSeconds sql.NullInt32 `db:"refresh_interval_seconds" json:"refresh_interval_seconds"`
+	IgnoreMetadataUpdates  bool          `db:"ignore_metadata_updates" json:"ignore_metadata_updates"`
+	NextRefreshAt          sql.NullTime  `db:"next_refresh_at" json:"next_refresh_at"`
+	LastRefreshedAt        sql.NullTime  `db:"last_refreshed_at" json:"last_refreshed_at"`
+}
+
+type ContractSpamScore struct {
+	ContractID  persist.DBID        `db:"contract_id" json:"contract_id"`
+	CreatedAt   time.Time           `db:"created_at" json:"created_at"`
+	LastUpdated time.Time           `db:"last_updated" json:"last_updated"`
+	Score       float64             `db:"score" json:"score"`
+	Reasons     persist.SpamReasons `db:"reasons" json:"reasons"`
+}
+
+type CustomMetadataHandler struct {
+	ID                persist.DBID                 `db:"id" json:"id"`
+	CreatedAt         time.Time                    `db:"created_at" json:"created_at"`
+	LastUpdated       time.Time                    `db:"last_updated" json:"last_updated"`
+	Deleted           bool                         `db:"deleted" json:"deleted"`
+	Chain             persist.Chain                `db:"chain" json:"chain"`
+	ContractAddress   persist.Address              `db:"contract_address" json:"contract_address"`
+	FunctionSignature string                       `db:"function_signature" json:"function_signature"`
+	Format            persist.CustomMetadataFormat `db:"format" json:"format"`
+	Template          persist.TokenMetadata        `db:"template" json:"template"`
+}
+
 type DevMetadataUser struct {
 	UserID          persist.DBID  `db:"user_id" json:"user_id"`
 	HasEmailAddress persist.Email `db:"has_email_address" json:"has_email_address"`
@@ -272,6 +344,16 @@ type FeedEvent struct {
 	GroupID     sql.NullString        `db:"group_id" json:"group_id"`
 }
 
+type FeedExposure struct {
+	ID        persist.DBID     `db:"id" json:"id"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+	ViewerID  persist.DBID     `db:"viewer_id" json:"viewer_id"`
+	Feed      string           `db:"feed" json:"feed"`
+	Variant   string           `db:"variant" json:"variant"`
+	PostIds   persist.DBIDList `db:"post_ids" json:"post_ids"`
+	Positions []int32          `db:"positions" json:"positions"`
+}
+
 type Follow struct {
 	ID          persist.DBID `db:"id" json:"id"`
 	Follower    persist.DBID `db:"follower" json:"follower"`
@@ -320,6 +402,22 @@ type HighlightMintClaim struct {
 	Deleted               bool                  `db:"deleted" json:"deleted"`
 }
 
+type IpfsPin struct {
+	ID            persist.DBID   `db:"id" json:"id"`
+	CreatedAt     time.Time      `db:"created_at" json:"created_at"`
+	LastUpdated   time.Time      `db:"last_updated" json:"last_updated"`
+	Cid           string         `db:"cid" json:"cid"`
+	Status        string         `db:"status" json:"status"`
+	RemoteRequest sql.NullString `db:"remote_request" json:"remote_request"`
+	LastError     sql.NullString `db:"last_error" json:"last_error"`
+}
+
+type IpfsPinReference struct {
+	GalleryID persist.DBID `db:"gallery_id" json:"gallery_id"`
+	Cid       string       `db:"cid" json:"cid"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+}
+
 type LegacyNonce struct {
 	ID          persist.DBID    `db:"id" json:"id"`
 	Deleted     bool            `db:"deleted" json:"deleted"`
@@ -389,6 +487,12 @@ type Merch struct {
 	Redeemed     bool               `db:"redeemed" json:"redeemed"`
 }
 
+type MetadataUpdateCursor struct {
+	Chain       persist.Chain `db:"chain" json:"chain"`
+	LastBlock   int64         `db:"last_block" json:"last_block"`
+	LastUpdated time.Time     `db:"last_updated" json:"last_updated"`
+}
+
 type MigrationValidation struct {
 	ID                       persist.DBID   `db:"id" json:"id"`
 	MediaID                  persist.DBID   `db:"media_id" json:"media_id"`
@@ -408,6 +512,11 @@ type MigrationValidation struct {
 	LastRefreshed            interface{}    `db:"last_refreshed" json:"last_refreshed"`
 }
 
+type MutedPost struct {
+	UserID persist.DBID `db:"user_id" json:"user_id"`
+	PostID persist.DBID `db:"post_id" json:"post_id"`
+}
+
 type Nonce struct {
 	ID        persist.DBID `db:"id" json:"id"`
 	Value     string       `db:"value" json:"value"`
@@ -415,6 +524,15 @@ type Nonce struct {
 	Consumed  bool         `db:"consumed" json:"consumed"`
 }
 
+type NotInterestedPost struct {
+	ID          persist.DBID `db:"id" json:"id"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	LastUpdated time.Time    `db:"last_updated" json:"last_updated"`
+	Deleted     bool         `db:"deleted" json:"deleted"`
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+	PostID      persist.DBID `db:"post_id" json:"post_id"`
+}
+
 type Notification struct {
 	ID          persist.DBID             `db:"id" json:"id"`
 	Deleted     bool                     `db:"deleted" json:"deleted"`
@@ -505,18 +623,80 @@ type PiiUserView struct {
 	PiiSocials                persist.Socials                  `db:"pii_socials" json:"pii_socials"`
 }
 
+type PinnedComment struct {
+	ID          persist.DBID `db:"id" json:"id"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	LastUpdated time.Time    `db:"last_updated" json:"last_updated"`
+	Deleted     bool         `db:"deleted" json:"deleted"`
+	PostID      persist.DBID `db:"post_id" json:"post_id"`
+	CommentID   persist.DBID `db:"comment_id" json:"comment_id"`
+}
+
+type PollVote struct {
+	ID           persist.DBID `db:"id" json:"id"`
+	CreatedAt    time.Time    `db:"created_at" json:"created_at"`
+	LastUpdated  time.Time    `db:"last_updated" json:"last_updated"`
+	Deleted      bool         `db:"deleted" json:"deleted"`
+	AttachmentID persist.DBID `db:"attachment_id" json:"attachment_id"`
+	VoterID      persist.DBID `db:"voter_id" json:"voter_id"`
+	OptionIndex  int32        `db:"option_index" json:"option_index"`
+}
+
 type Post struct {
-	ID          persist.DBID     `db:"id" json:"id"`
-	Version     int32            `db:"version" json:"version"`
-	TokenIds    persist.DBIDList `db:"token_ids" json:"token_ids"`
-	ContractIds persist.DBIDList `db:"contract_ids" json:"contract_ids"`
-	ActorID     persist.DBID     `db:"actor_id" json:"actor_id"`
-	Caption     sql.NullString   `db:"caption" json:"caption"`
-	CreatedAt   time.Time        `db:"created_at" json:"created_at"`
-	LastUpdated time.Time        `db:"last_updated" json:"last_updated"`
-	Deleted     bool             `db:"deleted" json:"deleted"`
-	IsFirstPost bool             `db:"is_first_post" json:"is_first_post"`
-	UserMintUrl sql.NullString   `db:"user_mint_url" json:"user_mint_url"`
+	ID           persist.DBID     `db:"id" json:"id"`
+	Version      int32            `db:"version" json:"version"`
+	TokenIds     persist.DBIDList `db:"token_ids" json:"token_ids"`
+	ContractIds  persist.DBIDList `db:"contract_ids" json:"contract_ids"`
+	ActorID      persist.DBID     `db:"actor_id" json:"actor_id"`
+	Caption      sql.NullString   `db:"caption" json:"caption"`
+	CreatedAt    time.Time        `db:"created_at" json:"created_at"`
+	LastUpdated  time.Time        `db:"last_updated" json:"last_updated"`
+	Deleted      bool             `db:"deleted" json:"deleted"`
+	IsFirstPost  bool             `db:"is_first_post" json:"is_first_post"`
+	UserMintUrl  sql.NullString   `db:"user_mint_url" json:"user_mint_url"`
+	QuotedPostID persist.DBID     `db:"quoted_post_id" json:"quoted_post_id"`
+	EditedAt     sql.NullTime     `db:"edited_at" json:"edited_at"`
+}
+
+type PostAttachment struct {
+	ID              persist.DBID   `db:"id" json:"id"`
+	CreatedAt       time.Time      `db:"created_at" json:"created_at"`
+	LastUpdated     time.Time      `db:"last_updated" json:"last_updated"`
+	Deleted         bool           `db:"deleted" json:"deleted"`
+	PostID          persist.DBID   `db:"post_id" json:"post_id"`
+	AttachmentType  int32          `db:"attachment_type" json:"attachment_type"`
+	PollOptions     []string       `db:"poll_options" json:"poll_options"`
+	PollClosesAt    sql.NullTime   `db:"poll_closes_at" json:"poll_closes_at"`
+	LinkUrl         sql.NullString `db:"link_url" json:"link_url"`
+	LinkTitle       sql.NullString `db:"link_title" json:"link_title"`
+	LinkDescription sql.NullString `db:"link_description" json:"link_description"`
+	LinkImageUrl    sql.NullString `db:"link_image_url" json:"link_image_url"`
+	LinkSiteName    sql.NullString `db:"link_site_name" json:"link_site_name"`
+	CommunityID     persist.DBID   `db:"community_id" json:"community_id"`
+}
+
+type PostDraft struct {
+	ID          persist.DBID          `db:"id" json:"id"`
+	CreatedAt   time.Time             `db:"created_at" json:"created_at"`
+	LastUpdated time.Time             `db:"last_updated" json:"last_updated"`
+	Deleted     bool                  `db:"deleted" json:"deleted"`
+	ActorID     persist.DBID          `db:"actor_id" json:"actor_id"`
+	TokenIds    persist.DBIDList      `db:"token_ids" json:"token_ids"`
+	Caption     sql.NullString        `db:"caption" json:"caption"`
+	Mentions    persist.DraftMentions `db:"mentions" json:"mentions"`
+	UserMintUrl sql.NullString        `db:"user_mint_url" json:"user_mint_url"`
+	PublishAt   sql.NullTime          `db:"publish_at" json:"publish_at"`
+	PublishedAt sql.NullTime          `db:"published_at" json:"published_at"`
+	PostID      persist.DBID          `db:"post_id" json:"post_id"`
+	ClaimedAt   sql.NullTime          `db:"claimed_at" json:"claimed_at"`
+}
+
+type PostRevision struct {
+	ID        persist.DBID   `db:"id" json:"id"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+	PostID    persist.DBID   `db:"post_id" json:"post_id"`
+	Caption   sql.NullString `db:"caption" json:"caption"`
+	WrittenAt time.Time      `db:"written_at" json:"written_at"`
 }
 
 type PrivyUser struct {
@@ -581,6 +761,15 @@ type ReportedPost struct {
 	Reason      persist.ReportReason `db:"reason" json:"reason"`
 }
 
+type Repost struct {
+	ID          persist.DBID `db:"id" json:"id"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	LastUpdated time.Time    `db:"last_updated" json:"last_updated"`
+	Deleted     bool         `db:"deleted" json:"deleted"`
+	ActorID     persist.DBID `db:"actor_id" json:"actor_id"`
+	PostID      persist.DBID `db:"post_id" json:"post_id"`
+}
+
 type ReprocessJob struct {
 	ID           int          `db:"id" json:"id"`
 	TokenStartID persist.DBID `db:"token_start_id" json:"token_start_id"`
@@ -678,6 +867,21 @@ type TokenDefinition struct {
 	IsFxhash        bool                  `db:"is_fxhash" json:"is_fxhash"`
 }
 
+type TokenDefinitionRarity struct {
+	CommunityID       persist.DBID `db:"community_id" json:"community_id"`
+	TokenDefinitionID persist.DBID `db:"token_definition_id" json:"token_definition_id"`
+	StatisticalScore  float64      `db:"statistical_score" json:"statistical_score"`
+	NormalizedScore   float64      `db:"normalized_score" json:"normalized_score"`
+	Rank              int32        `db:"rank" json:"rank"`
+}
+
+type TokenDefinitionTrait struct {
+	CommunityID       persist.DBID `db:"community_id" json:"community_id"`
+	TokenDefinitionID persist.DBID `db:"token_definition_id" json:"token_definition_id"`
+	TraitType         string       `db:"trait_type" json:"trait_type"`
+	Value             string       `db:"value" json:"value"`
+}
+
 type TokenMedia struct {
 	ID              persist.DBID           `db:"id" json:"id"`
 	CreatedAt       time.Time              `db:"created_at" json:"created_at"`
@@ -690,6 +894,7 @@ type TokenMedia struct {
 	Chain           persist.Chain          `db:"chain" json:"chain"`
 	ContractAddress persist.Address        `db:"contract_address" json:"contract_address"`
 	TokenID         persist.DecimalTokenID `db:"token_id" json:"token_id"`
+	PipelineTrace   persist.PipelineTrace  `db:"pipeline_trace" json:"pipeline_trace"`
 }
 
 type TokenMediasActive struct {
@@ -717,6 +922,22 @@ type TokenMediasNoValidationRule struct {
 	Reason      string       `db:"reason" json:"reason"`
 }
 
+type TokenProcessingDeadLetter struct {
+	ID                persist.DBID             `db:"id" json:"id"`
+	CreatedAt         time.Time                `db:"created_at" json:"created_at"`
+	LastUpdated       time.Time                `db:"last_updated" json:"last_updated"`
+	Deleted           bool                     `db:"deleted" json:"deleted"`
+	TokenDefinitionID persist.DBID             `db:"token_definition_id" json:"token_definition_id"`
+	ContractID        persist.DBID             `db:"contract_id" json:"contract_id"`
+	ProcessingJobID   persist.DBID             `db:"processing_job_id" json:"processing_job_id"`
+	Reason            persist.DeadLetterReason `db:"reason" json:"reason"`
+	ProcessingCause   persist.ProcessingCause  `db:"processing_cause" json:"processing_cause"`
+	LastError         sql.NullString           `db:"last_error" json:"last_error"`
+	Attempts          int32                    `db:"attempts" json:"attempts"`
+	PipelineMetadata  persist.PipelineMetadata `db:"pipeline_metadata" json:"pipeline_metadata"`
+	ReplayedAt        sql.NullTime             `db:"replayed_at" json:"replayed_at"`
+}
+
 type TokenProcessingJob struct {
 	ID               persist.DBID             `db:"id" json:"id"`
 	CreatedAt        time.Time                `db:"created_at" json:"created_at"`
@@ -772,6 +993,35 @@ type UserInternalRecommendation struct {
 	Deleted     bool         `db:"deleted" json:"deleted"`
 }
 
+type UserMediaPreference struct {
+	ID                  persist.DBID                `db:"id" json:"id"`
+	CreatedAt           time.Time                   `db:"created_at" json:"created_at"`
+	LastUpdated         time.Time                   `db:"last_updated" json:"last_updated"`
+	Deleted             bool                        `db:"deleted" json:"deleted"`
+	UserID              persist.DBID                `db:"user_id" json:"user_id"`
+	FlaggedMediaDisplay persist.FlaggedMediaDisplay `db:"flagged_media_display" json:"flagged_media_display"`
+}
+
+type UserMutedCommunity struct {
+	ID          persist.DBID `db:"id" json:"id"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	LastUpdated time.Time    `db:"last_updated" json:"last_updated"`
+	Deleted     bool         `db:"deleted" json:"deleted"`
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	Active      bool         `db:"active" json:"active"`
+}
+
+type UserMutedWord struct {
+	ID          persist.DBID `db:"id" json:"id"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	LastUpdated time.Time    `db:"last_updated" json:"last_updated"`
+	Deleted     bool         `db:"deleted" json:"deleted"`
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+	Word        string       `db:"word" json:"word"`
+	Active      bool         `db:"active" json:"active"`
+}
+
 type UserRelevance struct {
 	ID    persist.DBID `db:"id" json:"id"`
 	Score int32        `db:"score" json:"score"`
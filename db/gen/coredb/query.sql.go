@@ -157,6 +157,104 @@ func (q *Queries) CheckUserOwnsAllTokenDbids(ctx context.Context, arg CheckUserO
 	return owns_all, err
 }
 
+const claimDuePostDrafts = `-- name: ClaimDuePostDrafts :many
+update post_drafts set claimed_at = now(), last_updated = now()
+where id in (
+  select id from post_drafts
+  where publish_at <= now() and published_at is null and not deleted
+    and (claimed_at is null or claimed_at < $1)
+  order by publish_at
+  limit $2
+  for update skip locked
+)
+returning id, created_at, last_updated, deleted, actor_id, token_ids, caption, mentions, user_mint_url, publish_at, published_at, post_id, claimed_at
+`
+
+type ClaimDuePostDraftsParams struct {
+	ClaimedBefore sql.NullTime `db:"claimed_before" json:"claimed_before"`
+	Limit         int32        `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ClaimDuePostDrafts(ctx context.Context, arg ClaimDuePostDraftsParams) ([]PostDraft, error) {
+	rows, err := q.db.Query(ctx, claimDuePostDrafts, arg.ClaimedBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostDraft
+	for rows.Next() {
+		var i PostDraft
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.ActorID,
+			&i.TokenIds,
+			&i.Caption,
+			&i.Mentions,
+			&i.UserMintUrl,
+			&i.PublishAt,
+			&i.PublishedAt,
+			&i.PostID,
+			&i.ClaimedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimTokenProcessingDeadLettersToReplay = `-- name: ClaimTokenProcessingDeadLettersToReplay :many
+update token_processing_dead_letters set replayed_at = now(), last_updated = now()
+where not deleted and replayed_at is null
+    and (id = any($1::varchar[]) or contract_id = $2::text)
+returning id, created_at, last_updated, deleted, token_definition_id, contract_id, processing_job_id, reason, processing_cause, last_error, attempts, pipeline_metadata, replayed_at
+`
+
+type ClaimTokenProcessingDeadLettersToReplayParams struct {
+	Ids        []string       `db:"ids" json:"ids"`
+	ContractID sql.NullString `db:"contract_id" json:"contract_id"`
+}
+
+func (q *Queries) ClaimTokenProcessingDeadLettersToReplay(ctx context.Context, arg ClaimTokenProcessingDeadLettersToReplayParams) ([]TokenProcessingDeadLetter, error) {
+	rows, err := q.db.Query(ctx, claimTokenProcessingDeadLettersToReplay, arg.Ids, arg.ContractID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TokenProcessingDeadLetter
+	for rows.Next() {
+		var i TokenProcessingDeadLetter
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.TokenDefinitionID,
+			&i.ContractID,
+			&i.ProcessingJobID,
+			&i.Reason,
+			&i.ProcessingCause,
+			&i.LastError,
+			&i.Attempts,
+			&i.PipelineMetadata,
+			&i.ReplayedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const clearNotificationsForUser = `-- name: ClearNotificationsForUser :many
 UPDATE notifications SET seen = true WHERE owner_id = $1 AND seen = false RETURNING id, deleted, owner_id, version, last_updated, created_at, action, data, event_ids, feed_event_id, comment_id, gallery_id, seen, amount, post_id, token_id, mention_id, community_id
 `
@@ -1618,6 +1716,53 @@ func (q *Queries) DeleteCollections(ctx context.Context, ids []string) error {
 	return err
 }
 
+const deleteContractRefreshPolicy = `-- name: DeleteContractRefreshPolicy :exec
+update contract_refresh_policies set deleted = true, last_updated = now() where id = $1 and not deleted
+`
+
+func (q *Queries) DeleteContractRefreshPolicy(ctx context.Context, id persist.DBID) error {
+	_, err := q.db.Exec(ctx, deleteContractRefreshPolicy, id)
+	return err
+}
+
+const deleteCustomMetadataHandler = `-- name: DeleteCustomMetadataHandler :exec
+update custom_metadata_handlers set deleted = true, last_updated = now() where id = $1 and not deleted
+`
+
+func (q *Queries) DeleteCustomMetadataHandler(ctx context.Context, id persist.DBID) error {
+	_, err := q.db.Exec(ctx, deleteCustomMetadataHandler, id)
+	return err
+}
+
+const deleteGalleryPinReferencesExcept = `-- name: DeleteGalleryPinReferencesExcept :many
+delete from ipfs_pin_references where gallery_id = $1 and not cid = any($2::varchar[]) returning cid
+`
+
+type DeleteGalleryPinReferencesExceptParams struct {
+	GalleryID persist.DBID `db:"gallery_id" json:"gallery_id"`
+	Cids      []string     `db:"cids" json:"cids"`
+}
+
+func (q *Queries) DeleteGalleryPinReferencesExcept(ctx context.Context, arg DeleteGalleryPinReferencesExceptParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, deleteGalleryPinReferencesExcept, arg.GalleryID, arg.Cids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var cid string
+		if err := rows.Scan(&cid); err != nil {
+			return nil, err
+		}
+		items = append(items, cid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const deletePostByID = `-- name: DeletePostByID :exec
 update posts set deleted = true where id = $1
 `
@@ -1627,6 +1772,35 @@ func (q *Queries) DeletePostByID(ctx context.Context, id persist.DBID) error {
 	return err
 }
 
+const deletePostDraft = `-- name: DeletePostDraft :execrows
+update post_drafts set deleted = true, last_updated = now()
+where id = $1 and actor_id = $2 and published_at is null and not deleted
+  and (claimed_at is null or claimed_at < $3)
+`
+
+type DeletePostDraftParams struct {
+	ID            persist.DBID `db:"id" json:"id"`
+	ActorID       persist.DBID `db:"actor_id" json:"actor_id"`
+	ClaimedBefore sql.NullTime `db:"claimed_before" json:"claimed_before"`
+}
+
+func (q *Queries) DeletePostDraft(ctx context.Context, arg DeletePostDraftParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePostDraft, arg.ID, arg.ActorID, arg.ClaimedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deletePostMentionsByPostID = `-- name: DeletePostMentionsByPostID :exec
+update mentions set deleted = true where post_id = $1 and not deleted
+`
+
+func (q *Queries) DeletePostMentionsByPostID(ctx context.Context, postID persist.DBID) error {
+	_, err := q.db.Exec(ctx, deletePostMentionsByPostID, postID)
+	return err
+}
+
 const deletePushTokensByIDs = `-- name: DeletePushTokensByIDs :exec
 update push_notification_tokens set deleted = true where id = any($1::dbid[]) and deleted = false
 `
@@ -2010,6 +2184,78 @@ func (q *Queries) GetCommentsByCommentIDs(ctx context.Context, commentIds []pers
 	return items, nil
 }
 
+const getCommunitiesToIndexTraits = `-- name: GetCommunitiesToIndexTraits :many
+select c.id from communities c
+    left join community_trait_indexes cti on cti.community_id = c.id
+where not c.deleted and (cti.community_id is null or cti.last_updated < $1)
+order by cti.last_updated asc nulls first, c.id
+limit $2
+`
+
+type GetCommunitiesToIndexTraitsParams struct {
+	IndexedBefore time.Time `db:"indexed_before" json:"indexed_before"`
+	Limit         int32     `db:"limit" json:"limit"`
+}
+
+func (q *Queries) GetCommunitiesToIndexTraits(ctx context.Context, arg GetCommunitiesToIndexTraitsParams) ([]persist.DBID, error) {
+	rows, err := q.db.Query(ctx, getCommunitiesToIndexTraits, arg.IndexedBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []persist.DBID
+	for rows.Next() {
+		var id persist.DBID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCommunityTraitsByCommunityID = `-- name: GetCommunityTraitsByCommunityID :many
+select ct.trait_type, ct.value, ct.token_count, cti.token_count as total from community_traits ct
+    join community_trait_indexes cti on cti.community_id = ct.community_id
+where ct.community_id = $1
+order by ct.trait_type, ct.token_count desc, ct.value
+`
+
+type GetCommunityTraitsByCommunityIDRow struct {
+	TraitType  string `db:"trait_type" json:"trait_type"`
+	Value      string `db:"value" json:"value"`
+	TokenCount int32  `db:"token_count" json:"token_count"`
+	Total      int32  `db:"total" json:"total"`
+}
+
+func (q *Queries) GetCommunityTraitsByCommunityID(ctx context.Context, communityID persist.DBID) ([]GetCommunityTraitsByCommunityIDRow, error) {
+	rows, err := q.db.Query(ctx, getCommunityTraitsByCommunityID, communityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCommunityTraitsByCommunityIDRow
+	for rows.Next() {
+		var i GetCommunityTraitsByCommunityIDRow
+		if err := rows.Scan(
+			&i.TraitType,
+			&i.Value,
+			&i.TokenCount,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getContractByChainAddress = `-- name: GetContractByChainAddress :one
 select id, deleted, version, created_at, last_updated, name, symbol, address, creator_address, chain, profile_banner_url, profile_image_url, badge_url, description, owner_address, is_provider_marked_spam, parent_id, override_creator_user_id, l1_chain FROM contracts WHERE address = $1 AND chain = $2 AND deleted = false
 `
@@ -2117,51 +2363,39 @@ func (q *Queries) GetContractCreatorsByIds(ctx context.Context, contractIds []st
 	return items, nil
 }
 
-const getContractsByIDs = `-- name: GetContractsByIDs :many
-with keys as (
-    select unnest ($1::varchar[]) as id
-         , generate_subscripts($1::varchar[], 1) as batch_key_index
-)
-select k.batch_key_index, c.id, c.deleted, c.version, c.created_at, c.last_updated, c.name, c.symbol, c.address, c.creator_address, c.chain, c.profile_banner_url, c.profile_image_url, c.badge_url, c.description, c.owner_address, c.is_provider_marked_spam, c.parent_id, c.override_creator_user_id, c.l1_chain from keys k
-    join contracts c on c.id = k.id
-    where not c.deleted
+const getContractEnrichmentsByAddresses = `-- name: GetContractEnrichmentsByAddresses :many
+select id, created_at, last_updated, chain, address, contract_uri, uri_name, uri_symbol, uri_description, uri_image_url, rpc_name, rpc_symbol, sources, owner_ens_name from contract_enrichments where chain = $1 and address = any($2::varchar[])
 `
 
-type GetContractsByIDsRow struct {
-	BatchKeyIndex int32    `db:"batch_key_index" json:"batch_key_index"`
-	Contract      Contract `db:"contract" json:"contract"`
+type GetContractEnrichmentsByAddressesParams struct {
+	Chain     persist.Chain `db:"chain" json:"chain"`
+	Addresses []string      `db:"addresses" json:"addresses"`
 }
 
-func (q *Queries) GetContractsByIDs(ctx context.Context, contractIds []string) ([]GetContractsByIDsRow, error) {
-	rows, err := q.db.Query(ctx, getContractsByIDs, contractIds)
+func (q *Queries) GetContractEnrichmentsByAddresses(ctx context.Context, arg GetContractEnrichmentsByAddressesParams) ([]ContractEnrichment, error) {
+	rows, err := q.db.Query(ctx, getContractEnrichmentsByAddresses, arg.Chain, arg.Addresses)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetContractsByIDsRow
+	var items []ContractEnrichment
 	for rows.Next() {
-		var i GetContractsByIDsRow
+		var i ContractEnrichment
 		if err := rows.Scan(
-			&i.BatchKeyIndex,
-			&i.Contract.ID,
-			&i.Contract.Deleted,
-			&i.Contract.Version,
-			&i.Contract.CreatedAt,
-			&i.Contract.LastUpdated,
-			&i.Contract.Name,
-			&i.Contract.Symbol,
-			&i.Contract.Address,
-			&i.Contract.CreatorAddress,
-			&i.Contract.Chain,
-			&i.Contract.ProfileBannerUrl,
-			&i.Contract.ProfileImageUrl,
-			&i.Contract.BadgeUrl,
-			&i.Contract.Description,
-			&i.Contract.OwnerAddress,
-			&i.Contract.IsProviderMarkedSpam,
-			&i.Contract.ParentID,
-			&i.Contract.OverrideCreatorUserID,
-			&i.Contract.L1Chain,
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Chain,
+			&i.Address,
+			&i.ContractUri,
+			&i.UriName,
+			&i.UriSymbol,
+			&i.UriDescription,
+			&i.UriImageUrl,
+			&i.RpcName,
+			&i.RpcSymbol,
+			&i.Sources,
+			&i.OwnerEnsName,
 		); err != nil {
 			return nil, err
 		}
@@ -2173,39 +2407,29 @@ func (q *Queries) GetContractsByIDs(ctx context.Context, contractIds []string) (
 	return items, nil
 }
 
-const getContractsByTokenIDs = `-- name: GetContractsByTokenIDs :many
-select contracts.id, contracts.deleted, contracts.version, contracts.created_at, contracts.last_updated, contracts.name, contracts.symbol, contracts.address, contracts.creator_address, contracts.chain, contracts.profile_banner_url, contracts.profile_image_url, contracts.badge_url, contracts.description, contracts.owner_address, contracts.is_provider_marked_spam, contracts.parent_id, contracts.override_creator_user_id, contracts.l1_chain from contracts join tokens on contracts.id = tokens.contract_id where tokens.id = any($1::dbid[]) and contracts.deleted = false
+const getContractRefreshPolicies = `-- name: GetContractRefreshPolicies :many
+select id, created_at, last_updated, deleted, contract_id, refresh_interval_seconds, ignore_metadata_updates, next_refresh_at, last_refreshed_at from contract_refresh_policies where not deleted order by created_at
 `
 
-func (q *Queries) GetContractsByTokenIDs(ctx context.Context, tokenIds []persist.DBID) ([]Contract, error) {
-	rows, err := q.db.Query(ctx, getContractsByTokenIDs, tokenIds)
+func (q *Queries) GetContractRefreshPolicies(ctx context.Context) ([]ContractRefreshPolicy, error) {
+	rows, err := q.db.Query(ctx, getContractRefreshPolicies)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Contract
+	var items []ContractRefreshPolicy
 	for rows.Next() {
-		var i Contract
+		var i ContractRefreshPolicy
 		if err := rows.Scan(
 			&i.ID,
-			&i.Deleted,
-			&i.Version,
 			&i.CreatedAt,
 			&i.LastUpdated,
-			&i.Name,
-			&i.Symbol,
-			&i.Address,
-			&i.CreatorAddress,
-			&i.Chain,
-			&i.ProfileBannerUrl,
-			&i.ProfileImageUrl,
-			&i.BadgeUrl,
-			&i.Description,
-			&i.OwnerAddress,
-			&i.IsProviderMarkedSpam,
-			&i.ParentID,
-			&i.OverrideCreatorUserID,
-			&i.L1Chain,
+			&i.Deleted,
+			&i.ContractID,
+			&i.RefreshIntervalSeconds,
+			&i.IgnoreMetadataUpdates,
+			&i.NextRefreshAt,
+			&i.LastRefreshedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -2217,48 +2441,351 @@ func (q *Queries) GetContractsByTokenIDs(ctx context.Context, tokenIds []persist
 	return items, nil
 }
 
-const getCreatedContractsByUserID = `-- name: GetCreatedContractsByUserID :many
-select c.id, c.deleted, c.version, c.created_at, c.last_updated, c.name, c.symbol, c.address, c.creator_address, c.chain, c.profile_banner_url, c.profile_image_url, c.badge_url, c.description, c.owner_address, c.is_provider_marked_spam, c.parent_id, c.override_creator_user_id, c.l1_chain,
-       w.id as wallet_id,
-       false as is_override_creator
-from users u, contracts c, wallets w
-where u.id = $1
-  and c.chain = any($2::int[])
-  and w.id = any(u.wallets) and coalesce(nullif(c.owner_address, ''), nullif(c.creator_address, '')) = w.address 
-  and w.l1_chain = c.l1_chain
-  and u.deleted = false
-  and c.deleted = false
-  and w.deleted = false
-  and c.override_creator_user_id is null
-  and (not $3::bool or not exists(
-    select 1 from tokens t
-        where t.owner_user_id = $1
-          and t.contract_id = c.id
-          and t.is_creator_token
-          and not t.deleted
-        )
-    )
+const getContractSpamScoreByContractID = `-- name: GetContractSpamScoreByContractID :one
+select contract_id, created_at, last_updated, score, reasons from contract_spam_scores where contract_id = $1
+`
 
-union all
+func (q *Queries) GetContractSpamScoreByContractID(ctx context.Context, contractID persist.DBID) (ContractSpamScore, error) {
+	row := q.db.QueryRow(ctx, getContractSpamScoreByContractID, contractID)
+	var i ContractSpamScore
+	err := row.Scan(
+		&i.ContractID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Score,
+		&i.Reasons,
+	)
+	return i, err
+}
 
-select c.id, c.deleted, c.version, c.created_at, c.last_updated, c.name, c.symbol, c.address, c.creator_address, c.chain, c.profile_banner_url, c.profile_image_url, c.badge_url, c.description, c.owner_address, c.is_provider_marked_spam, c.parent_id, c.override_creator_user_id, c.l1_chain,
-       null as wallet_id,
-       true as is_override_creator
-from contracts c
-where c.override_creator_user_id = $1
-  and c.chain = any($2::int[])
-  and c.deleted = false
-  and (not $3::bool or not exists(
-    select 1 from tokens t
-        where t.owner_user_id = $1
-          and t.contract_id = c.id
-          and t.is_creator_token
-          and not t.deleted
-        )
-    )
+const getContractSpamScoresAboveThreshold = `-- name: GetContractSpamScoresAboveThreshold :many
+select contract_id, created_at, last_updated, score, reasons from contract_spam_scores where score >= $1 order by score desc, contract_id limit $2
 `
 
-type GetCreatedContractsByUserIDParams struct {
+type GetContractSpamScoresAboveThresholdParams struct {
+	MinScore float64 `db:"min_score" json:"min_score"`
+	Limit    int32   `db:"limit" json:"limit"`
+}
+
+func (q *Queries) GetContractSpamScoresAboveThreshold(ctx context.Context, arg GetContractSpamScoresAboveThresholdParams) ([]ContractSpamScore, error) {
+	rows, err := q.db.Query(ctx, getContractSpamScoresAboveThreshold, arg.MinScore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ContractSpamScore
+	for rows.Next() {
+		var i ContractSpamScore
+		if err := rows.Scan(
+			&i.ContractID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Score,
+			&i.Reasons,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContractSpamScoresByContractIDs = `-- name: GetContractSpamScoresByContractIDs :many
+select contract_id, created_at, last_updated, score, reasons from contract_spam_scores where contract_id = any($1::varchar[])
+`
+
+func (q *Queries) GetContractSpamScoresByContractIDs(ctx context.Context, contractIds []string) ([]ContractSpamScore, error) {
+	rows, err := q.db.Query(ctx, getContractSpamScoresByContractIDs, contractIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ContractSpamScore
+	for rows.Next() {
+		var i ContractSpamScore
+		if err := rows.Scan(
+			&i.ContractID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Score,
+			&i.Reasons,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContractSpamSignals = `-- name: GetContractSpamSignals :one
+select
+  contracts.name,
+  contracts.symbol,
+  contracts.is_provider_marked_spam,
+  (select count(distinct t.owner_user_id) from tokens t where t.contract_id = contracts.id and not t.deleted)::int as holders,
+  (select coalesce(max(receivers), 0) from (
+    select count(distinct t.owner_user_id) as receivers from tokens t where t.contract_id = contracts.id and not t.deleted and t.block_number is not null and t.block_number > 0 group by t.block_number
+  ) airdrops)::int as max_holders_in_block,
+  (select count(distinct t.owner_user_id) from tokens t where t.contract_id = contracts.id and not t.deleted and t.is_user_marked_spam = true)::int as spam_reports,
+  (select count(distinct t.owner_user_id) from tokens t where t.contract_id = contracts.id and not t.deleted and t.is_user_marked_spam = false)::int as not_spam_reports
+from contracts where contracts.id = $1 and not contracts.deleted
+`
+
+type GetContractSpamSignalsRow struct {
+	Name                 sql.NullString `db:"name" json:"name"`
+	Symbol               sql.NullString `db:"symbol" json:"symbol"`
+	IsProviderMarkedSpam bool           `db:"is_provider_marked_spam" json:"is_provider_marked_spam"`
+	Holders              int32          `db:"holders" json:"holders"`
+	MaxHoldersInBlock    int32          `db:"max_holders_in_block" json:"max_holders_in_block"`
+	SpamReports          int32          `db:"spam_reports" json:"spam_reports"`
+	NotSpamReports       int32          `db:"not_spam_reports" json:"not_spam_reports"`
+}
+
+func (q *Queries) GetContractSpamSignals(ctx context.Context, id persist.DBID) (GetContractSpamSignalsRow, error) {
+	row := q.db.QueryRow(ctx, getContractSpamSignals, id)
+	var i GetContractSpamSignalsRow
+	err := row.Scan(
+		&i.Name,
+		&i.Symbol,
+		&i.IsProviderMarkedSpam,
+		&i.Holders,
+		&i.MaxHoldersInBlock,
+		&i.SpamReports,
+		&i.NotSpamReports,
+	)
+	return i, err
+}
+
+const getContractsByChainAddresses = `-- name: GetContractsByChainAddresses :many
+select id, deleted, version, created_at, last_updated, name, symbol, address, creator_address, chain, profile_banner_url, profile_image_url, badge_url, description, owner_address, is_provider_marked_spam, parent_id, override_creator_user_id, l1_chain from contracts where chain = $1 and address = any($2::varchar[]) and not deleted
+`
+
+type GetContractsByChainAddressesParams struct {
+	Chain     persist.Chain `db:"chain" json:"chain"`
+	Addresses []string      `db:"addresses" json:"addresses"`
+}
+
+func (q *Queries) GetContractsByChainAddresses(ctx context.Context, arg GetContractsByChainAddressesParams) ([]Contract, error) {
+	rows, err := q.db.Query(ctx, getContractsByChainAddresses, arg.Chain, arg.Addresses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Contract
+	for rows.Next() {
+		var i Contract
+		if err := rows.Scan(
+			&i.ID,
+			&i.Deleted,
+			&i.Version,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Name,
+			&i.Symbol,
+			&i.Address,
+			&i.CreatorAddress,
+			&i.Chain,
+			&i.ProfileBannerUrl,
+			&i.ProfileImageUrl,
+			&i.BadgeUrl,
+			&i.Description,
+			&i.OwnerAddress,
+			&i.IsProviderMarkedSpam,
+			&i.ParentID,
+			&i.OverrideCreatorUserID,
+			&i.L1Chain,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContractsByIDs = `-- name: GetContractsByIDs :many
+with keys as (
+    select unnest ($1::varchar[]) as id
+         , generate_subscripts($1::varchar[], 1) as batch_key_index
+)
+select k.batch_key_index, c.id, c.deleted, c.version, c.created_at, c.last_updated, c.name, c.symbol, c.address, c.creator_address, c.chain, c.profile_banner_url, c.profile_image_url, c.badge_url, c.description, c.owner_address, c.is_provider_marked_spam, c.parent_id, c.override_creator_user_id, c.l1_chain from keys k
+    join contracts c on c.id = k.id
+    where not c.deleted
+`
+
+type GetContractsByIDsRow struct {
+	BatchKeyIndex int32    `db:"batch_key_index" json:"batch_key_index"`
+	Contract      Contract `db:"contract" json:"contract"`
+}
+
+func (q *Queries) GetContractsByIDs(ctx context.Context, contractIds []string) ([]GetContractsByIDsRow, error) {
+	rows, err := q.db.Query(ctx, getContractsByIDs, contractIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetContractsByIDsRow
+	for rows.Next() {
+		var i GetContractsByIDsRow
+		if err := rows.Scan(
+			&i.BatchKeyIndex,
+			&i.Contract.ID,
+			&i.Contract.Deleted,
+			&i.Contract.Version,
+			&i.Contract.CreatedAt,
+			&i.Contract.LastUpdated,
+			&i.Contract.Name,
+			&i.Contract.Symbol,
+			&i.Contract.Address,
+			&i.Contract.CreatorAddress,
+			&i.Contract.Chain,
+			&i.Contract.ProfileBannerUrl,
+			&i.Contract.ProfileImageUrl,
+			&i.Contract.BadgeUrl,
+			&i.Contract.Description,
+			&i.Contract.OwnerAddress,
+			&i.Contract.IsProviderMarkedSpam,
+			&i.Contract.ParentID,
+			&i.Contract.OverrideCreatorUserID,
+			&i.Contract.L1Chain,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContractsByTokenIDs = `-- name: GetContractsByTokenIDs :many
+select contracts.id, contracts.deleted, contracts.version, contracts.created_at, contracts.last_updated, contracts.name, contracts.symbol, contracts.address, contracts.creator_address, contracts.chain, contracts.profile_banner_url, contracts.profile_image_url, contracts.badge_url, contracts.description, contracts.owner_address, contracts.is_provider_marked_spam, contracts.parent_id, contracts.override_creator_user_id, contracts.l1_chain from contracts join tokens on contracts.id = tokens.contract_id where tokens.id = any($1::dbid[]) and contracts.deleted = false
+`
+
+func (q *Queries) GetContractsByTokenIDs(ctx context.Context, tokenIds []persist.DBID) ([]Contract, error) {
+	rows, err := q.db.Query(ctx, getContractsByTokenIDs, tokenIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Contract
+	for rows.Next() {
+		var i Contract
+		if err := rows.Scan(
+			&i.ID,
+			&i.Deleted,
+			&i.Version,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Name,
+			&i.Symbol,
+			&i.Address,
+			&i.CreatorAddress,
+			&i.Chain,
+			&i.ProfileBannerUrl,
+			&i.ProfileImageUrl,
+			&i.BadgeUrl,
+			&i.Description,
+			&i.OwnerAddress,
+			&i.IsProviderMarkedSpam,
+			&i.ParentID,
+			&i.OverrideCreatorUserID,
+			&i.L1Chain,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContractsToScoreForSpam = `-- name: GetContractsToScoreForSpam :many
+select contracts.id from contracts
+left join contract_spam_scores s on s.contract_id = contracts.id
+where not contracts.deleted and (s.contract_id is null or s.last_updated < $1)
+order by s.last_updated nulls first, contracts.id
+limit $2
+`
+
+type GetContractsToScoreForSpamParams struct {
+	ScoredBefore time.Time `db:"scored_before" json:"scored_before"`
+	Limit        int32     `db:"limit" json:"limit"`
+}
+
+func (q *Queries) GetContractsToScoreForSpam(ctx context.Context, arg GetContractsToScoreForSpamParams) ([]persist.DBID, error) {
+	rows, err := q.db.Query(ctx, getContractsToScoreForSpam, arg.ScoredBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []persist.DBID
+	for rows.Next() {
+		var id persist.DBID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCreatedContractsByUserID = `-- name: GetCreatedContractsByUserID :many
+select c.id, c.deleted, c.version, c.created_at, c.last_updated, c.name, c.symbol, c.address, c.creator_address, c.chain, c.profile_banner_url, c.profile_image_url, c.badge_url, c.description, c.owner_address, c.is_provider_marked_spam, c.parent_id, c.override_creator_user_id, c.l1_chain,
+       w.id as wallet_id,
+       false as is_override_creator
+from users u, contracts c, wallets w
+where u.id = $1
+  and c.chain = any($2::int[])
+  and w.id = any(u.wallets) and coalesce(nullif(c.owner_address, ''), nullif(c.creator_address, '')) = w.address 
+  and w.l1_chain = c.l1_chain
+  and u.deleted = false
+  and c.deleted = false
+  and w.deleted = false
+  and c.override_creator_user_id is null
+  and (not $3::bool or not exists(
+    select 1 from tokens t
+        where t.owner_user_id = $1
+          and t.contract_id = c.id
+          and t.is_creator_token
+          and not t.deleted
+        )
+    )
+
+union all
+
+select c.id, c.deleted, c.version, c.created_at, c.last_updated, c.name, c.symbol, c.address, c.creator_address, c.chain, c.profile_banner_url, c.profile_image_url, c.badge_url, c.description, c.owner_address, c.is_provider_marked_spam, c.parent_id, c.override_creator_user_id, c.l1_chain,
+       null as wallet_id,
+       true as is_override_creator
+from contracts c
+where c.override_creator_user_id = $1
+  and c.chain = any($2::int[])
+  and c.deleted = false
+  and (not $3::bool or not exists(
+    select 1 from tokens t
+        where t.owner_user_id = $1
+          and t.contract_id = c.id
+          and t.is_creator_token
+          and not t.deleted
+        )
+    )
+`
+
+type GetCreatedContractsByUserIDParams struct {
 	UserID           persist.DBID `db:"user_id" json:"user_id"`
 	Chains           []int32      `db:"chains" json:"chains"`
 	NewContractsOnly bool         `db:"new_contracts_only" json:"new_contracts_only"`
@@ -2323,6 +2850,77 @@ func (q *Queries) GetCurrentTime(ctx context.Context) (time.Time, error) {
 	return column_1, err
 }
 
+const getCustomMetadataHandlers = `-- name: GetCustomMetadataHandlers :many
+select id, created_at, last_updated, deleted, chain, contract_address, function_signature, format, template from custom_metadata_handlers where not deleted order by chain, contract_address
+`
+
+func (q *Queries) GetCustomMetadataHandlers(ctx context.Context) ([]CustomMetadataHandler, error) {
+	rows, err := q.db.Query(ctx, getCustomMetadataHandlers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CustomMetadataHandler
+	for rows.Next() {
+		var i CustomMetadataHandler
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.Chain,
+			&i.ContractAddress,
+			&i.FunctionSignature,
+			&i.Format,
+			&i.Template,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDueContractRefreshPolicies = `-- name: GetDueContractRefreshPolicies :many
+select id, created_at, last_updated, deleted, contract_id, refresh_interval_seconds, ignore_metadata_updates, next_refresh_at, last_refreshed_at from contract_refresh_policies
+where not deleted and refresh_interval_seconds is not null and (next_refresh_at is null or next_refresh_at <= now())
+order by next_refresh_at nulls first
+limit $1
+`
+
+func (q *Queries) GetDueContractRefreshPolicies(ctx context.Context, limit int32) ([]ContractRefreshPolicy, error) {
+	rows, err := q.db.Query(ctx, getDueContractRefreshPolicies, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ContractRefreshPolicy
+	for rows.Next() {
+		var i ContractRefreshPolicy
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.ContractID,
+			&i.RefreshIntervalSeconds,
+			&i.IgnoreMetadataUpdates,
+			&i.NextRefreshAt,
+			&i.LastRefreshedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getEthereumWalletsForEnsProfileImagesByUserID = `-- name: GetEthereumWalletsForEnsProfileImagesByUserID :many
 select w.id, w.created_at, w.last_updated, w.deleted, w.version, w.address, w.wallet_type, w.chain, w.l1_chain
 from wallets w
@@ -2644,6 +3242,38 @@ func (q *Queries) GetFeedEventsByIds(ctx context.Context, ids []string) ([]FeedE
 	return items, nil
 }
 
+const getFlaggedTokenIDs = `-- name: GetFlaggedTokenIDs :many
+select t.id from tokens t
+    join token_definitions td on t.token_definition_id = td.id and not td.deleted
+    join token_medias tm on td.token_media_id = tm.id and not tm.deleted
+where t.id = any($1::varchar[]) and tm.media->'moderation'->>'label' = any($2::varchar[]) and not t.deleted
+`
+
+type GetFlaggedTokenIDsParams struct {
+	TokenIds []string `db:"token_ids" json:"token_ids"`
+	Labels   []string `db:"labels" json:"labels"`
+}
+
+func (q *Queries) GetFlaggedTokenIDs(ctx context.Context, arg GetFlaggedTokenIDsParams) ([]persist.DBID, error) {
+	rows, err := q.db.Query(ctx, getFlaggedTokenIDs, arg.TokenIds, arg.Labels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []persist.DBID
+	for rows.Next() {
+		var id persist.DBID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getGalleriesByUserId = `-- name: GetGalleriesByUserId :many
 SELECT id, deleted, last_updated, created_at, version, owner_user_id, collections, name, description, hidden, position FROM galleries WHERE owner_user_id = $1 AND deleted = false order by position
 `
@@ -2841,6 +3471,38 @@ func (q *Queries) GetHighlightMintClaimByID(ctx context.Context, id persist.DBID
 	return i, err
 }
 
+const getIPFSPinsByCIDs = `-- name: GetIPFSPinsByCIDs :many
+select id, created_at, last_updated, cid, status, remote_request, last_error from ipfs_pins where cid = any($1::varchar[])
+`
+
+func (q *Queries) GetIPFSPinsByCIDs(ctx context.Context, cids []string) ([]IpfsPin, error) {
+	rows, err := q.db.Query(ctx, getIPFSPinsByCIDs, cids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IpfsPin
+	for rows.Next() {
+		var i IpfsPin
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Cid,
+			&i.Status,
+			&i.RemoteRequest,
+			&i.LastError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLastFeedEventForCollection = `-- name: GetLastFeedEventForCollection :one
 select id, version, owner_id, action, data, event_time, event_ids, deleted, last_updated, created_at, caption, group_id from feed_events where deleted = false
     and owner_id = $1
@@ -2961,7 +3623,7 @@ func (q *Queries) GetLastFeedEventForUser(ctx context.Context, arg GetLastFeedEv
 }
 
 const getMediaByTokenIdentifiersIgnoringStatus = `-- name: GetMediaByTokenIdentifiersIgnoringStatus :one
-select tm.id, tm.created_at, tm.last_updated, tm.version, tm.active, tm.media, tm.processing_job_id, tm.deleted, tm.chain, tm.contract_address, tm.token_id
+select tm.id, tm.created_at, tm.last_updated, tm.version, tm.active, tm.media, tm.processing_job_id, tm.deleted, tm.chain, tm.contract_address, tm.token_id, tm.pipeline_trace
 from token_definitions td
 join token_medias tm on td.token_media_id = tm.id
 where (td.chain, td.contract_address, td.token_id) = ($1, $2::address, $3::hextokenid)
@@ -2990,6 +3652,7 @@ func (q *Queries) GetMediaByTokenIdentifiersIgnoringStatus(ctx context.Context,
 		&i.Chain,
 		&i.ContractAddress,
 		&i.TokenID,
+		&i.PipelineTrace,
 	)
 	return i, err
 }
@@ -3036,6 +3699,40 @@ func (q *Queries) GetMentionByID(ctx context.Context, id persist.DBID) (Mention,
 	return i, err
 }
 
+const getMentionsByPostIDIncludingDeleted = `-- name: GetMentionsByPostIDIncludingDeleted :many
+select id, post_id, comment_id, user_id, start, length, created_at, deleted, community_id from mentions where post_id = $1
+`
+
+func (q *Queries) GetMentionsByPostIDIncludingDeleted(ctx context.Context, postID persist.DBID) ([]Mention, error) {
+	rows, err := q.db.Query(ctx, getMentionsByPostIDIncludingDeleted, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Mention
+	for rows.Next() {
+		var i Mention
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.CommentID,
+			&i.UserID,
+			&i.Start,
+			&i.Length,
+			&i.CreatedAt,
+			&i.Deleted,
+			&i.CommunityID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMerchDiscountCodeByTokenID = `-- name: GetMerchDiscountCodeByTokenID :one
 select discount_code from merch where token_id = $1 and redeemed = true and deleted = false
 `
@@ -3047,6 +3744,55 @@ func (q *Queries) GetMerchDiscountCodeByTokenID(ctx context.Context, tokenHex pe
 	return discount_code, err
 }
 
+const getMetadataUpdateContractsByAddresses = `-- name: GetMetadataUpdateContractsByAddresses :many
+select c.id, c.address, coalesce(p.ignore_metadata_updates, false)::bool as ignore_metadata_updates
+from contracts c
+left join contract_refresh_policies p on p.contract_id = c.id and not p.deleted
+where c.chain = $1 and c.address = any($2::varchar[]) and not c.deleted
+`
+
+type GetMetadataUpdateContractsByAddressesParams struct {
+	Chain     persist.Chain `db:"chain" json:"chain"`
+	Addresses []string      `db:"addresses" json:"addresses"`
+}
+
+type GetMetadataUpdateContractsByAddressesRow struct {
+	ID                    persist.DBID    `db:"id" json:"id"`
+	Address               persist.Address `db:"address" json:"address"`
+	IgnoreMetadataUpdates bool            `db:"ignore_metadata_updates" json:"ignore_metadata_updates"`
+}
+
+func (q *Queries) GetMetadataUpdateContractsByAddresses(ctx context.Context, arg GetMetadataUpdateContractsByAddressesParams) ([]GetMetadataUpdateContractsByAddressesRow, error) {
+	rows, err := q.db.Query(ctx, getMetadataUpdateContractsByAddresses, arg.Chain, arg.Addresses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMetadataUpdateContractsByAddressesRow
+	for rows.Next() {
+		var i GetMetadataUpdateContractsByAddressesRow
+		if err := rows.Scan(&i.ID, &i.Address, &i.IgnoreMetadataUpdates); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMetadataUpdateCursor = `-- name: GetMetadataUpdateCursor :one
+select last_block from metadata_update_cursors where chain = $1
+`
+
+func (q *Queries) GetMetadataUpdateCursor(ctx context.Context, chain persist.Chain) (int64, error) {
+	row := q.db.QueryRow(ctx, getMetadataUpdateCursor, chain)
+	var last_block int64
+	err := row.Scan(&last_block)
+	return last_block, err
+}
+
 const getMostActiveUsers = `-- name: GetMostActiveUsers :many
 WITH ag AS (
     SELECT actor_id, COUNT(*) AS admire_given
@@ -3231,38 +3977,142 @@ type GetMostRecentNotificationByOwnerIDTokenIDForActionParams struct {
 	OnlyForPost      bool           `db:"only_for_post" json:"only_for_post"`
 }
 
-func (q *Queries) GetMostRecentNotificationByOwnerIDTokenIDForAction(ctx context.Context, arg GetMostRecentNotificationByOwnerIDTokenIDForActionParams) (Notification, error) {
-	row := q.db.QueryRow(ctx, getMostRecentNotificationByOwnerIDTokenIDForAction,
-		arg.OwnerID,
-		arg.TokenID,
-		arg.Action,
-		arg.FeedEventID,
-		arg.PostID,
-		arg.OnlyForFeedEvent,
-		arg.OnlyForPost,
-	)
-	var i Notification
-	err := row.Scan(
-		&i.ID,
-		&i.Deleted,
-		&i.OwnerID,
-		&i.Version,
-		&i.LastUpdated,
-		&i.CreatedAt,
-		&i.Action,
-		&i.Data,
-		&i.EventIds,
-		&i.FeedEventID,
-		&i.CommentID,
-		&i.GalleryID,
-		&i.Seen,
-		&i.Amount,
-		&i.PostID,
-		&i.TokenID,
-		&i.MentionID,
-		&i.CommunityID,
-	)
-	return i, err
+func (q *Queries) GetMostRecentNotificationByOwnerIDTokenIDForAction(ctx context.Context, arg GetMostRecentNotificationByOwnerIDTokenIDForActionParams) (Notification, error) {
+	row := q.db.QueryRow(ctx, getMostRecentNotificationByOwnerIDTokenIDForAction,
+		arg.OwnerID,
+		arg.TokenID,
+		arg.Action,
+		arg.FeedEventID,
+		arg.PostID,
+		arg.OnlyForFeedEvent,
+		arg.OnlyForPost,
+	)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.Deleted,
+		&i.OwnerID,
+		&i.Version,
+		&i.LastUpdated,
+		&i.CreatedAt,
+		&i.Action,
+		&i.Data,
+		&i.EventIds,
+		&i.FeedEventID,
+		&i.CommentID,
+		&i.GalleryID,
+		&i.Seen,
+		&i.Amount,
+		&i.PostID,
+		&i.TokenID,
+		&i.MentionID,
+		&i.CommunityID,
+	)
+	return i, err
+}
+
+const getMutedCommunitiesByUserID = `-- name: GetMutedCommunitiesByUserID :many
+select communities.id, communities.version, communities.community_type, communities.key1, communities.key2, communities.key3, communities.key4, communities.name, communities.override_name, communities.description, communities.override_description, communities.profile_image_url, communities.override_profile_image_url, communities.badge_url, communities.override_badge_url, communities.contract_id, communities.created_at, communities.last_updated, communities.deleted, communities.website_url, communities.override_website_url, communities.mint_url, communities.override_mint_url from user_muted_communities
+    join communities on communities.id = user_muted_communities.community_id and not communities.deleted
+where user_muted_communities.user_id = $1 and user_muted_communities.active and not user_muted_communities.deleted
+order by user_muted_communities.created_at desc
+`
+
+func (q *Queries) GetMutedCommunitiesByUserID(ctx context.Context, userID persist.DBID) ([]Community, error) {
+	rows, err := q.db.Query(ctx, getMutedCommunitiesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Community
+	for rows.Next() {
+		var i Community
+		if err := rows.Scan(
+			&i.ID,
+			&i.Version,
+			&i.CommunityType,
+			&i.Key1,
+			&i.Key2,
+			&i.Key3,
+			&i.Key4,
+			&i.Name,
+			&i.OverrideName,
+			&i.Description,
+			&i.OverrideDescription,
+			&i.ProfileImageUrl,
+			&i.OverrideProfileImageUrl,
+			&i.BadgeUrl,
+			&i.OverrideBadgeUrl,
+			&i.ContractID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.WebsiteUrl,
+			&i.OverrideWebsiteUrl,
+			&i.MintUrl,
+			&i.OverrideMintUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMutedPostIDs = `-- name: GetMutedPostIDs :many
+select distinct post_id from muted_posts where user_id = $1 and post_id = any($2::varchar[])
+`
+
+type GetMutedPostIDsParams struct {
+	ViewerID persist.DBID `db:"viewer_id" json:"viewer_id"`
+	PostIds  []string     `db:"post_ids" json:"post_ids"`
+}
+
+func (q *Queries) GetMutedPostIDs(ctx context.Context, arg GetMutedPostIDsParams) ([]persist.DBID, error) {
+	rows, err := q.db.Query(ctx, getMutedPostIDs, arg.ViewerID, arg.PostIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []persist.DBID
+	for rows.Next() {
+		var post_id persist.DBID
+		if err := rows.Scan(&post_id); err != nil {
+			return nil, err
+		}
+		items = append(items, post_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMutedWordsByUserID = `-- name: GetMutedWordsByUserID :many
+select word from user_muted_words where user_id = $1 and active and not deleted order by word
+`
+
+func (q *Queries) GetMutedWordsByUserID(ctx context.Context, userID persist.DBID) ([]string, error) {
+	rows, err := q.db.Query(ctx, getMutedWordsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return nil, err
+		}
+		items = append(items, word)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getNotificationByID = `-- name: GetNotificationByID :one
@@ -3394,8 +4244,105 @@ func (q *Queries) GetOnboardingUserRecommendations(ctx context.Context, limit in
 	return items, nil
 }
 
+const getPinnableTokenDefinitionsByGalleryID = `-- name: GetPinnableTokenDefinitionsByGalleryID :many
+select td.id, td.created_at, td.last_updated, td.deleted, td.name, td.description, td.token_type, td.token_id, td.external_url, td.chain, td.metadata, td.fallback_media, td.contract_address, td.contract_id, td.token_media_id, td.is_fxhash from token_definitions td
+where not td.deleted and td.id in (
+    select t.token_definition_id
+    from galleries g, collections c, tokens t
+    where g.id = $1
+        and c.id = any(g.collections)
+        and t.id = any(c.nfts)
+        and t.owner_user_id = g.owner_user_id
+        and t.displayable
+        and not g.deleted
+        and not g.hidden
+        and not c.deleted
+        and not c.hidden
+        and not t.deleted
+)
+`
+
+func (q *Queries) GetPinnableTokenDefinitionsByGalleryID(ctx context.Context, galleryID persist.DBID) ([]TokenDefinition, error) {
+	rows, err := q.db.Query(ctx, getPinnableTokenDefinitionsByGalleryID, galleryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TokenDefinition
+	for rows.Next() {
+		var i TokenDefinition
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.Name,
+			&i.Description,
+			&i.TokenType,
+			&i.TokenID,
+			&i.ExternalUrl,
+			&i.Chain,
+			&i.Metadata,
+			&i.FallbackMedia,
+			&i.ContractAddress,
+			&i.ContractID,
+			&i.TokenMediaID,
+			&i.IsFxhash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollVoteByVoterID = `-- name: GetPollVoteByVoterID :one
+select option_index from poll_votes where attachment_id = $1 and voter_id = $2 and not deleted
+`
+
+type GetPollVoteByVoterIDParams struct {
+	AttachmentID persist.DBID `db:"attachment_id" json:"attachment_id"`
+	VoterID      persist.DBID `db:"voter_id" json:"voter_id"`
+}
+
+func (q *Queries) GetPollVoteByVoterID(ctx context.Context, arg GetPollVoteByVoterIDParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getPollVoteByVoterID, arg.AttachmentID, arg.VoterID)
+	var option_index int32
+	err := row.Scan(&option_index)
+	return option_index, err
+}
+
+const getPostAttachmentByID = `-- name: GetPostAttachmentByID :one
+select id, created_at, last_updated, deleted, post_id, attachment_type, poll_options, poll_closes_at, link_url, link_title, link_description, link_image_url, link_site_name, community_id from post_attachments where id = $1 and not deleted
+`
+
+func (q *Queries) GetPostAttachmentByID(ctx context.Context, iD persist.DBID) (PostAttachment, error) {
+	row := q.db.QueryRow(ctx, getPostAttachmentByID, iD)
+	var i PostAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Deleted,
+		&i.PostID,
+		&i.AttachmentType,
+		&i.PollOptions,
+		&i.PollClosesAt,
+		&i.LinkUrl,
+		&i.LinkTitle,
+		&i.LinkDescription,
+		&i.LinkImageUrl,
+		&i.LinkSiteName,
+		&i.CommunityID,
+	)
+	return i, err
+}
+
 const getPostByID = `-- name: GetPostByID :one
-SELECT id, version, token_ids, contract_ids, actor_id, caption, created_at, last_updated, deleted, is_first_post, user_mint_url FROM posts WHERE id = $1 AND deleted = false
+SELECT id, version, token_ids, contract_ids, actor_id, caption, created_at, last_updated, deleted, is_first_post, user_mint_url, quoted_post_id, edited_at FROM posts WHERE id = $1 AND deleted = false
 `
 
 func (q *Queries) GetPostByID(ctx context.Context, id persist.DBID) (Post, error) {
@@ -3413,12 +4360,52 @@ func (q *Queries) GetPostByID(ctx context.Context, id persist.DBID) (Post, error
 		&i.Deleted,
 		&i.IsFirstPost,
 		&i.UserMintUrl,
+		&i.QuotedPostID,
+		&i.EditedAt,
 	)
 	return i, err
 }
 
+const getPostDraftsByActorID = `-- name: GetPostDraftsByActorID :many
+select id, created_at, last_updated, deleted, actor_id, token_ids, caption, mentions, user_mint_url, publish_at, published_at, post_id, claimed_at from post_drafts where actor_id = $1 and published_at is null and not deleted order by last_updated desc
+`
+
+func (q *Queries) GetPostDraftsByActorID(ctx context.Context, actorID persist.DBID) ([]PostDraft, error) {
+	rows, err := q.db.Query(ctx, getPostDraftsByActorID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostDraft
+	for rows.Next() {
+		var i PostDraft
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.ActorID,
+			&i.TokenIds,
+			&i.Caption,
+			&i.Mentions,
+			&i.UserMintUrl,
+			&i.PublishAt,
+			&i.PublishedAt,
+			&i.PostID,
+			&i.ClaimedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPotentialENSProfileImageByUserId = `-- name: GetPotentialENSProfileImageByUserId :one
-select token_definitions.id, token_definitions.created_at, token_definitions.last_updated, token_definitions.deleted, token_definitions.name, token_definitions.description, token_definitions.token_type, token_definitions.token_id, token_definitions.external_url, token_definitions.chain, token_definitions.metadata, token_definitions.fallback_media, token_definitions.contract_address, token_definitions.contract_id, token_definitions.token_media_id, token_definitions.is_fxhash, token_medias.id, token_medias.created_at, token_medias.last_updated, token_medias.version, token_medias.active, token_medias.media, token_medias.processing_job_id, token_medias.deleted, token_medias.chain, token_medias.contract_address, token_medias.token_id, wallets.id, wallets.created_at, wallets.last_updated, wallets.deleted, wallets.version, wallets.address, wallets.wallet_type, wallets.chain, wallets.l1_chain
+select token_definitions.id, token_definitions.created_at, token_definitions.last_updated, token_definitions.deleted, token_definitions.name, token_definitions.description, token_definitions.token_type, token_definitions.token_id, token_definitions.external_url, token_definitions.chain, token_definitions.metadata, token_definitions.fallback_media, token_definitions.contract_address, token_definitions.contract_id, token_definitions.token_media_id, token_definitions.is_fxhash, token_medias.id, token_medias.created_at, token_medias.last_updated, token_medias.version, token_medias.active, token_medias.media, token_medias.processing_job_id, token_medias.deleted, token_medias.chain, token_medias.contract_address, token_medias.token_id, token_medias.pipeline_trace, wallets.id, wallets.created_at, wallets.last_updated, wallets.deleted, wallets.version, wallets.address, wallets.wallet_type, wallets.chain, wallets.l1_chain
 from token_definitions, tokens, users, token_medias, wallets, unnest(tokens.owned_by_wallets) tw(id)
 where token_definitions.contract_address = $1
     and token_definitions.chain = $2
@@ -3481,6 +4468,7 @@ func (q *Queries) GetPotentialENSProfileImageByUserId(ctx context.Context, arg G
 		&i.TokenMedia.Chain,
 		&i.TokenMedia.ContractAddress,
 		&i.TokenMedia.TokenID,
+		&i.TokenMedia.PipelineTrace,
 		&i.Wallet.ID,
 		&i.Wallet.CreatedAt,
 		&i.Wallet.LastUpdated,
@@ -4163,73 +5151,248 @@ func (q *Queries) GetTokenDefinitionById(ctx context.Context, id persist.DBID) (
 	return i, err
 }
 
-const getTokenDefinitionByTokenDbid = `-- name: GetTokenDefinitionByTokenDbid :one
-select token_definitions.id, token_definitions.created_at, token_definitions.last_updated, token_definitions.deleted, token_definitions.name, token_definitions.description, token_definitions.token_type, token_definitions.token_id, token_definitions.external_url, token_definitions.chain, token_definitions.metadata, token_definitions.fallback_media, token_definitions.contract_address, token_definitions.contract_id, token_definitions.token_media_id, token_definitions.is_fxhash
-from token_definitions, tokens
-where token_definitions.id = tokens.token_definition_id
-    and tokens.id = $1
-    and not tokens.deleted
-    and not token_definitions.deleted
+const getTokenDefinitionByTokenDbid = `-- name: GetTokenDefinitionByTokenDbid :one
+select token_definitions.id, token_definitions.created_at, token_definitions.last_updated, token_definitions.deleted, token_definitions.name, token_definitions.description, token_definitions.token_type, token_definitions.token_id, token_definitions.external_url, token_definitions.chain, token_definitions.metadata, token_definitions.fallback_media, token_definitions.contract_address, token_definitions.contract_id, token_definitions.token_media_id, token_definitions.is_fxhash
+from token_definitions, tokens
+where token_definitions.id = tokens.token_definition_id
+    and tokens.id = $1
+    and not tokens.deleted
+    and not token_definitions.deleted
+`
+
+func (q *Queries) GetTokenDefinitionByTokenDbid(ctx context.Context, id persist.DBID) (TokenDefinition, error) {
+	row := q.db.QueryRow(ctx, getTokenDefinitionByTokenDbid, id)
+	var i TokenDefinition
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Deleted,
+		&i.Name,
+		&i.Description,
+		&i.TokenType,
+		&i.TokenID,
+		&i.ExternalUrl,
+		&i.Chain,
+		&i.Metadata,
+		&i.FallbackMedia,
+		&i.ContractAddress,
+		&i.ContractID,
+		&i.TokenMediaID,
+		&i.IsFxhash,
+	)
+	return i, err
+}
+
+const getTokenDefinitionByTokenIdentifiers = `-- name: GetTokenDefinitionByTokenIdentifiers :one
+select id, created_at, last_updated, deleted, name, description, token_type, token_id, external_url, chain, metadata, fallback_media, contract_address, contract_id, token_media_id, is_fxhash
+from token_definitions
+where (chain, contract_address, token_id) = ($1, $2::address, $3::hextokenid) and not deleted
+`
+
+type GetTokenDefinitionByTokenIdentifiersParams struct {
+	Chain           persist.Chain      `db:"chain" json:"chain"`
+	ContractAddress persist.Address    `db:"contract_address" json:"contract_address"`
+	TokenID         persist.HexTokenID `db:"token_id" json:"token_id"`
+}
+
+func (q *Queries) GetTokenDefinitionByTokenIdentifiers(ctx context.Context, arg GetTokenDefinitionByTokenIdentifiersParams) (TokenDefinition, error) {
+	row := q.db.QueryRow(ctx, getTokenDefinitionByTokenIdentifiers, arg.Chain, arg.ContractAddress, arg.TokenID)
+	var i TokenDefinition
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Deleted,
+		&i.Name,
+		&i.Description,
+		&i.TokenType,
+		&i.TokenID,
+		&i.ExternalUrl,
+		&i.Chain,
+		&i.Metadata,
+		&i.FallbackMedia,
+		&i.ContractAddress,
+		&i.ContractID,
+		&i.TokenMediaID,
+		&i.IsFxhash,
+	)
+	return i, err
+}
+
+const getTokenDefinitionIDsByContractIDAndTokenIDs = `-- name: GetTokenDefinitionIDsByContractIDAndTokenIDs :many
+select id from token_definitions where contract_id = $1 and token_id = any($2::varchar[]) and not deleted
+`
+
+type GetTokenDefinitionIDsByContractIDAndTokenIDsParams struct {
+	ContractID persist.DBID `db:"contract_id" json:"contract_id"`
+	TokenIds   []string     `db:"token_ids" json:"token_ids"`
+}
+
+func (q *Queries) GetTokenDefinitionIDsByContractIDAndTokenIDs(ctx context.Context, arg GetTokenDefinitionIDsByContractIDAndTokenIDsParams) ([]persist.DBID, error) {
+	rows, err := q.db.Query(ctx, getTokenDefinitionIDsByContractIDAndTokenIDs, arg.ContractID, arg.TokenIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []persist.DBID
+	for rows.Next() {
+		var id persist.DBID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTokenDefinitionMetadataByCommunityID = `-- name: GetTokenDefinitionMetadataByCommunityID :many
+select td.id, td.metadata from token_definitions td
+where not td.deleted and (
+    td.contract_id = (select contract_id from communities where id = $1 and community_type = 0 and not deleted)
+    or td.id in (select token_definition_id from token_community_memberships where community_id = $1 and not deleted)
+)
+`
+
+type GetTokenDefinitionMetadataByCommunityIDRow struct {
+	ID       persist.DBID          `db:"id" json:"id"`
+	Metadata persist.TokenMetadata `db:"metadata" json:"metadata"`
+}
+
+func (q *Queries) GetTokenDefinitionMetadataByCommunityID(ctx context.Context, communityID persist.DBID) ([]GetTokenDefinitionMetadataByCommunityIDRow, error) {
+	rows, err := q.db.Query(ctx, getTokenDefinitionMetadataByCommunityID, communityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTokenDefinitionMetadataByCommunityIDRow
+	for rows.Next() {
+		var i GetTokenDefinitionMetadataByCommunityIDRow
+		if err := rows.Scan(&i.ID, &i.Metadata); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTokenDefinitionSpamSamplesByContractID = `-- name: GetTokenDefinitionSpamSamplesByContractID :many
+select name, description, external_url from token_definitions where contract_id = $1 and not deleted order by id limit $2
+`
+
+type GetTokenDefinitionSpamSamplesByContractIDParams struct {
+	ContractID persist.DBID `db:"contract_id" json:"contract_id"`
+	Limit      int32        `db:"limit" json:"limit"`
+}
+
+type GetTokenDefinitionSpamSamplesByContractIDRow struct {
+	Name        sql.NullString `db:"name" json:"name"`
+	Description sql.NullString `db:"description" json:"description"`
+	ExternalUrl sql.NullString `db:"external_url" json:"external_url"`
+}
+
+func (q *Queries) GetTokenDefinitionSpamSamplesByContractID(ctx context.Context, arg GetTokenDefinitionSpamSamplesByContractIDParams) ([]GetTokenDefinitionSpamSamplesByContractIDRow, error) {
+	rows, err := q.db.Query(ctx, getTokenDefinitionSpamSamplesByContractID, arg.ContractID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTokenDefinitionSpamSamplesByContractIDRow
+	for rows.Next() {
+		var i GetTokenDefinitionSpamSamplesByContractIDRow
+		if err := rows.Scan(&i.Name, &i.Description, &i.ExternalUrl); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTokenDefinitionTokenIDsByContractID = `-- name: GetTokenDefinitionTokenIDsByContractID :many
+select id, token_id from token_definitions where contract_id = $1 and not deleted
 `
 
-func (q *Queries) GetTokenDefinitionByTokenDbid(ctx context.Context, id persist.DBID) (TokenDefinition, error) {
-	row := q.db.QueryRow(ctx, getTokenDefinitionByTokenDbid, id)
-	var i TokenDefinition
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.LastUpdated,
-		&i.Deleted,
-		&i.Name,
-		&i.Description,
-		&i.TokenType,
-		&i.TokenID,
-		&i.ExternalUrl,
-		&i.Chain,
-		&i.Metadata,
-		&i.FallbackMedia,
-		&i.ContractAddress,
-		&i.ContractID,
-		&i.TokenMediaID,
-		&i.IsFxhash,
-	)
-	return i, err
+type GetTokenDefinitionTokenIDsByContractIDRow struct {
+	ID      persist.DBID       `db:"id" json:"id"`
+	TokenID persist.HexTokenID `db:"token_id" json:"token_id"`
 }
 
-const getTokenDefinitionByTokenIdentifiers = `-- name: GetTokenDefinitionByTokenIdentifiers :one
-select id, created_at, last_updated, deleted, name, description, token_type, token_id, external_url, chain, metadata, fallback_media, contract_address, contract_id, token_media_id, is_fxhash
-from token_definitions
-where (chain, contract_address, token_id) = ($1, $2::address, $3::hextokenid) and not deleted
+func (q *Queries) GetTokenDefinitionTokenIDsByContractID(ctx context.Context, contractID persist.DBID) ([]GetTokenDefinitionTokenIDsByContractIDRow, error) {
+	rows, err := q.db.Query(ctx, getTokenDefinitionTokenIDsByContractID, contractID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTokenDefinitionTokenIDsByContractIDRow
+	for rows.Next() {
+		var i GetTokenDefinitionTokenIDsByContractIDRow
+		if err := rows.Scan(&i.ID, &i.TokenID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTokenEditionsByTokenIDs = `-- name: GetTokenEditionsByTokenIDs :many
+select t.id, t.owner_user_id, t.quantity, td.contract_id, td.name, td.description,
+    coalesce(td.metadata->>'image', '')::varchar as image_url,
+    coalesce(td.metadata->>'animation_url', '')::varchar as animation_url
+from tokens t
+    join token_definitions td on td.id = t.token_definition_id
+where t.id = any($1::dbid[]) and not t.deleted and not td.deleted
 `
 
-type GetTokenDefinitionByTokenIdentifiersParams struct {
-	Chain           persist.Chain      `db:"chain" json:"chain"`
-	ContractAddress persist.Address    `db:"contract_address" json:"contract_address"`
-	TokenID         persist.HexTokenID `db:"token_id" json:"token_id"`
+type GetTokenEditionsByTokenIDsRow struct {
+	ID           persist.DBID      `db:"id" json:"id"`
+	OwnerUserID  persist.DBID      `db:"owner_user_id" json:"owner_user_id"`
+	Quantity     persist.HexString `db:"quantity" json:"quantity"`
+	ContractID   persist.DBID      `db:"contract_id" json:"contract_id"`
+	Name         sql.NullString    `db:"name" json:"name"`
+	Description  sql.NullString    `db:"description" json:"description"`
+	ImageUrl     string            `db:"image_url" json:"image_url"`
+	AnimationUrl string            `db:"animation_url" json:"animation_url"`
 }
 
-func (q *Queries) GetTokenDefinitionByTokenIdentifiers(ctx context.Context, arg GetTokenDefinitionByTokenIdentifiersParams) (TokenDefinition, error) {
-	row := q.db.QueryRow(ctx, getTokenDefinitionByTokenIdentifiers, arg.Chain, arg.ContractAddress, arg.TokenID)
-	var i TokenDefinition
-	err := row.Scan(
-		&i.ID,
-		&i.CreatedAt,
-		&i.LastUpdated,
-		&i.Deleted,
-		&i.Name,
-		&i.Description,
-		&i.TokenType,
-		&i.TokenID,
-		&i.ExternalUrl,
-		&i.Chain,
-		&i.Metadata,
-		&i.FallbackMedia,
-		&i.ContractAddress,
-		&i.ContractID,
-		&i.TokenMediaID,
-		&i.IsFxhash,
-	)
-	return i, err
+func (q *Queries) GetTokenEditionsByTokenIDs(ctx context.Context, tokenIds []persist.DBID) ([]GetTokenEditionsByTokenIDsRow, error) {
+	rows, err := q.db.Query(ctx, getTokenEditionsByTokenIDs, tokenIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTokenEditionsByTokenIDsRow
+	for rows.Next() {
+		var i GetTokenEditionsByTokenIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerUserID,
+			&i.Quantity,
+			&i.ContractID,
+			&i.Name,
+			&i.Description,
+			&i.ImageUrl,
+			&i.AnimationUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getTokenFullDetailsByUserTokenIdentifiers = `-- name: GetTokenFullDetailsByUserTokenIdentifiers :one
@@ -4768,6 +5931,38 @@ func (q *Queries) GetUniqueTokenIdentifiersByTokenID(ctx context.Context, id per
 	return i, err
 }
 
+const getUnreferencedIPFSPinsByCIDs = `-- name: GetUnreferencedIPFSPinsByCIDs :many
+select id, created_at, last_updated, cid, status, remote_request, last_error from ipfs_pins p where p.cid = any($1::varchar[]) and p.status = 'pinned' and not exists (select 1 from ipfs_pin_references r where r.cid = p.cid)
+`
+
+func (q *Queries) GetUnreferencedIPFSPinsByCIDs(ctx context.Context, cids []string) ([]IpfsPin, error) {
+	rows, err := q.db.Query(ctx, getUnreferencedIPFSPinsByCIDs, cids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IpfsPin
+	for rows.Next() {
+		var i IpfsPin
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Cid,
+			&i.Status,
+			&i.RemoteRequest,
+			&i.LastError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByAddressAndL1 = `-- name: GetUserByAddressAndL1 :one
 select users.id, users.deleted, users.version, users.last_updated, users.created_at, users.username, users.username_idempotent, users.wallets, users.bio, users.traits, users.universal, users.notification_settings, users.email_unsubscriptions, users.featured_gallery, users.primary_wallet_id, users.user_experiences, users.profile_image_id, users.persona
 from users, wallets
@@ -6025,6 +7220,20 @@ func (q *Queries) InsertCommentMention(ctx context.Context, arg InsertCommentMen
 	return i, err
 }
 
+const insertGalleryPinReferences = `-- name: InsertGalleryPinReferences :exec
+insert into ipfs_pin_references (gallery_id, cid) select $1, unnest($2::varchar[]) on conflict do nothing
+`
+
+type InsertGalleryPinReferencesParams struct {
+	GalleryID persist.DBID `db:"gallery_id" json:"gallery_id"`
+	Cids      []string     `db:"cids" json:"cids"`
+}
+
+func (q *Queries) InsertGalleryPinReferences(ctx context.Context, arg InsertGalleryPinReferencesParams) error {
+	_, err := q.db.Exec(ctx, insertGalleryPinReferences, arg.GalleryID, arg.Cids)
+	return err
+}
+
 const insertMention = `-- name: InsertMention :one
 insert into mentions (id, comment_id, user_id, community_id, start, length) values ($1, $2, $5::text, $6::text, $3, $4) returning id
 `
@@ -6052,20 +7261,41 @@ func (q *Queries) InsertMention(ctx context.Context, arg InsertMentionParams) (p
 	return id, err
 }
 
+const insertPollVote = `-- name: InsertPollVote :one
+insert into poll_votes (id, attachment_id, voter_id, option_index) values ($1, $2, $3, $4)
+on conflict (attachment_id, voter_id) where not deleted do nothing
+returning id
+`
+
+type InsertPollVoteParams struct {
+	ID           persist.DBID `db:"id" json:"id"`
+	AttachmentID persist.DBID `db:"attachment_id" json:"attachment_id"`
+	VoterID      persist.DBID `db:"voter_id" json:"voter_id"`
+	OptionIndex  int32        `db:"option_index" json:"option_index"`
+}
+
+func (q *Queries) InsertPollVote(ctx context.Context, arg InsertPollVoteParams) (persist.DBID, error) {
+	row := q.db.QueryRow(ctx, insertPollVote, arg.ID, arg.AttachmentID, arg.VoterID, arg.OptionIndex)
+	var id persist.DBID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const insertPost = `-- name: InsertPost :one
-insert into posts(id, token_ids, contract_ids, actor_id, caption, user_mint_url, is_first_post, created_at)
-values ($1, $2, $3, $4, $5, $6, not exists(select 1 from posts where posts.created_at < now() and posts.actor_id = $4::varchar limit 1), now())
+insert into posts(id, token_ids, contract_ids, actor_id, caption, user_mint_url, quoted_post_id, is_first_post, created_at)
+values ($1, $2, $3, $4, $5, $6, nullif($7::dbid, ''), not exists(select 1 from posts where posts.created_at < now() and posts.actor_id = $4 limit 1), now())
 on conflict (actor_id, is_first_post) where is_first_post do update set is_first_post = false
 returning id
 `
 
 type InsertPostParams struct {
-	ID          persist.DBID     `db:"id" json:"id"`
-	TokenIds    persist.DBIDList `db:"token_ids" json:"token_ids"`
-	ContractIds persist.DBIDList `db:"contract_ids" json:"contract_ids"`
-	ActorID     persist.DBID     `db:"actor_id" json:"actor_id"`
-	Caption     sql.NullString   `db:"caption" json:"caption"`
-	UserMintUrl sql.NullString   `db:"user_mint_url" json:"user_mint_url"`
+	ID           persist.DBID     `db:"id" json:"id"`
+	TokenIds     persist.DBIDList `db:"token_ids" json:"token_ids"`
+	ContractIds  persist.DBIDList `db:"contract_ids" json:"contract_ids"`
+	ActorID      persist.DBID     `db:"actor_id" json:"actor_id"`
+	Caption      sql.NullString   `db:"caption" json:"caption"`
+	UserMintUrl  sql.NullString   `db:"user_mint_url" json:"user_mint_url"`
+	QuotedPostID persist.DBID     `db:"quoted_post_id" json:"quoted_post_id"`
 }
 
 func (q *Queries) InsertPost(ctx context.Context, arg InsertPostParams) (persist.DBID, error) {
@@ -6076,12 +7306,93 @@ func (q *Queries) InsertPost(ctx context.Context, arg InsertPostParams) (persist
 		arg.ActorID,
 		arg.Caption,
 		arg.UserMintUrl,
+		arg.QuotedPostID,
 	)
 	var id persist.DBID
 	err := row.Scan(&id)
 	return id, err
 }
 
+const insertPostAttachment = `-- name: InsertPostAttachment :exec
+insert into post_attachments (id, post_id, attachment_type, poll_options, poll_closes_at, link_url, link_title, link_description, link_image_url, link_site_name, community_id)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, nullif($11::dbid, ''))
+`
+
+type InsertPostAttachmentParams struct {
+	ID              persist.DBID   `db:"id" json:"id"`
+	PostID          persist.DBID   `db:"post_id" json:"post_id"`
+	AttachmentType  int32          `db:"attachment_type" json:"attachment_type"`
+	PollOptions     []string       `db:"poll_options" json:"poll_options"`
+	PollClosesAt    sql.NullTime   `db:"poll_closes_at" json:"poll_closes_at"`
+	LinkUrl         sql.NullString `db:"link_url" json:"link_url"`
+	LinkTitle       sql.NullString `db:"link_title" json:"link_title"`
+	LinkDescription sql.NullString `db:"link_description" json:"link_description"`
+	LinkImageUrl    sql.NullString `db:"link_image_url" json:"link_image_url"`
+	LinkSiteName    sql.NullString `db:"link_site_name" json:"link_site_name"`
+	CommunityID     persist.DBID   `db:"community_id" json:"community_id"`
+}
+
+func (q *Queries) InsertPostAttachment(ctx context.Context, arg InsertPostAttachmentParams) error {
+	_, err := q.db.Exec(ctx, insertPostAttachment,
+		arg.ID,
+		arg.PostID,
+		arg.AttachmentType,
+		arg.PollOptions,
+		arg.PollClosesAt,
+		arg.LinkUrl,
+		arg.LinkTitle,
+		arg.LinkDescription,
+		arg.LinkImageUrl,
+		arg.LinkSiteName,
+		arg.CommunityID,
+	)
+	return err
+}
+
+const insertPostDraft = `-- name: InsertPostDraft :one
+insert into post_drafts (id, actor_id, token_ids, caption, mentions, user_mint_url, publish_at)
+values ($1, $2, $3, $4, $5, $6, $7) returning id, created_at, last_updated, deleted, actor_id, token_ids, caption, mentions, user_mint_url, publish_at, published_at, post_id, claimed_at
+`
+
+type InsertPostDraftParams struct {
+	ID          persist.DBID          `db:"id" json:"id"`
+	ActorID     persist.DBID          `db:"actor_id" json:"actor_id"`
+	TokenIds    persist.DBIDList      `db:"token_ids" json:"token_ids"`
+	Caption     sql.NullString        `db:"caption" json:"caption"`
+	Mentions    persist.DraftMentions `db:"mentions" json:"mentions"`
+	UserMintUrl sql.NullString        `db:"user_mint_url" json:"user_mint_url"`
+	PublishAt   sql.NullTime          `db:"publish_at" json:"publish_at"`
+}
+
+func (q *Queries) InsertPostDraft(ctx context.Context, arg InsertPostDraftParams) (PostDraft, error) {
+	row := q.db.QueryRow(ctx, insertPostDraft,
+		arg.ID,
+		arg.ActorID,
+		arg.TokenIds,
+		arg.Caption,
+		arg.Mentions,
+		arg.UserMintUrl,
+		arg.PublishAt,
+	)
+	var i PostDraft
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Deleted,
+		&i.ActorID,
+		&i.TokenIds,
+		&i.Caption,
+		&i.Mentions,
+		&i.UserMintUrl,
+		&i.PublishAt,
+		&i.PublishedAt,
+		&i.PostID,
+		&i.ClaimedAt,
+	)
+	return i, err
+}
+
 const insertPostMention = `-- name: InsertPostMention :one
 insert into mentions (id, user_id, community_id, post_id, start, length) values ($1, $2::text, $3::text, $4, $5, $6) returning id, post_id, comment_id, user_id, start, length, created_at, deleted, community_id
 `
@@ -6119,6 +7430,40 @@ func (q *Queries) InsertPostMention(ctx context.Context, arg InsertPostMentionPa
 	return i, err
 }
 
+const insertPostRevision = `-- name: InsertPostRevision :exec
+insert into post_revisions (id, post_id, caption, written_at)
+select $1, posts.id, posts.caption, coalesce(posts.edited_at, posts.created_at) from posts where posts.id = $2 and not posts.deleted
+`
+
+type InsertPostRevisionParams struct {
+	ID     persist.DBID `db:"id" json:"id"`
+	PostID persist.DBID `db:"post_id" json:"post_id"`
+}
+
+func (q *Queries) InsertPostRevision(ctx context.Context, arg InsertPostRevisionParams) error {
+	_, err := q.db.Exec(ctx, insertPostRevision, arg.ID, arg.PostID)
+	return err
+}
+
+const insertRepost = `-- name: InsertRepost :one
+with post_to_repost as (select id from posts where posts.id = $1 and not deleted)
+insert into reposts (id, actor_id, post_id) (select $2, $3, post_to_repost.id from post_to_repost)
+on conflict(actor_id, post_id) where not deleted do update set last_updated = now() returning id
+`
+
+type InsertRepostParams struct {
+	PostID  persist.DBID `db:"post_id" json:"post_id"`
+	ID      persist.DBID `db:"id" json:"id"`
+	ActorID persist.DBID `db:"actor_id" json:"actor_id"`
+}
+
+func (q *Queries) InsertRepost(ctx context.Context, arg InsertRepostParams) (persist.DBID, error) {
+	row := q.db.QueryRow(ctx, insertRepost, arg.PostID, arg.ID, arg.ActorID)
+	var id persist.DBID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const insertSpamContracts = `-- name: InsertSpamContracts :exec
 with insert_spam_contracts as (
     insert into alchemy_spam_contracts (id, chain, address, created_at, is_spam) (
@@ -6159,9 +7504,9 @@ with insert_job(id) as (
     returning id
 )
 , set_conditionally_current_media_to_inactive(last_updated) as (
-    insert into token_medias (id, media, processing_job_id, active, created_at, last_updated)
+    insert into token_medias (id, media, processing_job_id, active, created_at, last_updated, pipeline_trace)
     (
-        select $6, media, processing_job_id, false, created_at, now()
+        select $6, media, processing_job_id, false, created_at, now(), pipeline_trace
         from token_medias
         where id = (select token_media_id from token_definitions td where (td.chain, td.contract_address, td.token_id) = ($7, $8::address, $9::hextokenid) and not deleted)
         and not deleted
@@ -6170,19 +7515,20 @@ with insert_job(id) as (
     returning last_updated
 )
 , insert_new_media as (
-    insert into token_medias (id, chain, contract_address, token_id, media, processing_job_id, active, created_at, last_updated)
+    insert into token_medias (id, chain, contract_address, token_id, media, processing_job_id, active, created_at, last_updated, pipeline_trace)
     values ($11, $7, $8, $12, $13::jsonb, (select id from insert_job), $10,
         -- Using timestamps generated from set_conditionally_current_media_to_inactive ensures that the new record is only inserted after the current media is moved
         (select coalesce((select last_updated from set_conditionally_current_media_to_inactive), now())),
-        (select coalesce((select last_updated from set_conditionally_current_media_to_inactive), now()))
+        (select coalesce((select last_updated from set_conditionally_current_media_to_inactive), now())),
+        $14
     )
-    returning id, created_at, last_updated, version, active, media, processing_job_id, deleted, chain, contract_address, token_id
+    returning id, created_at, last_updated, version, active, media, processing_job_id, deleted, chain, contract_address, token_id, pipeline_trace
 )
 , update_token_definition as (
     update token_definitions
-    set metadata = $14::jsonb,
-        name = $15,
-        description = $16,
+    set metadata = $15::jsonb,
+        name = $16,
+        description = $17,
         last_updated = (select last_updated from insert_new_media),
         token_media_id = case
             -- If there isn't any media, use the new media regardless of its status
@@ -6194,7 +7540,7 @@ with insert_job(id) as (
         end
     where (chain, contract_address, token_id) = ($7, $8, $9) and not deleted
 )
-select token_medias.id, token_medias.created_at, token_medias.last_updated, token_medias.version, token_medias.active, token_medias.media, token_medias.processing_job_id, token_medias.deleted, token_medias.chain, token_medias.contract_address, token_medias.token_id from insert_new_media token_medias
+select token_medias.id, token_medias.created_at, token_medias.last_updated, token_medias.version, token_medias.active, token_medias.media, token_medias.processing_job_id, token_medias.deleted, token_medias.chain, token_medias.contract_address, token_medias.token_id, token_medias.pipeline_trace from insert_new_media token_medias
 `
 
 type InsertTokenPipelineResultsParams struct {
@@ -6211,6 +7557,7 @@ type InsertTokenPipelineResultsParams struct {
 	NewMediaID       persist.DBID             `db:"new_media_id" json:"new_media_id"`
 	DecimalTokenID   persist.DecimalTokenID   `db:"decimal_token_id" json:"decimal_token_id"`
 	NewMedia         pgtype.JSONB             `db:"new_media" json:"new_media"`
+	PipelineTrace    persist.PipelineTrace    `db:"pipeline_trace" json:"pipeline_trace"`
 	NewMetadata      pgtype.JSONB             `db:"new_metadata" json:"new_metadata"`
 	NewName          sql.NullString           `db:"new_name" json:"new_name"`
 	NewDescription   sql.NullString           `db:"new_description" json:"new_description"`
@@ -6236,6 +7583,7 @@ func (q *Queries) InsertTokenPipelineResults(ctx context.Context, arg InsertToke
 		arg.NewMediaID,
 		arg.DecimalTokenID,
 		arg.NewMedia,
+		arg.PipelineTrace,
 		arg.NewMetadata,
 		arg.NewName,
 		arg.NewDescription,
@@ -6253,6 +7601,7 @@ func (q *Queries) InsertTokenPipelineResults(ctx context.Context, arg InsertToke
 		&i.TokenMedia.Chain,
 		&i.TokenMedia.ContractAddress,
 		&i.TokenMedia.TokenID,
+		&i.TokenMedia.PipelineTrace,
 	)
 	return i, err
 }
@@ -6445,11 +7794,129 @@ SELECT exists(
 )
 `
 
-func (q *Queries) IsFeedEventExistsForGroup(ctx context.Context, groupID sql.NullString) (bool, error) {
-	row := q.db.QueryRow(ctx, isFeedEventExistsForGroup, groupID)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
+func (q *Queries) IsFeedEventExistsForGroup(ctx context.Context, groupID sql.NullString) (bool, error) {
+	row := q.db.QueryRow(ctx, isFeedEventExistsForGroup, groupID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const isIPFSPinReferenced = `-- name: IsIPFSPinReferenced :one
+select exists(select 1 from ipfs_pin_references where cid = $1)
+`
+
+func (q *Queries) IsIPFSPinReferenced(ctx context.Context, cid string) (bool, error) {
+	row := q.db.QueryRow(ctx, isIPFSPinReferenced, cid)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const lockGalleryPinReferences = `-- name: LockGalleryPinReferences :exec
+select pg_advisory_xact_lock(hashtext('ipfs_pin_references'), hashtext($1::varchar))
+`
+
+func (q *Queries) LockGalleryPinReferences(ctx context.Context, galleryID string) error {
+	_, err := q.db.Exec(ctx, lockGalleryPinReferences, galleryID)
+	return err
+}
+
+const lockIPFSPins = `-- name: LockIPFSPins :exec
+select pg_advisory_xact_lock(hashtext('ipfs_pins'), hashtext(c.cid))
+from (select unnest($1::varchar[]) as cid order by cid) c
+`
+
+func (q *Queries) LockIPFSPins(ctx context.Context, cids []string) error {
+	_, err := q.db.Exec(ctx, lockIPFSPins, cids)
+	return err
+}
+
+const lockIPFSPinsForGallery = `-- name: LockIPFSPinsForGallery :exec
+select pg_advisory_xact_lock(hashtext('ipfs_pins'), hashtext(c.cid))
+from (
+    select unnest($1::varchar[]) as cid
+    union
+    select cid from ipfs_pin_references where gallery_id = $2
+    order by cid
+) c
+`
+
+type LockIPFSPinsForGalleryParams struct {
+	Cids      []string     `db:"cids" json:"cids"`
+	GalleryID persist.DBID `db:"gallery_id" json:"gallery_id"`
+}
+
+func (q *Queries) LockIPFSPinsForGallery(ctx context.Context, arg LockIPFSPinsForGalleryParams) error {
+	_, err := q.db.Exec(ctx, lockIPFSPinsForGallery, arg.Cids, arg.GalleryID)
+	return err
+}
+
+const markPostDraftPublished = `-- name: MarkPostDraftPublished :exec
+update post_drafts set post_id = $1, published_at = now(), last_updated = now() where id = $2 and not deleted
+`
+
+type MarkPostDraftPublishedParams struct {
+	PostID persist.DBID `db:"post_id" json:"post_id"`
+	ID     persist.DBID `db:"id" json:"id"`
+}
+
+func (q *Queries) MarkPostDraftPublished(ctx context.Context, arg MarkPostDraftPublishedParams) error {
+	_, err := q.db.Exec(ctx, markPostDraftPublished, arg.PostID, arg.ID)
+	return err
+}
+
+const markPostNotInterested = `-- name: MarkPostNotInterested :one
+with post_to_mark as (select id from posts where posts.id = $1 and not deleted)
+insert into not_interested_posts (id, user_id, post_id) (select $2, $3, post_to_mark.id from post_to_mark)
+on conflict(user_id, post_id) where not deleted do update set last_updated = now() returning id
+`
+
+type MarkPostNotInterestedParams struct {
+	PostID persist.DBID `db:"post_id" json:"post_id"`
+	ID     persist.DBID `db:"id" json:"id"`
+	UserID persist.DBID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) MarkPostNotInterested(ctx context.Context, arg MarkPostNotInterestedParams) (persist.DBID, error) {
+	row := q.db.QueryRow(ctx, markPostNotInterested, arg.PostID, arg.ID, arg.UserID)
+	var id persist.DBID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const muteCommunity = `-- name: MuteCommunity :one
+with community_to_mute as (select id from communities where communities.id = $1 and not deleted)
+insert into user_muted_communities (id, user_id, community_id, active) (select $2, $3, community_to_mute.id, true from community_to_mute)
+on conflict(user_id, community_id) where not deleted do update set active = true, last_updated = now() returning id
+`
+
+type MuteCommunityParams struct {
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	ID          persist.DBID `db:"id" json:"id"`
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) MuteCommunity(ctx context.Context, arg MuteCommunityParams) (persist.DBID, error) {
+	row := q.db.QueryRow(ctx, muteCommunity, arg.CommunityID, arg.ID, arg.UserID)
+	var id persist.DBID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const muteWord = `-- name: MuteWord :exec
+insert into user_muted_words (id, user_id, word, active) values ($1, $2, $3, true)
+on conflict(user_id, word) where not deleted do update set active = true, last_updated = now()
+`
+
+type MuteWordParams struct {
+	ID     persist.DBID `db:"id" json:"id"`
+	UserID persist.DBID `db:"user_id" json:"user_id"`
+	Word   string       `db:"word" json:"word"`
+}
+
+func (q *Queries) MuteWord(ctx context.Context, arg MuteWordParams) error {
+	_, err := q.db.Exec(ctx, muteWord, arg.ID, arg.UserID, arg.Word)
+	return err
 }
 
 const paginateGlobalFeed = `-- name: PaginateGlobalFeed :many
@@ -6459,6 +7926,8 @@ left join feed_blocklist fb on fe.actor_id = fb.user_id and not fb.deleted and f
 where (fe.created_at, fe.id) < ($1, $2::dbid)
         and (fe.created_at, fe.id) > ($3, $4::dbid)
         and (fb.user_id is null or $5 = fb.user_id)
+        -- every post is already in the global feed, so reposts would only repeat them
+        and fe.feed_entity_type != 2
 order by
     case when $6::bool then (fe.created_at, fe.id) end asc,
     case when not $6::bool then (fe.created_at, fe.id) end desc
@@ -6515,6 +7984,23 @@ select fe.id, fe.feed_entity_type, fe.created_at, fe.actor_id from feed_entities
       and fl.follower = $1
       and (fe.created_at, fe.id) < ($2, $3::dbid)
       and (fe.created_at, fe.id) > ($4, $5::dbid)
+      and not exists (select 1 from muted_posts mp where mp.user_id = $1 and mp.post_id = fe.id)
+      -- A reposted post is only shown once: skip reposts of posts that are muted, that the viewer already sees because
+      -- they follow the author, or that someone else the viewer follows reposted more recently
+      and not exists (
+        select 1 from reposts r
+        join posts p on p.id = r.post_id
+        where r.id = fe.id
+          and (
+            exists (select 1 from muted_posts mp where mp.user_id = $1 and mp.post_id = r.post_id)
+            or exists (select 1 from follows f where f.follower = $1 and f.followee = p.actor_id and not f.deleted)
+            or exists (
+              select 1 from reposts later
+              join follows f on f.followee = later.actor_id and f.follower = $1 and not f.deleted
+              where later.post_id = r.post_id and not later.deleted and (later.created_at, later.id) > (r.created_at, r.id)
+            )
+          )
+      )
 order by
     case when $6::bool then (fe.created_at, fe.id) end asc,
     case when not $6::bool then (fe.created_at, fe.id) end desc
@@ -6578,7 +8064,7 @@ with valid_post_ids as (
     WHERE $7 = ANY(posts.contract_ids)
       AND posts.deleted = false
 )
-SELECT posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url from posts
+SELECT posts.id, posts.version, posts.token_ids, posts.contract_ids, posts.actor_id, posts.caption, posts.created_at, posts.last_updated, posts.deleted, posts.is_first_post, posts.user_mint_url, posts.quoted_post_id, posts.edited_at from posts
     join valid_post_ids on posts.id = valid_post_ids.id
 WHERE (posts.created_at, posts.id) < ($1, $2::dbid)
   AND (posts.created_at, posts.id) > ($3, $4::dbid)
@@ -6629,6 +8115,8 @@ func (q *Queries) PaginatePostsByContractIDAndProjectID(ctx context.Context, arg
 			&i.Deleted,
 			&i.IsFirstPost,
 			&i.UserMintUrl,
+			&i.QuotedPostID,
+			&i.EditedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -6641,7 +8129,7 @@ func (q *Queries) PaginatePostsByContractIDAndProjectID(ctx context.Context, arg
 }
 
 const paginatePostsByUserID = `-- name: PaginatePostsByUserID :many
-select id, version, token_ids, contract_ids, actor_id, caption, created_at, last_updated, deleted, is_first_post, user_mint_url
+select id, version, token_ids, contract_ids, actor_id, caption, created_at, last_updated, deleted, is_first_post, user_mint_url, quoted_post_id, edited_at
 from posts
 where actor_id = $1
         and (created_at, id) < ($2, $3::dbid)
@@ -6692,6 +8180,71 @@ func (q *Queries) PaginatePostsByUserID(ctx context.Context, arg PaginatePostsBy
 			&i.Deleted,
 			&i.IsFirstPost,
 			&i.UserMintUrl,
+			&i.QuotedPostID,
+			&i.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const paginateTokenProcessingDeadLetters = `-- name: PaginateTokenProcessingDeadLetters :many
+select id, created_at, last_updated, deleted, token_definition_id, contract_id, processing_job_id, reason, processing_cause, last_error, attempts, pipeline_metadata, replayed_at from token_processing_dead_letters
+where not deleted and replayed_at is null
+    and ($2::text is null or contract_id = $2::text)
+    and (created_at, id) < ($3, $4::dbid)
+    and (created_at, id) > ($5, $6::dbid)
+order by case when $7::bool then (created_at, id) end asc,
+         case when not $7::bool then (created_at, id) end desc
+limit $1
+`
+
+type PaginateTokenProcessingDeadLettersParams struct {
+	Limit         int32          `db:"limit" json:"limit"`
+	ContractID    sql.NullString `db:"contract_id" json:"contract_id"`
+	CurBeforeTime time.Time      `db:"cur_before_time" json:"cur_before_time"`
+	CurBeforeID   persist.DBID   `db:"cur_before_id" json:"cur_before_id"`
+	CurAfterTime  time.Time      `db:"cur_after_time" json:"cur_after_time"`
+	CurAfterID    persist.DBID   `db:"cur_after_id" json:"cur_after_id"`
+	PagingForward bool           `db:"paging_forward" json:"paging_forward"`
+}
+
+func (q *Queries) PaginateTokenProcessingDeadLetters(ctx context.Context, arg PaginateTokenProcessingDeadLettersParams) ([]TokenProcessingDeadLetter, error) {
+	rows, err := q.db.Query(ctx, paginateTokenProcessingDeadLetters,
+		arg.Limit,
+		arg.ContractID,
+		arg.CurBeforeTime,
+		arg.CurBeforeID,
+		arg.CurAfterTime,
+		arg.CurAfterID,
+		arg.PagingForward,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TokenProcessingDeadLetter
+	for rows.Next() {
+		var i TokenProcessingDeadLetter
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.LastUpdated,
+			&i.Deleted,
+			&i.TokenDefinitionID,
+			&i.ContractID,
+			&i.ProcessingJobID,
+			&i.Reason,
+			&i.ProcessingCause,
+			&i.LastError,
+			&i.Attempts,
+			&i.PipelineMetadata,
+			&i.ReplayedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -6703,6 +8256,22 @@ func (q *Queries) PaginatePostsByUserID(ctx context.Context, arg PaginatePostsBy
 	return items, nil
 }
 
+const pinComment = `-- name: PinComment :exec
+insert into pinned_comments (id, post_id, comment_id) values ($1, $2, $3)
+on conflict (post_id) where not deleted do update set comment_id = excluded.comment_id, created_at = now(), last_updated = now()
+`
+
+type PinCommentParams struct {
+	ID        persist.DBID `db:"id" json:"id"`
+	PostID    persist.DBID `db:"post_id" json:"post_id"`
+	CommentID persist.DBID `db:"comment_id" json:"comment_id"`
+}
+
+func (q *Queries) PinComment(ctx context.Context, arg PinCommentParams) error {
+	_, err := q.db.Exec(ctx, pinComment, arg.ID, arg.PostID, arg.CommentID)
+	return err
+}
+
 const redeemMerch = `-- name: RedeemMerch :one
 update merch set redeemed = true, token_id = $1, last_updated = now() where id = (select m.id from merch m where m.object_type = $2 and m.token_id is null and m.redeemed = false and m.deleted = false order by m.id limit 1) and token_id is null and redeemed = false returning discount_code
 `
@@ -6818,6 +8387,61 @@ func (q *Queries) RemoveWalletFromTokens(ctx context.Context, arg RemoveWalletFr
 	return err
 }
 
+const replaceCommunityTraits = `-- name: ReplaceCommunityTraits :exec
+with deleted as (delete from community_traits where community_id = $1)
+insert into community_traits (community_id, trait_type, value, token_count)
+select $1, unnest($2::varchar[]), unnest($3::varchar[]), unnest($4::int[])
+`
+
+type ReplaceCommunityTraitsParams struct {
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	TraitTypes  []string     `db:"trait_types" json:"trait_types"`
+	TraitValues []string     `db:"trait_values" json:"trait_values"`
+	TokenCounts []int32      `db:"token_counts" json:"token_counts"`
+}
+
+func (q *Queries) ReplaceCommunityTraits(ctx context.Context, arg ReplaceCommunityTraitsParams) error {
+	_, err := q.db.Exec(ctx, replaceCommunityTraits, arg.CommunityID, arg.TraitTypes, arg.TraitValues, arg.TokenCounts)
+	return err
+}
+
+const replaceTokenDefinitionRarities = `-- name: ReplaceTokenDefinitionRarities :exec
+with deleted as (delete from token_definition_rarities where community_id = $1)
+insert into token_definition_rarities (community_id, token_definition_id, statistical_score, normalized_score, rank)
+select $1, unnest($2::varchar[]), unnest($3::float8[]), unnest($4::float8[]), unnest($5::int[])
+`
+
+type ReplaceTokenDefinitionRaritiesParams struct {
+	CommunityID        persist.DBID `db:"community_id" json:"community_id"`
+	TokenDefinitionIds []string     `db:"token_definition_ids" json:"token_definition_ids"`
+	StatisticalScores  []float64    `db:"statistical_scores" json:"statistical_scores"`
+	NormalizedScores   []float64    `db:"normalized_scores" json:"normalized_scores"`
+	Ranks              []int32      `db:"ranks" json:"ranks"`
+}
+
+func (q *Queries) ReplaceTokenDefinitionRarities(ctx context.Context, arg ReplaceTokenDefinitionRaritiesParams) error {
+	_, err := q.db.Exec(ctx, replaceTokenDefinitionRarities, arg.CommunityID, arg.TokenDefinitionIds, arg.StatisticalScores, arg.NormalizedScores, arg.Ranks)
+	return err
+}
+
+const replaceTokenDefinitionTraits = `-- name: ReplaceTokenDefinitionTraits :exec
+with deleted as (delete from token_definition_traits where community_id = $1)
+insert into token_definition_traits (community_id, token_definition_id, trait_type, value)
+select $1, unnest($2::varchar[]), unnest($3::varchar[]), unnest($4::varchar[])
+`
+
+type ReplaceTokenDefinitionTraitsParams struct {
+	CommunityID        persist.DBID `db:"community_id" json:"community_id"`
+	TokenDefinitionIds []string     `db:"token_definition_ids" json:"token_definition_ids"`
+	TraitTypes         []string     `db:"trait_types" json:"trait_types"`
+	TraitValues        []string     `db:"trait_values" json:"trait_values"`
+}
+
+func (q *Queries) ReplaceTokenDefinitionTraits(ctx context.Context, arg ReplaceTokenDefinitionTraitsParams) error {
+	_, err := q.db.Exec(ctx, replaceTokenDefinitionTraits, arg.CommunityID, arg.TokenDefinitionIds, arg.TraitTypes, arg.TraitValues)
+	return err
+}
+
 const reportPost = `-- name: ReportPost :one
 with offending_post as (select id from posts where posts.id = $4 and not deleted)
 insert into reported_posts (id, post_id, reporter_id, reason) (select $1, offending_post.id, $2::text, $3 from offending_post)
@@ -7044,6 +8668,66 @@ func (q *Queries) UnblockUserFromFeed(ctx context.Context, userID persist.DBID)
 	return err
 }
 
+const unclaimTokenProcessingDeadLetters = `-- name: UnclaimTokenProcessingDeadLetters :exec
+update token_processing_dead_letters d set replayed_at = null, last_updated = now()
+where d.id = any($1::varchar[]) and not d.deleted
+    and not exists (
+        select 1 from token_processing_dead_letters n
+        where n.token_definition_id = d.token_definition_id and not n.deleted and n.replayed_at is null
+    )
+`
+
+func (q *Queries) UnclaimTokenProcessingDeadLetters(ctx context.Context, ids []string) error {
+	_, err := q.db.Exec(ctx, unclaimTokenProcessingDeadLetters, ids)
+	return err
+}
+
+const unmuteCommunity = `-- name: UnmuteCommunity :exec
+update user_muted_communities set active = false, last_updated = now() where user_id = $1 and community_id = $2 and not deleted
+`
+
+type UnmuteCommunityParams struct {
+	UserID      persist.DBID `db:"user_id" json:"user_id"`
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+}
+
+func (q *Queries) UnmuteCommunity(ctx context.Context, arg UnmuteCommunityParams) error {
+	_, err := q.db.Exec(ctx, unmuteCommunity, arg.UserID, arg.CommunityID)
+	return err
+}
+
+const unmuteWord = `-- name: UnmuteWord :exec
+update user_muted_words set active = false, last_updated = now() where user_id = $1 and word = $2 and not deleted
+`
+
+type UnmuteWordParams struct {
+	UserID persist.DBID `db:"user_id" json:"user_id"`
+	Word   string       `db:"word" json:"word"`
+}
+
+func (q *Queries) UnmuteWord(ctx context.Context, arg UnmuteWordParams) error {
+	_, err := q.db.Exec(ctx, unmuteWord, arg.UserID, arg.Word)
+	return err
+}
+
+const unpinCommentByPostID = `-- name: UnpinCommentByPostID :exec
+update pinned_comments set deleted = true, last_updated = now() where post_id = $1 and not deleted
+`
+
+func (q *Queries) UnpinCommentByPostID(ctx context.Context, postID persist.DBID) error {
+	_, err := q.db.Exec(ctx, unpinCommentByPostID, postID)
+	return err
+}
+
+const unschedulePostDraft = `-- name: UnschedulePostDraft :exec
+update post_drafts set publish_at = null, claimed_at = null, last_updated = now() where id = $1
+`
+
+func (q *Queries) UnschedulePostDraft(ctx context.Context, id persist.DBID) error {
+	_, err := q.db.Exec(ctx, unschedulePostDraft, id)
+	return err
+}
+
 const updateCollectionGallery = `-- name: UpdateCollectionGallery :exec
 update collections set gallery_id = $1, last_updated = now() where id = $2 and deleted = false
 `
@@ -7100,6 +8784,34 @@ func (q *Queries) UpdateCollectionsInfo(ctx context.Context, arg UpdateCollectio
 	return err
 }
 
+const updateContractEnrichedFields = `-- name: UpdateContractEnrichedFields :exec
+update contracts set name = $1, symbol = $2, description = $3, profile_image_url = $4, last_updated = now() where id = $5 and not deleted
+`
+
+type UpdateContractEnrichedFieldsParams struct {
+	Name            sql.NullString `db:"name" json:"name"`
+	Symbol          sql.NullString `db:"symbol" json:"symbol"`
+	Description     sql.NullString `db:"description" json:"description"`
+	ProfileImageUrl sql.NullString `db:"profile_image_url" json:"profile_image_url"`
+	ID              persist.DBID   `db:"id" json:"id"`
+}
+
+func (q *Queries) UpdateContractEnrichedFields(ctx context.Context, arg UpdateContractEnrichedFieldsParams) error {
+	_, err := q.db.Exec(ctx, updateContractEnrichedFields, arg.Name, arg.Symbol, arg.Description, arg.ProfileImageUrl, arg.ID)
+	return err
+}
+
+const updateContractRefreshPolicyRefreshed = `-- name: UpdateContractRefreshPolicyRefreshed :exec
+update contract_refresh_policies
+set last_refreshed_at = now(), next_refresh_at = now() + make_interval(secs => refresh_interval_seconds), last_updated = now()
+where id = $1
+`
+
+func (q *Queries) UpdateContractRefreshPolicyRefreshed(ctx context.Context, id persist.DBID) error {
+	_, err := q.db.Exec(ctx, updateContractRefreshPolicyRefreshed, id)
+	return err
+}
+
 const updateEventCaptionByGroup = `-- name: UpdateEventCaptionByGroup :exec
 update events set caption = $1 where group_id = $2 and deleted = false
 `
@@ -7367,6 +9079,69 @@ func (q *Queries) UpdateNotificationSettingsByID(ctx context.Context, arg Update
 	return err
 }
 
+const updatePostCaption = `-- name: UpdatePostCaption :exec
+update posts set caption = $1, edited_at = now(), last_updated = now() where id = $2 and not deleted
+`
+
+type UpdatePostCaptionParams struct {
+	Caption sql.NullString `db:"caption" json:"caption"`
+	PostID  persist.DBID   `db:"post_id" json:"post_id"`
+}
+
+func (q *Queries) UpdatePostCaption(ctx context.Context, arg UpdatePostCaptionParams) error {
+	_, err := q.db.Exec(ctx, updatePostCaption, arg.Caption, arg.PostID)
+	return err
+}
+
+const updatePostDraft = `-- name: UpdatePostDraft :one
+update post_drafts
+set token_ids = $1, caption = $2, mentions = $3, user_mint_url = $4, publish_at = $5, last_updated = now()
+where id = $6 and actor_id = $7 and published_at is null and not deleted
+  and (claimed_at is null or claimed_at < $8)
+returning id, created_at, last_updated, deleted, actor_id, token_ids, caption, mentions, user_mint_url, publish_at, published_at, post_id, claimed_at
+`
+
+type UpdatePostDraftParams struct {
+	TokenIds      persist.DBIDList      `db:"token_ids" json:"token_ids"`
+	Caption       sql.NullString        `db:"caption" json:"caption"`
+	Mentions      persist.DraftMentions `db:"mentions" json:"mentions"`
+	UserMintUrl   sql.NullString        `db:"user_mint_url" json:"user_mint_url"`
+	PublishAt     sql.NullTime          `db:"publish_at" json:"publish_at"`
+	ID            persist.DBID          `db:"id" json:"id"`
+	ActorID       persist.DBID          `db:"actor_id" json:"actor_id"`
+	ClaimedBefore sql.NullTime          `db:"claimed_before" json:"claimed_before"`
+}
+
+func (q *Queries) UpdatePostDraft(ctx context.Context, arg UpdatePostDraftParams) (PostDraft, error) {
+	row := q.db.QueryRow(ctx, updatePostDraft,
+		arg.TokenIds,
+		arg.Caption,
+		arg.Mentions,
+		arg.UserMintUrl,
+		arg.PublishAt,
+		arg.ID,
+		arg.ActorID,
+		arg.ClaimedBefore,
+	)
+	var i PostDraft
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Deleted,
+		&i.ActorID,
+		&i.TokenIds,
+		&i.Caption,
+		&i.Mentions,
+		&i.UserMintUrl,
+		&i.PublishAt,
+		&i.PublishedAt,
+		&i.PostID,
+		&i.ClaimedAt,
+	)
+	return i, err
+}
+
 const updatePushTickets = `-- name: UpdatePushTickets :exec
 with updates as (
     select unnest($1::text[]) as id, unnest($2::timestamptz[]) as check_after, unnest($3::int[]) as num_check_attempts, unnest($4::text[]) as status, unnest($5::bool[]) as deleted
@@ -7595,6 +9370,225 @@ func (q *Queries) UpdateUserVerifiedEmail(ctx context.Context, arg UpdateUserVer
 	return err
 }
 
+const upsertCommunityTraitIndex = `-- name: UpsertCommunityTraitIndex :exec
+insert into community_trait_indexes (community_id, token_count) values ($1, $2)
+on conflict (community_id) do update set token_count = excluded.token_count, last_updated = now()
+`
+
+type UpsertCommunityTraitIndexParams struct {
+	CommunityID persist.DBID `db:"community_id" json:"community_id"`
+	TokenCount  int32        `db:"token_count" json:"token_count"`
+}
+
+func (q *Queries) UpsertCommunityTraitIndex(ctx context.Context, arg UpsertCommunityTraitIndexParams) error {
+	_, err := q.db.Exec(ctx, upsertCommunityTraitIndex, arg.CommunityID, arg.TokenCount)
+	return err
+}
+
+const upsertContractEnrichment = `-- name: UpsertContractEnrichment :exec
+insert into contract_enrichments (id, chain, address, contract_uri, uri_name, uri_symbol, uri_description, uri_image_url, rpc_name, rpc_symbol, owner_ens_name, sources)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+on conflict (chain, address) do update set
+  contract_uri = excluded.contract_uri,
+  uri_name = excluded.uri_name,
+  uri_symbol = excluded.uri_symbol,
+  uri_description = excluded.uri_description,
+  uri_image_url = excluded.uri_image_url,
+  rpc_name = excluded.rpc_name,
+  rpc_symbol = excluded.rpc_symbol,
+  owner_ens_name = excluded.owner_ens_name,
+  sources = excluded.sources,
+  last_updated = now()
+`
+
+type UpsertContractEnrichmentParams struct {
+	ID             persist.DBID                 `db:"id" json:"id"`
+	Chain          persist.Chain                `db:"chain" json:"chain"`
+	Address        persist.Address              `db:"address" json:"address"`
+	ContractUri    sql.NullString               `db:"contract_uri" json:"contract_uri"`
+	UriName        sql.NullString               `db:"uri_name" json:"uri_name"`
+	UriSymbol      sql.NullString               `db:"uri_symbol" json:"uri_symbol"`
+	UriDescription sql.NullString               `db:"uri_description" json:"uri_description"`
+	UriImageUrl    sql.NullString               `db:"uri_image_url" json:"uri_image_url"`
+	RpcName        sql.NullString               `db:"rpc_name" json:"rpc_name"`
+	RpcSymbol      sql.NullString               `db:"rpc_symbol" json:"rpc_symbol"`
+	OwnerEnsName   sql.NullString               `db:"owner_ens_name" json:"owner_ens_name"`
+	Sources        persist.ContractFieldSources `db:"sources" json:"sources"`
+}
+
+func (q *Queries) UpsertContractEnrichment(ctx context.Context, arg UpsertContractEnrichmentParams) error {
+	_, err := q.db.Exec(ctx, upsertContractEnrichment,
+		arg.ID,
+		arg.Chain,
+		arg.Address,
+		arg.ContractUri,
+		arg.UriName,
+		arg.UriSymbol,
+		arg.UriDescription,
+		arg.UriImageUrl,
+		arg.RpcName,
+		arg.RpcSymbol,
+		arg.OwnerEnsName,
+		arg.Sources,
+	)
+	return err
+}
+
+const upsertContractRefreshPolicy = `-- name: UpsertContractRefreshPolicy :one
+insert into contract_refresh_policies (id, contract_id, refresh_interval_seconds, ignore_metadata_updates) values ($1, $2, $3, $4)
+on conflict (contract_id) where not deleted do update set
+    refresh_interval_seconds = excluded.refresh_interval_seconds,
+    ignore_metadata_updates = excluded.ignore_metadata_updates,
+    next_refresh_at = contract_refresh_policies.last_refreshed_at + make_interval(secs => excluded.refresh_interval_seconds),
+    last_updated = now()
+returning id, created_at, last_updated, deleted, contract_id, refresh_interval_seconds, ignore_metadata_updates, next_refresh_at, last_refreshed_at
+`
+
+type UpsertContractRefreshPolicyParams struct {
+	ID                     persist.DBID  `db:"id" json:"id"`
+	ContractID             persist.DBID  `db:"contract_id" json:"contract_id"`
+	RefreshIntervalSeconds sql.NullInt32 `db:"refresh_interval_seconds" json:"refresh_interval_seconds"`
+	IgnoreMetadataUpdates  bool          `db:"ignore_metadata_updates" json:"ignore_metadata_updates"`
+}
+
+// A changed interval applies from the last refresh, and a contract that hasn't been refreshed yet is refreshed on the next run
+func (q *Queries) UpsertContractRefreshPolicy(ctx context.Context, arg UpsertContractRefreshPolicyParams) (ContractRefreshPolicy, error) {
+	row := q.db.QueryRow(ctx, upsertContractRefreshPolicy, arg.ID, arg.ContractID, arg.RefreshIntervalSeconds, arg.IgnoreMetadataUpdates)
+	var i ContractRefreshPolicy
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Deleted,
+		&i.ContractID,
+		&i.RefreshIntervalSeconds,
+		&i.IgnoreMetadataUpdates,
+		&i.NextRefreshAt,
+		&i.LastRefreshedAt,
+	)
+	return i, err
+}
+
+const upsertContractSpamScore = `-- name: UpsertContractSpamScore :exec
+insert into contract_spam_scores (contract_id, score, reasons) values ($1, $2, $3)
+on conflict (contract_id) do update set score = excluded.score, reasons = excluded.reasons, last_updated = now()
+`
+
+type UpsertContractSpamScoreParams struct {
+	ContractID persist.DBID        `db:"contract_id" json:"contract_id"`
+	Score      float64             `db:"score" json:"score"`
+	Reasons    persist.SpamReasons `db:"reasons" json:"reasons"`
+}
+
+func (q *Queries) UpsertContractSpamScore(ctx context.Context, arg UpsertContractSpamScoreParams) error {
+	_, err := q.db.Exec(ctx, upsertContractSpamScore, arg.ContractID, arg.Score, arg.Reasons)
+	return err
+}
+
+const upsertCustomMetadataHandler = `-- name: UpsertCustomMetadataHandler :one
+insert into custom_metadata_handlers (id, chain, contract_address, function_signature, format, template) values ($1, $2, $3, $4, $5, $6)
+on conflict (chain, contract_address) where not deleted do update set function_signature = excluded.function_signature, format = excluded.format, template = excluded.template, last_updated = now()
+returning id, created_at, last_updated, deleted, chain, contract_address, function_signature, format, template
+`
+
+type UpsertCustomMetadataHandlerParams struct {
+	ID                persist.DBID                 `db:"id" json:"id"`
+	Chain             persist.Chain                `db:"chain" json:"chain"`
+	ContractAddress   persist.Address              `db:"contract_address" json:"contract_address"`
+	FunctionSignature string                       `db:"function_signature" json:"function_signature"`
+	Format            persist.CustomMetadataFormat `db:"format" json:"format"`
+	Template          persist.TokenMetadata        `db:"template" json:"template"`
+}
+
+func (q *Queries) UpsertCustomMetadataHandler(ctx context.Context, arg UpsertCustomMetadataHandlerParams) (CustomMetadataHandler, error) {
+	row := q.db.QueryRow(ctx, upsertCustomMetadataHandler,
+		arg.ID,
+		arg.Chain,
+		arg.ContractAddress,
+		arg.FunctionSignature,
+		arg.Format,
+		arg.Template,
+	)
+	var i CustomMetadataHandler
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Deleted,
+		&i.Chain,
+		&i.ContractAddress,
+		&i.FunctionSignature,
+		&i.Format,
+		&i.Template,
+	)
+	return i, err
+}
+
+const upsertIPFSPin = `-- name: UpsertIPFSPin :exec
+insert into ipfs_pins (id, cid, status, remote_request, last_error) values ($1, $2, $3, $4, $5)
+on conflict (cid) do update set status = excluded.status, remote_request = excluded.remote_request, last_error = excluded.last_error, last_updated = now()
+`
+
+type UpsertIPFSPinParams struct {
+	ID            persist.DBID   `db:"id" json:"id"`
+	Cid           string         `db:"cid" json:"cid"`
+	Status        string         `db:"status" json:"status"`
+	RemoteRequest sql.NullString `db:"remote_request" json:"remote_request"`
+	LastError     sql.NullString `db:"last_error" json:"last_error"`
+}
+
+func (q *Queries) UpsertIPFSPin(ctx context.Context, arg UpsertIPFSPinParams) error {
+	_, err := q.db.Exec(ctx, upsertIPFSPin,
+		arg.ID,
+		arg.Cid,
+		arg.Status,
+		arg.RemoteRequest,
+		arg.LastError,
+	)
+	return err
+}
+
+const upsertMediaPreferences = `-- name: UpsertMediaPreferences :one
+insert into user_media_preferences (id, user_id, flagged_media_display) values ($1, $2, $3)
+on conflict(user_id) where not deleted do update set flagged_media_display = excluded.flagged_media_display, last_updated = now()
+returning id, created_at, last_updated, deleted, user_id, flagged_media_display
+`
+
+type UpsertMediaPreferencesParams struct {
+	ID                  persist.DBID                `db:"id" json:"id"`
+	UserID              persist.DBID                `db:"user_id" json:"user_id"`
+	FlaggedMediaDisplay persist.FlaggedMediaDisplay `db:"flagged_media_display" json:"flagged_media_display"`
+}
+
+func (q *Queries) UpsertMediaPreferences(ctx context.Context, arg UpsertMediaPreferencesParams) (UserMediaPreference, error) {
+	row := q.db.QueryRow(ctx, upsertMediaPreferences, arg.ID, arg.UserID, arg.FlaggedMediaDisplay)
+	var i UserMediaPreference
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.LastUpdated,
+		&i.Deleted,
+		&i.UserID,
+		&i.FlaggedMediaDisplay,
+	)
+	return i, err
+}
+
+const upsertMetadataUpdateCursor = `-- name: UpsertMetadataUpdateCursor :exec
+insert into metadata_update_cursors (chain, last_block) values ($1, $2)
+on conflict (chain) do update set last_block = excluded.last_block, last_updated = now()
+`
+
+type UpsertMetadataUpdateCursorParams struct {
+	Chain     persist.Chain `db:"chain" json:"chain"`
+	LastBlock int64         `db:"last_block" json:"last_block"`
+}
+
+func (q *Queries) UpsertMetadataUpdateCursor(ctx context.Context, arg UpsertMetadataUpdateCursorParams) error {
+	_, err := q.db.Exec(ctx, upsertMetadataUpdateCursor, arg.Chain, arg.LastBlock)
+	return err
+}
+
 const upsertSession = `-- name: UpsertSession :one
 insert into sessions (id, user_id,
                       created_at, created_with_user_agent, created_with_platform, created_with_os,
@@ -7675,6 +9669,44 @@ func (q *Queries) UpsertSocialOAuth(ctx context.Context, arg UpsertSocialOAuthPa
 	return err
 }
 
+const upsertTokenProcessingDeadLetter = `-- name: UpsertTokenProcessingDeadLetter :exec
+insert into token_processing_dead_letters (id, token_definition_id, contract_id, processing_job_id, reason, processing_cause, last_error, attempts, pipeline_metadata)
+values ($1, $2, $3, $4::text, $5, $6, $7, $8, (select pipeline_metadata from token_processing_jobs where id = $4::text))
+on conflict(token_definition_id) where not deleted and replayed_at is null do update
+set processing_job_id = excluded.processing_job_id,
+    reason = excluded.reason,
+    processing_cause = excluded.processing_cause,
+    last_error = excluded.last_error,
+    attempts = excluded.attempts,
+    pipeline_metadata = excluded.pipeline_metadata,
+    last_updated = now()
+`
+
+type UpsertTokenProcessingDeadLetterParams struct {
+	ID                persist.DBID             `db:"id" json:"id"`
+	TokenDefinitionID persist.DBID             `db:"token_definition_id" json:"token_definition_id"`
+	ContractID        persist.DBID             `db:"contract_id" json:"contract_id"`
+	ProcessingJobID   sql.NullString           `db:"processing_job_id" json:"processing_job_id"`
+	Reason            persist.DeadLetterReason `db:"reason" json:"reason"`
+	ProcessingCause   persist.ProcessingCause  `db:"processing_cause" json:"processing_cause"`
+	LastError         sql.NullString           `db:"last_error" json:"last_error"`
+	Attempts          int32                    `db:"attempts" json:"attempts"`
+}
+
+func (q *Queries) UpsertTokenProcessingDeadLetter(ctx context.Context, arg UpsertTokenProcessingDeadLetterParams) error {
+	_, err := q.db.Exec(ctx, upsertTokenProcessingDeadLetter,
+		arg.ID,
+		arg.TokenDefinitionID,
+		arg.ContractID,
+		arg.ProcessingJobID,
+		arg.Reason,
+		arg.ProcessingCause,
+		arg.LastError,
+		arg.Attempts,
+	)
+	return err
+}
+
 const userHasDuplicateGalleryPositions = `-- name: UserHasDuplicateGalleryPositions :one
 select exists(select position,count(*) from galleries where owner_user_id = $1 and deleted = false group by position having count(*) > 1)
 `
@@ -99,7 +99,7 @@ with refreshed      as ( select greatest((select last_updated from feed_entity_s
      , t4           as ( select t3.id, (sum(cume) over (partition by actor_id order by created_at desc))::int group_number from t3 )
 select
   feed_entity_scores.id, feed_entity_scores.created_at, feed_entity_scores.actor_id, feed_entity_scores.action, feed_entity_scores.contract_ids, feed_entity_scores.interactions, feed_entity_scores.feed_entity_type, feed_entity_scores.last_updated
-  , p.id, p.version, p.token_ids, p.contract_ids, p.actor_id, p.caption, p.created_at, p.last_updated, p.deleted, p.is_first_post, p.user_mint_url
+  , p.id, p.version, p.token_ids, p.contract_ids, p.actor_id, p.caption, p.created_at, p.last_updated, p.deleted, p.is_first_post, p.user_mint_url, p.quoted_post_id, p.edited_at
   , row_number() over (partition by p.actor_id order by (t4.group_number, random() > 0.5)) streak
   , coalesce(p.actor_id = (select id from gallery_user), false)::bool is_gallery_post
 from t2 feed_entity_scores
@@ -151,6 +151,8 @@ func (q *Queries) GetFeedEntityScores(ctx context.Context, arg GetFeedEntityScor
 			&i.Post.Deleted,
 			&i.Post.IsFirstPost,
 			&i.Post.UserMintUrl,
+			&i.Post.QuotedPostID,
+			&i.Post.EditedAt,
 			&i.Streak,
 			&i.IsGalleryPost,
 		); err != nil {
@@ -164,6 +166,121 @@ func (q *Queries) GetFeedEntityScores(ctx context.Context, arg GetFeedEntityScor
 	return items, nil
 }
 
+const getFeedEntityScoresAsOf = `-- name: GetFeedEntityScoresAsOf :many
+with gallery_user as ( select id from users where username_idempotent = 'gallery' and not deleted and not universal )
+     , t0         as ( select id, actor_id, created_at from posts where created_at > $1 and created_at <= $2 and not deleted )
+     , t1         as ( select id, actor_id, created_at, (case when lag(created_at) over (partition by actor_id order by created_at desc) is null then 0
+                                       when extract(epoch from created_at - lag(created_at) over (partition by actor_id order by created_at desc)) > -($3::int) then 0
+                                       else 1 end)::int cume from t0 )
+     , t2         as ( select t1.id, (sum(cume) over (partition by actor_id order by created_at desc))::int group_number from t1 )
+select
+  p.id, p.version, p.token_ids, p.contract_ids, p.actor_id, p.caption, p.created_at, p.last_updated, p.deleted, p.is_first_post, p.user_mint_url, p.quoted_post_id, p.edited_at
+  , ((select count(*) from comments c where c.post_id = p.id and c.created_at <= $2)
+    + (select count(*) from admires a where a.post_id = p.id and a.created_at <= $2))::int interactions
+  , row_number() over (partition by p.actor_id order by (t2.group_number, p.id)) streak
+  , coalesce(p.actor_id = (select id from gallery_user), false)::bool is_gallery_post
+from t2
+join posts p on p.id = t2.id
+left join feed_blocklist fb on p.actor_id = fb.user_id and not fb.deleted and fb.active
+where fb.user_id is null
+`
+
+type GetFeedEntityScoresAsOfParams struct {
+	WindowEnd time.Time `db:"window_end" json:"window_end"`
+	AsOf      time.Time `db:"as_of" json:"as_of"`
+	Span      int32     `db:"span" json:"span"`
+}
+
+type GetFeedEntityScoresAsOfRow struct {
+	Post          Post  `db:"post" json:"post"`
+	Interactions  int32 `db:"interactions" json:"interactions"`
+	Streak        int64 `db:"streak" json:"streak"`
+	IsGalleryPost bool  `db:"is_gallery_post" json:"is_gallery_post"`
+}
+
+func (q *Queries) GetFeedEntityScoresAsOf(ctx context.Context, arg GetFeedEntityScoresAsOfParams) ([]GetFeedEntityScoresAsOfRow, error) {
+	rows, err := q.db.Query(ctx, getFeedEntityScoresAsOf, arg.WindowEnd, arg.AsOf, arg.Span)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFeedEntityScoresAsOfRow
+	for rows.Next() {
+		var i GetFeedEntityScoresAsOfRow
+		if err := rows.Scan(
+			&i.Post.ID,
+			&i.Post.Version,
+			&i.Post.TokenIds,
+			&i.Post.ContractIds,
+			&i.Post.ActorID,
+			&i.Post.Caption,
+			&i.Post.CreatedAt,
+			&i.Post.LastUpdated,
+			&i.Post.Deleted,
+			&i.Post.IsFirstPost,
+			&i.Post.UserMintUrl,
+			&i.Post.QuotedPostID,
+			&i.Post.EditedAt,
+			&i.Interactions,
+			&i.Streak,
+			&i.IsGalleryPost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFeedInteractionsByPostIDs = `-- name: GetFeedInteractionsByPostIDs :many
+select actor_id, post_id, created_at, 'admire'::varchar kind from admires
+where post_id = any($1::dbid[]) and created_at > $2 and created_at <= $3 and not deleted
+union all
+select actor_id, post_id, created_at, 'comment'::varchar kind from comments
+where post_id = any($1::dbid[]) and created_at > $2 and created_at <= $3 and not deleted and not removed
+`
+
+type GetFeedInteractionsByPostIDsParams struct {
+	PostIds []persist.DBID `db:"post_ids" json:"post_ids"`
+	After   time.Time      `db:"after" json:"after"`
+	Before  time.Time      `db:"before" json:"before"`
+}
+
+type GetFeedInteractionsByPostIDsRow struct {
+	ActorID   persist.DBID `db:"actor_id" json:"actor_id"`
+	PostID    persist.DBID `db:"post_id" json:"post_id"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+	Kind      string       `db:"kind" json:"kind"`
+}
+
+func (q *Queries) GetFeedInteractionsByPostIDs(ctx context.Context, arg GetFeedInteractionsByPostIDsParams) ([]GetFeedInteractionsByPostIDsRow, error) {
+	rows, err := q.db.Query(ctx, getFeedInteractionsByPostIDs, arg.PostIds, arg.After, arg.Before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFeedInteractionsByPostIDsRow
+	for rows.Next() {
+		var i GetFeedInteractionsByPostIDsRow
+		if err := rows.Scan(
+			&i.ActorID,
+			&i.PostID,
+			&i.CreatedAt,
+			&i.Kind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFollowEdgesByUserID = `-- name: GetFollowEdgesByUserID :many
 select id, follower, followee, deleted, created_at, last_updated from follows f where f.follower = $1 and f.deleted = false
 `
@@ -236,6 +353,43 @@ func (q *Queries) GetFollowGraphSource(ctx context.Context) ([]GetFollowGraphSou
 	return items, nil
 }
 
+const getNotInterestedSignalsByUserID = `-- name: GetNotInterestedSignalsByUserID :many
+select p.actor_id, p.contract_ids
+from not_interested_posts n
+join posts p on p.id = n.post_id
+where n.user_id = $1 and n.created_at > $2 and not n.deleted
+`
+
+type GetNotInterestedSignalsByUserIDParams struct {
+	UserID persist.DBID `db:"user_id" json:"user_id"`
+	Since  time.Time    `db:"since" json:"since"`
+}
+
+type GetNotInterestedSignalsByUserIDRow struct {
+	ActorID     persist.DBID     `db:"actor_id" json:"actor_id"`
+	ContractIds persist.DBIDList `db:"contract_ids" json:"contract_ids"`
+}
+
+func (q *Queries) GetNotInterestedSignalsByUserID(ctx context.Context, arg GetNotInterestedSignalsByUserIDParams) ([]GetNotInterestedSignalsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getNotInterestedSignalsByUserID, arg.UserID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotInterestedSignalsByUserIDRow
+	for rows.Next() {
+		var i GetNotInterestedSignalsByUserIDRow
+		if err := rows.Scan(&i.ActorID, &i.ContractIds); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTopRecommendedUserIDs = `-- name: GetTopRecommendedUserIDs :many
 select recommended_user_id from top_recommended_users
 `
@@ -292,6 +446,32 @@ func (q *Queries) GetUserLabels(ctx context.Context) ([]persist.DBID, error) {
 	return items, nil
 }
 
+const insertFeedExposure = `-- name: InsertFeedExposure :exec
+insert into feed_exposures (id, viewer_id, feed, variant, post_ids, positions)
+values ($1, nullif($2::varchar, ''), $3, $4, $5::varchar[], $6::int[])
+`
+
+type InsertFeedExposureParams struct {
+	ID        persist.DBID `db:"id" json:"id"`
+	ViewerID  string       `db:"viewer_id" json:"viewer_id"`
+	Feed      string       `db:"feed" json:"feed"`
+	Variant   string       `db:"variant" json:"variant"`
+	PostIds   []string     `db:"post_ids" json:"post_ids"`
+	Positions []int32      `db:"positions" json:"positions"`
+}
+
+func (q *Queries) InsertFeedExposure(ctx context.Context, arg InsertFeedExposureParams) error {
+	_, err := q.db.Exec(ctx, insertFeedExposure,
+		arg.ID,
+		arg.ViewerID,
+		arg.Feed,
+		arg.Variant,
+		arg.PostIds,
+		arg.Positions,
+	)
+	return err
+}
+
 const updatedRecommendationResults = `-- name: UpdatedRecommendationResults :exec
 insert into recommendation_results
 (
@@ -0,0 +1,9 @@
+create table if not exists user_media_preferences (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  user_id varchar(255) not null references users(id),
+  flagged_media_display varchar(32) not null default 'blur'
+);
+create unique index if not exists user_media_preferences_user_id_idx on user_media_preferences(user_id) where not deleted;
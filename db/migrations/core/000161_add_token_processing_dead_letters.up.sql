@@ -0,0 +1,17 @@
+create table if not exists token_processing_dead_letters (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  token_definition_id varchar(255) not null references token_definitions(id),
+  contract_id varchar(255) not null references contracts(id),
+  processing_job_id varchar(255) references token_processing_jobs(id),
+  reason varchar(32) not null,
+  processing_cause varchar not null,
+  last_error text,
+  attempts int not null default 0,
+  pipeline_metadata jsonb,
+  replayed_at timestamptz
+);
+create unique index if not exists token_processing_dead_letters_token_definition_id_idx on token_processing_dead_letters(token_definition_id) where not deleted and replayed_at is null;
+create index if not exists token_processing_dead_letters_contract_id_idx on token_processing_dead_letters(contract_id, created_at) where not deleted and replayed_at is null;
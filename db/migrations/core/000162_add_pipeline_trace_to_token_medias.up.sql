@@ -0,0 +1 @@
+alter table token_medias add column if not exists pipeline_trace jsonb;
@@ -0,0 +1,18 @@
+create table if not exists ipfs_pins (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  cid varchar not null,
+  status varchar(32) not null,
+  remote_request varchar,
+  last_error text
+);
+create unique index if not exists ipfs_pins_cid_idx on ipfs_pins(cid);
+
+create table if not exists ipfs_pin_references (
+  gallery_id varchar(255) not null references galleries(id),
+  cid varchar not null,
+  created_at timestamptz not null default current_timestamp,
+  primary key (gallery_id, cid)
+);
+create index if not exists ipfs_pin_references_cid_idx on ipfs_pin_references(cid);
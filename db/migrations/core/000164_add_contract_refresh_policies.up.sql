@@ -0,0 +1,19 @@
+create table if not exists contract_refresh_policies (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  contract_id varchar(255) not null references contracts(id),
+  refresh_interval_seconds integer,
+  ignore_metadata_updates boolean not null default false,
+  next_refresh_at timestamptz,
+  last_refreshed_at timestamptz
+);
+create unique index if not exists contract_refresh_policies_contract_id_idx on contract_refresh_policies(contract_id) where not deleted;
+create index if not exists contract_refresh_policies_next_refresh_at_idx on contract_refresh_policies(next_refresh_at) where not deleted and refresh_interval_seconds is not null;
+
+create table if not exists metadata_update_cursors (
+  chain int primary key,
+  last_block bigint not null,
+  last_updated timestamptz not null default current_timestamp
+);
@@ -0,0 +1,12 @@
+create table if not exists custom_metadata_handlers (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  chain int not null,
+  contract_address address not null,
+  function_signature varchar not null,
+  format varchar(32) not null,
+  template jsonb
+);
+create unique index if not exists custom_metadata_handlers_chain_contract_address_idx on custom_metadata_handlers(chain, contract_address) where not deleted;
@@ -0,0 +1,9 @@
+create table if not exists contract_spam_scores (
+  contract_id varchar(255) primary key references contracts(id),
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  score double precision not null,
+  reasons jsonb not null default '[]'
+);
+create index if not exists contract_spam_scores_score_idx on contract_spam_scores(score desc);
+create index if not exists contract_spam_scores_last_updated_idx on contract_spam_scores(last_updated);
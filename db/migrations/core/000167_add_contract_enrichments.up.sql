@@ -0,0 +1,17 @@
+create table if not exists contract_enrichments (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  chain int not null,
+  address varchar(255) not null,
+  contract_uri varchar,
+  uri_name varchar,
+  uri_symbol varchar,
+  uri_description varchar,
+  uri_image_url varchar,
+  rpc_name varchar,
+  rpc_symbol varchar,
+  owner_ens_name varchar,
+  sources jsonb not null default '{}'
+);
+create unique index if not exists contract_enrichments_chain_address_idx on contract_enrichments(chain, address);
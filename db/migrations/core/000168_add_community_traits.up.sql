@@ -0,0 +1,35 @@
+create table if not exists community_trait_indexes (
+  community_id varchar(255) primary key references communities(id),
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  token_count int not null default 0
+);
+create index if not exists community_trait_indexes_last_updated_idx on community_trait_indexes(last_updated);
+
+-- The rows of the following tables are replaced together each time a community is indexed, so they don't have primary keys
+create table if not exists community_traits (
+  community_id varchar(255) not null references communities(id),
+  trait_type varchar not null,
+  value varchar not null,
+  token_count int not null
+);
+create index if not exists community_traits_community_id_idx on community_traits(community_id, trait_type, value);
+
+create table if not exists token_definition_traits (
+  community_id varchar(255) not null references communities(id),
+  token_definition_id varchar(255) not null references token_definitions(id),
+  trait_type varchar not null,
+  value varchar not null
+);
+create index if not exists token_definition_traits_community_id_token_definition_id_idx on token_definition_traits(community_id, token_definition_id);
+create index if not exists token_definition_traits_token_definition_id_idx on token_definition_traits(token_definition_id);
+
+create table if not exists token_definition_rarities (
+  community_id varchar(255) not null references communities(id),
+  token_definition_id varchar(255) not null references token_definitions(id),
+  statistical_score double precision not null,
+  normalized_score double precision not null,
+  rank int not null
+);
+create index if not exists token_definition_rarities_community_id_token_definition_id_idx on token_definition_rarities(community_id, token_definition_id);
+create index if not exists token_definition_rarities_token_definition_id_idx on token_definition_rarities(token_definition_id);
@@ -0,0 +1,11 @@
+create table if not exists feed_exposures (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  viewer_id varchar(255) references users(id),
+  feed varchar not null,
+  variant varchar not null,
+  post_ids varchar(255)[] not null,
+  positions int[] not null
+);
+create index if not exists feed_exposures_feed_variant_created_at_idx on feed_exposures(feed, variant, created_at);
+create index if not exists feed_exposures_viewer_id_created_at_idx on feed_exposures(viewer_id, created_at) where viewer_id is not null;
@@ -0,0 +1,72 @@
+create table if not exists user_muted_words (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  user_id varchar(255) not null references users(id),
+  word varchar not null,
+  active bool not null default true
+);
+create unique index if not exists user_muted_words_user_id_word_idx on user_muted_words(user_id, word) where not deleted;
+
+create table if not exists user_muted_communities (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  user_id varchar(255) not null references users(id),
+  community_id varchar(255) not null references communities(id),
+  active bool not null default true
+);
+create unique index if not exists user_muted_communities_user_id_community_id_idx on user_muted_communities(user_id, community_id) where not deleted;
+
+create table if not exists not_interested_posts (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  user_id varchar(255) not null references users(id),
+  post_id varchar(255) not null references posts(id)
+);
+create unique index if not exists not_interested_posts_user_id_post_id_idx on not_interested_posts(user_id, post_id) where not deleted;
+create index if not exists not_interested_posts_user_id_created_at_idx on not_interested_posts(user_id, created_at) where not deleted;
+
+-- muted_posts are the posts that each user has muted, either directly by marking a post as not interested or indirectly
+-- by muting a word that appears in the post's caption or comments, or a community that the post is about. Words are
+-- stored lowercase and matched anywhere in the text.
+create or replace view muted_posts as (
+  select n.user_id, n.post_id
+  from not_interested_posts n
+  where not n.deleted
+
+  union
+
+  select w.user_id, p.id post_id
+  from user_muted_words w
+  join posts p on strpos(lower(p.caption), w.word) > 0 and not p.deleted
+  where w.active and not w.deleted
+
+  union
+
+  select w.user_id, c.post_id
+  from user_muted_words w
+  join comments c on strpos(lower(c.comment), w.word) > 0 and c.post_id is not null and not c.deleted and not c.removed
+  where w.active and not w.deleted
+
+  union
+
+  select m.user_id, p.id post_id
+  from user_muted_communities m
+  join communities c on c.id = m.community_id and c.community_type = 0 and not c.deleted
+  join posts p on c.contract_id = any(p.contract_ids) and not p.deleted
+  where m.active and not m.deleted
+
+  union
+
+  select m.user_id, p.id post_id
+  from user_muted_communities m
+  join token_community_memberships tcm on tcm.community_id = m.community_id and not tcm.deleted
+  join tokens t on t.token_definition_id = tcm.token_definition_id and not t.deleted
+  join posts p on t.id = any(p.token_ids) and not p.deleted
+  where m.active and not m.deleted
+);
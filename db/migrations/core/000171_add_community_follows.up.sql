@@ -0,0 +1,10 @@
+create table if not exists community_follows (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  user_id varchar(255) not null references users(id),
+  community_id varchar(255) not null references communities(id)
+);
+create unique index if not exists community_follows_user_id_community_id_idx on community_follows(user_id, community_id) where not deleted;
+create index if not exists community_follows_community_id_idx on community_follows(community_id) where not deleted;
@@ -0,0 +1,40 @@
+create table if not exists reposts (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  actor_id varchar(255) not null references users(id),
+  post_id varchar(255) not null references posts(id)
+);
+create unique index if not exists reposts_actor_id_post_id_idx on reposts(actor_id, post_id) where not deleted;
+create index if not exists reposts_post_id_idx on reposts(post_id) where not deleted;
+
+alter table posts add column if not exists quoted_post_id varchar(255) references posts(id);
+create index if not exists posts_quoted_post_id_idx on posts(quoted_post_id) where not deleted;
+
+-- Reposts are feed entities of their own so that they reach the reposter's followers. Reposts of deleted posts are
+-- left out.
+create or replace view feed_entities as (
+select subquery.id, subquery.feed_entity_type, subquery.created_at, subquery.actor_id
+
+    from (
+        (
+            select id, 0 as feed_entity_type, event_time as created_at, owner_id as actor_id
+            from feed_events
+            where deleted = false
+        )
+        union all
+        (
+            select id, 1 as feed_entity_type, created_at, actor_id
+            from posts
+            where deleted = false
+        )
+        union all
+        (
+            select r.id, 2 as feed_entity_type, r.created_at, r.actor_id
+            from reposts r
+            join posts p on p.id = r.post_id and not p.deleted
+            where not r.deleted
+        )
+    ) subquery
+);
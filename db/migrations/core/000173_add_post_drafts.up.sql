@@ -0,0 +1,16 @@
+create table if not exists post_drafts (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  actor_id varchar(255) not null references users(id),
+  token_ids varchar(255)[] not null default '{}',
+  caption varchar,
+  mentions jsonb not null default '[]',
+  user_mint_url varchar,
+  publish_at timestamptz,
+  published_at timestamptz,
+  post_id varchar(255) references posts(id)
+);
+create index if not exists post_drafts_actor_id_last_updated_idx on post_drafts(actor_id, last_updated desc) where not deleted and published_at is null;
+create index if not exists post_drafts_publish_at_idx on post_drafts(publish_at) where not deleted and published_at is null;
@@ -0,0 +1,12 @@
+alter table posts add column if not exists edited_at timestamptz;
+
+-- post_revisions are the captions that a post had before it was edited. Mentions from earlier revisions are kept in the
+-- mentions table as deleted rows.
+create table if not exists post_revisions (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  post_id varchar(255) not null references posts(id),
+  caption varchar,
+  written_at timestamptz not null
+);
+create index if not exists post_revisions_post_id_created_at_idx on post_revisions(post_id, created_at desc);
@@ -0,0 +1,30 @@
+-- post_attachments are the poll, link, or community card attached to a post. A post has at most one attachment, and
+-- which columns are set depends on its attachment_type.
+create table if not exists post_attachments (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  post_id varchar(255) not null references posts(id),
+  attachment_type int not null,
+  poll_options varchar[],
+  poll_closes_at timestamptz,
+  link_url varchar,
+  link_title varchar,
+  link_description varchar,
+  link_image_url varchar,
+  link_site_name varchar,
+  community_id varchar(255) references communities(id)
+);
+create unique index if not exists post_attachments_post_id_idx on post_attachments(post_id) where not deleted;
+
+create table if not exists poll_votes (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  attachment_id varchar(255) not null references post_attachments(id),
+  voter_id varchar(255) not null references users(id),
+  option_index int not null
+);
+create unique index if not exists poll_votes_attachment_id_voter_id_idx on poll_votes(attachment_id, voter_id) where not deleted;
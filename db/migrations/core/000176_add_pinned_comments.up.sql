@@ -0,0 +1,14 @@
+create table if not exists pinned_comments (
+  id varchar(255) primary key,
+  created_at timestamptz not null default current_timestamp,
+  last_updated timestamptz not null default current_timestamp,
+  deleted boolean not null default false,
+  post_id varchar(255) not null references posts(id),
+  comment_id varchar(255) not null references comments(id)
+);
+-- A post has at most one pinned comment
+create unique index if not exists pinned_comments_post_id_idx on pinned_comments(post_id) where not deleted;
+create index if not exists pinned_comments_comment_id_idx on pinned_comments(comment_id) where not deleted;
+
+-- Sorting comments by their admires counts the admires of each comment
+create index if not exists admires_comment_id_idx on admires(comment_id) where not deleted;
@@ -0,0 +1 @@
+alter table contract_enrichments drop column if exists owner_ens_name;
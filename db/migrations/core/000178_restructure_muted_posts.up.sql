@@ -0,0 +1,42 @@
+-- muted_posts is only ever filtered by a viewer and a post, so each branch starts from the viewer's mutes and checks the
+-- one post against them. A post is checked against each muted word once, looking at its caption before its comments,
+-- and against each muted community once, looking at its contracts before its tokens. Branches are combined with union
+-- all since callers only check whether a row exists.
+create or replace view muted_posts as (
+  select n.user_id, n.post_id
+  from not_interested_posts n
+  where not n.deleted
+
+  union all
+
+  select w.user_id, p.id post_id
+  from user_muted_words w
+  join posts p on not p.deleted and (
+    strpos(lower(p.caption), w.word) > 0
+    or exists (
+      select 1
+      from comments c
+      where c.post_id = p.id and not c.deleted and not c.removed and strpos(lower(c.comment), w.word) > 0
+    )
+  )
+  where w.active and not w.deleted
+
+  union all
+
+  select m.user_id, p.id post_id
+  from user_muted_communities m
+  join posts p on not p.deleted and (
+    exists (
+      select 1
+      from communities c
+      where c.id = m.community_id and c.community_type = 0 and not c.deleted and c.contract_id = any(p.contract_ids)
+    )
+    or exists (
+      select 1
+      from tokens t
+      join token_community_memberships tcm on tcm.token_definition_id = t.token_definition_id and tcm.community_id = m.community_id and not tcm.deleted
+      where t.id = any(p.token_ids) and not t.deleted
+    )
+  )
+  where m.active and not m.deleted
+);
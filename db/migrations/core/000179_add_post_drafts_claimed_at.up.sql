@@ -0,0 +1,3 @@
+-- Drafts were marked as published when the scheduler claimed them, so a draft was lost if publishing it failed
+-- partway. They're now leased while they're published instead, and only marked as published once their post exists.
+alter table post_drafts add column if not exists claimed_at timestamptz;
@@ -0,0 +1 @@
+alter table contract_enrichments add column if not exists owner_ens_name varchar;
@@ -0,0 +1,44 @@
+-- Muted words are matched as whole words so that muting a word doesn't hide posts that only contain it as part of a
+-- longer word, e.g. muting "art" doesn't hide a post about a party. Characters other than letters, digits, and
+-- underscores are escaped in the pattern so that words are matched literally.
+create or replace view muted_posts as (
+  select n.user_id, n.post_id
+  from not_interested_posts n
+  where not n.deleted
+
+  union all
+
+  select w.user_id, p.id post_id
+  from user_muted_words w
+  cross join lateral (
+    select '(^|\W)' || regexp_replace(w.word, '([^[:alnum:]_])', '\\\1', 'g') || '(\W|$)' as pattern
+  ) mw
+  join posts p on not p.deleted and (
+    p.caption ~* mw.pattern
+    or exists (
+      select 1
+      from comments c
+      where c.post_id = p.id and not c.deleted and not c.removed and c.comment ~* mw.pattern
+    )
+  )
+  where w.active and not w.deleted
+
+  union all
+
+  select m.user_id, p.id post_id
+  from user_muted_communities m
+  join posts p on not p.deleted and (
+    exists (
+      select 1
+      from communities c
+      where c.id = m.community_id and c.community_type = 0 and not c.deleted and c.contract_id = any(p.contract_ids)
+    )
+    or exists (
+      select 1
+      from tokens t
+      join token_community_memberships tcm on tcm.token_definition_id = t.token_definition_id and tcm.community_id = m.community_id and not tcm.deleted
+      where t.id = any(p.token_ids) and not t.deleted
+    )
+  )
+  where m.active and not m.deleted
+);
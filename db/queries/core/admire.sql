@@ -15,4 +15,4 @@ insert into admires (id, comment_id, actor_id) values (@id, @comment_id, @actor_
 on conflict (actor_id, comment_id) where deleted = false do update set last_updated = now() returning id;
 
 -- name: DeleteAdmireByID :exec
-update admires set deleted = true where id = $1;
+update admires set deleted = true, last_updated = now() where id = $1;
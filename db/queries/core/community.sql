@@ -151,6 +151,7 @@ select posts.*
         and posts.deleted = false
         and (posts.created_at, posts.id) < (@cur_before_time, @cur_before_id::dbid)
         and (posts.created_at, posts.id) > (@cur_after_time, @cur_after_id::dbid)
+        and not exists (select 1 from muted_posts where muted_posts.user_id = @viewer_id and muted_posts.post_id = posts.id)
     order by
         case when sqlc.arg('paging_forward')::bool then (posts.created_at, posts.id) end asc,
         case when not sqlc.arg('paging_forward')::bool then (posts.created_at, posts.id) end desc
@@ -170,6 +171,7 @@ select posts.*
       and posts.deleted = false
       and (posts.created_at, posts.id) < (@cur_before_time, @cur_before_id::dbid)
       and (posts.created_at, posts.id) > (@cur_after_time, @cur_after_id::dbid)
+      and not exists (select 1 from muted_posts where muted_posts.user_id = @viewer_id and muted_posts.post_id = posts.id)
     order by
         case when sqlc.arg('paging_forward')::bool then (posts.created_at, posts.id) end asc,
         case when not sqlc.arg('paging_forward')::bool then (posts.created_at, posts.id) end desc
@@ -206,7 +208,8 @@ community_posts as (
     )
 )
 
-select count(*) from community_posts;
+select count(*) from community_posts
+where not exists (select 1 from muted_posts where muted_posts.user_id = @viewer_id and muted_posts.post_id = community_posts.id);
 
 -- name: GetCreatorsByCommunityID :batchmany
 select
@@ -501,4 +504,128 @@ where cd.community_type != 0
     and td.deleted = false
     and u.deleted = false
     and u.universal = false
-limit sqlc.arg('limit'));
\ No newline at end of file
+limit sqlc.arg('limit'));
+-- name: PaginateTokensByCommunityIDWithTraits :many
+-- Pages through a community's tokens that have all of the given traits. Tokens match a trait type if they have any of the
+-- values given for it. Tokens are ordered by when they were created, or by rarity rank (rarest first) if sort_by_rarity is set.
+with community_data as (
+    select id as community_id, community_type, contract_id
+    from communities
+    where communities.id = @community_id and not deleted
+    limit 1
+),
+community_definitions as (
+    (select td.id as token_definition_id from community_data cd
+        join token_definitions td on td.contract_id = cd.contract_id
+    where cd.community_type = 0 and not td.deleted)
+
+    union all
+
+    (select tcm.token_definition_id from community_data cd
+        join token_community_memberships tcm on tcm.community_id = cd.community_id
+    where cd.community_type != 0 and not tcm.deleted)
+),
+matching_definitions as (
+    select cdef.token_definition_id, coalesce(tdr.rank, 2147483647)::int as rarity_rank from community_definitions cdef
+        left join token_definition_rarities tdr on tdr.community_id = @community_id and tdr.token_definition_id = cdef.token_definition_id
+    where cardinality(@trait_types::varchar[]) = 0
+        or (select count(distinct tdt.trait_type) from token_definition_traits tdt
+            where tdt.community_id = @community_id
+                and tdt.token_definition_id = cdef.token_definition_id
+                and (tdt.trait_type, tdt.value) in (select unnest(@trait_types::varchar[]), unnest(@trait_values::varchar[]))
+        ) = (select count(distinct trait_type) from unnest(@trait_types::varchar[]) trait_type)
+)
+select sqlc.embed(t), md.rarity_rank from matching_definitions md
+    join tokens t on t.token_definition_id = md.token_definition_id
+    join token_definitions td on td.id = t.token_definition_id
+    join users u on u.id = t.owner_user_id
+    join contracts c on t.contract_id = c.id
+where t.displayable
+    and t.deleted = false
+    and c.deleted = false
+    and td.deleted = false
+    and u.deleted = false
+    and u.universal = false
+    and (case when @sort_by_rarity::bool then md.rarity_rank else 0 end, t.created_at, t.id) < (@cur_before_int::int, @cur_before_time::timestamptz, @cur_before_id::dbid)
+    and (case when @sort_by_rarity::bool then md.rarity_rank else 0 end, t.created_at, t.id) > (@cur_after_int::int, @cur_after_time::timestamptz, @cur_after_id::dbid)
+order by case when @paging_forward::bool then (case when @sort_by_rarity::bool then md.rarity_rank else 0 end, t.created_at, t.id) end asc,
+         case when not @paging_forward::bool then (case when @sort_by_rarity::bool then md.rarity_rank else 0 end, t.created_at, t.id) end desc
+limit sqlc.arg('limit');
+
+-- name: CountTokensByCommunityIDWithTraits :one
+with community_data as (
+    select id as community_id, community_type, contract_id
+    from communities
+    where communities.id = @community_id and not deleted
+    limit 1
+),
+community_definitions as (
+    (select td.id as token_definition_id from community_data cd
+        join token_definitions td on td.contract_id = cd.contract_id
+    where cd.community_type = 0 and not td.deleted)
+
+    union all
+
+    (select tcm.token_definition_id from community_data cd
+        join token_community_memberships tcm on tcm.community_id = cd.community_id
+    where cd.community_type != 0 and not tcm.deleted)
+),
+matching_definitions as (
+    select cdef.token_definition_id from community_definitions cdef
+    where cardinality(@trait_types::varchar[]) = 0
+        or (select count(distinct tdt.trait_type) from token_definition_traits tdt
+            where tdt.community_id = @community_id
+                and tdt.token_definition_id = cdef.token_definition_id
+                and (tdt.trait_type, tdt.value) in (select unnest(@trait_types::varchar[]), unnest(@trait_values::varchar[]))
+        ) = (select count(distinct trait_type) from unnest(@trait_types::varchar[]) trait_type)
+)
+select count(t.*) from matching_definitions md
+    join tokens t on t.token_definition_id = md.token_definition_id
+    join token_definitions td on td.id = t.token_definition_id
+    join users u on u.id = t.owner_user_id
+    join contracts c on t.contract_id = c.id
+where t.displayable
+    and t.deleted = false
+    and c.deleted = false
+    and td.deleted = false
+    and u.deleted = false
+    and u.universal = false;
+
+-- name: FollowCommunity :one
+with community_to_follow as (select id from communities where communities.id = @community_id and not deleted)
+insert into community_follows (id, user_id, community_id) (select @id, @user_id, community_to_follow.id from community_to_follow)
+on conflict(user_id, community_id) where not deleted do update set last_updated = now() returning id;
+
+-- name: UnfollowCommunity :exec
+update community_follows set deleted = true, last_updated = now() where user_id = @user_id and community_id = @community_id and not deleted;
+
+-- name: IsFollowingCommunity :one
+select exists(select 1 from community_follows where user_id = @user_id and community_id = @community_id and not deleted);
+
+-- name: GetCommunityFollowsByCommunityIDs :many
+select * from community_follows where community_id = any(@community_ids::varchar[]) and not deleted;
+
+-- Posts from the communities that the user follows, newest first. Posts that the user muted or that are by users who
+-- were banned from the feed are left out.
+-- name: GetFollowedCommunityPosts :many
+with followed_communities as (
+    select communities.id, communities.community_type, communities.contract_id
+    from community_follows
+        join communities on communities.id = community_follows.community_id and not communities.deleted
+    where community_follows.user_id = @user_id and not community_follows.deleted
+)
+select posts.* from posts
+where not posts.deleted
+    and posts.created_at > @window_end
+    and (
+        exists (select 1 from followed_communities fc
+            where fc.community_type = 0 and fc.contract_id = any(posts.contract_ids))
+        or exists (select 1 from followed_communities fc
+            join token_community_memberships tcm on tcm.community_id = fc.id and not tcm.deleted
+            join tokens on tokens.token_definition_id = tcm.token_definition_id and not tokens.deleted
+            where fc.community_type = 1 and tokens.id = any(posts.token_ids))
+    )
+    and not exists (select 1 from muted_posts where muted_posts.user_id = @user_id and muted_posts.post_id = posts.id)
+    and not exists (select 1 from feed_blocklist fb where fb.user_id = posts.actor_id and fb.active and not fb.deleted)
+order by posts.created_at desc, posts.id desc
+limit sqlc.arg('limit');
@@ -544,6 +544,8 @@ left join feed_blocklist fb on fe.actor_id = fb.user_id and not fb.deleted and f
 where (fe.created_at, fe.id) < (@cur_before_time, @cur_before_id::dbid)
         and (fe.created_at, fe.id) > (@cur_after_time, @cur_after_id::dbid)
         and (fb.user_id is null or @viewer_id = fb.user_id)
+        -- every post is already in the global feed, so reposts would only repeat them
+        and fe.feed_entity_type != 2
 order by
     case when sqlc.arg('paging_forward')::bool then (fe.created_at, fe.id) end asc,
     case when not sqlc.arg('paging_forward')::bool then (fe.created_at, fe.id) end desc
@@ -556,6 +558,23 @@ select fe.* from feed_entities fe, follows fl
       and fl.follower = sqlc.arg('follower')
       and (fe.created_at, fe.id) < (@cur_before_time, @cur_before_id::dbid)
       and (fe.created_at, fe.id) > (@cur_after_time, @cur_after_id::dbid)
+      and not exists (select 1 from muted_posts mp where mp.user_id = sqlc.arg('follower') and mp.post_id = fe.id)
+      -- A reposted post is only shown once: skip reposts of posts that are muted, that the viewer already sees because
+      -- they follow the author, or that someone else the viewer follows reposted more recently
+      and not exists (
+        select 1 from reposts r
+        join posts p on p.id = r.post_id
+        where r.id = fe.id
+          and (
+            exists (select 1 from muted_posts mp where mp.user_id = sqlc.arg('follower') and mp.post_id = r.post_id)
+            or exists (select 1 from follows f where f.follower = sqlc.arg('follower') and f.followee = p.actor_id and not f.deleted)
+            or exists (
+              select 1 from reposts later
+              join follows f on f.followee = later.actor_id and f.follower = sqlc.arg('follower') and not f.deleted
+              where later.post_id = r.post_id and not later.deleted and (later.created_at, later.id) > (r.created_at, r.id)
+            )
+          )
+      )
 order by
     case when sqlc.arg('paging_forward')::bool then (fe.created_at, fe.id) end asc,
     case when not sqlc.arg('paging_forward')::bool then (fe.created_at, fe.id) end desc
@@ -763,12 +782,26 @@ SELECT count(*) FROM comments WHERE feed_event_id = sqlc.arg('feed_event_id') AN
 SELECT count(*) FROM comments WHERE feed_event_id = sqlc.arg('feed_event_id') AND deleted = false;
 
 -- name: PaginateCommentsByPostIDBatch :batchmany
-SELECT * FROM comments WHERE post_id = sqlc.arg('post_id') AND reply_to is null AND deleted = false
-    AND (created_at, id) < (@cur_before_time, @cur_before_id::dbid)
-    AND (created_at, id) > (@cur_after_time, @cur_after_id::dbid)
-    ORDER BY CASE WHEN sqlc.arg('paging_forward')::bool THEN (created_at, id) END ASC,
-             CASE WHEN NOT sqlc.arg('paging_forward')::bool THEN (created_at, id) END DESC
-    LIMIT sqlc.arg('limit');
+select sqlc.embed(c), (pc.id is null)::bool as not_pinned, k.sort_key::float8 as sort_key
+from comments c
+    left join pinned_comments pc on pc.comment_id = c.id and pc.post_id = c.post_id and not pc.deleted
+    cross join lateral (
+        select case
+            when @sort_by::varchar = 'TOP' then -(
+                select count(*) from admires a
+                where a.comment_id = c.id and a.created_at <= @snapshot_time::timestamptz
+                    and (not a.deleted or a.last_updated > @snapshot_time::timestamptz)
+            )
+            when @sort_by::varchar = 'NEWEST' then -extract(epoch from c.created_at)
+            else extract(epoch from c.created_at)
+        end::float8 as sort_key
+    ) k
+where c.post_id = @post_id and c.reply_to is null and not c.deleted
+    and (pc.id is null, c.post_id, k.sort_key, c.id) < (@cur_before_not_pinned::bool, @post_id, @cur_before_sort_key::float8, @cur_before_id::dbid)
+    and (pc.id is null, c.post_id, k.sort_key, c.id) > (@cur_after_not_pinned::bool, @post_id, @cur_after_sort_key::float8, @cur_after_id::dbid)
+order by case when @paging_forward::bool then (pc.id is null, c.post_id, k.sort_key, c.id) end asc,
+         case when not @paging_forward::bool then (pc.id is null, c.post_id, k.sort_key, c.id) end desc
+limit sqlc.arg('limit');
 
 -- name: CountCommentsByPostIDBatch :batchone
 SELECT count(*) FROM comments WHERE post_id = sqlc.arg('post_id') AND reply_to is null AND deleted = false;
@@ -777,24 +810,31 @@ SELECT count(*) FROM comments WHERE post_id = sqlc.arg('post_id') AND reply_to i
 SELECT count(*) FROM comments WHERE post_id = sqlc.arg('post_id') AND deleted = false;
 
 -- name: PaginateRepliesByCommentIDBatch :batchmany
-SELECT * FROM comments c 
-WHERE 
-    CASE 
-        WHEN (SELECT reply_to FROM comments cc WHERE cc.id = sqlc.arg('comment_id')) IS NULL 
-        THEN c.top_level_comment_id = sqlc.arg('comment_id') 
-        ELSE c.reply_to = sqlc.arg('comment_id') 
-    END
-    AND c.deleted = false
-    AND (c.created_at, c.id) < (@cur_before_time, @cur_before_id::dbid)
-    AND (c.created_at, c.id) > (@cur_after_time, @cur_after_id::dbid)
-ORDER BY 
-    CASE 
-        WHEN sqlc.arg('paging_forward')::bool THEN (c.created_at, c.id) 
-    END ASC,
-    CASE 
-        WHEN NOT sqlc.arg('paging_forward')::bool THEN (c.created_at, c.id) 
-    END DESC
-LIMIT sqlc.arg('limit');
+select sqlc.embed(c), k.sort_key::float8 as sort_key
+from comments c
+    cross join lateral (
+        select case
+            when @sort_by::varchar = 'TOP' then -(
+                select count(*) from admires a
+                where a.comment_id = c.id and a.created_at <= @snapshot_time::timestamptz
+                    and (not a.deleted or a.last_updated > @snapshot_time::timestamptz)
+            )
+            when @sort_by::varchar = 'NEWEST' then -extract(epoch from c.created_at)
+            else extract(epoch from c.created_at)
+        end::float8 as sort_key
+    ) k
+where
+    case
+        when (select reply_to from comments cc where cc.id = @comment_id) is null
+        then c.top_level_comment_id = @comment_id
+        else c.reply_to = @comment_id
+    end
+    and c.deleted = false
+    and (k.sort_key, c.id) < (@cur_before_sort_key::float8, @cur_before_id::dbid)
+    and (k.sort_key, c.id) > (@cur_after_sort_key::float8, @cur_after_id::dbid)
+order by case when @paging_forward::bool then (k.sort_key, c.id) end asc,
+         case when not @paging_forward::bool then (k.sort_key, c.id) end desc
+limit sqlc.arg('limit');
 
 -- name: CountRepliesByCommentIDBatch :batchone
 SELECT count(*) FROM comments c 
@@ -806,6 +846,18 @@ WHERE
     END
     AND c.deleted = false;
 
+-- name: PinComment :exec
+insert into pinned_comments (id, post_id, comment_id) values (@id, @post_id, @comment_id)
+on conflict (post_id) where not deleted do update set comment_id = excluded.comment_id, created_at = now(), last_updated = now();
+
+-- name: UnpinCommentByPostID :exec
+update pinned_comments set deleted = true, last_updated = now() where post_id = @post_id and not deleted;
+
+-- name: GetPinnedCommentByPostIDBatch :batchone
+select c.* from pinned_comments pc
+    join comments c on c.id = pc.comment_id and not c.deleted
+where pc.post_id = @post_id and not pc.deleted;
+
 -- name: GetUserNotifications :many
 SELECT * FROM notifications WHERE owner_id = $1 AND deleted = false
     AND (created_at, id) < (@cur_before_time, @cur_before_id::dbid)
@@ -996,14 +1048,102 @@ SELECT * FROM admires WHERE actor_id = $1 AND token_id = $2 AND deleted = false;
 SELECT * FROM admires WHERE actor_id = $1 AND comment_id = $2 AND deleted = false;
 
 -- name: InsertPost :one
-insert into posts(id, token_ids, contract_ids, actor_id, caption, user_mint_url, is_first_post, created_at)
-values ($1, $2, $3, $4, $5, $6, not exists(select 1 from posts where posts.created_at < now() and posts.actor_id = $4::varchar limit 1), now())
+insert into posts(id, token_ids, contract_ids, actor_id, caption, user_mint_url, quoted_post_id, is_first_post, created_at)
+values (@id, @token_ids, @contract_ids, @actor_id, @caption, @user_mint_url, nullif(@quoted_post_id::dbid, ''), not exists(select 1 from posts where posts.created_at < now() and posts.actor_id = @actor_id limit 1), now())
 on conflict (actor_id, is_first_post) where is_first_post do update set is_first_post = false
 returning id;
 
 -- name: DeletePostByID :exec
 update posts set deleted = true where id = $1;
 
+-- name: InsertPostRevision :exec
+insert into post_revisions (id, post_id, caption, written_at)
+select @id, posts.id, posts.caption, coalesce(posts.edited_at, posts.created_at) from posts where posts.id = @post_id and not posts.deleted;
+
+-- name: UpdatePostCaption :exec
+update posts set caption = @caption, edited_at = now(), last_updated = now() where id = @post_id and not deleted;
+
+-- name: GetPostRevisionsByPostID :batchmany
+select * from post_revisions where post_id = @post_id order by created_at desc;
+
+-- name: InsertPostAttachment :exec
+insert into post_attachments (id, post_id, attachment_type, poll_options, poll_closes_at, link_url, link_title, link_description, link_image_url, link_site_name, community_id)
+values (@id, @post_id, @attachment_type, @poll_options, @poll_closes_at, @link_url, @link_title, @link_description, @link_image_url, @link_site_name, nullif(@community_id::dbid, ''));
+
+-- name: GetPostAttachmentByPostIDBatch :batchone
+select * from post_attachments where post_id = $1 and not deleted;
+
+-- name: GetPostAttachmentByID :one
+select * from post_attachments where id = @id and not deleted;
+
+-- name: InsertPollVote :one
+insert into poll_votes (id, attachment_id, voter_id, option_index) values (@id, @attachment_id, @voter_id, @option_index)
+on conflict (attachment_id, voter_id) where not deleted do nothing
+returning id;
+
+-- name: GetPollVoteByVoterID :one
+select option_index from poll_votes where attachment_id = @attachment_id and voter_id = @voter_id and not deleted;
+
+-- name: CountPollVotesByOptionBatch :batchmany
+select option_index, count(*) from poll_votes where attachment_id = $1 and not deleted group by option_index;
+
+-- name: InsertRepost :one
+with post_to_repost as (select id from posts where posts.id = @post_id and not deleted)
+insert into reposts (id, actor_id, post_id) (select @id, @actor_id, post_to_repost.id from post_to_repost)
+on conflict(actor_id, post_id) where not deleted do update set last_updated = now() returning id;
+
+-- name: GetRepostByIdBatch :batchone
+select * from reposts where id = $1 and not deleted;
+
+-- name: CountRepostsByPostIDBatch :batchone
+select count(*) from (
+  select id from reposts where reposts.post_id = @post_id and not reposts.deleted
+  union all
+  select id from posts where posts.quoted_post_id = @post_id and not posts.deleted
+) r;
+
+-- name: InsertPostDraft :one
+insert into post_drafts (id, actor_id, token_ids, caption, mentions, user_mint_url, publish_at)
+values (@id, @actor_id, @token_ids, @caption, @mentions, @user_mint_url, @publish_at) returning *;
+
+-- Drafts that a run of the scheduler has claimed are being published, so they can't be changed until their lease runs out.
+-- name: UpdatePostDraft :one
+update post_drafts
+set token_ids = @token_ids, caption = @caption, mentions = @mentions, user_mint_url = @user_mint_url, publish_at = @publish_at, last_updated = now()
+where id = @id and actor_id = @actor_id and published_at is null and not deleted
+  and (claimed_at is null or claimed_at < @claimed_before)
+returning *;
+
+-- name: DeletePostDraft :execrows
+update post_drafts set deleted = true, last_updated = now()
+where id = @id and actor_id = @actor_id and published_at is null and not deleted
+  and (claimed_at is null or claimed_at < @claimed_before);
+
+-- name: GetPostDraftsByActorID :many
+select * from post_drafts where actor_id = @actor_id and published_at is null and not deleted order by last_updated desc;
+
+-- Due drafts are leased when they're claimed so that concurrent runs of the scheduler don't publish the same draft
+-- twice. Drafts are only marked as published once their post exists, so a draft whose lease runs out before it's
+-- published is claimed again by a later run.
+-- name: ClaimDuePostDrafts :many
+update post_drafts set claimed_at = now(), last_updated = now()
+where id in (
+  select id from post_drafts
+  where publish_at <= now() and published_at is null and not deleted
+    and (claimed_at is null or claimed_at < @claimed_before)
+  order by publish_at
+  limit sqlc.arg('limit')
+  for update skip locked
+)
+returning *;
+
+-- name: MarkPostDraftPublished :exec
+update post_drafts set post_id = @post_id, published_at = now(), last_updated = now() where id = @id and not deleted;
+
+-- Drafts that couldn't be published are returned to their author's drafts.
+-- name: UnschedulePostDraft :exec
+update post_drafts set publish_at = null, claimed_at = null, last_updated = now() where id = @id;
+
 -- for some reason this query will not allow me to use @tags for $1
 -- name: GetUsersWithEmailNotificationsOnForEmailType :many
 select u.* from pii.user_view u
@@ -1452,9 +1592,9 @@ with insert_job(id) as (
     returning id
 )
 , set_conditionally_current_media_to_inactive(last_updated) as (
-    insert into token_medias (id, media, processing_job_id, active, created_at, last_updated)
+    insert into token_medias (id, media, processing_job_id, active, created_at, last_updated, pipeline_trace)
     (
-        select @retiring_media_id, media, processing_job_id, false, created_at, now()
+        select @retiring_media_id, media, processing_job_id, false, created_at, now(), pipeline_trace
         from token_medias
         where id = (select token_media_id from token_definitions td where (td.chain, td.contract_address, td.token_id) = (@chain, @contract_address::address, @token_id::hextokenid) and not deleted)
         and not deleted
@@ -1463,11 +1603,12 @@ with insert_job(id) as (
     returning last_updated
 )
 , insert_new_media as (
-    insert into token_medias (id, chain, contract_address, token_id, media, processing_job_id, active, created_at, last_updated)
+    insert into token_medias (id, chain, contract_address, token_id, media, processing_job_id, active, created_at, last_updated, pipeline_trace)
     values (@new_media_id, @chain, @contract_address, @decimal_token_id, @new_media::jsonb, (select id from insert_job), @new_media_is_active,
         -- Using timestamps generated from set_conditionally_current_media_to_inactive ensures that the new record is only inserted after the current media is moved
         (select coalesce((select last_updated from set_conditionally_current_media_to_inactive), now())),
-        (select coalesce((select last_updated from set_conditionally_current_media_to_inactive), now()))
+        (select coalesce((select last_updated from set_conditionally_current_media_to_inactive), now())),
+        @pipeline_trace
     )
     returning *
 )
@@ -1805,6 +1946,14 @@ select * from mentions where comment_id = @comment_id and not deleted;
 -- name: GetMentionsByPostID :batchmany
 select * from mentions where post_id = @post_id and not deleted;
 
+-- Mentions that were removed by an edit are included so that a user or community isn't notified again when they're
+-- mentioned again.
+-- name: GetMentionsByPostIDIncludingDeleted :many
+select * from mentions where post_id = @post_id;
+
+-- name: DeletePostMentionsByPostID :exec
+update mentions set deleted = true where post_id = @post_id and not deleted;
+
 -- name: GetMentionByID :one
 select * from mentions where id = @id and not deleted;
 
@@ -1914,6 +2063,38 @@ on conflict(user_id, blocked_user_id) where not deleted do update set active = t
 -- name: UnblockUser :exec
 update user_blocklist set active = false, last_updated = now() where user_id = @user_id and blocked_user_id = @blocked_user_id and not deleted;
 
+-- name: MuteWord :exec
+insert into user_muted_words (id, user_id, word, active) values (@id, @user_id, @word, true)
+on conflict(user_id, word) where not deleted do update set active = true, last_updated = now();
+
+-- name: UnmuteWord :exec
+update user_muted_words set active = false, last_updated = now() where user_id = @user_id and word = @word and not deleted;
+
+-- name: GetMutedWordsByUserID :many
+select word from user_muted_words where user_id = @user_id and active and not deleted order by word;
+
+-- name: MuteCommunity :one
+with community_to_mute as (select id from communities where communities.id = @community_id and not deleted)
+insert into user_muted_communities (id, user_id, community_id, active) (select @id, @user_id, community_to_mute.id, true from community_to_mute)
+on conflict(user_id, community_id) where not deleted do update set active = true, last_updated = now() returning id;
+
+-- name: UnmuteCommunity :exec
+update user_muted_communities set active = false, last_updated = now() where user_id = @user_id and community_id = @community_id and not deleted;
+
+-- name: GetMutedCommunitiesByUserID :many
+select communities.* from user_muted_communities
+    join communities on communities.id = user_muted_communities.community_id and not communities.deleted
+where user_muted_communities.user_id = @user_id and user_muted_communities.active and not user_muted_communities.deleted
+order by user_muted_communities.created_at desc;
+
+-- name: MarkPostNotInterested :one
+with post_to_mark as (select id from posts where posts.id = @post_id and not deleted)
+insert into not_interested_posts (id, user_id, post_id) (select @id, @user_id, post_to_mark.id from post_to_mark)
+on conflict(user_id, post_id) where not deleted do update set last_updated = now() returning id;
+
+-- name: GetMutedPostIDs :many
+select distinct post_id from muted_posts where user_id = @viewer_id and post_id = any(@post_ids::varchar[]);
+
 -- name: GetTopCommunitiesByPosts :many
 with post_report as (
     select posts.id post_id, unnest(token_ids) token_id
@@ -2049,3 +2230,291 @@ update highlight_mint_claims set last_updated = now(), status = $1, minted_token
 
 -- name: UpdateHighlightMintClaimStatusMediaProcessing :one
 update highlight_mint_claims set last_updated = now(), status = $1, internal_token_id = $2 where id = @id returning *;
+
+-- name: GetMediaPreferencesByUserIDBatch :batchone
+select * from user_media_preferences where user_id = $1 and not deleted;
+
+-- name: UpsertMediaPreferences :one
+insert into user_media_preferences (id, user_id, flagged_media_display) values (@id, @user_id, @flagged_media_display)
+on conflict(user_id) where not deleted do update set flagged_media_display = excluded.flagged_media_display, last_updated = now()
+returning *;
+
+-- name: GetFlaggedTokenIDs :many
+select t.id from tokens t
+    join token_definitions td on t.token_definition_id = td.id and not td.deleted
+    join token_medias tm on td.token_media_id = tm.id and not tm.deleted
+where t.id = any(@token_ids::varchar[]) and tm.media->'moderation'->>'label' = any(@labels::varchar[]) and not t.deleted;
+
+-- name: UpsertTokenProcessingDeadLetter :exec
+insert into token_processing_dead_letters (id, token_definition_id, contract_id, processing_job_id, reason, processing_cause, last_error, attempts, pipeline_metadata)
+values (@id, @token_definition_id, @contract_id, sqlc.narg('processing_job_id')::text, @reason, @processing_cause, @last_error, @attempts, (select pipeline_metadata from token_processing_jobs where id = sqlc.narg('processing_job_id')::text))
+on conflict(token_definition_id) where not deleted and replayed_at is null do update
+set processing_job_id = excluded.processing_job_id,
+    reason = excluded.reason,
+    processing_cause = excluded.processing_cause,
+    last_error = excluded.last_error,
+    attempts = excluded.attempts,
+    pipeline_metadata = excluded.pipeline_metadata,
+    last_updated = now();
+
+-- name: PaginateTokenProcessingDeadLetters :many
+select * from token_processing_dead_letters
+where not deleted and replayed_at is null
+    and (sqlc.narg('contract_id')::text is null or contract_id = sqlc.narg('contract_id')::text)
+    and (created_at, id) < (@cur_before_time, @cur_before_id::dbid)
+    and (created_at, id) > (@cur_after_time, @cur_after_id::dbid)
+order by case when @paging_forward::bool then (created_at, id) end asc,
+         case when not @paging_forward::bool then (created_at, id) end desc
+limit $1;
+
+-- name: ClaimTokenProcessingDeadLettersToReplay :many
+update token_processing_dead_letters set replayed_at = now(), last_updated = now()
+where not deleted and replayed_at is null
+    and (id = any(@ids::varchar[]) or contract_id = sqlc.narg('contract_id')::text)
+returning *;
+
+-- name: UnclaimTokenProcessingDeadLetters :exec
+update token_processing_dead_letters d set replayed_at = null, last_updated = now()
+where d.id = any(@ids::varchar[]) and not d.deleted
+    and not exists (
+        select 1 from token_processing_dead_letters n
+        where n.token_definition_id = d.token_definition_id and not n.deleted and n.replayed_at is null
+    );
+
+-- name: GetPinnableTokenDefinitionsByGalleryID :many
+select td.* from token_definitions td
+where not td.deleted and td.id in (
+    select t.token_definition_id
+    from galleries g, collections c, tokens t
+    where g.id = @gallery_id
+        and c.id = any(g.collections)
+        and t.id = any(c.nfts)
+        and t.owner_user_id = g.owner_user_id
+        and t.displayable
+        and not g.deleted
+        and not g.hidden
+        and not c.deleted
+        and not c.hidden
+        and not t.deleted
+);
+
+-- name: LockGalleryPinReferences :exec
+select pg_advisory_xact_lock(hashtext('ipfs_pin_references'), hashtext(@gallery_id::varchar));
+
+-- name: LockIPFSPinsForGallery :exec
+select pg_advisory_xact_lock(hashtext('ipfs_pins'), hashtext(c.cid))
+from (
+    select unnest(@cids::varchar[]) as cid
+    union
+    select cid from ipfs_pin_references where gallery_id = @gallery_id
+    order by cid
+) c;
+
+-- name: LockIPFSPins :exec
+select pg_advisory_xact_lock(hashtext('ipfs_pins'), hashtext(c.cid))
+from (select unnest(@cids::varchar[]) as cid order by cid) c;
+
+-- name: DeleteGalleryPinReferencesExcept :many
+delete from ipfs_pin_references where gallery_id = @gallery_id and not cid = any(@cids::varchar[]) returning cid;
+
+-- name: InsertGalleryPinReferences :exec
+insert into ipfs_pin_references (gallery_id, cid) select @gallery_id, unnest(@cids::varchar[]) on conflict do nothing;
+
+-- name: GetIPFSPinsByCIDs :many
+select * from ipfs_pins where cid = any(@cids::varchar[]);
+
+-- name: GetUnreferencedIPFSPinsByCIDs :many
+select * from ipfs_pins p where p.cid = any(@cids::varchar[]) and p.status = 'pinned' and not exists (select 1 from ipfs_pin_references r where r.cid = p.cid);
+
+-- name: IsIPFSPinReferenced :one
+select exists(select 1 from ipfs_pin_references where cid = @cid);
+
+-- name: UpsertIPFSPin :exec
+insert into ipfs_pins (id, cid, status, remote_request, last_error) values (@id, @cid, @status, @remote_request, @last_error)
+on conflict (cid) do update set status = excluded.status, remote_request = excluded.remote_request, last_error = excluded.last_error, last_updated = now();
+
+-- name: GetMetadataUpdateCursor :one
+select last_block from metadata_update_cursors where chain = $1;
+
+-- name: UpsertMetadataUpdateCursor :exec
+insert into metadata_update_cursors (chain, last_block) values (@chain, @last_block)
+on conflict (chain) do update set last_block = excluded.last_block, last_updated = now();
+
+-- name: GetMetadataUpdateContractsByAddresses :many
+select c.id, c.address, coalesce(p.ignore_metadata_updates, false)::bool as ignore_metadata_updates
+from contracts c
+left join contract_refresh_policies p on p.contract_id = c.id and not p.deleted
+where c.chain = @chain and c.address = any(@addresses::varchar[]) and not c.deleted;
+
+-- name: GetDueContractRefreshPolicies :many
+select * from contract_refresh_policies
+where not deleted and refresh_interval_seconds is not null and (next_refresh_at is null or next_refresh_at <= now())
+order by next_refresh_at nulls first
+limit $1;
+
+-- name: UpdateContractRefreshPolicyRefreshed :exec
+update contract_refresh_policies
+set last_refreshed_at = now(), next_refresh_at = now() + make_interval(secs => refresh_interval_seconds), last_updated = now()
+where id = $1;
+
+-- name: GetContractRefreshPolicies :many
+select * from contract_refresh_policies where not deleted order by created_at;
+
+-- name: UpsertContractRefreshPolicy :one
+-- A changed interval applies from the last refresh, and a contract that hasn't been refreshed yet is refreshed on the next run
+insert into contract_refresh_policies (id, contract_id, refresh_interval_seconds, ignore_metadata_updates) values (@id, @contract_id, @refresh_interval_seconds, @ignore_metadata_updates)
+on conflict (contract_id) where not deleted do update set
+    refresh_interval_seconds = excluded.refresh_interval_seconds,
+    ignore_metadata_updates = excluded.ignore_metadata_updates,
+    next_refresh_at = contract_refresh_policies.last_refreshed_at + make_interval(secs => excluded.refresh_interval_seconds),
+    last_updated = now()
+returning *;
+
+-- name: DeleteContractRefreshPolicy :exec
+update contract_refresh_policies set deleted = true, last_updated = now() where id = @id and not deleted;
+
+-- name: GetTokenDefinitionTokenIDsByContractID :many
+select id, token_id from token_definitions where contract_id = $1 and not deleted;
+
+-- name: GetTokenDefinitionIDsByContractIDAndTokenIDs :many
+select id from token_definitions where contract_id = @contract_id and token_id = any(@token_ids::varchar[]) and not deleted;
+
+-- name: GetCustomMetadataHandlers :many
+select * from custom_metadata_handlers where not deleted order by chain, contract_address;
+
+-- name: UpsertCustomMetadataHandler :one
+insert into custom_metadata_handlers (id, chain, contract_address, function_signature, format, template) values (@id, @chain, @contract_address, @function_signature, @format, @template)
+on conflict (chain, contract_address) where not deleted do update set function_signature = excluded.function_signature, format = excluded.format, template = excluded.template, last_updated = now()
+returning *;
+
+-- name: DeleteCustomMetadataHandler :exec
+update custom_metadata_handlers set deleted = true, last_updated = now() where id = $1 and not deleted;
+
+-- name: GetContractsToScoreForSpam :many
+select contracts.id from contracts
+left join contract_spam_scores s on s.contract_id = contracts.id
+where not contracts.deleted and (s.contract_id is null or s.last_updated < @scored_before)
+order by s.last_updated nulls first, contracts.id
+limit sqlc.arg('limit');
+
+-- name: GetContractSpamSignals :one
+select
+  contracts.name,
+  contracts.symbol,
+  contracts.is_provider_marked_spam,
+  (select count(distinct t.owner_user_id) from tokens t where t.contract_id = contracts.id and not t.deleted)::int as holders,
+  (select coalesce(max(receivers), 0) from (
+    select count(distinct t.owner_user_id) as receivers from tokens t where t.contract_id = contracts.id and not t.deleted and t.block_number is not null and t.block_number > 0 group by t.block_number
+  ) airdrops)::int as max_holders_in_block,
+  (select count(distinct t.owner_user_id) from tokens t where t.contract_id = contracts.id and not t.deleted and t.is_user_marked_spam = true)::int as spam_reports,
+  (select count(distinct t.owner_user_id) from tokens t where t.contract_id = contracts.id and not t.deleted and t.is_user_marked_spam = false)::int as not_spam_reports
+from contracts where contracts.id = $1 and not contracts.deleted;
+
+-- name: GetTokenDefinitionSpamSamplesByContractID :many
+select name, description, external_url from token_definitions where contract_id = $1 and not deleted order by id limit $2;
+
+-- name: UpsertContractSpamScore :exec
+insert into contract_spam_scores (contract_id, score, reasons) values (@contract_id, @score, @reasons)
+on conflict (contract_id) do update set score = excluded.score, reasons = excluded.reasons, last_updated = now();
+
+-- name: GetContractSpamScoreByContractID :one
+select * from contract_spam_scores where contract_id = $1;
+
+-- name: GetContractSpamScoresByContractIDs :many
+select * from contract_spam_scores where contract_id = any(@contract_ids::varchar[]);
+
+-- name: GetContractSpamScoresAboveThreshold :many
+select * from contract_spam_scores where score >= @min_score order by score desc, contract_id limit sqlc.arg('limit');
+
+-- name: GetContractEnrichmentsByAddresses :many
+select * from contract_enrichments where chain = @chain and address = any(@addresses::varchar[]);
+
+-- name: UpsertContractEnrichment :exec
+insert into contract_enrichments (id, chain, address, contract_uri, uri_name, uri_symbol, uri_description, uri_image_url, rpc_name, rpc_symbol, owner_ens_name, sources)
+values (@id, @chain, @address, @contract_uri, @uri_name, @uri_symbol, @uri_description, @uri_image_url, @rpc_name, @rpc_symbol, @owner_ens_name, @sources)
+on conflict (chain, address) do update set
+  contract_uri = excluded.contract_uri,
+  uri_name = excluded.uri_name,
+  uri_symbol = excluded.uri_symbol,
+  uri_description = excluded.uri_description,
+  uri_image_url = excluded.uri_image_url,
+  rpc_name = excluded.rpc_name,
+  rpc_symbol = excluded.rpc_symbol,
+  owner_ens_name = excluded.owner_ens_name,
+  sources = excluded.sources,
+  last_updated = now();
+
+-- name: GetContractsByChainAddresses :many
+select * from contracts where chain = @chain and address = any(@addresses::varchar[]) and not deleted;
+
+-- name: UpdateContractEnrichedFields :exec
+update contracts set name = @name, symbol = @symbol, description = @description, profile_image_url = @profile_image_url, last_updated = now() where id = @id and not deleted;
+
+-- name: GetCommunitiesToIndexTraits :many
+select c.id from communities c
+    left join community_trait_indexes cti on cti.community_id = c.id
+where not c.deleted and (cti.community_id is null or cti.last_updated < @indexed_before)
+order by cti.last_updated asc nulls first, c.id
+limit sqlc.arg('limit');
+
+-- name: GetTokenDefinitionMetadataByCommunityID :many
+select td.id, td.metadata from token_definitions td
+where not td.deleted and (
+    td.contract_id = (select contract_id from communities where id = @community_id and community_type = 0 and not deleted)
+    or td.id in (select token_definition_id from token_community_memberships where community_id = @community_id and not deleted)
+);
+
+-- name: ReplaceCommunityTraits :exec
+with deleted as (delete from community_traits where community_id = @community_id)
+insert into community_traits (community_id, trait_type, value, token_count)
+select @community_id, unnest(@trait_types::varchar[]), unnest(@trait_values::varchar[]), unnest(@token_counts::int[]);
+
+-- name: ReplaceTokenDefinitionTraits :exec
+with deleted as (delete from token_definition_traits where community_id = @community_id)
+insert into token_definition_traits (community_id, token_definition_id, trait_type, value)
+select @community_id, unnest(@token_definition_ids::varchar[]), unnest(@trait_types::varchar[]), unnest(@trait_values::varchar[]);
+
+-- name: ReplaceTokenDefinitionRarities :exec
+with deleted as (delete from token_definition_rarities where community_id = @community_id)
+insert into token_definition_rarities (community_id, token_definition_id, statistical_score, normalized_score, rank)
+select @community_id, unnest(@token_definition_ids::varchar[]), unnest(@statistical_scores::float8[]), unnest(@normalized_scores::float8[]), unnest(@ranks::int[]);
+
+-- name: UpsertCommunityTraitIndex :exec
+insert into community_trait_indexes (community_id, token_count) values (@community_id, @token_count)
+on conflict (community_id) do update set token_count = excluded.token_count, last_updated = now();
+
+-- name: GetCommunityTraitsByCommunityID :many
+select ct.trait_type, ct.value, ct.token_count, cti.token_count as total from community_traits ct
+    join community_trait_indexes cti on cti.community_id = ct.community_id
+where ct.community_id = @community_id
+order by ct.trait_type, ct.token_count desc, ct.value;
+
+-- A token definition is scored in each of its communities. Token-based communities (e.g. an Art Blocks project) are
+-- more specific than the contract's community, so they're preferred.
+-- A token definition is ranked in each of its communities. If a community isn't given, the most specific one is used:
+-- a community other than the contract's (e.g. an Art Blocks project) is preferred, since it's ranked against tokens
+-- that share its traits.
+-- name: GetTokenDefinitionRarityBatch :batchone
+select tdr.community_id, tdr.statistical_score, tdr.normalized_score, tdr.rank, cti.token_count as total from token_definition_rarities tdr
+    join communities c on c.id = tdr.community_id
+    join community_trait_indexes cti on cti.community_id = tdr.community_id
+where tdr.token_definition_id = @token_definition_id and (@community_id::dbid = '' or tdr.community_id = @community_id) and not c.deleted
+order by c.community_type = 0, c.id
+limit 1;
+
+-- name: GetTokenDefinitionTraitsBatch :batchmany
+select tdt.trait_type, tdt.value, ct.token_count, cti.token_count as total from token_definition_traits tdt
+    join community_traits ct on ct.community_id = tdt.community_id and ct.trait_type = tdt.trait_type and ct.value = tdt.value
+    join community_trait_indexes cti on cti.community_id = tdt.community_id
+where tdt.community_id = @community_id and tdt.token_definition_id = @token_definition_id
+order by tdt.trait_type, tdt.value;
+
+-- Editions of an open edition or ERC-1155 drop are usually separate token definitions that share everything but the
+-- token ID, so they're matched on the contract, name, description, and media.
+-- name: GetTokenEditionsByTokenIDs :many
+select t.id, t.owner_user_id, t.quantity, td.contract_id, td.name, td.description,
+    coalesce(td.metadata->>'image', '')::varchar as image_url,
+    coalesce(td.metadata->>'animation_url', '')::varchar as animation_url
+from tokens t
+    join token_definitions td on td.id = t.token_definition_id
+where t.id = any(@token_ids::dbid[]) and not t.deleted and not td.deleted;
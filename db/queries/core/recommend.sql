@@ -91,3 +91,40 @@ join t4 using(id)
 join posts p on feed_entity_scores.id = p.id and not p.deleted
 left join feed_blocklist fb on p.actor_id = fb.user_id and not fb.deleted and fb.active
 where (fb.user_id is null or @viewer_id = fb.user_id);
+
+-- Rebuilds the posts that GetFeedEntityScores would have returned at a point in time, counting only the interactions
+-- that had happened by then. Posts in the same streak are ordered by ID instead of randomly so the result is repeatable.
+-- name: GetFeedEntityScoresAsOf :many
+with gallery_user as ( select id from users where username_idempotent = 'gallery' and not deleted and not universal )
+     , t0         as ( select id, actor_id, created_at from posts where created_at > @window_end and created_at <= @as_of and not deleted )
+     , t1         as ( select id, actor_id, created_at, (case when lag(created_at) over (partition by actor_id order by created_at desc) is null then 0
+                                       when extract(epoch from created_at - lag(created_at) over (partition by actor_id order by created_at desc)) > -(@span::int) then 0
+                                       else 1 end)::int cume from t0 )
+     , t2         as ( select t1.id, (sum(cume) over (partition by actor_id order by created_at desc))::int group_number from t1 )
+select
+  sqlc.embed(p)
+  , ((select count(*) from comments c where c.post_id = p.id and c.created_at <= @as_of)
+    + (select count(*) from admires a where a.post_id = p.id and a.created_at <= @as_of))::int interactions
+  , row_number() over (partition by p.actor_id order by (t2.group_number, p.id)) streak
+  , coalesce(p.actor_id = (select id from gallery_user), false)::bool is_gallery_post
+from t2
+join posts p on p.id = t2.id
+left join feed_blocklist fb on p.actor_id = fb.user_id and not fb.deleted and fb.active
+where fb.user_id is null;
+
+-- name: GetFeedInteractionsByPostIDs :many
+select actor_id, post_id, created_at, 'admire'::varchar kind from admires
+where post_id = any(@post_ids::dbid[]) and created_at > @after and created_at <= @before and not deleted
+union all
+select actor_id, post_id, created_at, 'comment'::varchar kind from comments
+where post_id = any(@post_ids::dbid[]) and created_at > @after and created_at <= @before and not deleted and not removed;
+
+-- name: InsertFeedExposure :exec
+insert into feed_exposures (id, viewer_id, feed, variant, post_ids, positions)
+values (@id, nullif(@viewer_id::varchar, ''), @feed, @variant, @post_ids::varchar[], @positions::int[]);
+
+-- name: GetNotInterestedSignalsByUserID :many
+select p.actor_id, p.contract_ids
+from not_interested_posts n
+join posts p on p.id = n.post_id
+where n.user_id = @user_id and n.created_at > @since and not n.deleted;
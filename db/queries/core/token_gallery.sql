@@ -113,7 +113,7 @@ with tokens_insert as (
       , now()
       , bulk_upsert.collectors_note
       , bulk_upsert.quantity
-      , bulk_upsert.block_number
+      , nullif(bulk_upsert.block_number, 0)
       , bulk_upsert.owner_user_id
       , case when @set_holder_fields::bool then bulk_upsert.owned_by_wallets[bulk_upsert.owned_by_wallets_start_idx::int:bulk_upsert.owned_by_wallets_end_idx::int] else '{}' end
       , case when @set_creator_fields::bool then bulk_upsert.is_creator_token else false end
@@ -143,7 +143,7 @@ with tokens_insert as (
     quantity = excluded.quantity
     , owned_by_wallets = case when @set_holder_fields then excluded.owned_by_wallets else tokens.owned_by_wallets end
     , is_creator_token = case when @set_creator_fields then excluded.is_creator_token else tokens.is_creator_token end
-    , block_number = excluded.block_number
+    , block_number = coalesce(excluded.block_number, tokens.block_number)
     , version = excluded.version
     , last_updated = excluded.last_updated
     , last_synced = greatest(excluded.last_synced,tokens.last_synced)
@@ -73,13 +73,20 @@ func AddTo(ctx *gin.Context, disableDataloaderCaching bool, notif *notifications
 	sender.addDelayedHandler(notifications, persist.ActionMentionCommunity, notificationHandler)
 	sender.addDelayedHandler(notifications, persist.ActionNewTokensReceived, notificationHandler)
 	sender.addDelayedHandler(notifications, persist.ActionUserPostedYourWork, notificationHandler)
+	sender.addDelayedHandler(notifications, persist.ActionUserReposted, notificationHandler)
 	sender.addDelayedHandler(notifications, persist.ActionUserPosted, &userPostedNotificationHandler{notif, queries, dataloaders, neynarAPI})
 	sender.addDelayedHandler(notifications, persist.ActionTopActivityBadgeReceived, notificationHandler)
 	sender.addDelayedHandler(notifications, persist.ActionAnnouncement, &announcementNotificationHandler{notif})
 	sender.addDelayedHandler(notifications, persist.ActionUserCreated, userCreatedNotificationHandler{notif, queries, dataloaders, neynarAPI})
 
+	pinning := newEventDispatcher()
+	pinHandler := ipfsPinHandler{taskClient, queries}
+	sender.addDelayedHandler(pinning, persist.ActionTokensAddedToCollection, pinHandler)
+	sender.addGroupHandler(pinning, persist.ActionGalleryUpdated, pinHandler)
+
 	sender.feed = feed
 	sender.notifications = notifications
+	sender.pinning = pinning
 	ctx.Set(eventSenderContextKey, &sender)
 }
 
@@ -161,6 +168,7 @@ func dispatchDelayed(ctx context.Context, event db.Event) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error { return sender.feed.dispatchDelayed(ctx, *e) })
 	eg.Go(func() error { return sender.notifications.dispatchDelayed(ctx, *e) })
+	eg.Go(func() error { return sender.pinning.dispatchDelayed(ctx, *e) })
 	return eg.Wait()
 }
 
@@ -198,6 +206,14 @@ func dispatchImmediate(ctx context.Context, events []db.Event) (*db.FeedEvent, e
 			sentryutil.ReportError(ctx, err)
 		}
 
+		// Pinning only has delayed handlers, so captioned events are sent to them here
+		for _, e := range persistedEvents {
+			if err := sender.pinning.dispatchDelayed(ctx, e); err != nil {
+				logger.For(ctx).Error(err)
+				sentryutil.ReportError(ctx, err)
+			}
+		}
+
 	}()
 
 	feedEvent, err := sender.feed.dispatchImmediate(ctx, persistedEvents)
@@ -236,6 +252,11 @@ func DispatchGroup(ctx context.Context, groupID string, action persist.Action, c
 			sentryutil.ReportError(ctx, err)
 		}
 
+		if _, err := sender.pinning.dispatchGroup(ctx, groupID, action); err != nil {
+			logger.For(ctx).Error(err)
+			sentryutil.ReportError(ctx, err)
+		}
+
 	}()
 
 	feedEvent, err := sender.feed.dispatchGroup(ctx, groupID, action)
@@ -256,6 +277,7 @@ type registedActions map[persist.Action]struct{}
 type eventSender struct {
 	feed          *eventDispatcher
 	notifications *eventDispatcher
+	pinning       *eventDispatcher
 	registry      map[sendType]registedActions
 	queries       *db.Queries
 	eventRepo     postgres.EventRepository
@@ -538,6 +560,12 @@ func (h notificationHandler) findOwnerForNotificationFromEvent(ctx context.Conte
 		}
 	case persist.ResourceTypeUser:
 		return event.SubjectID, nil
+	case persist.ResourceTypePost:
+		post, err := h.dataloaders.GetPostByIdBatch.Load(event.PostID)
+		if err != nil {
+			return "", err
+		}
+		return post.ActorID, nil
 	case persist.ResourceTypeToken:
 		return persist.DBID(event.ActorID.String), nil
 	case persist.ResourceTypeCommunity:
@@ -571,6 +599,10 @@ func (h notificationHandler) createNotificationDataForEvent(event db.Event) (dat
 		if event.ActorID.String != "" {
 			data.AdmirerIDs = []persist.DBID{persist.NullStrToDBID(event.ActorID)}
 		}
+	case persist.ActionUserReposted:
+		if event.ActorID.String != "" {
+			data.ReposterIDs = []persist.DBID{persist.NullStrToDBID(event.ActorID)}
+		}
 	case persist.ActionUserFollowedUsers:
 		if event.ActorID.String != "" {
 			data.FollowerIDs = []persist.DBID{persist.NullStrToDBID(event.ActorID)}
@@ -612,6 +644,39 @@ func (s slackHandler) handleDelayed(ctx context.Context, e db.Event) error {
 	return s.tc.CreateTaskForSlackPostFeedBot(ctx, task.FeedbotSlackPostMessage{PostID: e.PostID})
 }
 
+// ipfsPinHandler pins the media of the tokens in a gallery after its collections change
+type ipfsPinHandler struct {
+	tc *task.Client
+	q  *db.Queries
+}
+
+func (i ipfsPinHandler) handleDelayed(ctx context.Context, e db.Event) error {
+	if e.GalleryID == "" {
+		return nil
+	}
+	return i.tc.CreateTaskForIPFSPinGallery(ctx, task.IPFSPinGalleryMessage{GalleryID: e.GalleryID})
+}
+
+// handleGroup pins the galleries that were edited once the edit is published
+func (i ipfsPinHandler) handleGroup(ctx context.Context, groupID string, action persist.Action) (*db.FeedEvent, error) {
+	events, err := i.q.GetEventsInGroup(ctx, persist.StrPtrToNullStr(&groupID))
+	if err != nil {
+		return nil, err
+	}
+
+	galleryIDs := util.Dedupe(util.MapWithoutError(events, func(e db.Event) persist.DBID { return e.GalleryID }), false)
+	for _, galleryID := range galleryIDs {
+		if galleryID == "" {
+			continue
+		}
+		if err := i.tc.CreateTaskForIPFSPinGallery(ctx, task.IPFSPinGalleryMessage{GalleryID: galleryID}); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
 type userCreatedNotificationHandler struct {
 	notificationHandlers *notifications.NotificationHandlers
 	q                    *db.Queries
@@ -705,8 +770,16 @@ func (u userPostedNotificationHandler) handleDelayed(ctx context.Context, e db.E
 		logger.For(ctx).Errorf("failed to get receipients of first post notification: %s", err)
 	}
 
+	communityCreatorPostedRecipients, err := u.recipientsOfFollowedCommunityCreatorPostedNotification(ctx, e)
+	if err != nil {
+		logger.For(ctx).Errorf("failed to get recipients of followed community creator posted notification: %s", err)
+	}
+
 	// Merge recipients with the more specific notification merged last
 	recipients := postedFistPostReceipients
+	for u, n := range communityCreatorPostedRecipients {
+		recipients[u] = n
+	}
 	for u, n := range postedYourWorkReceipients {
 		recipients[u] = n
 	}
@@ -729,6 +802,82 @@ func (u userPostedNotificationHandler) receipientsOfUserPostedYourWorkNotificati
 		return recipients, err
 	}
 
+	// Only notifiy creators once per posts, even if the post includes tokens from multiple
+	// communities owned by the same creator.
+	for _, c := range u.creatorsOfPost(post) {
+		if c.CreatorUserID != "" && c.CreatorUserID != post.ActorID {
+			recipients[c.CreatorUserID] = db.Notification{
+				OwnerID:     c.CreatorUserID,
+				Action:      persist.ActionUserPostedYourWork,
+				PostID:      e.PostID,
+				EventIds:    []persist.DBID{e.ID},
+				CommunityID: c.CommunityID,
+			}
+		}
+	}
+
+	return recipients, nil
+}
+
+// recipientsOfFollowedCommunityCreatorPostedNotification returns the followers of the communities that the post is
+// about, if the post's author is a creator of those communities
+func (u userPostedNotificationHandler) recipientsOfFollowedCommunityCreatorPostedNotification(ctx context.Context, e db.Event) (recipients map[persist.DBID]db.Notification, err error) {
+	post, err := u.dataloaders.GetPostByIdBatch.Load(e.PostID)
+	if err != nil {
+		return map[persist.DBID]db.Notification{}, err
+	}
+
+	creators := u.creatorsOfPost(post)
+
+	communityIDs := make([]string, 0)
+	for _, c := range creators {
+		if c.CreatorUserID == post.ActorID {
+			communityIDs = append(communityIDs, c.CommunityID.String())
+		}
+	}
+
+	if len(communityIDs) == 0 {
+		return map[persist.DBID]db.Notification{}, nil
+	}
+
+	follows, err := u.q.GetCommunityFollowsByCommunityIDs(ctx, communityIDs)
+	if err != nil {
+		return map[persist.DBID]db.Notification{}, err
+	}
+
+	return followedCommunityCreatorPostedNotifications(e, post, creators, follows), nil
+}
+
+// followedCommunityCreatorPostedNotifications returns a notification for each follower of the communities that the
+// post's author created. The author isn't notified of their own post.
+func followedCommunityCreatorPostedNotifications(e db.Event, post db.Post, creators []db.GetCreatorsByCommunityIDRow, follows []db.CommunityFollow) map[persist.DBID]db.Notification {
+	created := make(map[persist.DBID]bool)
+	for _, c := range creators {
+		if c.CreatorUserID == post.ActorID {
+			created[c.CommunityID] = true
+		}
+	}
+
+	// Only notify followers once per post, even if they follow more than one of the post's communities
+	recipients := make(map[persist.DBID]db.Notification)
+	for _, f := range follows {
+		if _, ok := recipients[f.UserID]; ok || f.UserID == post.ActorID || !created[f.CommunityID] {
+			continue
+		}
+		recipients[f.UserID] = db.Notification{
+			OwnerID:     f.UserID,
+			Action:      persist.ActionFollowedCommunityCreatorPosted,
+			PostID:      e.PostID,
+			EventIds:    []persist.DBID{e.ID},
+			CommunityID: f.CommunityID,
+		}
+	}
+
+	return recipients
+}
+
+// creatorsOfPost returns the creators of the communities of the post's tokens
+func (u userPostedNotificationHandler) creatorsOfPost(post db.Post) []db.GetCreatorsByCommunityIDRow {
 	// Load token definitions from the post
 	tokenDefinitions, errors := u.dataloaders.GetTokenDefinitionByTokenDbidBatch.LoadAll(post.TokenIds)
 	j := 0
@@ -760,21 +909,7 @@ func (u userPostedNotificationHandler) receipientsOfUserPostedYourWorkNotificati
 		}
 	}
 
-	// Only notifiy creators once per posts, even if the post includes tokens from multiple
-	// communities owned by the same creator.
-	for _, c := range creatorsFlat {
-		if c.CreatorUserID != "" && c.CreatorUserID != post.ActorID {
-			recipients[c.CreatorUserID] = db.Notification{
-				OwnerID:     c.CreatorUserID,
-				Action:      persist.ActionUserPostedYourWork,
-				PostID:      e.PostID,
-				EventIds:    []persist.DBID{e.ID},
-				CommunityID: c.CommunityID,
-			}
-		}
-	}
-
-	return recipients, nil
+	return creatorsFlat
 }
 
 func (u userPostedNotificationHandler) receipientsOfUserPostedFirstPostNotification(ctx context.Context, e db.Event) (recipients map[persist.DBID]db.Notification, err error) {
@@ -0,0 +1,69 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+func TestFollowedCommunityCreatorPostedNotifications(t *testing.T) {
+	e := db.Event{ID: "event", PostID: "post"}
+	post := db.Post{ID: "post", ActorID: "creator"}
+	creators := []db.GetCreatorsByCommunityIDRow{
+		{CommunityID: "created", CreatorUserID: "creator"},
+		{CommunityID: "alsoCreated", CreatorUserID: "creator"},
+		{CommunityID: "other", CreatorUserID: "otherCreator"},
+	}
+	notification := func(userID, communityID persist.DBID) db.Notification {
+		return db.Notification{
+			OwnerID:     userID,
+			Action:      persist.ActionFollowedCommunityCreatorPosted,
+			PostID:      "post",
+			EventIds:    []persist.DBID{"event"},
+			CommunityID: communityID,
+		}
+	}
+
+	tests := []struct {
+		title    string
+		follows  []db.CommunityFollow
+		expected map[persist.DBID]db.Notification
+	}{
+		{
+			title:    "notifies followers of communities the author created",
+			follows:  []db.CommunityFollow{{UserID: "follower", CommunityID: "created"}},
+			expected: map[persist.DBID]db.Notification{"follower": notification("follower", "created")},
+		},
+		{
+			title:    "doesn't notify the author",
+			follows:  []db.CommunityFollow{{UserID: "creator", CommunityID: "created"}},
+			expected: map[persist.DBID]db.Notification{},
+		},
+		{
+			title:    "doesn't notify followers of communities the author didn't create",
+			follows:  []db.CommunityFollow{{UserID: "follower", CommunityID: "other"}},
+			expected: map[persist.DBID]db.Notification{},
+		},
+		{
+			title:    "doesn't notify anyone if the communities have no followers",
+			expected: map[persist.DBID]db.Notification{},
+		},
+		{
+			title: "notifies followers of several of the communities once",
+			follows: []db.CommunityFollow{
+				{UserID: "follower", CommunityID: "created"},
+				{UserID: "follower", CommunityID: "alsoCreated"},
+			},
+			expected: map[persist.DBID]db.Notification{"follower": notification("follower", "created")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, followedCommunityCreatorPostedNotifications(e, post, creators, tt.follows))
+		})
+	}
+}
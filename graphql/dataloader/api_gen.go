@@ -23,7 +23,9 @@ type Loaders struct {
 	CountGalleriesDisplayingCommunityIDBatch             *CountGalleriesDisplayingCommunityIDBatch
 	CountInteractionsByFeedEventIDBatch                  *CountInteractionsByFeedEventIDBatch
 	CountInteractionsByPostIDBatch                       *CountInteractionsByPostIDBatch
+	CountPollVotesByOptionBatch                          *CountPollVotesByOptionBatch
 	CountRepliesByCommentIDBatch                         *CountRepliesByCommentIDBatch
+	CountRepostsByPostIDBatch                            *CountRepostsByPostIDBatch
 	GetAdmireByActorIDAndCommentID                       *GetAdmireByActorIDAndCommentID
 	GetAdmireByActorIDAndFeedEventID                     *GetAdmireByActorIDAndFeedEventID
 	GetAdmireByActorIDAndPostID                          *GetAdmireByActorIDAndPostID
@@ -51,15 +53,20 @@ type Loaders struct {
 	GetGalleryByIdBatch                                  *GetGalleryByIdBatch
 	GetGalleryTokenMediasByGalleryIDBatch                *GetGalleryTokenMediasByGalleryIDBatch
 	GetMediaByMediaIdIgnoringStatusBatch                 *GetMediaByMediaIdIgnoringStatusBatch
+	GetMediaPreferencesByUserIDBatch                     *GetMediaPreferencesByUserIDBatch
 	GetMembershipByMembershipIdBatch                     *GetMembershipByMembershipIdBatch
 	GetMentionsByCommentID                               *GetMentionsByCommentID
 	GetMentionsByPostID                                  *GetMentionsByPostID
 	GetNewTokensByFeedEventIdBatch                       *GetNewTokensByFeedEventIdBatch
 	GetNotificationByIDBatch                             *GetNotificationByIDBatch
 	GetOwnersByContractIdBatchPaginate                   *GetOwnersByContractIdBatchPaginate
+	GetPinnedCommentByPostIDBatch                        *GetPinnedCommentByPostIDBatch
+	GetPostAttachmentByPostIDBatch                       *GetPostAttachmentByPostIDBatch
 	GetPostByIdBatch                                     *GetPostByIdBatch
+	GetPostRevisionsByPostID                             *GetPostRevisionsByPostID
 	GetPostsByIdsPaginateBatch                           *GetPostsByIdsPaginateBatch
 	GetProfileImageByIdBatch                             *GetProfileImageByIdBatch
+	GetRepostByIdBatch                                   *GetRepostByIdBatch
 	GetSharedCommunitiesBatchPaginate                    *GetSharedCommunitiesBatchPaginate
 	GetSharedFollowersBatchPaginate                      *GetSharedFollowersBatchPaginate
 	GetTokenByIdBatch                                    *GetTokenByIdBatch
@@ -68,6 +75,8 @@ type Loaders struct {
 	GetTokenByUserTokenIdentifiersIgnoreDisplayableBatch *GetTokenByUserTokenIdentifiersIgnoreDisplayableBatch
 	GetTokenDefinitionByIdBatch                          *GetTokenDefinitionByIdBatch
 	GetTokenDefinitionByTokenDbidBatch                   *GetTokenDefinitionByTokenDbidBatch
+	GetTokenDefinitionRarityBatch                        *GetTokenDefinitionRarityBatch
+	GetTokenDefinitionTraitsBatch                        *GetTokenDefinitionTraitsBatch
 	GetTokensByCollectionIdBatch                         *GetTokensByCollectionIdBatch
 	GetTokensByUserIdBatch                               *GetTokensByUserIdBatch
 	GetTokensByWalletIdsBatch                            *GetTokensByWalletIdsBatch
@@ -111,7 +120,9 @@ func NewLoaders(ctx context.Context, q *coredb.Queries, disableCaching bool, pre
 	loaders.CountGalleriesDisplayingCommunityIDBatch = newCountGalleriesDisplayingCommunityIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadCountGalleriesDisplayingCommunityIDBatch(q), preFetchHook, postFetchHook)
 	loaders.CountInteractionsByFeedEventIDBatch = newCountInteractionsByFeedEventIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadCountInteractionsByFeedEventIDBatch(q), preFetchHook, postFetchHook)
 	loaders.CountInteractionsByPostIDBatch = newCountInteractionsByPostIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadCountInteractionsByPostIDBatch(q), preFetchHook, postFetchHook)
+	loaders.CountPollVotesByOptionBatch = newCountPollVotesByOptionBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadCountPollVotesByOptionBatch(q), preFetchHook, postFetchHook)
 	loaders.CountRepliesByCommentIDBatch = newCountRepliesByCommentIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadCountRepliesByCommentIDBatch(q), preFetchHook, postFetchHook)
+	loaders.CountRepostsByPostIDBatch = newCountRepostsByPostIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadCountRepostsByPostIDBatch(q), preFetchHook, postFetchHook)
 	loaders.GetAdmireByActorIDAndCommentID = newGetAdmireByActorIDAndCommentID(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetAdmireByActorIDAndCommentID(q), preFetchHook, postFetchHook)
 	loaders.GetAdmireByActorIDAndFeedEventID = newGetAdmireByActorIDAndFeedEventID(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetAdmireByActorIDAndFeedEventID(q), preFetchHook, postFetchHook)
 	loaders.GetAdmireByActorIDAndPostID = newGetAdmireByActorIDAndPostID(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetAdmireByActorIDAndPostID(q), preFetchHook, postFetchHook)
@@ -139,15 +150,20 @@ func NewLoaders(ctx context.Context, q *coredb.Queries, disableCaching bool, pre
 	loaders.GetGalleryByIdBatch = newGetGalleryByIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetGalleryByIdBatch(q), preFetchHook, postFetchHook)
 	loaders.GetGalleryTokenMediasByGalleryIDBatch = newGetGalleryTokenMediasByGalleryIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetGalleryTokenMediasByGalleryIDBatch(q), preFetchHook, postFetchHook)
 	loaders.GetMediaByMediaIdIgnoringStatusBatch = newGetMediaByMediaIdIgnoringStatusBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetMediaByMediaIdIgnoringStatusBatch(q), preFetchHook, postFetchHook)
+	loaders.GetMediaPreferencesByUserIDBatch = newGetMediaPreferencesByUserIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetMediaPreferencesByUserIDBatch(q), preFetchHook, postFetchHook)
 	loaders.GetMembershipByMembershipIdBatch = newGetMembershipByMembershipIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetMembershipByMembershipIdBatch(q), preFetchHook, postFetchHook)
 	loaders.GetMentionsByCommentID = newGetMentionsByCommentID(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetMentionsByCommentID(q), preFetchHook, postFetchHook)
 	loaders.GetMentionsByPostID = newGetMentionsByPostID(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetMentionsByPostID(q), preFetchHook, postFetchHook)
 	loaders.GetNewTokensByFeedEventIdBatch = newGetNewTokensByFeedEventIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetNewTokensByFeedEventIdBatch(q), preFetchHook, postFetchHook)
 	loaders.GetNotificationByIDBatch = newGetNotificationByIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetNotificationByIDBatch(q), preFetchHook, postFetchHook)
 	loaders.GetOwnersByContractIdBatchPaginate = newGetOwnersByContractIdBatchPaginate(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetOwnersByContractIdBatchPaginate(q), preFetchHook, postFetchHook)
+	loaders.GetPinnedCommentByPostIDBatch = newGetPinnedCommentByPostIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetPinnedCommentByPostIDBatch(q), preFetchHook, postFetchHook)
+	loaders.GetPostAttachmentByPostIDBatch = newGetPostAttachmentByPostIDBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetPostAttachmentByPostIDBatch(q), preFetchHook, postFetchHook)
 	loaders.GetPostByIdBatch = newGetPostByIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetPostByIdBatch(q), preFetchHook, postFetchHook)
+	loaders.GetPostRevisionsByPostID = newGetPostRevisionsByPostID(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetPostRevisionsByPostID(q), preFetchHook, postFetchHook)
 	loaders.GetPostsByIdsPaginateBatch = newGetPostsByIdsPaginateBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetPostsByIdsPaginateBatch(q), preFetchHook, postFetchHook)
 	loaders.GetProfileImageByIdBatch = newGetProfileImageByIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetProfileImageByIdBatch(q), preFetchHook, postFetchHook)
+	loaders.GetRepostByIdBatch = newGetRepostByIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetRepostByIdBatch(q), preFetchHook, postFetchHook)
 	loaders.GetSharedCommunitiesBatchPaginate = newGetSharedCommunitiesBatchPaginate(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetSharedCommunitiesBatchPaginate(q), preFetchHook, postFetchHook)
 	loaders.GetSharedFollowersBatchPaginate = newGetSharedFollowersBatchPaginate(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetSharedFollowersBatchPaginate(q), preFetchHook, postFetchHook)
 	loaders.GetTokenByIdBatch = newGetTokenByIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokenByIdBatch(q), preFetchHook, postFetchHook)
@@ -156,6 +172,8 @@ func NewLoaders(ctx context.Context, q *coredb.Queries, disableCaching bool, pre
 	loaders.GetTokenByUserTokenIdentifiersIgnoreDisplayableBatch = newGetTokenByUserTokenIdentifiersIgnoreDisplayableBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokenByUserTokenIdentifiersIgnoreDisplayableBatch(q), preFetchHook, postFetchHook)
 	loaders.GetTokenDefinitionByIdBatch = newGetTokenDefinitionByIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokenDefinitionByIdBatch(q), preFetchHook, postFetchHook)
 	loaders.GetTokenDefinitionByTokenDbidBatch = newGetTokenDefinitionByTokenDbidBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokenDefinitionByTokenDbidBatch(q), preFetchHook, postFetchHook)
+	loaders.GetTokenDefinitionRarityBatch = newGetTokenDefinitionRarityBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokenDefinitionRarityBatch(q), preFetchHook, postFetchHook)
+	loaders.GetTokenDefinitionTraitsBatch = newGetTokenDefinitionTraitsBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokenDefinitionTraitsBatch(q), preFetchHook, postFetchHook)
 	loaders.GetTokensByCollectionIdBatch = newGetTokensByCollectionIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokensByCollectionIdBatch(q), preFetchHook, postFetchHook)
 	loaders.GetTokensByUserIdBatch = newGetTokensByUserIdBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokensByUserIdBatch(q), preFetchHook, postFetchHook)
 	loaders.GetTokensByWalletIdsBatch = newGetTokensByWalletIdsBatch(ctx, 100, time.Duration(2000000), !disableCaching, true, loadGetTokensByWalletIdsBatch(q), preFetchHook, postFetchHook)
@@ -243,14 +261,14 @@ func NewLoaders(ctx context.Context, q *coredb.Queries, disableCaching bool, pre
 			loaders.GetCommentByCommentIDBatch.Prime(loaders.GetCommentByCommentIDBatch.getKeyForResult(entry), entry)
 		}
 	})
-	loaders.PaginateCommentsByPostIDBatch.RegisterResultSubscriber(func(result []coredb.Comment) {
+	loaders.PaginateCommentsByPostIDBatch.RegisterResultSubscriber(func(result []coredb.PaginateCommentsByPostIDBatchRow) {
 		for _, entry := range result {
-			loaders.GetCommentByCommentIDBatch.Prime(loaders.GetCommentByCommentIDBatch.getKeyForResult(entry), entry)
+			loaders.GetCommentByCommentIDBatch.Prime(loaders.GetCommentByCommentIDBatch.getKeyForResult(entry.Comment), entry.Comment)
 		}
 	})
-	loaders.PaginateRepliesByCommentIDBatch.RegisterResultSubscriber(func(result []coredb.Comment) {
+	loaders.PaginateRepliesByCommentIDBatch.RegisterResultSubscriber(func(result []coredb.PaginateRepliesByCommentIDBatchRow) {
 		for _, entry := range result {
-			loaders.GetCommentByCommentIDBatch.Prime(loaders.GetCommentByCommentIDBatch.getKeyForResult(entry), entry)
+			loaders.GetCommentByCommentIDBatch.Prime(loaders.GetCommentByCommentIDBatch.getKeyForResult(entry.Comment), entry.Comment)
 		}
 	})
 	loaders.GetCommunitiesByTokenDefinitionID.RegisterResultSubscriber(func(result []coredb.Community) {
@@ -753,6 +771,22 @@ func loadCountInteractionsByPostIDBatch(q *coredb.Queries) func(context.Context,
 	}
 }
 
+func loadCountPollVotesByOptionBatch(q *coredb.Queries) func(context.Context, *CountPollVotesByOptionBatch, []persist.DBID) ([][]coredb.CountPollVotesByOptionBatchRow, []error) {
+	return func(ctx context.Context, d *CountPollVotesByOptionBatch, params []persist.DBID) ([][]coredb.CountPollVotesByOptionBatchRow, []error) {
+		results := make([][]coredb.CountPollVotesByOptionBatchRow, len(params))
+		errors := make([]error, len(params))
+
+		b := q.CountPollVotesByOptionBatch(ctx, params)
+		defer b.Close()
+
+		b.Query(func(i int, r []coredb.CountPollVotesByOptionBatchRow, err error) {
+			results[i], errors[i] = r, err
+		})
+
+		return results, errors
+	}
+}
+
 func loadCountRepliesByCommentIDBatch(q *coredb.Queries) func(context.Context, *CountRepliesByCommentIDBatch, []persist.DBID) ([]int64, []error) {
 	return func(ctx context.Context, d *CountRepliesByCommentIDBatch, params []persist.DBID) ([]int64, []error) {
 		results := make([]int64, len(params))
@@ -772,6 +806,25 @@ func loadCountRepliesByCommentIDBatch(q *coredb.Queries) func(context.Context, *
 	}
 }
 
+func loadCountRepostsByPostIDBatch(q *coredb.Queries) func(context.Context, *CountRepostsByPostIDBatch, []persist.DBID) ([]int64, []error) {
+	return func(ctx context.Context, d *CountRepostsByPostIDBatch, params []persist.DBID) ([]int64, []error) {
+		results := make([]int64, len(params))
+		errors := make([]error, len(params))
+
+		b := q.CountRepostsByPostIDBatch(ctx, params)
+		defer b.Close()
+
+		b.QueryRow(func(i int, r int64, err error) {
+			results[i], errors[i] = r, err
+			if errors[i] == pgx.ErrNoRows {
+				errors[i] = d.getNotFoundError(params[i])
+			}
+		})
+
+		return results, errors
+	}
+}
+
 func loadGetAdmireByActorIDAndCommentID(q *coredb.Queries) func(context.Context, *GetAdmireByActorIDAndCommentID, []coredb.GetAdmireByActorIDAndCommentIDParams) ([]coredb.Admire, []error) {
 	return func(ctx context.Context, d *GetAdmireByActorIDAndCommentID, params []coredb.GetAdmireByActorIDAndCommentIDParams) ([]coredb.Admire, []error) {
 		results := make([]coredb.Admire, len(params))
@@ -1246,6 +1299,25 @@ func loadGetMediaByMediaIdIgnoringStatusBatch(q *coredb.Queries) func(context.Co
 	}
 }
 
+func loadGetMediaPreferencesByUserIDBatch(q *coredb.Queries) func(context.Context, *GetMediaPreferencesByUserIDBatch, []persist.DBID) ([]coredb.UserMediaPreference, []error) {
+	return func(ctx context.Context, d *GetMediaPreferencesByUserIDBatch, params []persist.DBID) ([]coredb.UserMediaPreference, []error) {
+		results := make([]coredb.UserMediaPreference, len(params))
+		errors := make([]error, len(params))
+
+		b := q.GetMediaPreferencesByUserIDBatch(ctx, params)
+		defer b.Close()
+
+		b.QueryRow(func(i int, r coredb.UserMediaPreference, err error) {
+			results[i], errors[i] = r, err
+			if errors[i] == pgx.ErrNoRows {
+				errors[i] = d.getNotFoundError(params[i])
+			}
+		})
+
+		return results, errors
+	}
+}
+
 func loadGetMembershipByMembershipIdBatch(q *coredb.Queries) func(context.Context, *GetMembershipByMembershipIdBatch, []persist.DBID) ([]coredb.Membership, []error) {
 	return func(ctx context.Context, d *GetMembershipByMembershipIdBatch, params []persist.DBID) ([]coredb.Membership, []error) {
 		results := make([]coredb.Membership, len(params))
@@ -1348,6 +1420,44 @@ func loadGetOwnersByContractIdBatchPaginate(q *coredb.Queries) func(context.Cont
 	}
 }
 
+func loadGetPinnedCommentByPostIDBatch(q *coredb.Queries) func(context.Context, *GetPinnedCommentByPostIDBatch, []persist.DBID) ([]coredb.Comment, []error) {
+	return func(ctx context.Context, d *GetPinnedCommentByPostIDBatch, params []persist.DBID) ([]coredb.Comment, []error) {
+		results := make([]coredb.Comment, len(params))
+		errors := make([]error, len(params))
+
+		b := q.GetPinnedCommentByPostIDBatch(ctx, params)
+		defer b.Close()
+
+		b.QueryRow(func(i int, r coredb.Comment, err error) {
+			results[i], errors[i] = r, err
+			if errors[i] == pgx.ErrNoRows {
+				errors[i] = d.getNotFoundError(params[i])
+			}
+		})
+
+		return results, errors
+	}
+}
+
+func loadGetPostAttachmentByPostIDBatch(q *coredb.Queries) func(context.Context, *GetPostAttachmentByPostIDBatch, []persist.DBID) ([]coredb.PostAttachment, []error) {
+	return func(ctx context.Context, d *GetPostAttachmentByPostIDBatch, params []persist.DBID) ([]coredb.PostAttachment, []error) {
+		results := make([]coredb.PostAttachment, len(params))
+		errors := make([]error, len(params))
+
+		b := q.GetPostAttachmentByPostIDBatch(ctx, params)
+		defer b.Close()
+
+		b.QueryRow(func(i int, r coredb.PostAttachment, err error) {
+			results[i], errors[i] = r, err
+			if errors[i] == pgx.ErrNoRows {
+				errors[i] = d.getNotFoundError(params[i])
+			}
+		})
+
+		return results, errors
+	}
+}
+
 func loadGetPostByIdBatch(q *coredb.Queries) func(context.Context, *GetPostByIdBatch, []persist.DBID) ([]coredb.Post, []error) {
 	return func(ctx context.Context, d *GetPostByIdBatch, params []persist.DBID) ([]coredb.Post, []error) {
 		results := make([]coredb.Post, len(params))
@@ -1367,6 +1477,22 @@ func loadGetPostByIdBatch(q *coredb.Queries) func(context.Context, *GetPostByIdB
 	}
 }
 
+func loadGetPostRevisionsByPostID(q *coredb.Queries) func(context.Context, *GetPostRevisionsByPostID, []persist.DBID) ([][]coredb.PostRevision, []error) {
+	return func(ctx context.Context, d *GetPostRevisionsByPostID, params []persist.DBID) ([][]coredb.PostRevision, []error) {
+		results := make([][]coredb.PostRevision, len(params))
+		errors := make([]error, len(params))
+
+		b := q.GetPostRevisionsByPostID(ctx, params)
+		defer b.Close()
+
+		b.Query(func(i int, r []coredb.PostRevision, err error) {
+			results[i], errors[i] = r, err
+		})
+
+		return results, errors
+	}
+}
+
 func loadGetPostsByIdsPaginateBatch(q *coredb.Queries) func(context.Context, *GetPostsByIdsPaginateBatch, []coredb.GetPostsByIdsPaginateBatchParams) ([][]coredb.Post, []error) {
 	return func(ctx context.Context, d *GetPostsByIdsPaginateBatch, params []coredb.GetPostsByIdsPaginateBatchParams) ([][]coredb.Post, []error) {
 		results := make([][]coredb.Post, len(params))
@@ -1402,6 +1528,25 @@ func loadGetProfileImageByIdBatch(q *coredb.Queries) func(context.Context, *GetP
 	}
 }
 
+func loadGetRepostByIdBatch(q *coredb.Queries) func(context.Context, *GetRepostByIdBatch, []persist.DBID) ([]coredb.Repost, []error) {
+	return func(ctx context.Context, d *GetRepostByIdBatch, params []persist.DBID) ([]coredb.Repost, []error) {
+		results := make([]coredb.Repost, len(params))
+		errors := make([]error, len(params))
+
+		b := q.GetRepostByIdBatch(ctx, params)
+		defer b.Close()
+
+		b.QueryRow(func(i int, r coredb.Repost, err error) {
+			results[i], errors[i] = r, err
+			if errors[i] == pgx.ErrNoRows {
+				errors[i] = d.getNotFoundError(params[i])
+			}
+		})
+
+		return results, errors
+	}
+}
+
 func loadGetSharedCommunitiesBatchPaginate(q *coredb.Queries) func(context.Context, *GetSharedCommunitiesBatchPaginate, []coredb.GetSharedCommunitiesBatchPaginateParams) ([][]coredb.GetSharedCommunitiesBatchPaginateRow, []error) {
 	return func(ctx context.Context, d *GetSharedCommunitiesBatchPaginate, params []coredb.GetSharedCommunitiesBatchPaginateParams) ([][]coredb.GetSharedCommunitiesBatchPaginateRow, []error) {
 		results := make([][]coredb.GetSharedCommunitiesBatchPaginateRow, len(params))
@@ -1548,6 +1693,41 @@ func loadGetTokenDefinitionByTokenDbidBatch(q *coredb.Queries) func(context.Cont
 	}
 }
 
+func loadGetTokenDefinitionRarityBatch(q *coredb.Queries) func(context.Context, *GetTokenDefinitionRarityBatch, []coredb.GetTokenDefinitionRarityBatchParams) ([]coredb.GetTokenDefinitionRarityBatchRow, []error) {
+	return func(ctx context.Context, d *GetTokenDefinitionRarityBatch, params []coredb.GetTokenDefinitionRarityBatchParams) ([]coredb.GetTokenDefinitionRarityBatchRow, []error) {
+		results := make([]coredb.GetTokenDefinitionRarityBatchRow, len(params))
+		errors := make([]error, len(params))
+
+		b := q.GetTokenDefinitionRarityBatch(ctx, params)
+		defer b.Close()
+
+		b.QueryRow(func(i int, r coredb.GetTokenDefinitionRarityBatchRow, err error) {
+			results[i], errors[i] = r, err
+			if errors[i] == pgx.ErrNoRows {
+				errors[i] = d.getNotFoundError(params[i])
+			}
+		})
+
+		return results, errors
+	}
+}
+
+func loadGetTokenDefinitionTraitsBatch(q *coredb.Queries) func(context.Context, *GetTokenDefinitionTraitsBatch, []coredb.GetTokenDefinitionTraitsBatchParams) ([][]coredb.GetTokenDefinitionTraitsBatchRow, []error) {
+	return func(ctx context.Context, d *GetTokenDefinitionTraitsBatch, params []coredb.GetTokenDefinitionTraitsBatchParams) ([][]coredb.GetTokenDefinitionTraitsBatchRow, []error) {
+		results := make([][]coredb.GetTokenDefinitionTraitsBatchRow, len(params))
+		errors := make([]error, len(params))
+
+		b := q.GetTokenDefinitionTraitsBatch(ctx, params)
+		defer b.Close()
+
+		b.Query(func(i int, r []coredb.GetTokenDefinitionTraitsBatchRow, err error) {
+			results[i], errors[i] = r, err
+		})
+
+		return results, errors
+	}
+}
+
 func loadGetTokensByCollectionIdBatch(q *coredb.Queries) func(context.Context, *GetTokensByCollectionIdBatch, []coredb.GetTokensByCollectionIdBatchParams) ([][]coredb.Token, []error) {
 	return func(ctx context.Context, d *GetTokensByCollectionIdBatch, params []coredb.GetTokensByCollectionIdBatchParams) ([][]coredb.Token, []error) {
 		results := make([][]coredb.Token, len(params))
@@ -1848,15 +2028,15 @@ func loadPaginateCommentsByFeedEventIDBatch(q *coredb.Queries) func(context.Cont
 	}
 }
 
-func loadPaginateCommentsByPostIDBatch(q *coredb.Queries) func(context.Context, *PaginateCommentsByPostIDBatch, []coredb.PaginateCommentsByPostIDBatchParams) ([][]coredb.Comment, []error) {
-	return func(ctx context.Context, d *PaginateCommentsByPostIDBatch, params []coredb.PaginateCommentsByPostIDBatchParams) ([][]coredb.Comment, []error) {
-		results := make([][]coredb.Comment, len(params))
+func loadPaginateCommentsByPostIDBatch(q *coredb.Queries) func(context.Context, *PaginateCommentsByPostIDBatch, []coredb.PaginateCommentsByPostIDBatchParams) ([][]coredb.PaginateCommentsByPostIDBatchRow, []error) {
+	return func(ctx context.Context, d *PaginateCommentsByPostIDBatch, params []coredb.PaginateCommentsByPostIDBatchParams) ([][]coredb.PaginateCommentsByPostIDBatchRow, []error) {
+		results := make([][]coredb.PaginateCommentsByPostIDBatchRow, len(params))
 		errors := make([]error, len(params))
 
 		b := q.PaginateCommentsByPostIDBatch(ctx, params)
 		defer b.Close()
 
-		b.Query(func(i int, r []coredb.Comment, err error) {
+		b.Query(func(i int, r []coredb.PaginateCommentsByPostIDBatchRow, err error) {
 			results[i], errors[i] = r, err
 		})
 
@@ -1944,15 +2124,15 @@ func loadPaginatePostsByContractID(q *coredb.Queries) func(context.Context, *Pag
 	}
 }
 
-func loadPaginateRepliesByCommentIDBatch(q *coredb.Queries) func(context.Context, *PaginateRepliesByCommentIDBatch, []coredb.PaginateRepliesByCommentIDBatchParams) ([][]coredb.Comment, []error) {
-	return func(ctx context.Context, d *PaginateRepliesByCommentIDBatch, params []coredb.PaginateRepliesByCommentIDBatchParams) ([][]coredb.Comment, []error) {
-		results := make([][]coredb.Comment, len(params))
+func loadPaginateRepliesByCommentIDBatch(q *coredb.Queries) func(context.Context, *PaginateRepliesByCommentIDBatch, []coredb.PaginateRepliesByCommentIDBatchParams) ([][]coredb.PaginateRepliesByCommentIDBatchRow, []error) {
+	return func(ctx context.Context, d *PaginateRepliesByCommentIDBatch, params []coredb.PaginateRepliesByCommentIDBatchParams) ([][]coredb.PaginateRepliesByCommentIDBatchRow, []error) {
+		results := make([][]coredb.PaginateRepliesByCommentIDBatchRow, len(params))
 		errors := make([]error, len(params))
 
 		b := q.PaginateRepliesByCommentIDBatch(ctx, params)
 		defer b.Close()
 
-		b.Query(func(i int, r []coredb.Comment, err error) {
+		b.Query(func(i int, r []coredb.PaginateRepliesByCommentIDBatchRow, err error) {
 			results[i], errors[i] = r, err
 		})
 
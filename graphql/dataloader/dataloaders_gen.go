@@ -361,6 +361,43 @@ func newCountInteractionsByPostIDBatch(
 	return d
 }
 
+// CountPollVotesByOptionBatch batches and caches requests
+type CountPollVotesByOptionBatch struct {
+	generator.Dataloader[persist.DBID, []coredb.CountPollVotesByOptionBatchRow]
+}
+
+// newCountPollVotesByOptionBatch creates a new CountPollVotesByOptionBatch with the given settings, functions, and options
+func newCountPollVotesByOptionBatch(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *CountPollVotesByOptionBatch, []persist.DBID) ([][]coredb.CountPollVotesByOptionBatchRow, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *CountPollVotesByOptionBatch {
+	d := &CountPollVotesByOptionBatch{}
+
+	fetchWithHooks := func(ctx context.Context, keys []persist.DBID) ([][]coredb.CountPollVotesByOptionBatchRow, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "CountPollVotesByOptionBatch")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "CountPollVotesByOptionBatch")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
 // CountRepliesByCommentIDBatch batches and caches requests
 type CountRepliesByCommentIDBatch struct {
 	generator.Dataloader[persist.DBID, int64]
@@ -398,6 +435,43 @@ func newCountRepliesByCommentIDBatch(
 	return d
 }
 
+// CountRepostsByPostIDBatch batches and caches requests
+type CountRepostsByPostIDBatch struct {
+	generator.Dataloader[persist.DBID, int64]
+}
+
+// newCountRepostsByPostIDBatch creates a new CountRepostsByPostIDBatch with the given settings, functions, and options
+func newCountRepostsByPostIDBatch(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *CountRepostsByPostIDBatch, []persist.DBID) ([]int64, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *CountRepostsByPostIDBatch {
+	d := &CountRepostsByPostIDBatch{}
+
+	fetchWithHooks := func(ctx context.Context, keys []persist.DBID) ([]int64, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "CountRepostsByPostIDBatch")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "CountRepostsByPostIDBatch")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
 // GetAdmireByActorIDAndCommentID batches and caches requests
 type GetAdmireByActorIDAndCommentID struct {
 	generator.Dataloader[coredb.GetAdmireByActorIDAndCommentIDParams, coredb.Admire]
@@ -1429,6 +1503,43 @@ func (*GetMediaByMediaIdIgnoringStatusBatch) getKeyForResult(result coredb.Token
 	return result.ID
 }
 
+// GetMediaPreferencesByUserIDBatch batches and caches requests
+type GetMediaPreferencesByUserIDBatch struct {
+	generator.Dataloader[persist.DBID, coredb.UserMediaPreference]
+}
+
+// newGetMediaPreferencesByUserIDBatch creates a new GetMediaPreferencesByUserIDBatch with the given settings, functions, and options
+func newGetMediaPreferencesByUserIDBatch(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *GetMediaPreferencesByUserIDBatch, []persist.DBID) ([]coredb.UserMediaPreference, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *GetMediaPreferencesByUserIDBatch {
+	d := &GetMediaPreferencesByUserIDBatch{}
+
+	fetchWithHooks := func(ctx context.Context, keys []persist.DBID) ([]coredb.UserMediaPreference, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "GetMediaPreferencesByUserIDBatch")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "GetMediaPreferencesByUserIDBatch")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
 // GetMembershipByMembershipIdBatch batches and caches requests
 type GetMembershipByMembershipIdBatch struct {
 	generator.Dataloader[persist.DBID, coredb.Membership]
@@ -1659,6 +1770,80 @@ func newGetOwnersByContractIdBatchPaginate(
 	return d
 }
 
+// GetPinnedCommentByPostIDBatch batches and caches requests
+type GetPinnedCommentByPostIDBatch struct {
+	generator.Dataloader[persist.DBID, coredb.Comment]
+}
+
+// newGetPinnedCommentByPostIDBatch creates a new GetPinnedCommentByPostIDBatch with the given settings, functions, and options
+func newGetPinnedCommentByPostIDBatch(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *GetPinnedCommentByPostIDBatch, []persist.DBID) ([]coredb.Comment, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *GetPinnedCommentByPostIDBatch {
+	d := &GetPinnedCommentByPostIDBatch{}
+
+	fetchWithHooks := func(ctx context.Context, keys []persist.DBID) ([]coredb.Comment, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "GetPinnedCommentByPostIDBatch")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "GetPinnedCommentByPostIDBatch")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
+// GetPostAttachmentByPostIDBatch batches and caches requests
+type GetPostAttachmentByPostIDBatch struct {
+	generator.Dataloader[persist.DBID, coredb.PostAttachment]
+}
+
+// newGetPostAttachmentByPostIDBatch creates a new GetPostAttachmentByPostIDBatch with the given settings, functions, and options
+func newGetPostAttachmentByPostIDBatch(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *GetPostAttachmentByPostIDBatch, []persist.DBID) ([]coredb.PostAttachment, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *GetPostAttachmentByPostIDBatch {
+	d := &GetPostAttachmentByPostIDBatch{}
+
+	fetchWithHooks := func(ctx context.Context, keys []persist.DBID) ([]coredb.PostAttachment, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "GetPostAttachmentByPostIDBatch")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "GetPostAttachmentByPostIDBatch")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
 // GetPostByIdBatch batches and caches requests
 type GetPostByIdBatch struct {
 	generator.Dataloader[persist.DBID, coredb.Post]
@@ -1700,6 +1885,43 @@ func (*GetPostByIdBatch) getKeyForResult(result coredb.Post) persist.DBID {
 	return result.ID
 }
 
+// GetPostRevisionsByPostID batches and caches requests
+type GetPostRevisionsByPostID struct {
+	generator.Dataloader[persist.DBID, []coredb.PostRevision]
+}
+
+// newGetPostRevisionsByPostID creates a new GetPostRevisionsByPostID with the given settings, functions, and options
+func newGetPostRevisionsByPostID(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *GetPostRevisionsByPostID, []persist.DBID) ([][]coredb.PostRevision, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *GetPostRevisionsByPostID {
+	d := &GetPostRevisionsByPostID{}
+
+	fetchWithHooks := func(ctx context.Context, keys []persist.DBID) ([][]coredb.PostRevision, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "GetPostRevisionsByPostID")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "GetPostRevisionsByPostID")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
 // GetPostsByIdsPaginateBatch batches and caches requests
 type GetPostsByIdsPaginateBatch struct {
 	generator.Dataloader[coredb.GetPostsByIdsPaginateBatchParams, []coredb.Post]
@@ -1774,6 +1996,47 @@ func newGetProfileImageByIdBatch(
 	return d
 }
 
+// GetRepostByIdBatch batches and caches requests
+type GetRepostByIdBatch struct {
+	generator.Dataloader[persist.DBID, coredb.Repost]
+}
+
+// newGetRepostByIdBatch creates a new GetRepostByIdBatch with the given settings, functions, and options
+func newGetRepostByIdBatch(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *GetRepostByIdBatch, []persist.DBID) ([]coredb.Repost, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *GetRepostByIdBatch {
+	d := &GetRepostByIdBatch{}
+
+	fetchWithHooks := func(ctx context.Context, keys []persist.DBID) ([]coredb.Repost, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "GetRepostByIdBatch")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "GetRepostByIdBatch")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
+func (*GetRepostByIdBatch) getKeyForResult(result coredb.Repost) persist.DBID {
+	return result.ID
+}
+
 // GetSharedCommunitiesBatchPaginate batches and caches requests
 type GetSharedCommunitiesBatchPaginate struct {
 	generator.Dataloader[coredb.GetSharedCommunitiesBatchPaginateParams, []coredb.GetSharedCommunitiesBatchPaginateRow]
@@ -2078,6 +2341,80 @@ func (*GetTokenDefinitionByTokenDbidBatch) getKeyForResult(result coredb.TokenDe
 	return result.ID
 }
 
+// GetTokenDefinitionRarityBatch batches and caches requests
+type GetTokenDefinitionRarityBatch struct {
+	generator.Dataloader[coredb.GetTokenDefinitionRarityBatchParams, coredb.GetTokenDefinitionRarityBatchRow]
+}
+
+// newGetTokenDefinitionRarityBatch creates a new GetTokenDefinitionRarityBatch with the given settings, functions, and options
+func newGetTokenDefinitionRarityBatch(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *GetTokenDefinitionRarityBatch, []coredb.GetTokenDefinitionRarityBatchParams) ([]coredb.GetTokenDefinitionRarityBatchRow, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *GetTokenDefinitionRarityBatch {
+	d := &GetTokenDefinitionRarityBatch{}
+
+	fetchWithHooks := func(ctx context.Context, keys []coredb.GetTokenDefinitionRarityBatchParams) ([]coredb.GetTokenDefinitionRarityBatchRow, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "GetTokenDefinitionRarityBatch")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "GetTokenDefinitionRarityBatch")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
+// GetTokenDefinitionTraitsBatch batches and caches requests
+type GetTokenDefinitionTraitsBatch struct {
+	generator.Dataloader[coredb.GetTokenDefinitionTraitsBatchParams, []coredb.GetTokenDefinitionTraitsBatchRow]
+}
+
+// newGetTokenDefinitionTraitsBatch creates a new GetTokenDefinitionTraitsBatch with the given settings, functions, and options
+func newGetTokenDefinitionTraitsBatch(
+	ctx context.Context,
+	maxBatchSize int,
+	batchTimeout time.Duration,
+	cacheResults bool,
+	publishResults bool,
+	fetch func(context.Context, *GetTokenDefinitionTraitsBatch, []coredb.GetTokenDefinitionTraitsBatchParams) ([][]coredb.GetTokenDefinitionTraitsBatchRow, []error),
+	preFetchHook PreFetchHook,
+	postFetchHook PostFetchHook,
+) *GetTokenDefinitionTraitsBatch {
+	d := &GetTokenDefinitionTraitsBatch{}
+
+	fetchWithHooks := func(ctx context.Context, keys []coredb.GetTokenDefinitionTraitsBatchParams) ([][]coredb.GetTokenDefinitionTraitsBatchRow, []error) {
+		// Allow the preFetchHook to modify and return a new context
+		if preFetchHook != nil {
+			ctx = preFetchHook(ctx, "GetTokenDefinitionTraitsBatch")
+		}
+
+		results, errors := fetch(ctx, d, keys)
+
+		if postFetchHook != nil {
+			postFetchHook(ctx, "GetTokenDefinitionTraitsBatch")
+		}
+
+		return results, errors
+	}
+
+	d.Dataloader = *generator.NewDataloader(ctx, maxBatchSize, batchTimeout, cacheResults, publishResults, fetchWithHooks)
+	return d
+}
+
 // GetTokensByCollectionIdBatch batches and caches requests
 type GetTokensByCollectionIdBatch struct {
 	generator.Dataloader[coredb.GetTokensByCollectionIdBatchParams, []coredb.Token]
@@ -2754,7 +3091,7 @@ func newPaginateCommentsByFeedEventIDBatch(
 
 // PaginateCommentsByPostIDBatch batches and caches requests
 type PaginateCommentsByPostIDBatch struct {
-	generator.Dataloader[coredb.PaginateCommentsByPostIDBatchParams, []coredb.Comment]
+	generator.Dataloader[coredb.PaginateCommentsByPostIDBatchParams, []coredb.PaginateCommentsByPostIDBatchRow]
 }
 
 // newPaginateCommentsByPostIDBatch creates a new PaginateCommentsByPostIDBatch with the given settings, functions, and options
@@ -2764,13 +3101,13 @@ func newPaginateCommentsByPostIDBatch(
 	batchTimeout time.Duration,
 	cacheResults bool,
 	publishResults bool,
-	fetch func(context.Context, *PaginateCommentsByPostIDBatch, []coredb.PaginateCommentsByPostIDBatchParams) ([][]coredb.Comment, []error),
+	fetch func(context.Context, *PaginateCommentsByPostIDBatch, []coredb.PaginateCommentsByPostIDBatchParams) ([][]coredb.PaginateCommentsByPostIDBatchRow, []error),
 	preFetchHook PreFetchHook,
 	postFetchHook PostFetchHook,
 ) *PaginateCommentsByPostIDBatch {
 	d := &PaginateCommentsByPostIDBatch{}
 
-	fetchWithHooks := func(ctx context.Context, keys []coredb.PaginateCommentsByPostIDBatchParams) ([][]coredb.Comment, []error) {
+	fetchWithHooks := func(ctx context.Context, keys []coredb.PaginateCommentsByPostIDBatchParams) ([][]coredb.PaginateCommentsByPostIDBatchRow, []error) {
 		// Allow the preFetchHook to modify and return a new context
 		if preFetchHook != nil {
 			ctx = preFetchHook(ctx, "PaginateCommentsByPostIDBatch")
@@ -2976,7 +3313,7 @@ func newPaginatePostsByContractID(
 
 // PaginateRepliesByCommentIDBatch batches and caches requests
 type PaginateRepliesByCommentIDBatch struct {
-	generator.Dataloader[coredb.PaginateRepliesByCommentIDBatchParams, []coredb.Comment]
+	generator.Dataloader[coredb.PaginateRepliesByCommentIDBatchParams, []coredb.PaginateRepliesByCommentIDBatchRow]
 }
 
 // newPaginateRepliesByCommentIDBatch creates a new PaginateRepliesByCommentIDBatch with the given settings, functions, and options
@@ -2986,13 +3323,13 @@ func newPaginateRepliesByCommentIDBatch(
 	batchTimeout time.Duration,
 	cacheResults bool,
 	publishResults bool,
-	fetch func(context.Context, *PaginateRepliesByCommentIDBatch, []coredb.PaginateRepliesByCommentIDBatchParams) ([][]coredb.Comment, []error),
+	fetch func(context.Context, *PaginateRepliesByCommentIDBatch, []coredb.PaginateRepliesByCommentIDBatchParams) ([][]coredb.PaginateRepliesByCommentIDBatchRow, []error),
 	preFetchHook PreFetchHook,
 	postFetchHook PostFetchHook,
 ) *PaginateRepliesByCommentIDBatch {
 	d := &PaginateRepliesByCommentIDBatch{}
 
-	fetchWithHooks := func(ctx context.Context, keys []coredb.PaginateRepliesByCommentIDBatchParams) ([][]coredb.Comment, []error) {
+	fetchWithHooks := func(ctx context.Context, keys []coredb.PaginateRepliesByCommentIDBatchParams) ([][]coredb.PaginateRepliesByCommentIDBatchRow, []error) {
 		// Allow the preFetchHook to modify and return a new context
 		if preFetchHook != nil {
 			ctx = preFetchHook(ctx, "PaginateRepliesByCommentIDBatch")
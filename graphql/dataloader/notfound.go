@@ -16,6 +16,10 @@ func (*CountAdmiresByPostIDBatch) getNotFoundError(key persist.DBID) error {
 	return pgx.ErrNoRows
 }
 
+func (*CountRepostsByPostIDBatch) getNotFoundError(key persist.DBID) error {
+	return pgx.ErrNoRows
+}
+
 func (*CountAdmiresByTokenIDBatch) getNotFoundError(key persist.DBID) error {
 	return pgx.ErrNoRows
 }
@@ -179,3 +183,23 @@ func (*CountGalleriesDisplayingCommunityIDBatch) getNotFoundError(key persist.DB
 func (*GetTokenDefinitionByTokenDbidBatch) getNotFoundError(key persist.DBID) error {
 	return fmt.Errorf("tokenDefinition not found by tokenDBID=%s", key)
 }
+
+func (*GetMediaPreferencesByUserIDBatch) getNotFoundError(key persist.DBID) error {
+	return pgx.ErrNoRows
+}
+
+func (*GetRepostByIdBatch) getNotFoundError(key persist.DBID) error {
+	return persist.ErrRepostNotFoundByID{ID: key}
+}
+
+func (*GetPostAttachmentByPostIDBatch) getNotFoundError(key persist.DBID) error {
+	return pgx.ErrNoRows
+}
+
+func (*GetPinnedCommentByPostIDBatch) getNotFoundError(key persist.DBID) error {
+	return pgx.ErrNoRows
+}
+
+func (*GetTokenDefinitionRarityBatch) getNotFoundError(key coredb.GetTokenDefinitionRarityBatchParams) error {
+	return pgx.ErrNoRows
+}
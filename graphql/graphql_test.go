@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
 	"github.com/mikeydub/go-gallery/publicapi"
 	"github.com/mikeydub/go-gallery/server"
 	"github.com/mikeydub/go-gallery/service/auth"
@@ -31,6 +32,7 @@ import (
 	"github.com/mikeydub/go-gallery/service/multichain/common"
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/redis"
+	"github.com/mikeydub/go-gallery/service/spam"
 	"github.com/mikeydub/go-gallery/service/tokenmanage"
 	"github.com/mikeydub/go-gallery/tokenprocessing"
 	"github.com/mikeydub/go-gallery/util"
@@ -80,6 +82,12 @@ func testGraphQL(t *testing.T) {
 		{title: "should get trending feed events", run: testTrendingFeedEvents},
 		{title: "should delete a post", run: testDeletePost},
 		{title: "should get community with posts", run: testGetCommunity},
+		{title: "should hide muted posts from feeds", run: testMutedPostsAreHiddenFromFeeds},
+		{title: "should follow and unfollow a community", run: testFollowCommunity},
+		{title: "should get posts from followed communities", run: testCommunitiesFeed},
+		{title: "should show each reposted post once in the personal feed", run: testPersonalFeedReposts},
+		{title: "should sort post comments with the pinned comment first", run: testSortPostComments},
+		{title: "should vote on a poll", run: testVoteOnPoll},
 		{title: "should delete collection in gallery update", run: testUpdateGalleryDeleteCollection},
 		{title: "should update user experiences", run: testUpdateUserExperiences},
 		{title: "should create gallery", run: testCreateGallery},
@@ -104,6 +112,7 @@ func testTokenSyncs(t *testing.T) {
 		{title: "should keep old tokens", run: testSyncKeepsOldTokens},
 		{title: "should merge duplicates within provider", run: testSyncShouldMergeDuplicatesInProvider},
 		{title: "should process media", run: testSyncShouldProcessMedia},
+		{title: "should not score tokens without a block number as an airdrop", run: testSyncedTokensWithoutBlocksAreNotAirdropped},
 	}
 	for _, test := range tests {
 		t.Run(test.title, testWithFixtures(test.run, test.fixtures...))
@@ -727,6 +736,288 @@ func testGetCommunity(t *testing.T) {
 	})
 }
 
+func testMutedPostsAreHiddenFromFeeds(t *testing.T) {
+	ctx := context.Background()
+	author := newUserWithFeedEntitiesFixture(t)
+	viewer := newUserFixture(t)
+	c := authedHandlerClient(t, viewer.ID)
+	followUser(t, ctx, c, author.ID)
+	// The trending feed only includes posts with interactions
+	for _, postID := range author.PostIDs {
+		admirePost(t, ctx, c, postID)
+	}
+	address := ChainAddressInput{
+		Address: contractAddressByTokenID(t, ctx, c, author.TokenIDs[0]),
+		Chain:   ChainEthereum,
+	}
+
+	// postOne is marked as not interested, and postTwo has a muted word in its caption
+	markNotInterested(t, ctx, c, author.PostIDs[0])
+	muteWord(t, ctx, c, "PostTwo")
+	muted := author.PostIDs[:2]
+	kept := author.PostIDs[2]
+
+	t.Run("should hide muted posts from the personal feed", func(t *testing.T) {
+		actual := viewerFeedPosts(t, ctx, c, 10)
+		assert.Contains(t, actual, kept)
+		assertNoneContained(t, actual, muted)
+	})
+
+	t.Run("should hide muted posts from the trending feed", func(t *testing.T) {
+		assertNoneContained(t, trendingFeedEvents(t, ctx, c, 10, true), muted)
+	})
+
+	t.Run("should hide muted posts from the curated feed", func(t *testing.T) {
+		assertNoneContained(t, curatedFeedPosts(t, ctx, c, 10), muted)
+	})
+
+	t.Run("should hide muted posts from the community feed", func(t *testing.T) {
+		_, actual := communityPosts(t, ctx, c, address, 10)
+		assert.Contains(t, actual, kept)
+		assertNoneContained(t, actual, muted)
+	})
+
+	t.Run("should not hide posts that only contain a muted word in a longer word", func(t *testing.T) {
+		// Every caption starts with "post", e.g. "postThree"
+		muteWord(t, ctx, c, "post")
+		assert.Contains(t, viewerFeedPosts(t, ctx, c, 10), kept)
+		_, actual := communityPosts(t, ctx, c, address, 10)
+		assert.Contains(t, actual, kept)
+	})
+
+	t.Run("should hide posts about a muted community", func(t *testing.T) {
+		communityID, _ := communityPosts(t, ctx, c, address, 10)
+		muteCommunity(t, ctx, c, communityID)
+		_, actual := communityPosts(t, ctx, c, address, 10)
+		assert.Empty(t, actual)
+		assert.NotContains(t, viewerFeedPosts(t, ctx, c, 10), kept)
+	})
+
+	t.Run("should show muted posts to other users", func(t *testing.T) {
+		other := authedHandlerClient(t, newUserFixture(t).ID)
+		_, actual := communityPosts(t, ctx, other, address, 10)
+		for _, postID := range author.PostIDs {
+			assert.Contains(t, actual, postID)
+		}
+	})
+}
+
+func testFollowCommunity(t *testing.T) {
+	ctx := context.Background()
+	author := newUserWithFeedEntitiesFixture(t)
+	viewer := newUserFixture(t)
+	c := authedHandlerClient(t, viewer.ID)
+	address := ChainAddressInput{
+		Address: contractAddressByTokenID(t, ctx, c, author.TokenIDs[0]),
+		Chain:   ChainEthereum,
+	}
+	communityID, _ := communityPosts(t, ctx, c, address, 10)
+
+	t.Run("should follow a community", func(t *testing.T) {
+		assert.True(t, followCommunity(t, ctx, c, communityID))
+	})
+
+	t.Run("should do nothing when following a followed community", func(t *testing.T) {
+		assert.True(t, followCommunity(t, ctx, c, communityID))
+	})
+
+	t.Run("should unfollow a community", func(t *testing.T) {
+		assert.False(t, unfollowCommunity(t, ctx, c, communityID))
+	})
+
+	t.Run("should do nothing when unfollowing an unfollowed community", func(t *testing.T) {
+		assert.False(t, unfollowCommunity(t, ctx, c, communityID))
+	})
+}
+
+func testCommunitiesFeed(t *testing.T) {
+	ctx := context.Background()
+	viewer := newUserFixture(t)
+	c := authedHandlerClient(t, viewer.ID)
+	// Fixture tokens all belong to the same contract, so each author posts a token of their own contract instead
+	postInNewCommunity := func(t *testing.T) (persist.DBID, persist.DBID, persist.DBID) {
+		author := newUserFixture(t)
+		contract := common.ChainAgnosticContract{Address: persist.Address(strings.ToLower("0x" + persist.GenerateID().String()))}
+		provider := newStubProvider(withDummyTokenN(contract, author.Wallet.Address, 1))
+		h := handlerWithProviders(t, &noopSubmitter{}, multichain.ProviderLookup{persist.ChainETH: provider})
+		authorC := customHandlerClient(t, h, withJWTOpt(t, author.ID))
+		tokenIDs := syncTokens(t, ctx, authorC, author.ID)
+		postID := createPost(t, ctx, authorC, PostTokensInput{TokenIds: tokenIDs})
+		communityID, _ := communityPosts(t, ctx, c, ChainAddressInput{Address: contract.Address.String(), Chain: ChainEthereum}, 10)
+		return author.ID, communityID, postID
+	}
+	_, followedCommunityID, followedPostID := postInNewCommunity(t)
+	otherAuthorID, _, otherPostID := postInNewCommunity(t)
+	followCommunity(t, ctx, c, followedCommunityID)
+	// Following an author shouldn't add their posts to the communities feed
+	followUser(t, ctx, c, otherAuthorID)
+
+	actual := viewerCommunitiesFeedPosts(t, ctx, c, 10)
+
+	assert.Contains(t, actual, followedPostID)
+	assert.NotContains(t, actual, otherPostID)
+}
+
+func testVoteOnPoll(t *testing.T) {
+	ctx := context.Background()
+	author := newUserWithTokensFixture(t)
+	// The poll closes partway through the test so that its results can be checked
+	closesAt := time.Now().Add(5 * time.Second)
+	postID := createPost(t, ctx, authedHandlerClient(t, author.ID), PostTokensInput{
+		TokenIds: author.TokenIDs[:1],
+		Attachment: &PostAttachmentInput{
+			Poll: &PollInput{Options: []string{"yes", "no"}, ClosesAt: closesAt.Format(time.RFC3339)},
+		},
+	})
+	voter := newUserFixture(t)
+	c := authedHandlerClient(t, voter.ID)
+	pollID := postPoll(t, ctx, c, postID).Dbid
+
+	t.Run("should count a vote", func(t *testing.T) {
+		resp, err := voteOnPollMutation(ctx, c, pollID, 0)
+		require.NoError(t, err)
+		_ = (*resp.VoteOnPoll).(*voteOnPollMutationVoteOnPollVoteOnPollPayload)
+		poll := postPoll(t, ctx, c, postID)
+		assert.Equal(t, util.ToPointer(0), poll.ViewerVote)
+		assert.Equal(t, util.ToPointer(1), poll.TotalVotes)
+	})
+
+	t.Run("should only let a user vote once", func(t *testing.T) {
+		resp, err := voteOnPollMutation(ctx, c, pollID, 1)
+		require.NoError(t, err)
+		_ = (*resp.VoteOnPoll).(*voteOnPollMutationVoteOnPollErrInvalidInput)
+		poll := postPoll(t, ctx, c, postID)
+		assert.Equal(t, util.ToPointer(0), poll.ViewerVote)
+		assert.Equal(t, util.ToPointer(1), poll.TotalVotes)
+	})
+
+	t.Run("should reject an option that isn't in the poll", func(t *testing.T) {
+		other := authedHandlerClient(t, newUserFixture(t).ID)
+		resp, err := voteOnPollMutation(ctx, other, pollID, 2)
+		require.NoError(t, err)
+		_ = (*resp.VoteOnPoll).(*voteOnPollMutationVoteOnPollErrInvalidInput)
+	})
+
+	t.Run("should hide results until the poll closes", func(t *testing.T) {
+		poll := postPoll(t, ctx, c, postID)
+		assert.False(t, poll.IsClosed)
+		assert.Nil(t, poll.Results)
+	})
+
+	time.Sleep(time.Until(closesAt))
+
+	t.Run("should show results once the poll closes", func(t *testing.T) {
+		poll := postPoll(t, ctx, c, postID)
+		assert.True(t, poll.IsClosed)
+		assert.Equal(t, []postPollQueryPostByIdPostAttachmentPostPollResultsPollOptionResult{
+			{Option: "yes", Votes: 1},
+			{Option: "no", Votes: 0},
+		}, poll.Results)
+	})
+
+	t.Run("should not allow votes on a closed poll", func(t *testing.T) {
+		other := authedHandlerClient(t, newUserFixture(t).ID)
+		resp, err := voteOnPollMutation(ctx, other, pollID, 0)
+		require.NoError(t, err)
+		_ = (*resp.VoteOnPoll).(*voteOnPollMutationVoteOnPollErrInvalidInput)
+	})
+}
+
+func testPersonalFeedReposts(t *testing.T) {
+	ctx := context.Background()
+	author := newUserWithFeedEntitiesFixture(t)
+	alice := newUserFixture(t)
+	bob := newUserFixture(t)
+	aliceC := authedHandlerClient(t, alice.ID)
+	bobC := authedHandlerClient(t, bob.ID)
+	viewer := newUserFixture(t)
+	c := authedHandlerClient(t, viewer.ID)
+	followUser(t, ctx, c, alice.ID)
+	followUser(t, ctx, c, bob.ID)
+
+	aliceRepostOne := repost(t, ctx, aliceC, author.PostIDs[0])
+	bobRepostOne := repost(t, ctx, bobC, author.PostIDs[0])
+	aliceRepostTwo := repost(t, ctx, aliceC, author.PostIDs[1])
+	aliceRepostThree := repost(t, ctx, aliceC, author.PostIDs[2])
+
+	t.Run("should show reposts by followed users", func(t *testing.T) {
+		actual := viewerFeedReposts(t, ctx, c, 10)
+		assert.Contains(t, actual, aliceRepostTwo)
+		assert.Contains(t, actual, aliceRepostThree)
+	})
+
+	t.Run("should skip a repost if someone the viewer follows reposted the post more recently", func(t *testing.T) {
+		actual := viewerFeedReposts(t, ctx, c, 10)
+		assert.Contains(t, actual, bobRepostOne)
+		assert.NotContains(t, actual, aliceRepostOne)
+	})
+
+	t.Run("should skip reposts of muted posts", func(t *testing.T) {
+		markNotInterested(t, ctx, c, author.PostIDs[2])
+		actual := viewerFeedReposts(t, ctx, c, 10)
+		assert.NotContains(t, actual, aliceRepostThree)
+		assert.Contains(t, actual, aliceRepostTwo)
+	})
+
+	t.Run("should skip reposts of posts by users the viewer follows", func(t *testing.T) {
+		followUser(t, ctx, c, author.ID)
+		assert.Empty(t, viewerFeedReposts(t, ctx, c, 10))
+		assert.Contains(t, viewerFeedPosts(t, ctx, c, 10), author.PostIDs[1])
+	})
+}
+
+func testSortPostComments(t *testing.T) {
+	ctx := context.Background()
+	author := newUserWithFeedEntitiesFixture(t)
+	c := authedHandlerClient(t, author.ID)
+	postID := author.PostIDs[0]
+	first := commentOnPost(t, ctx, c, postID, "first")
+	second := commentOnPost(t, ctx, c, postID, "second")
+	third := commentOnPost(t, ctx, c, postID, "third")
+	fourth := commentOnPost(t, ctx, c, postID, "fourth")
+	admirers := []genql.Client{
+		authedHandlerClient(t, newUserFixture(t).ID),
+		authedHandlerClient(t, newUserFixture(t).ID),
+		authedHandlerClient(t, newUserFixture(t).ID),
+	}
+	admireComment(t, ctx, admirers[0], third)
+	admireComment(t, ctx, admirers[1], third)
+	admireComment(t, ctx, admirers[0], first)
+	pinComment(t, ctx, c, second)
+
+	t.Run("should sort comments with the pinned comment first", func(t *testing.T) {
+		for _, tt := range []struct {
+			sortBy   *CommentSort
+			expected []persist.DBID
+		}{
+			{sortBy: nil, expected: []persist.DBID{second, first, third, fourth}},
+			{sortBy: util.ToPointer(CommentSortOldest), expected: []persist.DBID{second, first, third, fourth}},
+			{sortBy: util.ToPointer(CommentSortNewest), expected: []persist.DBID{second, fourth, third, first}},
+			{sortBy: util.ToPointer(CommentSortTop), expected: []persist.DBID{second, third, first, fourth}},
+		} {
+			actual, _ := postComments(t, ctx, c, postID, tt.sortBy, 10, nil)
+			assert.Equal(t, tt.expected, actual)
+		}
+	})
+
+	t.Run("should keep the order of top comments that are admired between pages", func(t *testing.T) {
+		top := util.ToPointer(CommentSortTop)
+		page, cursor := postComments(t, ctx, c, postID, top, 2, nil)
+		assert.Equal(t, []persist.DBID{second, third}, page)
+		for _, admirer := range admirers {
+			admireComment(t, ctx, admirer, fourth)
+		}
+		page, _ = postComments(t, ctx, c, postID, top, 2, &cursor)
+		assert.Equal(t, []persist.DBID{first, fourth}, page)
+	})
+
+	t.Run("should reject a cursor from a different sort", func(t *testing.T) {
+		_, cursor := postComments(t, ctx, c, postID, util.ToPointer(CommentSortTop), 2, nil)
+		_, err := postCommentsQuery(ctx, c, postID, util.ToPointer(CommentSortNewest), util.ToPointer(2), &cursor)
+		assert.Error(t, err)
+	})
+}
+
 func testAdmireToken(t *testing.T) {
 	ctx := context.Background()
 	userF := newUserFixture(t)
@@ -993,6 +1284,28 @@ func testSyncShouldMergeDuplicatesInProvider(t *testing.T) {
 	assertSyncedTokens(t, response, err, 1)
 }
 
+func testSyncedTokensWithoutBlocksAreNotAirdropped(t *testing.T) {
+	ctx := context.Background()
+	// Providers that don't know the block a token was received in leave its block number as 0
+	contract := common.ChainAgnosticContract{Address: persist.Address(strings.ToLower("0x" + persist.GenerateID().String()))}
+	for i := 0; i < 30; i++ {
+		userF := newUserFixture(t)
+		provider := newStubProvider(withDummyTokenN(contract, userF.Wallet.Address, 1))
+		h := handlerWithProviders(t, &noopSubmitter{}, multichain.ProviderLookup{persist.ChainETH: provider})
+		syncTokens(t, ctx, customHandlerClient(t, h, withJWTOpt(t, userF.ID)), userF.ID)
+	}
+	c := server.ClientInit(ctx)
+	t.Cleanup(c.Close)
+	dbContract, err := c.Queries.GetContractByChainAddress(ctx, db.GetContractByChainAddressParams{Address: contract.Address, Chain: persist.ChainETH})
+	require.NoError(t, err)
+
+	score, err := spam.NewScorer(c.Queries).ScoreContract(ctx, dbContract.ID)
+
+	require.NoError(t, err)
+	assert.Empty(t, score.Reasons)
+	assert.False(t, score.IsSpam(spam.DefaultThreshold))
+}
+
 // newDummyMetadataProviderFixture creates a new handler configured with a stubbed provider that reads from a fixed dummy metadata server endpoint
 func newDummyMetadataProviderFixture(t *testing.T, ctx context.Context, chain persist.Chain, ownerAddress persist.Address, dummyEndpointToHit string, opts ...providerOpt) http.Handler {
 	metadataServerF := newMetadataServerFixture(t)
@@ -1528,11 +1841,32 @@ func admireComment(t *testing.T, ctx context.Context, c genql.Client, commentID
 }
 
 // commentOnPost makes a GraphQL request to comment on a post
-func commentOnPost(t *testing.T, ctx context.Context, c genql.Client, postID persist.DBID, comment string) {
+func commentOnPost(t *testing.T, ctx context.Context, c genql.Client, postID persist.DBID, comment string) persist.DBID {
 	t.Helper()
 	resp, err := commentOnPostMutation(ctx, c, postID, comment)
 	require.NoError(t, err)
-	_ = (*resp.CommentOnPost).(*commentOnPostMutationCommentOnPostCommentOnPostPayload)
+	payload := (*resp.CommentOnPost).(*commentOnPostMutationCommentOnPostCommentOnPostPayload)
+	return payload.Comment.Dbid
+}
+
+// pinComment makes a GraphQL request to pin a comment to its post
+func pinComment(t *testing.T, ctx context.Context, c genql.Client, commentID persist.DBID) {
+	t.Helper()
+	resp, err := pinCommentMutation(ctx, c, commentID)
+	require.NoError(t, err)
+	_ = (*resp.PinComment).(*pinCommentMutationPinCommentPinCommentPayload)
+}
+
+// postComments makes a GraphQL request to return a page of a post's comments and the cursor of the page's last comment
+func postComments(t *testing.T, ctx context.Context, c genql.Client, postID persist.DBID, sortBy *CommentSort, first int, after *string) ([]persist.DBID, string) {
+	t.Helper()
+	resp, err := postCommentsQuery(ctx, c, postID, sortBy, &first, after)
+	require.NoError(t, err)
+	post := (*resp.PostById).(*postCommentsQueryPostByIdPost)
+	comments := util.MapWithoutError(post.Comments.Edges, func(e *postCommentsQueryPostByIdPostCommentsPostCommentsConnectionEdgesPostCommentEdge) persist.DBID {
+		return e.Node.Dbid
+	})
+	return comments, post.Comments.PageInfo.EndCursor
 }
 
 func deletePost(t *testing.T, ctx context.Context, c genql.Client, postID persist.DBID) {
@@ -1563,6 +1897,157 @@ func contractAddressByTokenID(t *testing.T, ctx context.Context, c genql.Client,
 	return *co
 }
 
+// followUser makes a GraphQL request to follow a user
+func followUser(t *testing.T, ctx context.Context, c genql.Client, userID persist.DBID) {
+	t.Helper()
+	resp, err := followUserMutation(ctx, c, userID)
+	require.NoError(t, err)
+	_ = (*resp.FollowUser).(*followUserMutationFollowUserFollowUserPayload)
+}
+
+// muteWord makes a GraphQL request to mute a word
+func muteWord(t *testing.T, ctx context.Context, c genql.Client, word string) {
+	t.Helper()
+	resp, err := muteWordMutation(ctx, c, word)
+	require.NoError(t, err)
+	_ = (*resp.MuteWord).(*muteWordMutationMuteWordMuteWordPayload)
+}
+
+// muteCommunity makes a GraphQL request to mute a community
+func muteCommunity(t *testing.T, ctx context.Context, c genql.Client, communityID persist.DBID) {
+	t.Helper()
+	resp, err := muteCommunityMutation(ctx, c, communityID)
+	require.NoError(t, err)
+	_ = (*resp.MuteCommunity).(*muteCommunityMutationMuteCommunityMuteCommunityPayload)
+}
+
+// followCommunity makes a GraphQL request to follow a community and returns whether the viewer is following it
+func followCommunity(t *testing.T, ctx context.Context, c genql.Client, communityID persist.DBID) bool {
+	t.Helper()
+	resp, err := followCommunityMutation(ctx, c, communityID)
+	require.NoError(t, err)
+	payload := (*resp.FollowCommunity).(*followCommunityMutationFollowCommunityFollowCommunityPayload)
+	return util.FromPointer(payload.Community.ViewerIsFollowing)
+}
+
+// unfollowCommunity makes a GraphQL request to unfollow a community and returns whether the viewer is following it
+func unfollowCommunity(t *testing.T, ctx context.Context, c genql.Client, communityID persist.DBID) bool {
+	t.Helper()
+	resp, err := unfollowCommunityMutation(ctx, c, communityID)
+	require.NoError(t, err)
+	payload := (*resp.UnfollowCommunity).(*unfollowCommunityMutationUnfollowCommunityUnfollowCommunityPayload)
+	return util.FromPointer(payload.Community.ViewerIsFollowing)
+}
+
+// postPoll makes a GraphQL request to return the poll attached to a post
+func postPoll(t *testing.T, ctx context.Context, c genql.Client, postID persist.DBID) *postPollQueryPostByIdPostAttachmentPostPoll {
+	t.Helper()
+	resp, err := postPollQuery(ctx, c, postID)
+	require.NoError(t, err)
+	post := (*resp.PostById).(*postPollQueryPostByIdPost)
+	require.NotNil(t, post.Attachment)
+	return (*post.Attachment).(*postPollQueryPostByIdPostAttachmentPostPoll)
+}
+
+// markNotInterested makes a GraphQL request to mark a post as not interested
+func markNotInterested(t *testing.T, ctx context.Context, c genql.Client, postID persist.DBID) {
+	t.Helper()
+	resp, err := markNotInterestedMutation(ctx, c, postID)
+	require.NoError(t, err)
+	_ = (*resp.MarkNotInterested).(*markNotInterestedMutationMarkNotInterestedMarkNotInterestedPayload)
+}
+
+// repost makes a GraphQL request to repost a post
+func repost(t *testing.T, ctx context.Context, c genql.Client, postID persist.DBID) persist.DBID {
+	t.Helper()
+	resp, err := repostMutation(ctx, c, postID)
+	require.NoError(t, err)
+	payload := (*resp.Repost).(*repostMutationRepostRepostPayload)
+	return payload.Repost.Dbid
+}
+
+// viewerFeedPosts makes a GraphQL request to return the posts in the viewer's feed
+func viewerFeedPosts(t *testing.T, ctx context.Context, c genql.Client, limit int) []persist.DBID {
+	t.Helper()
+	resp, err := viewerFeedQuery(ctx, c, &limit)
+	require.NoError(t, err)
+	viewer := (*resp.Viewer).(*viewerFeedQueryViewer)
+	posts := make([]persist.DBID, 0, len(viewer.Feed.Edges))
+	for _, edge := range viewer.Feed.Edges {
+		if post, ok := (*edge.Node).(*viewerFeedQueryViewerFeedFeedConnectionEdgesFeedEdgeNodePost); ok {
+			posts = append(posts, post.Dbid)
+		}
+	}
+	return posts
+}
+
+// viewerCommunitiesFeedPosts makes a GraphQL request to return the posts in the viewer's communities feed
+func viewerCommunitiesFeedPosts(t *testing.T, ctx context.Context, c genql.Client, limit int) []persist.DBID {
+	t.Helper()
+	resp, err := viewerCommunitiesFeedQuery(ctx, c, &limit)
+	require.NoError(t, err)
+	viewer := (*resp.Viewer).(*viewerCommunitiesFeedQueryViewer)
+	posts := make([]persist.DBID, 0, len(viewer.CommunitiesFeed.Edges))
+	for _, edge := range viewer.CommunitiesFeed.Edges {
+		if post, ok := (*edge.Node).(*viewerCommunitiesFeedQueryViewerCommunitiesFeedFeedConnectionEdgesFeedEdgeNodePost); ok {
+			posts = append(posts, post.Dbid)
+		}
+	}
+	return posts
+}
+
+// viewerFeedReposts makes a GraphQL request to return the reposts in the viewer's feed
+func viewerFeedReposts(t *testing.T, ctx context.Context, c genql.Client, limit int) []persist.DBID {
+	t.Helper()
+	resp, err := viewerFeedQuery(ctx, c, &limit)
+	require.NoError(t, err)
+	viewer := (*resp.Viewer).(*viewerFeedQueryViewer)
+	reposts := make([]persist.DBID, 0, len(viewer.Feed.Edges))
+	for _, edge := range viewer.Feed.Edges {
+		if r, ok := (*edge.Node).(*viewerFeedQueryViewerFeedFeedConnectionEdgesFeedEdgeNodeRepost); ok {
+			reposts = append(reposts, r.Dbid)
+		}
+	}
+	return reposts
+}
+
+// curatedFeedPosts makes a GraphQL request to return the posts in the curated feed
+func curatedFeedPosts(t *testing.T, ctx context.Context, c genql.Client, limit int) []persist.DBID {
+	t.Helper()
+	resp, err := curatedFeedQuery(ctx, c, &limit)
+	require.NoError(t, err)
+	posts := make([]persist.DBID, 0, len(resp.CuratedFeed.Edges))
+	for _, edge := range resp.CuratedFeed.Edges {
+		if post, ok := (*edge.Node).(*curatedFeedQueryCuratedFeedFeedConnectionEdgesFeedEdgeNodePost); ok {
+			posts = append(posts, post.Dbid)
+		}
+	}
+	return posts
+}
+
+// communityPosts makes a GraphQL request to return a community and its posts
+func communityPosts(t *testing.T, ctx context.Context, c genql.Client, address ChainAddressInput, limit int) (persist.DBID, []persist.DBID) {
+	t.Helper()
+	resp, err := communityPostsQuery(ctx, c, address, &limit)
+	require.NoError(t, err)
+	community := (*resp.CommunityByAddress).(*communityPostsQueryCommunityByAddressCommunity)
+	posts := make([]persist.DBID, 0, len(community.Posts.Edges))
+	for _, edge := range community.Posts.Edges {
+		if post, ok := (*edge.Node).(*communityPostsQueryCommunityByAddressCommunityPostsPostsConnectionEdgesPostEdgeNodePost); ok {
+			posts = append(posts, post.Dbid)
+		}
+	}
+	return community.Dbid, posts
+}
+
+// assertNoneContained asserts that none of the IDs are in the list
+func assertNoneContained(t *testing.T, actual []persist.DBID, ids []persist.DBID) {
+	t.Helper()
+	for _, id := range ids {
+		assert.NotContains(t, actual, id)
+	}
+}
+
 // defaultLayout returns a collection layout of one section with one column
 func defaultLayout() CollectionLayoutInput {
 	return CollectionLayoutInput{
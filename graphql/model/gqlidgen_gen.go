@@ -52,6 +52,10 @@ func (r *FeedEvent) ID() GqlID {
 	return GqlID(fmt.Sprintf("FeedEvent:%s", r.Dbid))
 }
 
+func (r *FollowedCommunityCreatorPostedNotification) ID() GqlID {
+	return GqlID(fmt.Sprintf("FollowedCommunityCreatorPostedNotification:%s", r.Dbid))
+}
+
 func (r *Gallery) ID() GqlID {
 	return GqlID(fmt.Sprintf("Gallery:%s", r.Dbid))
 }
@@ -80,6 +84,10 @@ func (r *Post) ID() GqlID {
 	return GqlID(fmt.Sprintf("Post:%s", r.Dbid))
 }
 
+func (r *Repost) ID() GqlID {
+	return GqlID(fmt.Sprintf("Repost:%s", r.Dbid))
+}
+
 func (r *SocialConnection) ID() GqlID {
 	return GqlID(fmt.Sprintf("SocialConnection:%s:%s", r.SocialID, r.SocialType))
 }
@@ -132,6 +140,10 @@ func (r *SomeoneRepliedToYourCommentNotification) ID() GqlID {
 	return GqlID(fmt.Sprintf("SomeoneRepliedToYourCommentNotification:%s", r.Dbid))
 }
 
+func (r *SomeoneRepostedYourPostNotification) ID() GqlID {
+	return GqlID(fmt.Sprintf("SomeoneRepostedYourPostNotification:%s", r.Dbid))
+}
+
 func (r *SomeoneViewedYourGalleryNotification) ID() GqlID {
 	return GqlID(fmt.Sprintf("SomeoneViewedYourGalleryNotification:%s", r.Dbid))
 }
@@ -182,6 +194,7 @@ type NodeFetcher struct {
 	OnContract                                         func(ctx context.Context, dbid persist.DBID) (*Contract, error)
 	OnDeletedNode                                      func(ctx context.Context, dbid persist.DBID) (*DeletedNode, error)
 	OnFeedEvent                                        func(ctx context.Context, dbid persist.DBID) (*FeedEvent, error)
+	OnFollowedCommunityCreatorPostedNotification       func(ctx context.Context, dbid persist.DBID) (*FollowedCommunityCreatorPostedNotification, error)
 	OnGallery                                          func(ctx context.Context, dbid persist.DBID) (*Gallery, error)
 	OnGalleryAnnouncementNotification                  func(ctx context.Context, dbid persist.DBID) (*GalleryAnnouncementNotification, error)
 	OnGalleryUser                                      func(ctx context.Context, dbid persist.DBID) (*GalleryUser, error)
@@ -189,6 +202,7 @@ type NodeFetcher struct {
 	OnMerchToken                                       func(ctx context.Context, tokenId string) (*MerchToken, error)
 	OnNewTokensNotification                            func(ctx context.Context, dbid persist.DBID) (*NewTokensNotification, error)
 	OnPost                                             func(ctx context.Context, dbid persist.DBID) (*Post, error)
+	OnRepost                                           func(ctx context.Context, dbid persist.DBID) (*Repost, error)
 	OnSocialConnection                                 func(ctx context.Context, socialId string, socialType persist.SocialProvider) (*SocialConnection, error)
 	OnSomeoneAdmiredYourCommentNotification            func(ctx context.Context, dbid persist.DBID) (*SomeoneAdmiredYourCommentNotification, error)
 	OnSomeoneAdmiredYourFeedEventNotification          func(ctx context.Context, dbid persist.DBID) (*SomeoneAdmiredYourFeedEventNotification, error)
@@ -202,6 +216,7 @@ type NodeFetcher struct {
 	OnSomeoneMentionedYourCommunityNotification        func(ctx context.Context, dbid persist.DBID) (*SomeoneMentionedYourCommunityNotification, error)
 	OnSomeonePostedYourWorkNotification                func(ctx context.Context, dbid persist.DBID) (*SomeonePostedYourWorkNotification, error)
 	OnSomeoneRepliedToYourCommentNotification          func(ctx context.Context, dbid persist.DBID) (*SomeoneRepliedToYourCommentNotification, error)
+	OnSomeoneRepostedYourPostNotification              func(ctx context.Context, dbid persist.DBID) (*SomeoneRepostedYourPostNotification, error)
 	OnSomeoneViewedYourGalleryNotification             func(ctx context.Context, dbid persist.DBID) (*SomeoneViewedYourGalleryNotification, error)
 	OnSomeoneYouFollowOnFarcasterJoinedNotification    func(ctx context.Context, dbid persist.DBID) (*SomeoneYouFollowOnFarcasterJoinedNotification, error)
 	OnSomeoneYouFollowPostedTheirFirstPostNotification func(ctx context.Context, dbid persist.DBID) (*SomeoneYouFollowPostedTheirFirstPostNotification, error)
@@ -262,6 +277,11 @@ func (n *NodeFetcher) GetNodeByGqlID(ctx context.Context, id GqlID) (Node, error
 			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'FeedEvent' type requires 1 ID component(s) (%d component(s) supplied)", len(ids))}
 		}
 		return n.OnFeedEvent(ctx, persist.DBID(ids[0]))
+	case "FollowedCommunityCreatorPostedNotification":
+		if len(ids) != 1 {
+			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'FollowedCommunityCreatorPostedNotification' type requires 1 ID component(s) (%d component(s) supplied)", len(ids))}
+		}
+		return n.OnFollowedCommunityCreatorPostedNotification(ctx, persist.DBID(ids[0]))
 	case "Gallery":
 		if len(ids) != 1 {
 			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'Gallery' type requires 1 ID component(s) (%d component(s) supplied)", len(ids))}
@@ -297,6 +317,11 @@ func (n *NodeFetcher) GetNodeByGqlID(ctx context.Context, id GqlID) (Node, error
 			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'Post' type requires 1 ID component(s) (%d component(s) supplied)", len(ids))}
 		}
 		return n.OnPost(ctx, persist.DBID(ids[0]))
+	case "Repost":
+		if len(ids) != 1 {
+			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'Repost' type requires 1 ID component(s) (%d component(s) supplied)", len(ids))}
+		}
+		return n.OnRepost(ctx, persist.DBID(ids[0]))
 	case "SocialConnection":
 		if len(ids) != 2 {
 			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'SocialConnection' type requires 2 ID component(s) (%d component(s) supplied)", len(ids))}
@@ -362,6 +387,11 @@ func (n *NodeFetcher) GetNodeByGqlID(ctx context.Context, id GqlID) (Node, error
 			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'SomeoneRepliedToYourCommentNotification' type requires 1 ID component(s) (%d component(s) supplied)", len(ids))}
 		}
 		return n.OnSomeoneRepliedToYourCommentNotification(ctx, persist.DBID(ids[0]))
+	case "SomeoneRepostedYourPostNotification":
+		if len(ids) != 1 {
+			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'SomeoneRepostedYourPostNotification' type requires 1 ID component(s) (%d component(s) supplied)", len(ids))}
+		}
+		return n.OnSomeoneRepostedYourPostNotification(ctx, persist.DBID(ids[0]))
 	case "SomeoneViewedYourGalleryNotification":
 		if len(ids) != 1 {
 			return nil, ErrInvalidIDFormat{message: fmt.Sprintf("'SomeoneViewedYourGalleryNotification' type requires 1 ID component(s) (%d component(s) supplied)", len(ids))}
@@ -425,6 +455,8 @@ func (n *NodeFetcher) ValidateHandlers() {
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnDeletedNode")
 	case n.OnFeedEvent == nil:
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnFeedEvent")
+	case n.OnFollowedCommunityCreatorPostedNotification == nil:
+		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnFollowedCommunityCreatorPostedNotification")
 	case n.OnGallery == nil:
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnGallery")
 	case n.OnGalleryAnnouncementNotification == nil:
@@ -439,6 +471,8 @@ func (n *NodeFetcher) ValidateHandlers() {
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnNewTokensNotification")
 	case n.OnPost == nil:
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnPost")
+	case n.OnRepost == nil:
+		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnRepost")
 	case n.OnSocialConnection == nil:
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnSocialConnection")
 	case n.OnSomeoneAdmiredYourCommentNotification == nil:
@@ -465,6 +499,8 @@ func (n *NodeFetcher) ValidateHandlers() {
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnSomeonePostedYourWorkNotification")
 	case n.OnSomeoneRepliedToYourCommentNotification == nil:
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnSomeoneRepliedToYourCommentNotification")
+	case n.OnSomeoneRepostedYourPostNotification == nil:
+		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnSomeoneRepostedYourPostNotification")
 	case n.OnSomeoneViewedYourGalleryNotification == nil:
 		panic("NodeFetcher handler validation failed: no handler set for NodeFetcher.OnSomeoneViewedYourGalleryNotification")
 	case n.OnSomeoneYouFollowOnFarcasterJoinedNotification == nil:
@@ -77,6 +77,15 @@ type HelperMentionData struct {
 	CommunityID *persist.DBID
 }
 
+type HelperTokenProcessingDeadLetterData struct {
+	TokenDefinitionID persist.DBID
+	ContractID        persist.DBID
+}
+
+type HelperContractSpamScoreData struct {
+	ContractID persist.DBID
+}
+
 type HelperAdmireData struct {
 	PostID      *persist.DBID
 	FeedEventID *persist.DBID
@@ -127,6 +136,12 @@ type HelperSomeoneAdmiredYourPostNotificationData struct {
 	NotificationData persist.NotificationData
 }
 
+type HelperSomeoneRepostedYourPostNotificationData struct {
+	OwnerID          persist.DBID
+	PostID           persist.DBID
+	NotificationData persist.NotificationData
+}
+
 type HelperSomeoneAdmiredYourCommentNotificationData struct {
 	CommentID        persist.DBID
 	NotificationData persist.NotificationData
@@ -164,6 +179,11 @@ type HelperSomeonePostedYourWorkNotificationData struct {
 	PostID      persist.DBID
 }
 
+type HelperFollowedCommunityCreatorPostedNotificationData struct {
+	CommunityID persist.DBID
+	PostID      persist.DBID
+}
+
 type HelperSomeoneYouFollowPostedTheirFirstPostNotificationData struct {
 	PostID persist.DBID
 }
@@ -213,8 +233,22 @@ type HelperEnsProfileImageData struct {
 }
 
 type HelperPostData struct {
+	TokenIDs     persist.DBIDList
+	AuthorID     persist.DBID
+	QuotedPostID persist.DBID
+}
+
+type HelperPostCommunityCardData struct {
+	CommunityID persist.DBID
+}
+
+type HelperPostDraftData struct {
 	TokenIDs persist.DBIDList
-	AuthorID persist.DBID
+}
+
+type HelperRepostData struct {
+	ReposterID persist.DBID
+	PostID     persist.DBID
 }
 
 type HelperPostComposerDraftDetailsPayloadData struct {
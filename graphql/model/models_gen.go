@@ -103,6 +103,10 @@ type CreateGalleryPayloadOrError interface {
 	IsCreateGalleryPayloadOrError()
 }
 
+type CreatePostDraftPayloadOrError interface {
+	IsCreatePostDraftPayloadOrError()
+}
+
 type CreateUserPayloadOrError interface {
 	IsCreateUserPayloadOrError()
 }
@@ -111,10 +115,22 @@ type DeleteCollectionPayloadOrError interface {
 	IsDeleteCollectionPayloadOrError()
 }
 
+type DeleteContractRefreshPolicyPayloadOrError interface {
+	IsDeleteContractRefreshPolicyPayloadOrError()
+}
+
+type DeleteCustomMetadataHandlerPayloadOrError interface {
+	IsDeleteCustomMetadataHandlerPayloadOrError()
+}
+
 type DeleteGalleryPayloadOrError interface {
 	IsDeleteGalleryPayloadOrError()
 }
 
+type DeletePostDraftPayloadOrError interface {
+	IsDeletePostDraftPayloadOrError()
+}
+
 type DeletePostPayloadOrError interface {
 	IsDeletePostPayloadOrError()
 }
@@ -123,6 +139,10 @@ type DisconnectSocialAccountPayloadOrError interface {
 	IsDisconnectSocialAccountPayloadOrError()
 }
 
+type EditPostPayloadOrError interface {
+	IsEditPostPayloadOrError()
+}
+
 type Error interface {
 	IsError()
 }
@@ -148,6 +168,10 @@ type FollowAllSocialConnectionsPayloadOrError interface {
 	IsFollowAllSocialConnectionsPayloadOrError()
 }
 
+type FollowCommunityPayloadOrError interface {
+	IsFollowCommunityPayloadOrError()
+}
+
 type FollowUserPayloadOrError interface {
 	IsFollowUserPayloadOrError()
 }
@@ -194,6 +218,10 @@ type LoginPayloadOrError interface {
 	IsLoginPayloadOrError()
 }
 
+type MarkNotInterestedPayloadOrError interface {
+	IsMarkNotInterestedPayloadOrError()
+}
+
 type Media interface {
 	IsMedia()
 }
@@ -222,6 +250,14 @@ type MoveCollectionToGalleryPayloadOrError interface {
 	IsMoveCollectionToGalleryPayloadOrError()
 }
 
+type MuteCommunityPayloadOrError interface {
+	IsMuteCommunityPayloadOrError()
+}
+
+type MuteWordPayloadOrError interface {
+	IsMuteWordPayloadOrError()
+}
+
 type Node interface {
 	IsNode()
 }
@@ -239,6 +275,14 @@ type OptOutForRolesPayloadOrError interface {
 	IsOptOutForRolesPayloadOrError()
 }
 
+type PinCommentPayloadOrError interface {
+	IsPinCommentPayloadOrError()
+}
+
+type PostAttachment interface {
+	IsPostAttachment()
+}
+
 type PostComposerDraftDetailsPayloadOrError interface {
 	IsPostComposerDraftDetailsPayloadOrError()
 }
@@ -263,6 +307,10 @@ type PublishGalleryPayloadOrError interface {
 	IsPublishGalleryPayloadOrError()
 }
 
+type QuotePostPayloadOrError interface {
+	IsQuotePostPayloadOrError()
+}
+
 type RedeemMerchPayloadOrError interface {
 	IsRedeemMerchPayloadOrError()
 }
@@ -307,10 +355,18 @@ type RemoveUserWalletsPayloadOrError interface {
 	IsRemoveUserWalletsPayloadOrError()
 }
 
+type ReplayTokenProcessingPayloadOrError interface {
+	IsReplayTokenProcessingPayloadOrError()
+}
+
 type ReportPostPayloadOrError interface {
 	IsReportPostPayloadOrError()
 }
 
+type RepostPayloadOrError interface {
+	IsRepostPayloadOrError()
+}
+
 type ResendVerificationEmailPayloadOrError interface {
 	IsResendVerificationEmailPayloadOrError()
 }
@@ -399,10 +455,26 @@ type UnblockUserPayloadOrError interface {
 	IsUnblockUserPayloadOrError()
 }
 
+type UnfollowCommunityPayloadOrError interface {
+	IsUnfollowCommunityPayloadOrError()
+}
+
 type UnfollowUserPayloadOrError interface {
 	IsUnfollowUserPayloadOrError()
 }
 
+type UnmuteCommunityPayloadOrError interface {
+	IsUnmuteCommunityPayloadOrError()
+}
+
+type UnmuteWordPayloadOrError interface {
+	IsUnmuteWordPayloadOrError()
+}
+
+type UnpinCommentPayloadOrError interface {
+	IsUnpinCommentPayloadOrError()
+}
+
 type UnregisterUserPushTokenPayloadOrError interface {
 	IsUnregisterUserPushTokenPayloadOrError()
 }
@@ -455,6 +527,14 @@ type UpdateGalleryPayloadOrError interface {
 	IsUpdateGalleryPayloadOrError()
 }
 
+type UpdateMediaModerationSettingsPayloadOrError interface {
+	IsUpdateMediaModerationSettingsPayloadOrError()
+}
+
+type UpdatePostDraftPayloadOrError interface {
+	IsUpdatePostDraftPayloadOrError()
+}
+
 type UpdatePrimaryWalletPayloadOrError interface {
 	IsUpdatePrimaryWalletPayloadOrError()
 }
@@ -479,6 +559,14 @@ type UploadPersistedQueriesPayloadOrError interface {
 	IsUploadPersistedQueriesPayloadOrError()
 }
 
+type UpsertContractRefreshPolicyPayloadOrError interface {
+	IsUpsertContractRefreshPolicyPayloadOrError()
+}
+
+type UpsertCustomMetadataHandlerPayloadOrError interface {
+	IsUpsertCustomMetadataHandlerPayloadOrError()
+}
+
 type UserByAddressOrError interface {
 	IsUserByAddressOrError()
 }
@@ -519,6 +607,10 @@ type ViewerOrError interface {
 	IsViewerOrError()
 }
 
+type VoteOnPollPayloadOrError interface {
+	IsVoteOnPollPayloadOrError()
+}
+
 type AddUserWalletPayload struct {
 	Viewer *Viewer `json:"viewer"`
 }
@@ -724,14 +816,17 @@ func (CollectionToken) IsNode()                       {}
 func (CollectionToken) IsCollectionTokenByIDOrError() {}
 
 type CollectionTokenSettings struct {
-	RenderLive     *bool `json:"renderLive"`
-	HighDefinition *bool `json:"highDefinition"`
+	RenderLive       *bool `json:"renderLive"`
+	HighDefinition   *bool `json:"highDefinition"`
+	ShowEditionCount *bool `json:"showEditionCount"`
+	EditionCount     *int  `json:"editionCount"`
 }
 
 type CollectionTokenSettingsInput struct {
-	TokenID        persist.DBID `json:"tokenId"`
-	RenderLive     bool         `json:"renderLive"`
-	HighDefinition bool         `json:"highDefinition"`
+	TokenID          persist.DBID `json:"tokenId"`
+	RenderLive       bool         `json:"renderLive"`
+	HighDefinition   bool         `json:"highDefinition"`
+	ShowEditionCount *bool        `json:"showEditionCount"`
 }
 
 type CollectionUpdatedFeedEventData struct {
@@ -773,14 +868,16 @@ func (CollectorsNoteAddedToTokenFeedEventData) IsFeedEventData() {}
 
 type Comment struct {
 	HelperCommentData
-	Dbid         persist.DBID              `json:"dbid"`
-	CreationTime *time.Time                `json:"creationTime"`
-	LastUpdated  *time.Time                `json:"lastUpdated"`
-	ReplyTo      *Comment                  `json:"replyTo"`
-	Commenter    *GalleryUser              `json:"commenter"`
-	Comment      *string                   `json:"comment"`
-	Mentions     []*Mention                `json:"mentions"`
-	Replies      *CommentsConnection       `json:"replies"`
+	Dbid         persist.DBID        `json:"dbid"`
+	CreationTime *time.Time          `json:"creationTime"`
+	LastUpdated  *time.Time          `json:"lastUpdated"`
+	ReplyTo      *Comment            `json:"replyTo"`
+	Commenter    *GalleryUser        `json:"commenter"`
+	Comment      *string             `json:"comment"`
+	Mentions     []*Mention          `json:"mentions"`
+	Replies      *CommentsConnection `json:"replies"`
+	// The number of replies in this comment's thread
+	ReplyCount   *int                      `json:"replyCount"`
 	Source       CommentSource             `json:"source"`
 	Deleted      *bool                     `json:"deleted"`
 	ViewerAdmire *Admire                   `json:"viewerAdmire"`
@@ -849,6 +946,8 @@ type Community struct {
 	Holders           *TokenHoldersConnection       `json:"holders"`
 	Tokens            *TokensConnection             `json:"tokens"`
 	Posts             *PostsConnection              `json:"posts"`
+	Traits            []*CommunityTrait             `json:"traits"`
+	ViewerIsFollowing *bool                         `json:"viewerIsFollowing"`
 	TokensForFrame    []*Token                      `json:"tokensForFrame"`
 	Contract          *Contract                     `json:"contract"`
 	ContractAddress   *persist.ChainAddress         `json:"contractAddress"`
@@ -891,6 +990,13 @@ type CommunitySearchResult struct {
 	Community *Community `json:"community"`
 }
 
+type CommunityTrait struct {
+	TraitType  string  `json:"traitType"`
+	Value      string  `json:"value"`
+	TokenCount int     `json:"tokenCount"`
+	Frequency  float64 `json:"frequency"`
+}
+
 type ConnectSocialAccountPayload struct {
 	Viewer *Viewer `json:"viewer"`
 }
@@ -929,6 +1035,25 @@ type ContractCommunityKeyInput struct {
 	Contract *persist.ChainAddress `json:"contract"`
 }
 
+type ContractRefreshPolicy struct {
+	Dbid                   persist.DBID  `json:"dbid"`
+	ContractID             *persist.DBID `json:"contractId"`
+	RefreshIntervalSeconds *int          `json:"refreshIntervalSeconds"`
+	IgnoreMetadataUpdates  *bool         `json:"ignoreMetadataUpdates"`
+	NextRefreshTime        *time.Time    `json:"nextRefreshTime"`
+	LastRefreshedTime      *time.Time    `json:"lastRefreshedTime"`
+	CreationTime           *time.Time    `json:"creationTime"`
+	LastUpdated            *time.Time    `json:"lastUpdated"`
+}
+
+type ContractSpamScore struct {
+	HelperContractSpamScoreData
+	Contract    *Contract             `json:"contract"`
+	Score       float64               `json:"score"`
+	Reasons     []*persist.SpamReason `json:"reasons"`
+	LastUpdated *time.Time            `json:"lastUpdated"`
+}
+
 type CreateCollectionInGalleryInput struct {
 	Name           string                          `json:"name"`
 	CollectorsNote string                          `json:"collectorsNote"`
@@ -968,6 +1093,13 @@ type CreateGalleryPayload struct {
 
 func (CreateGalleryPayload) IsCreateGalleryPayloadOrError() {}
 
+type CreatePostDraftPayload struct {
+	Draft  *PostDraft `json:"draft"`
+	Viewer *Viewer    `json:"viewer"`
+}
+
+func (CreatePostDraftPayload) IsCreatePostDraftPayloadOrError() {}
+
 type CreateUserInput struct {
 	Username           string               `json:"username"`
 	Bio                *string              `json:"bio"`
@@ -990,6 +1122,17 @@ type CreatedCommunitiesInput struct {
 	IncludeChains []persist.Chain `json:"includeChains"`
 }
 
+type CustomMetadataHandler struct {
+	Dbid              persist.DBID                  `json:"dbid"`
+	Chain             *persist.Chain                `json:"chain"`
+	ContractAddress   *persist.Address              `json:"contractAddress"`
+	FunctionSignature *string                       `json:"functionSignature"`
+	Format            *persist.CustomMetadataFormat `json:"format"`
+	Template          *string                       `json:"template"`
+	CreationTime      *time.Time                    `json:"creationTime"`
+	LastUpdated       *time.Time                    `json:"lastUpdated"`
+}
+
 type DebugAuth struct {
 	AsUsername         *string                 `json:"asUsername"`
 	UserID             *persist.DBID           `json:"userId"`
@@ -1010,12 +1153,30 @@ type DeleteCollectionPayload struct {
 
 func (DeleteCollectionPayload) IsDeleteCollectionPayloadOrError() {}
 
+type DeleteContractRefreshPolicyPayload struct {
+	DeletedID *DeletedNode `json:"deletedId"`
+}
+
+func (DeleteContractRefreshPolicyPayload) IsDeleteContractRefreshPolicyPayloadOrError() {}
+
+type DeleteCustomMetadataHandlerPayload struct {
+	DeletedID *DeletedNode `json:"deletedId"`
+}
+
+func (DeleteCustomMetadataHandlerPayload) IsDeleteCustomMetadataHandlerPayloadOrError() {}
+
 type DeleteGalleryPayload struct {
 	DeletedID *DeletedNode `json:"deletedId"`
 }
 
 func (DeleteGalleryPayload) IsDeleteGalleryPayloadOrError() {}
 
+type DeletePostDraftPayload struct {
+	Viewer *Viewer `json:"viewer"`
+}
+
+func (DeletePostDraftPayload) IsDeletePostDraftPayloadOrError() {}
+
 type DeletePostPayload struct {
 	DeletedID *DeletedNode `json:"deletedId"`
 }
@@ -1034,6 +1195,12 @@ type DisconnectSocialAccountPayload struct {
 
 func (DisconnectSocialAccountPayload) IsDisconnectSocialAccountPayloadOrError() {}
 
+type EditPostPayload struct {
+	Post *Post `json:"post"`
+}
+
+func (EditPostPayload) IsEditPostPayloadOrError() {}
+
 type EmailNotificationSettings struct {
 	UnsubscribedFromAll           bool  `json:"unsubscribedFromAll"`
 	UnsubscribedFromNotifications bool  `json:"unsubscribedFromNotifications"`
@@ -1122,6 +1289,7 @@ type ErrCommentNotFound struct {
 
 func (ErrCommentNotFound) IsError()                       {}
 func (ErrCommentNotFound) IsRemoveCommentPayloadOrError() {}
+func (ErrCommentNotFound) IsPinCommentPayloadOrError()    {}
 func (ErrCommentNotFound) IsAdmireCommentPayloadOrError() {}
 
 type ErrCommunityNotFound struct {
@@ -1134,6 +1302,9 @@ func (ErrCommunityNotFound) IsCommunityByKeyOrError()
 func (ErrCommunityNotFound) IsPostComposerDraftDetailsPayloadOrError()                        {}
 func (ErrCommunityNotFound) IsError()                                                         {}
 func (ErrCommunityNotFound) IsSyncCreatedTokensForUsernameAndExistingContractPayloadOrError() {}
+func (ErrCommunityNotFound) IsMuteCommunityPayloadOrError()                                   {}
+func (ErrCommunityNotFound) IsFollowCommunityPayloadOrError()                                 {}
+func (ErrCommunityNotFound) IsUnfollowCommunityPayloadOrError()                               {}
 
 type ErrDoesNotOwnRequiredToken struct {
 	Message string `json:"message"`
@@ -1269,8 +1440,14 @@ func (ErrInvalidInput) IsUnsubscribeFromEmailTypePayloadOrError()
 func (ErrInvalidInput) IsOptInForRolesPayloadOrError()                                   {}
 func (ErrInvalidInput) IsOptOutForRolesPayloadOrError()                                  {}
 func (ErrInvalidInput) IsSetPersonaPayloadOrError()                                      {}
+func (ErrInvalidInput) IsUpdateMediaModerationSettingsPayloadOrError()                   {}
 func (ErrInvalidInput) IsRedeemMerchPayloadOrError()                                     {}
 func (ErrInvalidInput) IsSyncCreatedTokensForUsernameAndExistingContractPayloadOrError() {}
+func (ErrInvalidInput) IsReplayTokenProcessingPayloadOrError()                           {}
+func (ErrInvalidInput) IsUpsertCustomMetadataHandlerPayloadOrError()                     {}
+func (ErrInvalidInput) IsDeleteCustomMetadataHandlerPayloadOrError()                     {}
+func (ErrInvalidInput) IsUpsertContractRefreshPolicyPayloadOrError()                     {}
+func (ErrInvalidInput) IsDeleteContractRefreshPolicyPayloadOrError()                     {}
 func (ErrInvalidInput) IsCreateGalleryPayloadOrError()                                   {}
 func (ErrInvalidInput) IsUpdateGalleryInfoPayloadOrError()                               {}
 func (ErrInvalidInput) IsUpdateGalleryHiddenPayloadOrError()                             {}
@@ -1289,17 +1466,33 @@ func (ErrInvalidInput) IsDisconnectSocialAccountPayloadOrError()
 func (ErrInvalidInput) IsFollowAllSocialConnectionsPayloadOrError()                      {}
 func (ErrInvalidInput) IsFollowAllOnboardingRecommendationsPayloadOrError()              {}
 func (ErrInvalidInput) IsSetProfileImagePayloadOrError()                                 {}
+func (ErrInvalidInput) IsVoteOnPollPayloadOrError()                                      {}
+func (ErrInvalidInput) IsPinCommentPayloadOrError()                                      {}
+func (ErrInvalidInput) IsUnpinCommentPayloadOrError()                                    {}
 func (ErrInvalidInput) IsPostTokensPayloadOrError()                                      {}
+func (ErrInvalidInput) IsCreatePostDraftPayloadOrError()                                 {}
+func (ErrInvalidInput) IsUpdatePostDraftPayloadOrError()                                 {}
+func (ErrInvalidInput) IsDeletePostDraftPayloadOrError()                                 {}
 func (ErrInvalidInput) IsReferralPostTokenPayloadOrError()                               {}
+func (ErrInvalidInput) IsRepostPayloadOrError()                                          {}
+func (ErrInvalidInput) IsQuotePostPayloadOrError()                                       {}
 func (ErrInvalidInput) IsAdmirePostPayloadOrError()                                      {}
 func (ErrInvalidInput) IsAdmireTokenPayloadOrError()                                     {}
 func (ErrInvalidInput) IsAdmireCommentPayloadOrError()                                   {}
 func (ErrInvalidInput) IsCommentOnPostPayloadOrError()                                   {}
 func (ErrInvalidInput) IsDeletePostPayloadOrError()                                      {}
+func (ErrInvalidInput) IsEditPostPayloadOrError()                                        {}
 func (ErrInvalidInput) IsReferralPostPreflightPayloadOrError()                           {}
 func (ErrInvalidInput) IsReportPostPayloadOrError()                                      {}
 func (ErrInvalidInput) IsBlockUserPayloadOrError()                                       {}
 func (ErrInvalidInput) IsUnblockUserPayloadOrError()                                     {}
+func (ErrInvalidInput) IsMuteWordPayloadOrError()                                        {}
+func (ErrInvalidInput) IsUnmuteWordPayloadOrError()                                      {}
+func (ErrInvalidInput) IsMuteCommunityPayloadOrError()                                   {}
+func (ErrInvalidInput) IsUnmuteCommunityPayloadOrError()                                 {}
+func (ErrInvalidInput) IsFollowCommunityPayloadOrError()                                 {}
+func (ErrInvalidInput) IsUnfollowCommunityPayloadOrError()                               {}
+func (ErrInvalidInput) IsMarkNotInterestedPayloadOrError()                               {}
 
 type ErrInvalidToken struct {
 	Message string `json:"message"`
@@ -1363,6 +1556,7 @@ func (ErrNotAuthorized) IsRevokeRolesFromUserPayloadOrError()
 func (ErrNotAuthorized) IsOptInForRolesPayloadOrError()                                   {}
 func (ErrNotAuthorized) IsOptOutForRolesPayloadOrError()                                  {}
 func (ErrNotAuthorized) IsSetPersonaPayloadOrError()                                      {}
+func (ErrNotAuthorized) IsUpdateMediaModerationSettingsPayloadOrError()                   {}
 func (ErrNotAuthorized) IsUploadPersistedQueriesPayloadOrError()                          {}
 func (ErrNotAuthorized) IsSyncTokensForUsernamePayloadOrError()                           {}
 func (ErrNotAuthorized) IsSyncCreatedTokensForUsernamePayloadOrError()                    {}
@@ -1370,6 +1564,11 @@ func (ErrNotAuthorized) IsSyncCreatedTokensForUsernameAndExistingContractPayload
 func (ErrNotAuthorized) IsBanUserFromFeedPayloadOrError()                                 {}
 func (ErrNotAuthorized) IsUnbanUserFromFeedPayloadOrError()                               {}
 func (ErrNotAuthorized) IsSetCommunityOverrideCreatorPayloadOrError()                     {}
+func (ErrNotAuthorized) IsReplayTokenProcessingPayloadOrError()                           {}
+func (ErrNotAuthorized) IsUpsertCustomMetadataHandlerPayloadOrError()                     {}
+func (ErrNotAuthorized) IsDeleteCustomMetadataHandlerPayloadOrError()                     {}
+func (ErrNotAuthorized) IsUpsertContractRefreshPolicyPayloadOrError()                     {}
+func (ErrNotAuthorized) IsDeleteContractRefreshPolicyPayloadOrError()                     {}
 func (ErrNotAuthorized) IsCreateGalleryPayloadOrError()                                   {}
 func (ErrNotAuthorized) IsUpdateGalleryInfoPayloadOrError()                               {}
 func (ErrNotAuthorized) IsUpdateGalleryHiddenPayloadOrError()                             {}
@@ -1390,15 +1589,31 @@ func (ErrNotAuthorized) IsFollowAllSocialConnectionsPayloadOrError()
 func (ErrNotAuthorized) IsFollowAllOnboardingRecommendationsPayloadOrError()              {}
 func (ErrNotAuthorized) IsGenerateQRCodeLoginTokenPayloadOrError()                        {}
 func (ErrNotAuthorized) IsSetProfileImagePayloadOrError()                                 {}
+func (ErrNotAuthorized) IsVoteOnPollPayloadOrError()                                      {}
+func (ErrNotAuthorized) IsPinCommentPayloadOrError()                                      {}
+func (ErrNotAuthorized) IsUnpinCommentPayloadOrError()                                    {}
 func (ErrNotAuthorized) IsPostTokensPayloadOrError()                                      {}
+func (ErrNotAuthorized) IsCreatePostDraftPayloadOrError()                                 {}
+func (ErrNotAuthorized) IsUpdatePostDraftPayloadOrError()                                 {}
+func (ErrNotAuthorized) IsDeletePostDraftPayloadOrError()                                 {}
 func (ErrNotAuthorized) IsReferralPostTokenPayloadOrError()                               {}
+func (ErrNotAuthorized) IsRepostPayloadOrError()                                          {}
+func (ErrNotAuthorized) IsQuotePostPayloadOrError()                                       {}
 func (ErrNotAuthorized) IsAdmirePostPayloadOrError()                                      {}
 func (ErrNotAuthorized) IsAdmireTokenPayloadOrError()                                     {}
 func (ErrNotAuthorized) IsAdmireCommentPayloadOrError()                                   {}
 func (ErrNotAuthorized) IsCommentOnPostPayloadOrError()                                   {}
 func (ErrNotAuthorized) IsDeletePostPayloadOrError()                                      {}
+func (ErrNotAuthorized) IsEditPostPayloadOrError()                                        {}
 func (ErrNotAuthorized) IsBlockUserPayloadOrError()                                       {}
 func (ErrNotAuthorized) IsUnblockUserPayloadOrError()                                     {}
+func (ErrNotAuthorized) IsMuteWordPayloadOrError()                                        {}
+func (ErrNotAuthorized) IsUnmuteWordPayloadOrError()                                      {}
+func (ErrNotAuthorized) IsMuteCommunityPayloadOrError()                                   {}
+func (ErrNotAuthorized) IsUnmuteCommunityPayloadOrError()                                 {}
+func (ErrNotAuthorized) IsFollowCommunityPayloadOrError()                                 {}
+func (ErrNotAuthorized) IsUnfollowCommunityPayloadOrError()                               {}
+func (ErrNotAuthorized) IsMarkNotInterestedPayloadOrError()                               {}
 func (ErrNotAuthorized) IsHighlightClaimMintPayloadOrError()                              {}
 func (ErrNotAuthorized) IsHighlightMintClaimStatusPayloadOrError()                        {}
 
@@ -1406,11 +1621,19 @@ type ErrPostNotFound struct {
 	Message string `json:"message"`
 }
 
-func (ErrPostNotFound) IsPostOrError()              {}
-func (ErrPostNotFound) IsError()                    {}
-func (ErrPostNotFound) IsFeedEventOrError()         {}
-func (ErrPostNotFound) IsAdmirePostPayloadOrError() {}
-func (ErrPostNotFound) IsReportPostPayloadOrError() {}
+func (ErrPostNotFound) IsPostOrError()                     {}
+func (ErrPostNotFound) IsError()                           {}
+func (ErrPostNotFound) IsFeedEventOrError()                {}
+func (ErrPostNotFound) IsVoteOnPollPayloadOrError()        {}
+func (ErrPostNotFound) IsUnpinCommentPayloadOrError()      {}
+func (ErrPostNotFound) IsUpdatePostDraftPayloadOrError()   {}
+func (ErrPostNotFound) IsDeletePostDraftPayloadOrError()   {}
+func (ErrPostNotFound) IsRepostPayloadOrError()            {}
+func (ErrPostNotFound) IsQuotePostPayloadOrError()         {}
+func (ErrPostNotFound) IsAdmirePostPayloadOrError()        {}
+func (ErrPostNotFound) IsEditPostPayloadOrError()          {}
+func (ErrPostNotFound) IsReportPostPayloadOrError()        {}
+func (ErrPostNotFound) IsMarkNotInterestedPayloadOrError() {}
 
 type ErrPushTokenBelongsToAnotherUser struct {
 	Message string `json:"message"`
@@ -1583,6 +1806,13 @@ type FollowAllSocialConnectionsPayload struct {
 
 func (FollowAllSocialConnectionsPayload) IsFollowAllSocialConnectionsPayloadOrError() {}
 
+type FollowCommunityPayload struct {
+	Viewer    *Viewer    `json:"viewer"`
+	Community *Community `json:"community"`
+}
+
+func (FollowCommunityPayload) IsFollowCommunityPayloadOrError() {}
+
 type FollowInfo struct {
 	User         *GalleryUser `json:"user"`
 	FollowedBack *bool        `json:"followedBack"`
@@ -1595,6 +1825,19 @@ type FollowUserPayload struct {
 
 func (FollowUserPayload) IsFollowUserPayloadOrError() {}
 
+type FollowedCommunityCreatorPostedNotification struct {
+	HelperFollowedCommunityCreatorPostedNotificationData
+	Dbid         persist.DBID `json:"dbid"`
+	Seen         *bool        `json:"seen"`
+	CreationTime *time.Time   `json:"creationTime"`
+	UpdatedTime  *time.Time   `json:"updatedTime"`
+	Post         *Post        `json:"post"`
+	Community    *Community   `json:"community"`
+}
+
+func (FollowedCommunityCreatorPostedNotification) IsNotification() {}
+func (FollowedCommunityCreatorPostedNotification) IsNode()         {}
+
 type GIFMedia struct {
 	PreviewURLs       *PreviewURLSet   `json:"previewURLs"`
 	StaticPreviewURLs *PreviewURLSet   `json:"staticPreviewURLs"`
@@ -1868,12 +2111,30 @@ type MagicLinkAuth struct {
 	Token string `json:"token"`
 }
 
+type MarkNotInterestedPayload struct {
+	PostID persist.DBID `json:"postId"`
+}
+
+func (MarkNotInterestedPayload) IsMarkNotInterestedPayloadOrError() {}
+
 type MediaDimensions struct {
 	Width       *int     `json:"width"`
 	Height      *int     `json:"height"`
 	AspectRatio *float64 `json:"aspectRatio"`
 }
 
+type MediaModeration struct {
+	Label *ModerationLabel `json:"label"`
+	// How the media is displayed to the viewer. Media with a Blur or Hide display is already blurred or removed
+	// when returned from the media field.
+	Display persist.FlaggedMediaDisplay `json:"display"`
+	Reasons []string                    `json:"reasons"`
+}
+
+type MediaModerationSettings struct {
+	FlaggedMediaDisplay persist.FlaggedMediaDisplay `json:"flaggedMediaDisplay"`
+}
+
 type MembershipTier struct {
 	Dbid     persist.DBID   `json:"dbid"`
 	Name     *string        `json:"name"`
@@ -1939,6 +2200,18 @@ type MoveCollectionToGalleryPayload struct {
 
 func (MoveCollectionToGalleryPayload) IsMoveCollectionToGalleryPayloadOrError() {}
 
+type MuteCommunityPayload struct {
+	Viewer *Viewer `json:"viewer"`
+}
+
+func (MuteCommunityPayload) IsMuteCommunityPayloadOrError() {}
+
+type MuteWordPayload struct {
+	Viewer *Viewer `json:"viewer"`
+}
+
+func (MuteWordPayload) IsMuteWordPayloadOrError() {}
+
 type NewTokensNotification struct {
 	HelperNewTokensNotificationData
 	Dbid         persist.DBID `json:"dbid"`
@@ -2031,21 +2304,70 @@ type PDFMedia struct {
 func (PDFMedia) IsMediaSubtype() {}
 func (PDFMedia) IsMedia()        {}
 
+type PinCommentPayload struct {
+	Post    *Post    `json:"post"`
+	Comment *Comment `json:"comment"`
+}
+
+func (PinCommentPayload) IsPinCommentPayloadOrError() {}
+
+type PipelineTraceAttempt struct {
+	Source     string  `json:"source"`
+	DurationMs int     `json:"durationMs"`
+	Error      *string `json:"error"`
+}
+
+type PipelineTraceAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type PipelineTraceStep struct {
+	Name       string                    `json:"name"`
+	Status     string                    `json:"status"`
+	DurationMs int                       `json:"durationMs"`
+	Attributes []*PipelineTraceAttribute `json:"attributes"`
+	Attempts   []*PipelineTraceAttempt   `json:"attempts"`
+}
+
+type PollInput struct {
+	// Between 2 and 4 options
+	Options []string `json:"options"`
+	// When voting ends. Polls can be open for up to a week.
+	ClosesAt time.Time `json:"closesAt"`
+}
+
+type PollOptionResult struct {
+	Option string `json:"option"`
+	Votes  int    `json:"votes"`
+}
+
 type Post struct {
 	HelperPostData
-	Dbid             persist.DBID            `json:"dbid"`
-	Author           *GalleryUser            `json:"author"`
-	CreationTime     *time.Time              `json:"creationTime"`
-	Tokens           []*Token                `json:"tokens"`
-	Caption          *string                 `json:"caption"`
-	Mentions         []*Mention              `json:"mentions"`
-	Admires          *PostAdmiresConnection  `json:"admires"`
+	Dbid         persist.DBID           `json:"dbid"`
+	Author       *GalleryUser           `json:"author"`
+	CreationTime *time.Time             `json:"creationTime"`
+	Tokens       []*Token               `json:"tokens"`
+	Caption      *string                `json:"caption"`
+	Mentions     []*Mention             `json:"mentions"`
+	Admires      *PostAdmiresConnection `json:"admires"`
+	// The post's comments. A comment pinned by the post's author comes first regardless of the sort.
 	Comments         *PostCommentsConnection `json:"comments"`
+	PinnedComment    *Comment                `json:"pinnedComment"`
 	TotalComments    *int                    `json:"totalComments"`
 	Interactions     *InteractionsConnection `json:"interactions"`
 	ViewerAdmire     *Admire                 `json:"viewerAdmire"`
 	IsFirstPost      bool                    `json:"isFirstPost"`
 	UserAddedMintURL *string                 `json:"userAddedMintURL"`
+	// The post this post quotes, if it's a quote post
+	QuotedPost *Post `json:"quotedPost"`
+	// The number of times this post has been reposted, including quote posts
+	RepostCount *int `json:"repostCount"`
+	// When the post was last edited, if it has been
+	EditedAt *time.Time `json:"editedAt"`
+	// The captions this post had before it was edited, most recent first
+	Revisions  []*PostRevision `json:"revisions"`
+	Attachment PostAttachment  `json:"attachment"`
 }
 
 func (Post) IsAdmireSource()     {}
@@ -2066,6 +2388,13 @@ type PostAdmiresConnection struct {
 	PageInfo *PageInfo         `json:"pageInfo"`
 }
 
+// Exactly one kind of attachment must be set
+type PostAttachmentInput struct {
+	Poll        *PollInput    `json:"poll"`
+	LinkURL     *string       `json:"linkURL"`
+	CommunityID *persist.DBID `json:"communityId"`
+}
+
 type PostCommentEdge struct {
 	Node   *Comment `json:"node"`
 	Cursor *string  `json:"cursor"`
@@ -2076,6 +2405,13 @@ type PostCommentsConnection struct {
 	PageInfo *PageInfo          `json:"pageInfo"`
 }
 
+type PostCommunityCard struct {
+	HelperPostCommunityCardData
+	Community *Community `json:"community"`
+}
+
+func (PostCommunityCard) IsPostAttachment() {}
+
 type PostComposerDraftDetailsInput struct {
 	Token *ChainAddressTokenInput `json:"token"`
 }
@@ -2090,16 +2426,72 @@ type PostComposerDraftDetailsPayload struct {
 
 func (PostComposerDraftDetailsPayload) IsPostComposerDraftDetailsPayloadOrError() {}
 
-type PostEdge struct {
-	Node   PostOrError `json:"node"`
-	Cursor *string     `json:"cursor"`
+type PostDraft struct {
+	HelperPostDraftData
+	Dbid         persist.DBID `json:"dbid"`
+	CreationTime *time.Time   `json:"creationTime"`
+	LastUpdated  *time.Time   `json:"lastUpdated"`
+	Tokens       []*Token     `json:"tokens"`
+	Caption      *string      `json:"caption"`
+	Mentions     []*Mention   `json:"mentions"`
+	MintURL      *string      `json:"mintURL"`
+	// When the draft will be posted. Drafts without a publish time are kept until they're posted or deleted.
+	PublishAt *time.Time `json:"publishAt"`
 }
 
-type PostTokensInput struct {
+// Drafts can't have attachments, so posts with a poll, link or community have to be posted right away.
+type PostDraftInput struct {
 	TokenIds []persist.DBID  `json:"tokenIds"`
 	Caption  *string         `json:"caption"`
 	Mentions []*MentionInput `json:"mentions"`
 	MintURL  *string         `json:"mintURL"`
+	// When to post the draft. A scheduled draft must have at least one token and a publish time in the future.
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+type PostEdge struct {
+	Node   PostOrError `json:"node"`
+	Cursor *string     `json:"cursor"`
+}
+
+type PostLink struct {
+	URL         string  `json:"url"`
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	ImageURL    *string `json:"imageURL"`
+	SiteName    *string `json:"siteName"`
+}
+
+func (PostLink) IsPostAttachment() {}
+
+type PostPoll struct {
+	Dbid     persist.DBID `json:"dbid"`
+	Options  []string     `json:"options"`
+	ClosesAt time.Time    `json:"closesAt"`
+	IsClosed bool         `json:"isClosed"`
+	// The index of the option the viewer voted for, if they've voted
+	ViewerVote *int `json:"viewerVote"`
+	TotalVotes *int `json:"totalVotes"`
+	// The number of votes for each option, in the same order as options. Results are hidden until the poll closes.
+	Results []*PollOptionResult `json:"results"`
+}
+
+func (PostPoll) IsPostAttachment() {}
+
+type PostRevision struct {
+	Caption *string `json:"caption"`
+	// When this version of the post was written
+	CreationTime *time.Time `json:"creationTime"`
+	// When this version of the post was replaced by an edit
+	ReplacedAt *time.Time `json:"replacedAt"`
+}
+
+type PostTokensInput struct {
+	TokenIds   []persist.DBID       `json:"tokenIds"`
+	Caption    *string              `json:"caption"`
+	Mentions   []*MentionInput      `json:"mentions"`
+	MintURL    *string              `json:"mintURL"`
+	Attachment *PostAttachmentInput `json:"attachment"`
 }
 
 type PostTokensPayload struct {
@@ -2151,6 +2543,12 @@ type PublishGalleryPayload struct {
 
 func (PublishGalleryPayload) IsPublishGalleryPayloadOrError() {}
 
+type QuotePostPayload struct {
+	Post *Post `json:"post"`
+}
+
+func (QuotePostPayload) IsQuotePostPayloadOrError() {}
+
 type RedeemMerchInput struct {
 	TokenIds   []string              `json:"tokenIds"`
 	Address    *persist.ChainAddress `json:"address"`
@@ -2239,12 +2637,40 @@ type RemoveUserWalletsPayload struct {
 
 func (RemoveUserWalletsPayload) IsRemoveUserWalletsPayloadOrError() {}
 
+type ReplayTokenProcessingInput struct {
+	DeadLetterIds []persist.DBID `json:"deadLetterIds"`
+	ContractID    *persist.DBID  `json:"contractId"`
+}
+
+type ReplayTokenProcessingPayload struct {
+	Replayed []*TokenProcessingDeadLetter `json:"replayed"`
+}
+
+func (ReplayTokenProcessingPayload) IsReplayTokenProcessingPayloadOrError() {}
+
 type ReportPostPayload struct {
 	PostID persist.DBID `json:"postId"`
 }
 
 func (ReportPostPayload) IsReportPostPayloadOrError() {}
 
+type Repost struct {
+	HelperRepostData
+	Dbid         persist.DBID `json:"dbid"`
+	Reposter     *GalleryUser `json:"reposter"`
+	CreationTime *time.Time   `json:"creationTime"`
+	Post         *Post        `json:"post"`
+}
+
+func (Repost) IsNode()             {}
+func (Repost) IsFeedEventOrError() {}
+
+type RepostPayload struct {
+	Repost *Repost `json:"repost"`
+}
+
+func (RepostPayload) IsRepostPayloadOrError() {}
+
 type ResendVerificationEmailPayload struct {
 	Viewer *Viewer `json:"viewer"`
 }
@@ -2511,6 +2937,21 @@ type SomeoneRepliedToYourCommentNotification struct {
 func (SomeoneRepliedToYourCommentNotification) IsNotification() {}
 func (SomeoneRepliedToYourCommentNotification) IsNode()         {}
 
+type SomeoneRepostedYourPostNotification struct {
+	HelperSomeoneRepostedYourPostNotificationData
+	Dbid         persist.DBID                      `json:"dbid"`
+	Seen         *bool                             `json:"seen"`
+	CreationTime *time.Time                        `json:"creationTime"`
+	UpdatedTime  *time.Time                        `json:"updatedTime"`
+	Count        *int                              `json:"count"`
+	Post         *Post                             `json:"post"`
+	Reposters    *GroupNotificationUsersConnection `json:"reposters"`
+}
+
+func (SomeoneRepostedYourPostNotification) IsNotification()        {}
+func (SomeoneRepostedYourPostNotification) IsNode()                {}
+func (SomeoneRepostedYourPostNotification) IsGroupedNotification() {}
+
 type SomeoneViewedYourGalleryNotification struct {
 	HelperSomeoneViewedYourGalleryNotificationData
 	Dbid               persist.DBID                      `json:"dbid"`
@@ -2629,6 +3070,7 @@ type Token struct {
 	LastUpdated           *time.Time              `json:"lastUpdated"`
 	CollectorsNote        *string                 `json:"collectorsNote"`
 	Quantity              *string                 `json:"quantity"`
+	OwnedQuantity         *int                    `json:"ownedQuantity"`
 	Owner                 *GalleryUser            `json:"owner"`
 	OwnedByWallets        []*Wallet               `json:"ownedByWallets"`
 	OwnershipHistory      []*OwnerAtBlock         `json:"ownershipHistory"`
@@ -2638,6 +3080,8 @@ type Token struct {
 	IsSpamByUser          *bool                   `json:"isSpamByUser"`
 	Admires               *TokenAdmiresConnection `json:"admires"`
 	ViewerAdmire          *Admire                 `json:"viewerAdmire"`
+	Traits                []*TokenTrait           `json:"traits"`
+	Rarity                *TokenRarity            `json:"rarity"`
 	Media                 MediaSubtype            `json:"media"`
 	TokenType             *TokenType              `json:"tokenType"`
 	Chain                 *persist.Chain          `json:"chain"`
@@ -2671,21 +3115,23 @@ type TokenAdmiresConnection struct {
 
 type TokenDefinition struct {
 	HelperTokenDefinitionData
-	Dbid          persist.DBID   `json:"dbid"`
-	CreationTime  *time.Time     `json:"creationTime"`
-	LastUpdated   *time.Time     `json:"lastUpdated"`
-	Media         MediaSubtype   `json:"media"`
-	TokenType     *TokenType     `json:"tokenType"`
-	Contract      *Contract      `json:"contract"`
-	Chain         *persist.Chain `json:"chain"`
-	Name          *string        `json:"name"`
-	Description   *string        `json:"description"`
-	TokenID       *string        `json:"tokenId"`
-	TokenMetadata *string        `json:"tokenMetadata"`
-	Community     *Community     `json:"community"`
-	Communities   []*Community   `json:"communities"`
-	ExternalURL   *string        `json:"externalUrl"`
-	MintURL       *string        `json:"mintUrl"`
+	Dbid            persist.DBID          `json:"dbid"`
+	CreationTime    *time.Time            `json:"creationTime"`
+	LastUpdated     *time.Time            `json:"lastUpdated"`
+	Media           MediaSubtype          `json:"media"`
+	TokenType       *TokenType            `json:"tokenType"`
+	Contract        *Contract             `json:"contract"`
+	Chain           *persist.Chain        `json:"chain"`
+	Name            *string               `json:"name"`
+	Description     *string               `json:"description"`
+	TokenID         *string               `json:"tokenId"`
+	TokenMetadata   *string               `json:"tokenMetadata"`
+	Community       *Community            `json:"community"`
+	Communities     []*Community          `json:"communities"`
+	ExternalURL     *string               `json:"externalUrl"`
+	MintURL         *string               `json:"mintUrl"`
+	MediaModeration *MediaModeration      `json:"mediaModeration"`
+	ProcessingState *TokenProcessingState `json:"processingState"`
 }
 
 func (TokenDefinition) IsNode() {}
@@ -2713,12 +3159,57 @@ type TokenHoldersConnection struct {
 	PageInfo *PageInfo          `json:"pageInfo"`
 }
 
+type TokenProcessingDeadLetter struct {
+	HelperTokenProcessingDeadLetterData
+	Dbid             persist.DBID              `json:"dbid"`
+	TokenDefinition  *TokenDefinition          `json:"tokenDefinition"`
+	Contract         *Contract                 `json:"contract"`
+	Reason           *persist.DeadLetterReason `json:"reason"`
+	Cause            *string                   `json:"cause"`
+	LastError        *string                   `json:"lastError"`
+	Attempts         *int                      `json:"attempts"`
+	PipelineMetadata *string                   `json:"pipelineMetadata"`
+	CreationTime     *time.Time                `json:"creationTime"`
+	LastUpdated      *time.Time                `json:"lastUpdated"`
+}
+
+type TokenProcessingDeadLetterEdge struct {
+	Node   *TokenProcessingDeadLetter `json:"node"`
+	Cursor *string                    `json:"cursor"`
+}
+
+type TokenProcessingDeadLettersConnection struct {
+	Edges    []*TokenProcessingDeadLetterEdge `json:"edges"`
+	PageInfo *PageInfo                        `json:"pageInfo"`
+}
+
+type TokenProcessingState struct {
+	InFlight bool `json:"inFlight"`
+	// The steps of the pipeline run that produced the token's current media. Only returned to admins.
+	Trace []*PipelineTraceStep `json:"trace"`
+}
+
 type TokenProfileImage struct {
 	Token *Token `json:"token"`
 }
 
 func (TokenProfileImage) IsProfileImage() {}
 
+type TokenRarity struct {
+	StatisticalScore float64 `json:"statisticalScore"`
+	NormalizedScore  float64 `json:"normalizedScore"`
+	Rank             int     `json:"rank"`
+	Total            int     `json:"total"`
+}
+
+type TokenTrait struct {
+	TraitType   string  `json:"traitType"`
+	Value       string  `json:"value"`
+	TokenCount  int     `json:"tokenCount"`
+	Frequency   float64 `json:"frequency"`
+	RarityScore float64 `json:"rarityScore"`
+}
+
 type TokensAddedToCollectionFeedEventData struct {
 	HelperTokensAddedToCollectionFeedEventDataData
 	EventTime  *time.Time         `json:"eventTime"`
@@ -2736,6 +3227,11 @@ type TokensConnection struct {
 	PageInfo *PageInfo    `json:"pageInfo"`
 }
 
+type TraitInput struct {
+	TraitType string `json:"traitType"`
+	Value     string `json:"value"`
+}
+
 type TrendingUsersInput struct {
 	Report Window `json:"report"`
 }
@@ -2774,6 +3270,13 @@ type UnblockUserPayload struct {
 
 func (UnblockUserPayload) IsUnblockUserPayloadOrError() {}
 
+type UnfollowCommunityPayload struct {
+	Viewer    *Viewer    `json:"viewer"`
+	Community *Community `json:"community"`
+}
+
+func (UnfollowCommunityPayload) IsUnfollowCommunityPayloadOrError() {}
+
 type UnfollowUserPayload struct {
 	Viewer *Viewer      `json:"viewer"`
 	User   *GalleryUser `json:"user"`
@@ -2793,6 +3296,24 @@ type UnknownMedia struct {
 func (UnknownMedia) IsMediaSubtype() {}
 func (UnknownMedia) IsMedia()        {}
 
+type UnmuteCommunityPayload struct {
+	Viewer *Viewer `json:"viewer"`
+}
+
+func (UnmuteCommunityPayload) IsUnmuteCommunityPayloadOrError() {}
+
+type UnmuteWordPayload struct {
+	Viewer *Viewer `json:"viewer"`
+}
+
+func (UnmuteWordPayload) IsUnmuteWordPayloadOrError() {}
+
+type UnpinCommentPayload struct {
+	Post *Post `json:"post"`
+}
+
+func (UnpinCommentPayload) IsUnpinCommentPayloadOrError() {}
+
 type UnregisterUserPushTokenPayload struct {
 	Viewer *Viewer `json:"viewer"`
 }
@@ -2953,6 +3474,19 @@ type UpdateGalleryPayload struct {
 
 func (UpdateGalleryPayload) IsUpdateGalleryPayloadOrError() {}
 
+type UpdateMediaModerationSettingsPayload struct {
+	Viewer *Viewer `json:"viewer"`
+}
+
+func (UpdateMediaModerationSettingsPayload) IsUpdateMediaModerationSettingsPayloadOrError() {}
+
+type UpdatePostDraftPayload struct {
+	Draft  *PostDraft `json:"draft"`
+	Viewer *Viewer    `json:"viewer"`
+}
+
+func (UpdatePostDraftPayload) IsUpdatePostDraftPayloadOrError() {}
+
 type UpdatePrimaryWalletPayload struct {
 	Viewer *Viewer `json:"viewer"`
 }
@@ -3014,6 +3548,32 @@ type UploadPersistedQueriesPayload struct {
 
 func (UploadPersistedQueriesPayload) IsUploadPersistedQueriesPayloadOrError() {}
 
+type UpsertContractRefreshPolicyInput struct {
+	ContractID             persist.DBID `json:"contractId"`
+	RefreshIntervalSeconds *int         `json:"refreshIntervalSeconds"`
+	IgnoreMetadataUpdates  bool         `json:"ignoreMetadataUpdates"`
+}
+
+type UpsertContractRefreshPolicyPayload struct {
+	Policy *ContractRefreshPolicy `json:"policy"`
+}
+
+func (UpsertContractRefreshPolicyPayload) IsUpsertContractRefreshPolicyPayloadOrError() {}
+
+type UpsertCustomMetadataHandlerInput struct {
+	Chain             persist.Chain                `json:"chain"`
+	ContractAddress   persist.Address              `json:"contractAddress"`
+	FunctionSignature string                       `json:"functionSignature"`
+	Format            persist.CustomMetadataFormat `json:"format"`
+	Template          *string                      `json:"template"`
+}
+
+type UpsertCustomMetadataHandlerPayload struct {
+	Handler *CustomMetadataHandler `json:"handler"`
+}
+
+func (UpsertCustomMetadataHandlerPayload) IsUpsertCustomMetadataHandlerPayloadOrError() {}
+
 type UserCreatedFeedEventData struct {
 	EventTime *time.Time      `json:"eventTime"`
 	Owner     *GalleryUser    `json:"owner"`
@@ -3119,15 +3679,24 @@ type Viewer struct {
 	SocialAccounts  *SocialAccounts  `json:"socialAccounts"`
 	ViewerGalleries []*ViewerGallery `json:"viewerGalleries"`
 	Feed            *FeedConnection  `json:"feed"`
-	Email           *UserEmail       `json:"email"`
+	// Returns posts about the communities the viewer follows in reverse chronological order
+	CommunitiesFeed *FeedConnection `json:"communitiesFeed"`
+	Email           *UserEmail      `json:"email"`
 	// Returns a list of notifications in reverse chronological order.
 	// Seen notifications come after unseen notifications
 	Notifications           *NotificationsConnection `json:"notifications"`
 	NotificationSettings    *NotificationSettings    `json:"notificationSettings"`
 	UserExperiences         []*UserExperience        `json:"userExperiences"`
 	Persona                 *persist.Persona         `json:"persona"`
-	SuggestedUsers          *UsersConnection         `json:"suggestedUsers"`
-	SuggestedUsersFarcaster *UsersConnection         `json:"suggestedUsersFarcaster"`
+	MediaModerationSettings *MediaModerationSettings `json:"mediaModerationSettings"`
+	// Words that hide posts from the viewer's feeds when they appear in a post's caption or comments
+	MutedWords []string `json:"mutedWords"`
+	// Communities whose posts are hidden from the viewer's feeds
+	MutedCommunities []*Community `json:"mutedCommunities"`
+	// Posts the viewer has saved for later, including scheduled posts, most recently updated first
+	PostDrafts              []*PostDraft     `json:"postDrafts"`
+	SuggestedUsers          *UsersConnection `json:"suggestedUsers"`
+	SuggestedUsersFarcaster *UsersConnection `json:"suggestedUsersFarcaster"`
 }
 
 func (Viewer) IsNode()          {}
@@ -3139,6 +3708,12 @@ type ViewerGallery struct {
 
 func (ViewerGallery) IsViewerGalleryByIDPayloadOrError() {}
 
+type VoteOnPollPayload struct {
+	Poll *PostPoll `json:"poll"`
+}
+
+func (VoteOnPollPayload) IsVoteOnPollPayloadOrError() {}
+
 type Wallet struct {
 	Dbid         persist.DBID          `json:"dbid"`
 	ChainAddress *persist.ChainAddress `json:"chainAddress"`
@@ -3165,6 +3740,92 @@ type TopCollectionsForCommunityInput struct {
 	ChainAddress *persist.ChainAddress `json:"chainAddress"`
 }
 
+// How comments are ordered. Comments are sorted oldest first when no sort is given.
+type CommentSort string
+
+const (
+	// Most admired first
+	CommentSortTop    CommentSort = "TOP"
+	CommentSortNewest CommentSort = "NEWEST"
+	CommentSortOldest CommentSort = "OLDEST"
+)
+
+var AllCommentSort = []CommentSort{
+	CommentSortTop,
+	CommentSortNewest,
+	CommentSortOldest,
+}
+
+func (e CommentSort) IsValid() bool {
+	switch e {
+	case CommentSortTop, CommentSortNewest, CommentSortOldest:
+		return true
+	}
+	return false
+}
+
+func (e CommentSort) String() string {
+	return string(e)
+}
+
+func (e *CommentSort) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommentSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CommentSort", str)
+	}
+	return nil
+}
+
+func (e CommentSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CommunityTokensSort string
+
+const (
+	CommunityTokensSortRecent CommunityTokensSort = "Recent"
+	CommunityTokensSortRarity CommunityTokensSort = "Rarity"
+)
+
+var AllCommunityTokensSort = []CommunityTokensSort{
+	CommunityTokensSortRecent,
+	CommunityTokensSortRarity,
+}
+
+func (e CommunityTokensSort) IsValid() bool {
+	switch e {
+	case CommunityTokensSortRecent, CommunityTokensSortRarity:
+		return true
+	}
+	return false
+}
+
+func (e CommunityTokensSort) String() string {
+	return string(e)
+}
+
+func (e *CommunityTokensSort) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommunityTokensSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CommunityTokensSort", str)
+	}
+	return nil
+}
+
+func (e CommunityTokensSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type EmailUnsubscriptionType string
 
 const (
@@ -3337,6 +3998,49 @@ func (e MerchType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ModerationLabel string
+
+const (
+	ModerationLabelSafe      ModerationLabel = "Safe"
+	ModerationLabelNsfw      ModerationLabel = "NSFW"
+	ModerationLabelMalicious ModerationLabel = "Malicious"
+)
+
+var AllModerationLabel = []ModerationLabel{
+	ModerationLabelSafe,
+	ModerationLabelNsfw,
+	ModerationLabelMalicious,
+}
+
+func (e ModerationLabel) IsValid() bool {
+	switch e {
+	case ModerationLabelSafe, ModerationLabelNsfw, ModerationLabelMalicious:
+		return true
+	}
+	return false
+}
+
+func (e ModerationLabel) String() string {
+	return string(e)
+}
+
+func (e *ModerationLabel) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModerationLabel(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModerationLabel", str)
+	}
+	return nil
+}
+
+func (e ModerationLabel) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type Platform string
 
 const (
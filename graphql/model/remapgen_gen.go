@@ -118,6 +118,11 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"CreatePostDraftPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(CreatePostDraftPayloadOrError)
+		return obj, ok
+	},
+
 	"CreateUserPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(CreateUserPayloadOrError)
 		return obj, ok
@@ -128,11 +133,26 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"DeleteContractRefreshPolicyPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(DeleteContractRefreshPolicyPayloadOrError)
+		return obj, ok
+	},
+
+	"DeleteCustomMetadataHandlerPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(DeleteCustomMetadataHandlerPayloadOrError)
+		return obj, ok
+	},
+
 	"DeleteGalleryPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(DeleteGalleryPayloadOrError)
 		return obj, ok
 	},
 
+	"DeletePostDraftPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(DeletePostDraftPayloadOrError)
+		return obj, ok
+	},
+
 	"DeletePostPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(DeletePostPayloadOrError)
 		return obj, ok
@@ -143,6 +163,11 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"EditPostPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(EditPostPayloadOrError)
+		return obj, ok
+	},
+
 	"Error": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(Error)
 		return obj, ok
@@ -173,6 +198,11 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"FollowCommunityPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(FollowCommunityPayloadOrError)
+		return obj, ok
+	},
+
 	"FollowUserPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(FollowUserPayloadOrError)
 		return obj, ok
@@ -228,6 +258,11 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"MarkNotInterestedPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(MarkNotInterestedPayloadOrError)
+		return obj, ok
+	},
+
 	"Media": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(Media)
 		return obj, ok
@@ -263,6 +298,16 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"MuteCommunityPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(MuteCommunityPayloadOrError)
+		return obj, ok
+	},
+
+	"MuteWordPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(MuteWordPayloadOrError)
+		return obj, ok
+	},
+
 	"Node": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(Node)
 		return obj, ok
@@ -283,6 +328,16 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"PinCommentPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(PinCommentPayloadOrError)
+		return obj, ok
+	},
+
+	"PostAttachment": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(PostAttachment)
+		return obj, ok
+	},
+
 	"PostComposerDraftDetailsPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(PostComposerDraftDetailsPayloadOrError)
 		return obj, ok
@@ -313,6 +368,11 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"QuotePostPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(QuotePostPayloadOrError)
+		return obj, ok
+	},
+
 	"RedeemMerchPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(RedeemMerchPayloadOrError)
 		return obj, ok
@@ -368,11 +428,21 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"ReplayTokenProcessingPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(ReplayTokenProcessingPayloadOrError)
+		return obj, ok
+	},
+
 	"ReportPostPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(ReportPostPayloadOrError)
 		return obj, ok
 	},
 
+	"RepostPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(RepostPayloadOrError)
+		return obj, ok
+	},
+
 	"ResendVerificationEmailPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(ResendVerificationEmailPayloadOrError)
 		return obj, ok
@@ -483,11 +553,31 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"UnfollowCommunityPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(UnfollowCommunityPayloadOrError)
+		return obj, ok
+	},
+
 	"UnfollowUserPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(UnfollowUserPayloadOrError)
 		return obj, ok
 	},
 
+	"UnmuteCommunityPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(UnmuteCommunityPayloadOrError)
+		return obj, ok
+	},
+
+	"UnmuteWordPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(UnmuteWordPayloadOrError)
+		return obj, ok
+	},
+
+	"UnpinCommentPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(UnpinCommentPayloadOrError)
+		return obj, ok
+	},
+
 	"UnregisterUserPushTokenPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(UnregisterUserPushTokenPayloadOrError)
 		return obj, ok
@@ -553,6 +643,16 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"UpdateMediaModerationSettingsPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(UpdateMediaModerationSettingsPayloadOrError)
+		return obj, ok
+	},
+
+	"UpdatePostDraftPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(UpdatePostDraftPayloadOrError)
+		return obj, ok
+	},
+
 	"UpdatePrimaryWalletPayloadOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(UpdatePrimaryWalletPayloadOrError)
 		return obj, ok
@@ -583,6 +683,16 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		return obj, ok
 	},
 
+	"UpsertContractRefreshPolicyPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(UpsertContractRefreshPolicyPayloadOrError)
+		return obj, ok
+	},
+
+	"UpsertCustomMetadataHandlerPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(UpsertCustomMetadataHandlerPayloadOrError)
+		return obj, ok
+	},
+
 	"UserByAddressOrError": func(object interface{}) (interface{}, bool) {
 		obj, ok := object.(UserByAddressOrError)
 		return obj, ok
@@ -632,6 +742,11 @@ var typeConversionMap = map[string]func(object interface{}) (objectAsType interf
 		obj, ok := object.(ViewerOrError)
 		return obj, ok
 	},
+
+	"VoteOnPollPayloadOrError": func(object interface{}) (interface{}, bool) {
+		obj, ok := object.(VoteOnPollPayloadOrError)
+		return obj, ok
+	},
 }
 
 func ConvertToModelType(object interface{}, gqlTypeName string) (objectAsType interface{}, ok bool) {
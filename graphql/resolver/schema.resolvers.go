@@ -210,8 +210,8 @@ func (r *commentResolver) Mentions(ctx context.Context, obj *model.Comment) ([]*
 }
 
 // Replies is the resolver for the replies field.
-func (r *commentResolver) Replies(ctx context.Context, obj *model.Comment, before *string, after *string, first *int, last *int) (*model.CommentsConnection, error) {
-	comments, pageInfo, err := publicapi.For(ctx).Interaction.PaginateRepliesByCommentID(ctx, obj.Dbid, before, after, first, last)
+func (r *commentResolver) Replies(ctx context.Context, obj *model.Comment, before *string, after *string, first *int, last *int, sortBy *model.CommentSort) (*model.CommentsConnection, error) {
+	comments, pageInfo, err := publicapi.For(ctx).Interaction.PaginateRepliesByCommentID(ctx, obj.Dbid, sortBy, before, after, first, last)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +228,11 @@ func (r *commentResolver) Replies(ctx context.Context, obj *model.Comment, befor
 	}, nil
 }
 
+// ReplyCount is the resolver for the replyCount field.
+func (r *commentResolver) ReplyCount(ctx context.Context, obj *model.Comment) (*int, error) {
+	return publicapi.For(ctx).Interaction.GetTotalRepliesByCommentID(ctx, obj.Dbid)
+}
+
 // Source is the resolver for the source field.
 func (r *commentResolver) Source(ctx context.Context, obj *model.Comment) (model.CommentSource, error) {
 	if obj.PostID != nil {
@@ -368,8 +373,8 @@ func (r *communityResolver) Holders(ctx context.Context, obj *model.Community, b
 }
 
 // Tokens is the resolver for the tokens field.
-func (r *communityResolver) Tokens(ctx context.Context, obj *model.Community, before *string, after *string, first *int, last *int) (*model.TokensConnection, error) {
-	return resolveCommunityTokensByCommunityID(ctx, obj.Dbid, before, after, first, last)
+func (r *communityResolver) Tokens(ctx context.Context, obj *model.Community, before *string, after *string, first *int, last *int, tokensWithTraits []*model.TraitInput, sortBy *model.CommunityTokensSort) (*model.TokensConnection, error) {
+	return resolveCommunityTokensByCommunityID(ctx, obj.Dbid, tokensWithTraits, sortBy, before, after, first, last)
 }
 
 // Posts is the resolver for the posts field.
@@ -377,6 +382,20 @@ func (r *communityResolver) Posts(ctx context.Context, obj *model.Community, bef
 	return resolveCommunityPostsByCommunityID(ctx, obj.Dbid, before, after, first, last)
 }
 
+// Traits is the resolver for the traits field.
+func (r *communityResolver) Traits(ctx context.Context, obj *model.Community) ([]*model.CommunityTrait, error) {
+	return resolveCommunityTraitsByCommunityID(ctx, obj.Dbid)
+}
+
+// ViewerIsFollowing is the resolver for the viewerIsFollowing field.
+func (r *communityResolver) ViewerIsFollowing(ctx context.Context, obj *model.Community) (*bool, error) {
+	following, err := publicapi.For(ctx).Community.IsViewerFollowingCommunity(ctx, obj.Dbid)
+	if err != nil {
+		return nil, err
+	}
+	return &following, nil
+}
+
 // TokensForFrame is the resolver for the tokensForFrame field.
 func (r *communityResolver) TokensForFrame(ctx context.Context, obj *model.Community, limit int) ([]*model.Token, error) {
 	tokens, err := publicapi.For(ctx).Community.GetFrameTokensByCommunityID(ctx, obj.Dbid, int32(limit))
@@ -462,7 +481,7 @@ func (r *communityResolver) Creator(ctx context.Context, obj *model.Community) (
 
 // TokensInCommunity is the resolver for the tokensInCommunity field.
 func (r *communityResolver) TokensInCommunity(ctx context.Context, obj *model.Community, before *string, after *string, first *int, last *int, onlyGalleryUsers *bool) (*model.TokensConnection, error) {
-	return resolveCommunityTokensByCommunityID(ctx, obj.Dbid, before, after, first, last)
+	return resolveCommunityTokensByCommunityID(ctx, obj.Dbid, nil, nil, before, after, first, last)
 }
 
 // Owners is the resolver for the owners field.
@@ -532,6 +551,11 @@ func (r *contractCommunityResolver) Contract(ctx context.Context, obj *model.Con
 	return resolveContractByContractID(ctx, obj.HelperContractCommunityData.Community.ContractID)
 }
 
+// Contract is the resolver for the contract field.
+func (r *contractSpamScoreResolver) Contract(ctx context.Context, obj *model.ContractSpamScore) (*model.Contract, error) {
+	return resolveContractByContractID(ctx, obj.HelperContractSpamScoreData.ContractID)
+}
+
 // FeedEvent is the resolver for the feedEvent field.
 func (r *createCollectionPayloadResolver) FeedEvent(ctx context.Context, obj *model.CreateCollectionPayload) (*model.FeedEvent, error) {
 	if obj.FeedEvent.Dbid == "" {
@@ -670,6 +694,16 @@ func (r *followUserPayloadResolver) User(ctx context.Context, obj *model.FollowU
 	return resolveGalleryUserByUserID(ctx, obj.User.Dbid)
 }
 
+// Post is the resolver for the post field.
+func (r *followedCommunityCreatorPostedNotificationResolver) Post(ctx context.Context, obj *model.FollowedCommunityCreatorPostedNotification) (*model.Post, error) {
+	return resolvePostByPostID(ctx, obj.PostID)
+}
+
+// Community is the resolver for the community field.
+func (r *followedCommunityCreatorPostedNotificationResolver) Community(ctx context.Context, obj *model.FollowedCommunityCreatorPostedNotification) (*model.Community, error) {
+	return resolveCommunityByID(ctx, obj.CommunityID)
+}
+
 // TokenPreviews is the resolver for the tokenPreviews field.
 func (r *galleryResolver) TokenPreviews(ctx context.Context, obj *model.Gallery) ([]*model.PreviewURLSet, error) {
 	return resolveTokenPreviewsByGalleryID(ctx, obj.Dbid)
@@ -757,8 +791,8 @@ func (r *galleryUserResolver) SocialAccounts(ctx context.Context, obj *model.Gal
 }
 
 // Tokens is the resolver for the tokens field.
-func (r *galleryUserResolver) Tokens(ctx context.Context, obj *model.GalleryUser, ownershipFilter []persist.TokenOwnershipType) ([]*model.Token, error) {
-	tokens, err := publicapi.For(ctx).Token.GetTokensByUserID(ctx, obj.Dbid, ownershipFilter)
+func (r *galleryUserResolver) Tokens(ctx context.Context, obj *model.GalleryUser, ownershipFilter []persist.TokenOwnershipType, maxSpamScore *float64) ([]*model.Token, error) {
+	tokens, err := publicapi.For(ctx).Token.GetTokensByUserID(ctx, obj.Dbid, ownershipFilter, maxSpamScore)
 
 	if err != nil {
 		return nil, err
@@ -1042,6 +1076,81 @@ func (r *mutationResolver) UnblockUser(ctx context.Context, userID persist.DBID)
 	return model.UnblockUserPayload{UserID: userID}, nil
 }
 
+// MuteWord is the resolver for the muteWord field.
+func (r *mutationResolver) MuteWord(ctx context.Context, word string) (model.MuteWordPayloadOrError, error) {
+	err := publicapi.For(ctx).User.MuteWord(ctx, word)
+	if err != nil {
+		return nil, err
+	}
+	return model.MuteWordPayload{Viewer: resolveViewer(ctx)}, nil
+}
+
+// UnmuteWord is the resolver for the unmuteWord field.
+func (r *mutationResolver) UnmuteWord(ctx context.Context, word string) (model.UnmuteWordPayloadOrError, error) {
+	err := publicapi.For(ctx).User.UnmuteWord(ctx, word)
+	if err != nil {
+		return nil, err
+	}
+	return model.UnmuteWordPayload{Viewer: resolveViewer(ctx)}, nil
+}
+
+// MuteCommunity is the resolver for the muteCommunity field.
+func (r *mutationResolver) MuteCommunity(ctx context.Context, communityID persist.DBID) (model.MuteCommunityPayloadOrError, error) {
+	err := publicapi.For(ctx).User.MuteCommunity(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	return model.MuteCommunityPayload{Viewer: resolveViewer(ctx)}, nil
+}
+
+// UnmuteCommunity is the resolver for the unmuteCommunity field.
+func (r *mutationResolver) UnmuteCommunity(ctx context.Context, communityID persist.DBID) (model.UnmuteCommunityPayloadOrError, error) {
+	err := publicapi.For(ctx).User.UnmuteCommunity(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	return model.UnmuteCommunityPayload{Viewer: resolveViewer(ctx)}, nil
+}
+
+// MarkNotInterested is the resolver for the markNotInterested field.
+func (r *mutationResolver) MarkNotInterested(ctx context.Context, postID persist.DBID) (model.MarkNotInterestedPayloadOrError, error) {
+	err := publicapi.For(ctx).Interaction.MarkNotInterested(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return model.MarkNotInterestedPayload{PostID: postID}, nil
+}
+
+// FollowCommunity is the resolver for the followCommunity field.
+func (r *mutationResolver) FollowCommunity(ctx context.Context, communityID persist.DBID) (model.FollowCommunityPayloadOrError, error) {
+	err := publicapi.For(ctx).Community.FollowCommunity(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	community, err := resolveCommunityByID(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.FollowCommunityPayload{Viewer: resolveViewer(ctx), Community: community}, nil
+}
+
+// UnfollowCommunity is the resolver for the unfollowCommunity field.
+func (r *mutationResolver) UnfollowCommunity(ctx context.Context, communityID persist.DBID) (model.UnfollowCommunityPayloadOrError, error) {
+	err := publicapi.For(ctx).Community.UnfollowCommunity(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	community, err := resolveCommunityByID(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.UnfollowCommunityPayload{Viewer: resolveViewer(ctx), Community: community}, nil
+}
+
 // UpdateGalleryCollections is the resolver for the updateGalleryCollections field.
 func (r *mutationResolver) UpdateGalleryCollections(ctx context.Context, input model.UpdateGalleryCollectionsInput) (model.UpdateGalleryCollectionsPayloadOrError, error) {
 	api := publicapi.For(ctx)
@@ -1082,7 +1191,11 @@ func (r *mutationResolver) CreateCollection(ctx context.Context, input model.Cre
 
 	settings := make(map[persist.DBID]persist.CollectionTokenSettings)
 	for _, tokenSetting := range input.TokenSettings {
-		settings[tokenSetting.TokenID] = persist.CollectionTokenSettings{RenderLive: tokenSetting.RenderLive, HighDefinition: tokenSetting.HighDefinition}
+		settings[tokenSetting.TokenID] = persist.CollectionTokenSettings{
+			RenderLive:       tokenSetting.RenderLive,
+			HighDefinition:   tokenSetting.HighDefinition,
+			ShowEditionCount: util.FromPointer(tokenSetting.ShowEditionCount),
+		}
 	}
 
 	collection, feedEvent, err := api.Collection.CreateCollection(ctx, input.GalleryID, input.Name, input.CollectorsNote, input.Tokens, layout, settings, input.Caption)
@@ -1174,7 +1287,11 @@ func (r *mutationResolver) UpdateCollectionTokens(ctx context.Context, input mod
 
 	settings := make(map[persist.DBID]persist.CollectionTokenSettings)
 	for _, tokenSetting := range input.TokenSettings {
-		settings[tokenSetting.TokenID] = persist.CollectionTokenSettings{RenderLive: tokenSetting.RenderLive, HighDefinition: tokenSetting.HighDefinition}
+		settings[tokenSetting.TokenID] = persist.CollectionTokenSettings{
+			RenderLive:       tokenSetting.RenderLive,
+			HighDefinition:   tokenSetting.HighDefinition,
+			ShowEditionCount: util.FromPointer(tokenSetting.ShowEditionCount),
+		}
 	}
 
 	feedEvent, err := api.Collection.UpdateCollectionTokens(ctx, input.CollectionID, input.Tokens, layout, settings, input.Caption)
@@ -1746,7 +1863,7 @@ func (r *mutationResolver) CommentOnPost(ctx context.Context, postID persist.DBI
 
 // PostTokens is the resolver for the postTokens field.
 func (r *mutationResolver) PostTokens(ctx context.Context, input model.PostTokensInput) (model.PostTokensPayloadOrError, error) {
-	id, err := publicapi.For(ctx).Feed.PostTokens(ctx, input.TokenIds, input.Mentions, input.Caption, input.MintURL)
+	id, err := publicapi.For(ctx).Feed.PostTokens(ctx, input.TokenIds, input.Mentions, input.Caption, input.MintURL, input.Attachment)
 	if err != nil {
 		return nil, err
 	}
@@ -1763,6 +1880,36 @@ func (r *mutationResolver) PostTokens(ctx context.Context, input model.PostToken
 	return output, nil
 }
 
+// CreatePostDraft is the resolver for the createPostDraft field.
+func (r *mutationResolver) CreatePostDraft(ctx context.Context, input model.PostDraftInput) (model.CreatePostDraftPayloadOrError, error) {
+	draft, err := publicapi.For(ctx).Feed.CreatePostDraft(ctx, input.TokenIds, input.Mentions, input.Caption, input.MintURL, input.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.CreatePostDraftPayload{Draft: postDraftToModel(ctx, draft), Viewer: resolveViewer(ctx)}, nil
+}
+
+// UpdatePostDraft is the resolver for the updatePostDraft field.
+func (r *mutationResolver) UpdatePostDraft(ctx context.Context, draftID persist.DBID, input model.PostDraftInput) (model.UpdatePostDraftPayloadOrError, error) {
+	draft, err := publicapi.For(ctx).Feed.UpdatePostDraft(ctx, draftID, input.TokenIds, input.Mentions, input.Caption, input.MintURL, input.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.UpdatePostDraftPayload{Draft: postDraftToModel(ctx, draft), Viewer: resolveViewer(ctx)}, nil
+}
+
+// DeletePostDraft is the resolver for the deletePostDraft field.
+func (r *mutationResolver) DeletePostDraft(ctx context.Context, draftID persist.DBID) (model.DeletePostDraftPayloadOrError, error) {
+	err := publicapi.For(ctx).Feed.DeletePostDraft(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.DeletePostDraftPayload{Viewer: resolveViewer(ctx)}, nil
+}
+
 // ReferralPostToken is the resolver for the referralPostToken field.
 func (r *mutationResolver) ReferralPostToken(ctx context.Context, input model.ReferralPostTokenInput) (model.ReferralPostTokenPayloadOrError, error) {
 	token := persist.TokenIdentifiers{
@@ -1806,6 +1953,98 @@ func (r *mutationResolver) DeletePost(ctx context.Context, postID persist.DBID)
 	return output, nil
 }
 
+// EditPost is the resolver for the editPost field.
+func (r *mutationResolver) EditPost(ctx context.Context, postID persist.DBID, caption *string, mentions []*model.MentionInput) (model.EditPostPayloadOrError, error) {
+	err := publicapi.For(ctx).Feed.EditPost(ctx, postID, caption, mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := resolvePostByPostID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.EditPostPayload{Post: post}, nil
+}
+
+// Repost is the resolver for the repost field.
+func (r *mutationResolver) Repost(ctx context.Context, postID persist.DBID) (model.RepostPayloadOrError, error) {
+	id, err := publicapi.For(ctx).Feed.Repost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	repost, err := resolveRepostByRepostID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.RepostPayload{Repost: repost}, nil
+}
+
+// VoteOnPoll is the resolver for the voteOnPoll field.
+func (r *mutationResolver) VoteOnPoll(ctx context.Context, pollID persist.DBID, optionIndex int) (model.VoteOnPollPayloadOrError, error) {
+	api := publicapi.For(ctx)
+
+	err := api.Feed.VoteOnPoll(ctx, pollID, optionIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := api.Feed.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.VoteOnPollPayload{Poll: postPollToModel(poll)}, nil
+}
+
+// QuotePost is the resolver for the quotePost field.
+func (r *mutationResolver) QuotePost(ctx context.Context, postID persist.DBID, caption string) (model.QuotePostPayloadOrError, error) {
+	id, err := publicapi.For(ctx).Feed.QuotePost(ctx, postID, caption)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := resolvePostByPostID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.QuotePostPayload{Post: post}, nil
+}
+
+// PinComment is the resolver for the pinComment field.
+func (r *mutationResolver) PinComment(ctx context.Context, commentID persist.DBID) (model.PinCommentPayloadOrError, error) {
+	comment, err := publicapi.For(ctx).Interaction.PinComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := resolvePostByPostID(ctx, comment.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.PinCommentPayload{Post: post, Comment: commentToModel(ctx, comment)}, nil
+}
+
+// UnpinComment is the resolver for the unpinComment field.
+func (r *mutationResolver) UnpinComment(ctx context.Context, postID persist.DBID) (model.UnpinCommentPayloadOrError, error) {
+	err := publicapi.For(ctx).Interaction.UnpinComment(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := resolvePostByPostID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.UnpinCommentPayload{Post: post}, nil
+}
+
 // HighlightClaimMint is the resolver for the highlightClaimMint field.
 func (r *mutationResolver) HighlightClaimMint(ctx context.Context, input model.HighlightClaimMintInput) (model.HighlightClaimMintPayloadOrError, error) {
 	claimID, err := publicapi.For(ctx).Mint.ClaimHighlightMint(ctx, input.CollectionID, input.RecipientWalletID)
@@ -2106,6 +2345,18 @@ func (r *mutationResolver) SetPersona(ctx context.Context, persona persist.Perso
 	}, nil
 }
 
+// UpdateMediaModerationSettings is the resolver for the updateMediaModerationSettings field.
+func (r *mutationResolver) UpdateMediaModerationSettings(ctx context.Context, flaggedMediaDisplay persist.FlaggedMediaDisplay) (model.UpdateMediaModerationSettingsPayloadOrError, error) {
+	err := publicapi.For(ctx).User.SetFlaggedMediaDisplay(ctx, flaggedMediaDisplay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UpdateMediaModerationSettingsPayload{
+		Viewer: resolveViewer(ctx),
+	}, nil
+}
+
 // AddRolesToUser is the resolver for the addRolesToUser field.
 func (r *mutationResolver) AddRolesToUser(ctx context.Context, username string, roles []*persist.Role) (model.AddRolesToUserPayloadOrError, error) {
 	user, err := publicapi.For(ctx).Admin.AddRolesToUser(ctx, username, roles)
@@ -2280,6 +2531,56 @@ func (r *mutationResolver) SetCommunityOverrideCreator(ctx context.Context, comm
 	return model.SetCommunityOverrideCreatorPayload{User: userToModel(ctx, *user)}, nil
 }
 
+// ReplayTokenProcessing is the resolver for the replayTokenProcessing field.
+func (r *mutationResolver) ReplayTokenProcessing(ctx context.Context, input model.ReplayTokenProcessingInput) (model.ReplayTokenProcessingPayloadOrError, error) {
+	replayed, err := publicapi.For(ctx).Token.ReplayTokenProcessing(ctx, input.DeadLetterIds, input.ContractID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ReplayTokenProcessingPayload{Replayed: tokenProcessingDeadLettersToModels(replayed)}, nil
+}
+
+// UpsertCustomMetadataHandler is the resolver for the upsertCustomMetadataHandler field.
+func (r *mutationResolver) UpsertCustomMetadataHandler(ctx context.Context, input model.UpsertCustomMetadataHandlerInput) (model.UpsertCustomMetadataHandlerPayloadOrError, error) {
+	handler, err := publicapi.For(ctx).Contract.UpsertCustomMetadataHandler(ctx, input.Chain, input.ContractAddress, input.FunctionSignature, input.Format, input.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.UpsertCustomMetadataHandlerPayload{Handler: customMetadataHandlerToModel(handler)}, nil
+}
+
+// DeleteCustomMetadataHandler is the resolver for the deleteCustomMetadataHandler field.
+func (r *mutationResolver) DeleteCustomMetadataHandler(ctx context.Context, handlerID persist.DBID) (model.DeleteCustomMetadataHandlerPayloadOrError, error) {
+	err := publicapi.For(ctx).Contract.DeleteCustomMetadataHandler(ctx, handlerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.DeleteCustomMetadataHandlerPayload{DeletedID: &model.DeletedNode{Dbid: handlerID}}, nil
+}
+
+// UpsertContractRefreshPolicy is the resolver for the upsertContractRefreshPolicy field.
+func (r *mutationResolver) UpsertContractRefreshPolicy(ctx context.Context, input model.UpsertContractRefreshPolicyInput) (model.UpsertContractRefreshPolicyPayloadOrError, error) {
+	policy, err := publicapi.For(ctx).Contract.UpsertContractRefreshPolicy(ctx, input.ContractID, input.RefreshIntervalSeconds, input.IgnoreMetadataUpdates)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.UpsertContractRefreshPolicyPayload{Policy: contractRefreshPolicyToModel(policy)}, nil
+}
+
+// DeleteContractRefreshPolicy is the resolver for the deleteContractRefreshPolicy field.
+func (r *mutationResolver) DeleteContractRefreshPolicy(ctx context.Context, policyID persist.DBID) (model.DeleteContractRefreshPolicyPayloadOrError, error) {
+	err := publicapi.For(ctx).Contract.DeleteContractRefreshPolicy(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.DeleteContractRefreshPolicyPayload{DeletedID: &model.DeletedNode{Dbid: policyID}}, nil
+}
+
 // UploadPersistedQueries is the resolver for the uploadPersistedQueries field.
 func (r *mutationResolver) UploadPersistedQueries(ctx context.Context, input *model.UploadPersistedQueriesInput) (model.UploadPersistedQueriesPayloadOrError, error) {
 	err := publicapi.For(ctx).APQ.UploadPersistedQueries(ctx, *input.PersistedQueries)
@@ -2401,8 +2702,8 @@ func (r *postResolver) Admires(ctx context.Context, obj *model.Post, before *str
 }
 
 // Comments is the resolver for the comments field.
-func (r *postResolver) Comments(ctx context.Context, obj *model.Post, before *string, after *string, first *int, last *int) (*model.PostCommentsConnection, error) {
-	comments, pageInfo, err := publicapi.For(ctx).Interaction.PaginateCommentsByPostID(ctx, obj.Dbid, before, after, first, last)
+func (r *postResolver) Comments(ctx context.Context, obj *model.Post, before *string, after *string, first *int, last *int, sortBy *model.CommentSort) (*model.PostCommentsConnection, error) {
+	comments, pageInfo, err := publicapi.For(ctx).Interaction.PaginateCommentsByPostID(ctx, obj.Dbid, sortBy, before, after, first, last)
 	if err != nil {
 		return nil, err
 	}
@@ -2420,6 +2721,16 @@ func (r *postResolver) Comments(ctx context.Context, obj *model.Post, before *st
 	}, nil
 }
 
+// PinnedComment is the resolver for the pinnedComment field.
+func (r *postResolver) PinnedComment(ctx context.Context, obj *model.Post) (*model.Comment, error) {
+	comment, err := publicapi.For(ctx).Interaction.GetPinnedCommentByPostID(ctx, obj.Dbid)
+	if err != nil || comment == nil {
+		return nil, err
+	}
+
+	return commentToModel(ctx, *comment), nil
+}
+
 // TotalComments is the resolver for the totalComments field.
 func (r *postResolver) TotalComments(ctx context.Context, obj *model.Post) (*int, error) {
 	return publicapi.For(ctx).Interaction.GetTotalCommentsByPostID(ctx, obj.Dbid)
@@ -2470,6 +2781,42 @@ func (r *postResolver) ViewerAdmire(ctx context.Context, obj *model.Post) (*mode
 	return admireToModel(ctx, *admire), nil
 }
 
+// QuotedPost is the resolver for the quotedPost field.
+func (r *postResolver) QuotedPost(ctx context.Context, obj *model.Post) (*model.Post, error) {
+	if obj.QuotedPostID == "" {
+		return nil, nil
+	}
+	return resolvePostByPostID(ctx, obj.QuotedPostID)
+}
+
+// RepostCount is the resolver for the repostCount field.
+func (r *postResolver) RepostCount(ctx context.Context, obj *model.Post) (*int, error) {
+	return publicapi.For(ctx).Feed.GetRepostCountByPostID(ctx, obj.Dbid)
+}
+
+// Revisions is the resolver for the revisions field.
+func (r *postResolver) Revisions(ctx context.Context, obj *model.Post) ([]*model.PostRevision, error) {
+	revisions, err := publicapi.For(ctx).Feed.GetPostRevisionsByPostID(ctx, obj.Dbid)
+	if err != nil {
+		return nil, err
+	}
+	return util.MapWithoutError(revisions, func(r coredb.PostRevision) *model.PostRevision { return postRevisionToModel(r) }), nil
+}
+
+// Attachment is the resolver for the attachment field.
+func (r *postResolver) Attachment(ctx context.Context, obj *model.Post) (model.PostAttachment, error) {
+	attachment, err := publicapi.For(ctx).Feed.GetPostAttachmentByPostID(ctx, obj.Dbid)
+	if err != nil || attachment == nil {
+		return nil, err
+	}
+	return postAttachmentToModel(attachment), nil
+}
+
+// Community is the resolver for the community field.
+func (r *postCommunityCardResolver) Community(ctx context.Context, obj *model.PostCommunityCard) (*model.Community, error) {
+	return resolveCommunityByID(ctx, obj.CommunityID)
+}
+
 // Media is the resolver for the media field.
 func (r *postComposerDraftDetailsPayloadResolver) Media(ctx context.Context, obj *model.PostComposerDraftDetailsPayload, darkMode *persist.DarkMode) (model.MediaSubtype, error) {
 	highDef := false
@@ -2490,6 +2837,60 @@ func (r *postComposerDraftDetailsPayloadResolver) Community(ctx context.Context,
 	return nil, nil
 }
 
+// Tokens is the resolver for the tokens field.
+func (r *postDraftResolver) Tokens(ctx context.Context, obj *model.PostDraft) ([]*model.Token, error) {
+	result := make([]*model.Token, len(obj.TokenIDs))
+	for i, token := range obj.TokenIDs {
+		t, err := publicapi.For(ctx).Token.GetTokenByIdIgnoreDisplayable(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = tokenToModel(ctx, *t, nil)
+	}
+
+	return result, nil
+}
+
+// ViewerVote is the resolver for the viewerVote field.
+func (r *postPollResolver) ViewerVote(ctx context.Context, obj *model.PostPoll) (*int, error) {
+	return publicapi.For(ctx).Feed.GetViewerPollVote(ctx, obj.Dbid)
+}
+
+// TotalVotes is the resolver for the totalVotes field.
+func (r *postPollResolver) TotalVotes(ctx context.Context, obj *model.PostPoll) (*int, error) {
+	counts, err := publicapi.For(ctx).Feed.GetPollVoteCounts(ctx, obj.Dbid, len(obj.Options))
+	if err != nil {
+		return nil, err
+	}
+
+	var total int
+	for _, c := range counts {
+		total += c
+	}
+
+	return &total, nil
+}
+
+// Results is the resolver for the results field.
+func (r *postPollResolver) Results(ctx context.Context, obj *model.PostPoll) ([]*model.PollOptionResult, error) {
+	// Results are hidden while the poll is open so that they don't sway votes
+	if !obj.IsClosed {
+		return nil, nil
+	}
+
+	counts, err := publicapi.For(ctx).Feed.GetPollVoteCounts(ctx, obj.Dbid, len(obj.Options))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*model.PollOptionResult, len(obj.Options))
+	for i, option := range obj.Options {
+		results[i] = &model.PollOptionResult{Option: option, Votes: counts[i]}
+	}
+
+	return results, nil
+}
+
 // Blurhash is the resolver for the blurhash field.
 func (r *previewURLSetResolver) Blurhash(ctx context.Context, obj *model.PreviewURLSet) (*string, error) {
 	mm := mediamapper.For(ctx)
@@ -2853,6 +3254,63 @@ func (r *queryResolver) UsersByRole(ctx context.Context, role persist.Role, befo
 	}, nil
 }
 
+// TokenProcessingDeadLetters is the resolver for the tokenProcessingDeadLetters field.
+func (r *queryResolver) TokenProcessingDeadLetters(ctx context.Context, contractID *persist.DBID, before *string, after *string, first *int, last *int) (*model.TokenProcessingDeadLettersConnection, error) {
+	deadLetters, pageInfo, err := publicapi.For(ctx).Token.PaginateTokenProcessingDeadLetters(ctx, contractID, before, after, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := util.MapWithoutError(tokenProcessingDeadLettersToModels(deadLetters), func(d *model.TokenProcessingDeadLetter) *model.TokenProcessingDeadLetterEdge {
+		return &model.TokenProcessingDeadLetterEdge{Node: d}
+	})
+
+	return &model.TokenProcessingDeadLettersConnection{
+		Edges:    edges,
+		PageInfo: pageInfoToModel(ctx, pageInfo),
+	}, nil
+}
+
+// CustomMetadataHandlers is the resolver for the customMetadataHandlers field.
+func (r *queryResolver) CustomMetadataHandlers(ctx context.Context) ([]*model.CustomMetadataHandler, error) {
+	handlers, err := publicapi.For(ctx).Contract.GetCustomMetadataHandlers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.MapWithoutError(handlers, customMetadataHandlerToModel), nil
+}
+
+// ContractSpamScore is the resolver for the contractSpamScore field.
+func (r *queryResolver) ContractSpamScore(ctx context.Context, contractID persist.DBID) (*model.ContractSpamScore, error) {
+	score, err := publicapi.For(ctx).Contract.GetContractSpamScore(ctx, contractID)
+	if err != nil || score == nil {
+		return nil, err
+	}
+
+	return contractSpamScoreToModel(*score), nil
+}
+
+// ContractSpamScores is the resolver for the contractSpamScores field.
+func (r *queryResolver) ContractSpamScores(ctx context.Context, minScore float64, limit *int) ([]*model.ContractSpamScore, error) {
+	scores, err := publicapi.For(ctx).Contract.GetContractSpamScoresAboveThreshold(ctx, minScore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.MapWithoutError(scores, contractSpamScoreToModel), nil
+}
+
+// ContractRefreshPolicies is the resolver for the contractRefreshPolicies field.
+func (r *queryResolver) ContractRefreshPolicies(ctx context.Context) ([]*model.ContractRefreshPolicy, error) {
+	policies, err := publicapi.For(ctx).Contract.GetContractRefreshPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.MapWithoutError(policies, contractRefreshPolicyToModel), nil
+}
+
 // SocialConnections is the resolver for the socialConnections field.
 func (r *queryResolver) SocialConnections(ctx context.Context, socialAccountType persist.SocialProvider, excludeAlreadyFollowing *bool, before *string, after *string, first *int, last *int) (*model.SocialConnectionsConnection, error) {
 	connections, pageInfo, err := publicapi.For(ctx).Social.GetConnectionsPaginate(ctx, socialAccountType, before, after, first, last, excludeAlreadyFollowing)
@@ -3016,6 +3474,16 @@ func (r *removeCommentPayloadResolver) Post(ctx context.Context, obj *model.Remo
 	return resolvePostByPostID(ctx, obj.Post.Dbid)
 }
 
+// Reposter is the resolver for the reposter field.
+func (r *repostResolver) Reposter(ctx context.Context, obj *model.Repost) (*model.GalleryUser, error) {
+	return resolveGalleryUserByUserID(ctx, obj.ReposterID)
+}
+
+// Post is the resolver for the post field.
+func (r *repostResolver) Post(ctx context.Context, obj *model.Repost) (*model.Post, error) {
+	return resolvePostByPostID(ctx, obj.PostID)
+}
+
 // Tokens is the resolver for the tokens field.
 func (r *setSpamPreferencePayloadResolver) Tokens(ctx context.Context, obj *model.SetSpamPreferencePayload) ([]*model.Token, error) {
 	tokenIDs := make([]persist.DBID, len(obj.Tokens))
@@ -3171,6 +3639,16 @@ func (r *someoneRepliedToYourCommentNotificationResolver) OriginalComment(ctx co
 	return resolveCommentByCommentID(ctx, obj.NotificationData.OriginalCommentID)
 }
 
+// Post is the resolver for the post field.
+func (r *someoneRepostedYourPostNotificationResolver) Post(ctx context.Context, obj *model.SomeoneRepostedYourPostNotification) (*model.Post, error) {
+	return resolvePostByPostID(ctx, obj.PostID)
+}
+
+// Reposters is the resolver for the reposters field.
+func (r *someoneRepostedYourPostNotificationResolver) Reposters(ctx context.Context, obj *model.SomeoneRepostedYourPostNotification, before *string, after *string, first *int, last *int) (*model.GroupNotificationUsersConnection, error) {
+	return resolveGroupNotificationUsersConnectionByUserIDs(ctx, obj.NotificationData.ReposterIDs, before, after, first, last)
+}
+
 // UserViewers is the resolver for the userViewers field.
 func (r *someoneViewedYourGalleryNotificationResolver) UserViewers(ctx context.Context, obj *model.SomeoneViewedYourGalleryNotification, before *string, after *string, first *int, last *int) (*model.GroupNotificationUsersConnection, error) {
 	return resolveGroupNotificationUsersConnectionByUserIDs(ctx, obj.NotificationData.AuthedViewerIDs, before, after, first, last)
@@ -3268,6 +3746,16 @@ func (r *tokenResolver) ViewerAdmire(ctx context.Context, obj *model.Token) (*mo
 	return admireToModel(ctx, *admire), nil
 }
 
+// Traits is the resolver for the traits field.
+func (r *tokenResolver) Traits(ctx context.Context, obj *model.Token, communityID *persist.DBID) ([]*model.TokenTrait, error) {
+	return resolveTokenTraitsByTokenDefinitionID(ctx, obj.HelperTokenData.Token.TokenDefinitionID, communityID)
+}
+
+// Rarity is the resolver for the rarity field.
+func (r *tokenResolver) Rarity(ctx context.Context, obj *model.Token, communityID *persist.DBID) (*model.TokenRarity, error) {
+	return resolveTokenRarityByTokenDefinitionID(ctx, obj.HelperTokenData.Token.TokenDefinitionID, communityID)
+}
+
 // Media is the resolver for the media field.
 func (r *tokenResolver) Media(ctx context.Context, obj *model.Token, darkMode *persist.DarkMode) (model.MediaSubtype, error) {
 	var highDef bool
@@ -3472,6 +3960,31 @@ func (r *tokenDefinitionResolver) MintURL(ctx context.Context, obj *model.TokenD
 	return &mintURL, nil
 }
 
+// MediaModeration is the resolver for the mediaModeration field.
+func (r *tokenDefinitionResolver) MediaModeration(ctx context.Context, obj *model.TokenDefinition) (*model.MediaModeration, error) {
+	// It's possible that the token is waiting to be processed so there is no media ID yet
+	mediaID := obj.HelperTokenDefinitionData.Definition.TokenMediaID
+	if mediaID == "" {
+		return nil, nil
+	}
+
+	media, err := publicapi.For(ctx).Token.GetMediaByMediaID(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveMediaModeration(ctx, media.Media.Moderation)
+}
+
+// ProcessingState is the resolver for the processingState field.
+func (r *tokenDefinitionResolver) ProcessingState(ctx context.Context, obj *model.TokenDefinition) (*model.TokenProcessingState, error) {
+	state, err := publicapi.For(ctx).Token.GetProcessingStateByTokenDefinitionID(ctx, obj.Dbid)
+	if err != nil {
+		return nil, err
+	}
+	return processingStateToModel(state), nil
+}
+
 // Wallets is the resolver for the wallets field.
 func (r *tokenHolderResolver) Wallets(ctx context.Context, obj *model.TokenHolder) ([]*model.Wallet, error) {
 	wallets := make([]*model.Wallet, 0, len(obj.WalletIds))
@@ -3503,6 +4016,16 @@ func (r *tokenHolderResolver) PreviewTokens(ctx context.Context, obj *model.Toke
 	return previewURLs, nil
 }
 
+// TokenDefinition is the resolver for the tokenDefinition field.
+func (r *tokenProcessingDeadLetterResolver) TokenDefinition(ctx context.Context, obj *model.TokenProcessingDeadLetter) (*model.TokenDefinition, error) {
+	return resolveTokenDefinitionByID(ctx, obj.HelperTokenProcessingDeadLetterData.TokenDefinitionID)
+}
+
+// Contract is the resolver for the contract field.
+func (r *tokenProcessingDeadLetterResolver) Contract(ctx context.Context, obj *model.TokenProcessingDeadLetter) (*model.Contract, error) {
+	return resolveContractByContractID(ctx, obj.HelperTokenProcessingDeadLetterData.ContractID)
+}
+
 // Owner is the resolver for the owner field.
 func (r *tokensAddedToCollectionFeedEventDataResolver) Owner(ctx context.Context, obj *model.TokensAddedToCollectionFeedEventData) (*model.GalleryUser, error) {
 	return resolveGalleryUserByUserID(ctx, obj.Owner.Dbid)
@@ -3606,6 +4129,24 @@ func (r *viewerResolver) Feed(ctx context.Context, obj *model.Viewer, before *st
 	}, nil
 }
 
+// CommunitiesFeed is the resolver for the communitiesFeed field.
+func (r *viewerResolver) CommunitiesFeed(ctx context.Context, obj *model.Viewer, before *string, after *string, first *int, last *int) (*model.FeedConnection, error) {
+	posts, pageInfo, err := publicapi.For(ctx).Feed.CommunitiesFeed(ctx, before, after, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := entitiesToFeedEdges(posts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FeedConnection{
+		Edges:    edges,
+		PageInfo: pageInfoToModel(ctx, pageInfo),
+	}, nil
+}
+
 // Email is the resolver for the email field.
 func (r *viewerResolver) Email(ctx context.Context, obj *model.Viewer) (*model.UserEmail, error) {
 	return resolveViewerEmail(ctx), nil
@@ -3631,6 +4172,38 @@ func (r *viewerResolver) Persona(ctx context.Context, obj *model.Viewer) (*persi
 	return resolveViewerPersonaByUserID(ctx, obj.UserId)
 }
 
+// MediaModerationSettings is the resolver for the mediaModerationSettings field.
+func (r *viewerResolver) MediaModerationSettings(ctx context.Context, obj *model.Viewer) (*model.MediaModerationSettings, error) {
+	display, err := publicapi.For(ctx).User.GetFlaggedMediaDisplay(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &model.MediaModerationSettings{FlaggedMediaDisplay: display}, nil
+}
+
+// MutedWords is the resolver for the mutedWords field.
+func (r *viewerResolver) MutedWords(ctx context.Context, obj *model.Viewer) ([]string, error) {
+	return publicapi.For(ctx).User.GetMutedWords(ctx)
+}
+
+// MutedCommunities is the resolver for the mutedCommunities field.
+func (r *viewerResolver) MutedCommunities(ctx context.Context, obj *model.Viewer) ([]*model.Community, error) {
+	communities, err := publicapi.For(ctx).User.GetMutedCommunities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return util.MapWithoutError(communities, func(c coredb.Community) *model.Community { return communityToModel(ctx, c) }), nil
+}
+
+// PostDrafts is the resolver for the postDrafts field.
+func (r *viewerResolver) PostDrafts(ctx context.Context, obj *model.Viewer) ([]*model.PostDraft, error) {
+	drafts, err := publicapi.For(ctx).Feed.GetViewerPostDrafts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return util.MapWithoutError(drafts, func(d coredb.PostDraft) *model.PostDraft { return postDraftToModel(ctx, &d) }), nil
+}
+
 // SuggestedUsers is the resolver for the suggestedUsers field.
 func (r *viewerResolver) SuggestedUsers(ctx context.Context, obj *model.Viewer, before *string, after *string, first *int, last *int) (*model.UsersConnection, error) {
 	users, pageInfo, err := publicapi.For(ctx).User.GetSuggestedUsers(ctx, before, after, first, last)
@@ -3656,8 +4229,8 @@ func (r *viewerResolver) SuggestedUsersFarcaster(ctx context.Context, obj *model
 }
 
 // Tokens is the resolver for the tokens field.
-func (r *walletResolver) Tokens(ctx context.Context, obj *model.Wallet) ([]*model.Token, error) {
-	return resolveTokensByWalletID(ctx, obj.Dbid)
+func (r *walletResolver) Tokens(ctx context.Context, obj *model.Wallet, maxSpamScore *float64) ([]*model.Token, error) {
+	return resolveTokensByWalletID(ctx, obj.Dbid, maxSpamScore)
 }
 
 // Address is the resolver for the address field.
@@ -3757,6 +4330,11 @@ func (r *Resolver) ContractCommunity() generated.ContractCommunityResolver {
 	return &contractCommunityResolver{r}
 }
 
+// ContractSpamScore returns generated.ContractSpamScoreResolver implementation.
+func (r *Resolver) ContractSpamScore() generated.ContractSpamScoreResolver {
+	return &contractSpamScoreResolver{r}
+}
+
 // CreateCollectionPayload returns generated.CreateCollectionPayloadResolver implementation.
 func (r *Resolver) CreateCollectionPayload() generated.CreateCollectionPayloadResolver {
 	return &createCollectionPayloadResolver{r}
@@ -3778,6 +4356,11 @@ func (r *Resolver) FollowUserPayload() generated.FollowUserPayloadResolver {
 	return &followUserPayloadResolver{r}
 }
 
+// FollowedCommunityCreatorPostedNotification returns generated.FollowedCommunityCreatorPostedNotificationResolver implementation.
+func (r *Resolver) FollowedCommunityCreatorPostedNotification() generated.FollowedCommunityCreatorPostedNotificationResolver {
+	return &followedCommunityCreatorPostedNotificationResolver{r}
+}
+
 // Gallery returns generated.GalleryResolver implementation.
 func (r *Resolver) Gallery() generated.GalleryResolver { return &galleryResolver{r} }
 
@@ -3816,11 +4399,22 @@ func (r *Resolver) OwnerAtBlock() generated.OwnerAtBlockResolver { return &owner
 // Post returns generated.PostResolver implementation.
 func (r *Resolver) Post() generated.PostResolver { return &postResolver{r} }
 
+// PostCommunityCard returns generated.PostCommunityCardResolver implementation.
+func (r *Resolver) PostCommunityCard() generated.PostCommunityCardResolver {
+	return &postCommunityCardResolver{r}
+}
+
 // PostComposerDraftDetailsPayload returns generated.PostComposerDraftDetailsPayloadResolver implementation.
 func (r *Resolver) PostComposerDraftDetailsPayload() generated.PostComposerDraftDetailsPayloadResolver {
 	return &postComposerDraftDetailsPayloadResolver{r}
 }
 
+// PostDraft returns generated.PostDraftResolver implementation.
+func (r *Resolver) PostDraft() generated.PostDraftResolver { return &postDraftResolver{r} }
+
+// PostPoll returns generated.PostPollResolver implementation.
+func (r *Resolver) PostPoll() generated.PostPollResolver { return &postPollResolver{r} }
+
 // PreviewURLSet returns generated.PreviewURLSetResolver implementation.
 func (r *Resolver) PreviewURLSet() generated.PreviewURLSetResolver { return &previewURLSetResolver{r} }
 
@@ -3837,6 +4431,9 @@ func (r *Resolver) RemoveCommentPayload() generated.RemoveCommentPayloadResolver
 	return &removeCommentPayloadResolver{r}
 }
 
+// Repost returns generated.RepostResolver implementation.
+func (r *Resolver) Repost() generated.RepostResolver { return &repostResolver{r} }
+
 // SetSpamPreferencePayload returns generated.SetSpamPreferencePayloadResolver implementation.
 func (r *Resolver) SetSpamPreferencePayload() generated.SetSpamPreferencePayloadResolver {
 	return &setSpamPreferencePayloadResolver{r}
@@ -3910,6 +4507,11 @@ func (r *Resolver) SomeoneRepliedToYourCommentNotification() generated.SomeoneRe
 	return &someoneRepliedToYourCommentNotificationResolver{r}
 }
 
+// SomeoneRepostedYourPostNotification returns generated.SomeoneRepostedYourPostNotificationResolver implementation.
+func (r *Resolver) SomeoneRepostedYourPostNotification() generated.SomeoneRepostedYourPostNotificationResolver {
+	return &someoneRepostedYourPostNotificationResolver{r}
+}
+
 // SomeoneViewedYourGalleryNotification returns generated.SomeoneViewedYourGalleryNotificationResolver implementation.
 func (r *Resolver) SomeoneViewedYourGalleryNotification() generated.SomeoneViewedYourGalleryNotificationResolver {
 	return &someoneViewedYourGalleryNotificationResolver{r}
@@ -3939,6 +4541,11 @@ func (r *Resolver) TokenDefinition() generated.TokenDefinitionResolver {
 // TokenHolder returns generated.TokenHolderResolver implementation.
 func (r *Resolver) TokenHolder() generated.TokenHolderResolver { return &tokenHolderResolver{r} }
 
+// TokenProcessingDeadLetter returns generated.TokenProcessingDeadLetterResolver implementation.
+func (r *Resolver) TokenProcessingDeadLetter() generated.TokenProcessingDeadLetterResolver {
+	return &tokenProcessingDeadLetterResolver{r}
+}
+
 // TokensAddedToCollectionFeedEventData returns generated.TokensAddedToCollectionFeedEventDataResolver implementation.
 func (r *Resolver) TokensAddedToCollectionFeedEventData() generated.TokensAddedToCollectionFeedEventDataResolver {
 	return &tokensAddedToCollectionFeedEventDataResolver{r}
@@ -4000,11 +4607,13 @@ type commentOnFeedEventPayloadResolver struct{ *Resolver }
 type commentOnPostPayloadResolver struct{ *Resolver }
 type communityResolver struct{ *Resolver }
 type contractCommunityResolver struct{ *Resolver }
+type contractSpamScoreResolver struct{ *Resolver }
 type createCollectionPayloadResolver struct{ *Resolver }
 type ensProfileImageResolver struct{ *Resolver }
 type feedEventResolver struct{ *Resolver }
 type followInfoResolver struct{ *Resolver }
 type followUserPayloadResolver struct{ *Resolver }
+type followedCommunityCreatorPostedNotificationResolver struct{ *Resolver }
 type galleryResolver struct{ *Resolver }
 type galleryInfoUpdatedFeedEventDataResolver struct{ *Resolver }
 type galleryUpdatedFeedEventDataResolver struct{ *Resolver }
@@ -4015,11 +4624,15 @@ type mutationResolver struct{ *Resolver }
 type newTokensNotificationResolver struct{ *Resolver }
 type ownerAtBlockResolver struct{ *Resolver }
 type postResolver struct{ *Resolver }
+type postCommunityCardResolver struct{ *Resolver }
 type postComposerDraftDetailsPayloadResolver struct{ *Resolver }
+type postDraftResolver struct{ *Resolver }
+type postPollResolver struct{ *Resolver }
 type previewURLSetResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type removeAdmirePayloadResolver struct{ *Resolver }
 type removeCommentPayloadResolver struct{ *Resolver }
+type repostResolver struct{ *Resolver }
 type setSpamPreferencePayloadResolver struct{ *Resolver }
 type socialConnectionResolver struct{ *Resolver }
 type socialQueriesResolver struct{ *Resolver }
@@ -4035,6 +4648,7 @@ type someoneMentionedYouNotificationResolver struct{ *Resolver }
 type someoneMentionedYourCommunityNotificationResolver struct{ *Resolver }
 type someonePostedYourWorkNotificationResolver struct{ *Resolver }
 type someoneRepliedToYourCommentNotificationResolver struct{ *Resolver }
+type someoneRepostedYourPostNotificationResolver struct{ *Resolver }
 type someoneViewedYourGalleryNotificationResolver struct{ *Resolver }
 type someoneYouFollowOnFarcasterJoinedNotificationResolver struct{ *Resolver }
 type someoneYouFollowPostedTheirFirstPostNotificationResolver struct{ *Resolver }
@@ -4042,6 +4656,7 @@ type subscriptionResolver struct{ *Resolver }
 type tokenResolver struct{ *Resolver }
 type tokenDefinitionResolver struct{ *Resolver }
 type tokenHolderResolver struct{ *Resolver }
+type tokenProcessingDeadLetterResolver struct{ *Resolver }
 type tokensAddedToCollectionFeedEventDataResolver struct{ *Resolver }
 type unfollowUserPayloadResolver struct{ *Resolver }
 type updateCollectionTokensPayloadResolver struct{ *Resolver }
@@ -7,6 +7,7 @@ package graphql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -35,6 +36,7 @@ import (
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/rpc"
 	"github.com/mikeydub/go-gallery/service/socialauth"
+	"github.com/mikeydub/go-gallery/service/traits"
 	"github.com/mikeydub/go-gallery/service/twitter"
 	"github.com/mikeydub/go-gallery/util"
 	"github.com/mikeydub/go-gallery/validate"
@@ -45,6 +47,9 @@ const topActivityImageURL = "https://storage.googleapis.com/gallery-prod-325303.
 const lightModeMP4BackgroundColor = "F2F2F2"
 const darkModeMP4BackgroundColor = "202020"
 
+// flaggedMediaBlurAmount is how much media flagged by moderation is blurred when the viewer has opted to blur it
+const flaggedMediaBlurAmount = 800
+
 var errNoAuthMechanismFound = fmt.Errorf("no auth mechanism found")
 
 var nodeFetcher = model.NodeFetcher{
@@ -63,6 +68,7 @@ var nodeFetcher = model.NodeFetcher{
 	OnDeletedNode:      resolveDeletedNodeByID,
 	OnSocialConnection: resolveSocialConnectionByIdentifiers,
 	OnPost:             resolvePostByPostID,
+	OnRepost:           resolveRepostByRepostID,
 	OnTokenDefinition:  resolveTokenDefinitionByID,
 	OnCommunity:        resolveCommunityByID,
 
@@ -84,10 +90,12 @@ var nodeFetcher = model.NodeFetcher{
 	OnSomeoneRepliedToYourCommentNotification:          fetchNotificationByID[model.SomeoneRepliedToYourCommentNotification],
 	OnSomeoneAdmiredYourTokenNotification:              fetchNotificationByID[model.SomeoneAdmiredYourTokenNotification],
 	OnSomeonePostedYourWorkNotification:                fetchNotificationByID[model.SomeonePostedYourWorkNotification],
+	OnSomeoneRepostedYourPostNotification:              fetchNotificationByID[model.SomeoneRepostedYourPostNotification],
 	OnSomeoneYouFollowPostedTheirFirstPostNotification: fetchNotificationByID[model.SomeoneYouFollowPostedTheirFirstPostNotification],
 	OnYouReceivedTopActivityBadgeNotification:          fetchNotificationByID[model.YouReceivedTopActivityBadgeNotification],
 	OnGalleryAnnouncementNotification:                  fetchNotificationByID[model.GalleryAnnouncementNotification],
 	OnSomeoneYouFollowOnFarcasterJoinedNotification:    fetchNotificationByID[model.SomeoneYouFollowOnFarcasterJoinedNotification],
+	OnFollowedCommunityCreatorPostedNotification:       fetchNotificationByID[model.FollowedCommunityCreatorPostedNotification],
 }
 
 // T any is a notification type, will panic if it is not a notification type
@@ -160,7 +168,9 @@ func errorToGraphqlType(ctx context.Context, err error, gqlTypeName string) (gql
 		mappedErr = model.ErrPushTokenBelongsToAnotherUser{Message: message}
 	case errors.Is(err, publicapi.ErrProfileImageTooManySources) || errors.Is(err, publicapi.ErrProfileImageUnknownSource):
 		mappedErr = model.ErrInvalidInput{Message: message}
-	case errors.Is(err, publicapi.ErrProfileImageNotTokenOwner) || errors.Is(err, publicapi.ErrProfileImageNotWalletOwner):
+	case errors.Is(err, publicapi.ErrPostEditWindowClosed) || errors.Is(err, publicapi.ErrPollClosed) || errors.Is(err, publicapi.ErrAlreadyVotedOnPoll) || errors.Is(err, publicapi.ErrOnlyPinTopLevelPostComments):
+		mappedErr = model.ErrInvalidInput{Message: message}
+	case errors.Is(err, publicapi.ErrProfileImageNotTokenOwner) || errors.Is(err, publicapi.ErrProfileImageNotWalletOwner) || errors.Is(err, publicapi.ErrOnlyEditOwnPost) || errors.Is(err, publicapi.ErrOnlyPinOnOwnPost):
 		mappedErr = model.ErrNotAuthorized{Message: message}
 	case errors.Is(err, auth.ErrEmailUnverified):
 		mappedErr = model.ErrEmailUnverified{Message: message}
@@ -501,8 +511,8 @@ func resolveTokenByTokenID(ctx context.Context, tokenID persist.DBID) (*model.To
 	return tokenToModel(ctx, *token, nil), nil
 }
 
-func resolveTokensByWalletID(ctx context.Context, walletID persist.DBID) ([]*model.Token, error) {
-	tokens, err := publicapi.For(ctx).Token.GetTokensByWalletID(ctx, walletID)
+func resolveTokensByWalletID(ctx context.Context, walletID persist.DBID, maxSpamScore *float64) ([]*model.Token, error) {
+	tokens, err := publicapi.For(ctx).Token.GetTokensByWalletID(ctx, walletID, maxSpamScore)
 
 	if err != nil {
 		return nil, err
@@ -511,8 +521,12 @@ func resolveTokensByWalletID(ctx context.Context, walletID persist.DBID) ([]*mod
 	return tokensToModel(ctx, tokens), nil
 }
 
-func resolveCommunityTokensByCommunityID(ctx context.Context, communityID persist.DBID, before, after *string, first, last *int) (*model.TokensConnection, error) {
-	tokens, pageInfo, err := publicapi.For(ctx).Community.PaginateTokensByCommunityID(ctx, communityID, before, after, first, last)
+func resolveCommunityTokensByCommunityID(ctx context.Context, communityID persist.DBID, withTraits []*model.TraitInput, sortBy *model.CommunityTokensSort, before, after *string, first, last *int) (*model.TokensConnection, error) {
+	traitFilter := util.MapWithoutError(withTraits, func(t *model.TraitInput) traits.Trait {
+		return traits.Trait{TraitType: t.TraitType, Value: t.Value}
+	})
+	sortByRarity := sortBy != nil && *sortBy == model.CommunityTokensSortRarity
+	tokens, pageInfo, err := publicapi.For(ctx).Community.PaginateTokensByCommunityID(ctx, communityID, traitFilter, sortByRarity, before, after, first, last)
 	if err != nil {
 		return nil, err
 	}
@@ -520,6 +534,62 @@ func resolveCommunityTokensByCommunityID(ctx context.Context, communityID persis
 	return &connection, nil
 }
 
+func resolveCommunityTraitsByCommunityID(ctx context.Context, communityID persist.DBID) ([]*model.CommunityTrait, error) {
+	rows, err := publicapi.For(ctx).Community.GetCommunityTraits(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	return util.MapWithoutError(rows, func(r db.GetCommunityTraitsByCommunityIDRow) *model.CommunityTrait {
+		return &model.CommunityTrait{
+			TraitType:  r.TraitType,
+			Value:      r.Value,
+			TokenCount: int(r.TokenCount),
+			Frequency:  traitFrequency(r.TokenCount, r.Total),
+		}
+	}), nil
+}
+
+func resolveTokenTraitsByTokenDefinitionID(ctx context.Context, tokenDefinitionID persist.DBID, communityID *persist.DBID) ([]*model.TokenTrait, error) {
+	rows, err := publicapi.For(ctx).Token.GetTokenTraits(ctx, tokenDefinitionID, util.FromPointer(communityID))
+	if err != nil {
+		return nil, err
+	}
+	return util.MapWithoutError(rows, func(r db.GetTokenDefinitionTraitsBatchRow) *model.TokenTrait {
+		frequency := traitFrequency(r.TokenCount, r.Total)
+		var rarityScore float64
+		if frequency > 0 {
+			rarityScore = 1 / frequency
+		}
+		return &model.TokenTrait{
+			TraitType:   r.TraitType,
+			Value:       r.Value,
+			TokenCount:  int(r.TokenCount),
+			Frequency:   frequency,
+			RarityScore: rarityScore,
+		}
+	}), nil
+}
+
+func resolveTokenRarityByTokenDefinitionID(ctx context.Context, tokenDefinitionID persist.DBID, communityID *persist.DBID) (*model.TokenRarity, error) {
+	rarity, err := publicapi.For(ctx).Token.GetTokenRarity(ctx, tokenDefinitionID, util.FromPointer(communityID))
+	if err != nil || rarity == nil {
+		return nil, err
+	}
+	return &model.TokenRarity{
+		StatisticalScore: rarity.StatisticalScore,
+		NormalizedScore:  rarity.NormalizedScore,
+		Rank:             int(rarity.Rank),
+		Total:            int(rarity.Total),
+	}, nil
+}
+
+func traitFrequency(count, total int32) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
 func tokensToConnection(ctx context.Context, tokens []db.Token, pageInfo publicapi.PageInfo) model.TokensConnection {
 	edges := make([]*model.TokenEdge, len(tokens))
 	for i, token := range tokens {
@@ -827,6 +897,15 @@ func resolvePostByPostID(ctx context.Context, postID persist.DBID) (*model.Post,
 	return postToModel(post), nil
 }
 
+func resolveRepostByRepostID(ctx context.Context, repostID persist.DBID) (*model.Repost, error) {
+	repost, err := publicapi.For(ctx).Feed.GetRepostById(ctx, repostID)
+	if err != nil {
+		return nil, err
+	}
+
+	return repostToModel(repost), nil
+}
+
 func resolveTokenDefinitionByID(ctx context.Context, dbid persist.DBID) (*model.TokenDefinition, error) {
 	td, err := publicapi.For(ctx).Token.GetTokenDefinitionByID(ctx, dbid)
 	if err != nil {
@@ -950,6 +1029,21 @@ func notificationToModel(notif db.Notification) (model.Notification, error) {
 			Post:         nil, // handled by dedicated resolver
 			Admirers:     nil, // handled by dedicated resolver
 		}, nil
+	case persist.ActionUserReposted:
+		return model.SomeoneRepostedYourPostNotification{
+			HelperSomeoneRepostedYourPostNotificationData: model.HelperSomeoneRepostedYourPostNotificationData{
+				OwnerID:          notif.OwnerID,
+				PostID:           notif.PostID,
+				NotificationData: notif.Data,
+			},
+			Dbid:         notif.ID,
+			Seen:         &notif.Seen,
+			CreationTime: &notif.CreatedAt,
+			UpdatedTime:  &notif.LastUpdated,
+			Count:        &amount,
+			Post:         nil, // handled by dedicated resolver
+			Reposters:    nil, // handled by dedicated resolver
+		}, nil
 	case persist.ActionAdmiredToken:
 		return model.SomeoneAdmiredYourTokenNotification{
 			HelperSomeoneAdmiredYourTokenNotificationData: model.HelperSomeoneAdmiredYourTokenNotificationData{
@@ -1112,6 +1206,19 @@ func notificationToModel(notif db.Notification) (model.Notification, error) {
 			Community:    nil, // handled by dedicated resolver
 			Post:         nil, // handled by dedicated resolver
 		}, nil
+	case persist.ActionFollowedCommunityCreatorPosted:
+		return model.FollowedCommunityCreatorPostedNotification{
+			HelperFollowedCommunityCreatorPostedNotificationData: model.HelperFollowedCommunityCreatorPostedNotificationData{
+				CommunityID: notif.CommunityID,
+				PostID:      notif.PostID,
+			},
+			Dbid:         notif.ID,
+			Seen:         &notif.Seen,
+			CreationTime: &notif.CreatedAt,
+			UpdatedTime:  &notif.LastUpdated,
+			Community:    nil, // handled by dedicated resolver
+			Post:         nil, // handled by dedicated resolver
+		}, nil
 	case persist.ActionUserPostedFirstPost:
 		return model.SomeoneYouFollowPostedTheirFirstPostNotification{
 			HelperSomeoneYouFollowPostedTheirFirstPostNotificationData: model.HelperSomeoneYouFollowPostedTheirFirstPostNotificationData{
@@ -1327,11 +1434,26 @@ func resolveTokenSettingsByIDs(ctx context.Context, tokenID, collectionID persis
 		return nil, err
 	}
 
-	if settings, ok := collection.TokenSettings[tokenID]; ok {
-		return &model.CollectionTokenSettings{RenderLive: &settings.RenderLive, HighDefinition: &settings.HighDefinition}, nil
+	settings, ok := collection.TokenSettings[tokenID]
+	if !ok {
+		settings = defaultTokenSettings
+	}
+
+	result := &model.CollectionTokenSettings{
+		RenderLive:       &settings.RenderLive,
+		HighDefinition:   &settings.HighDefinition,
+		ShowEditionCount: &settings.ShowEditionCount,
+	}
+
+	if settings.ShowEditionCount {
+		count, err := publicapi.For(ctx).Collection.GetEditionCount(ctx, tokenID, settings)
+		if err != nil {
+			return nil, err
+		}
+		result.EditionCount = &count
 	}
 
-	return &model.CollectionTokenSettings{RenderLive: &defaultTokenSettings.RenderLive, HighDefinition: &defaultTokenSettings.HighDefinition}, nil
+	return result, nil
 }
 
 func resolveNotificationByID(ctx context.Context, id persist.DBID) (model.Notification, error) {
@@ -1509,6 +1631,8 @@ func feedEntityToModel(event any) (model.FeedEventOrError, error) {
 	switch event := event.(type) {
 	case db.Post:
 		return postToModel(&event), nil
+	case db.Repost:
+		return repostToModel(&event), nil
 	case db.FeedEvent:
 		var groupID sql.NullString
 		if event.GroupID.String != "" {
@@ -1812,10 +1936,16 @@ func postToModel(post *db.Post) *model.Post {
 		captionVal = util.ToPointer(html.UnescapeString(caption.(string)))
 	}
 
+	var editedAt *time.Time
+	if post.EditedAt.Valid {
+		editedAt = &post.EditedAt.Time
+	}
+
 	return &model.Post{
 		HelperPostData: model.HelperPostData{
-			TokenIDs: post.TokenIds,
-			AuthorID: post.ActorID,
+			TokenIDs:     post.TokenIds,
+			AuthorID:     post.ActorID,
+			QuotedPostID: post.QuotedPostID,
 		},
 		Dbid:             post.ID,
 		Tokens:           nil, // handled by dedicated resolver
@@ -1827,6 +1957,109 @@ func postToModel(post *db.Post) *model.Post {
 		ViewerAdmire:     nil, // handled by dedicated resolver
 		IsFirstPost:      post.IsFirstPost,
 		UserAddedMintURL: &post.UserMintUrl.String,
+		QuotedPost:       nil, // handled by dedicated resolver
+		RepostCount:      nil, // handled by dedicated resolver
+		EditedAt:         editedAt,
+		Revisions:        nil, // handled by dedicated resolver
+	}
+}
+
+func postAttachmentToModel(attachment *db.PostAttachment) model.PostAttachment {
+	switch persist.PostAttachmentType(attachment.AttachmentType) {
+	case persist.PostAttachmentTypePoll:
+		return postPollToModel(attachment)
+	case persist.PostAttachmentTypeLink:
+		return &model.PostLink{
+			URL:         attachment.LinkUrl.String,
+			Title:       util.StringToPointerIfNotEmpty(attachment.LinkTitle.String),
+			Description: util.StringToPointerIfNotEmpty(attachment.LinkDescription.String),
+			ImageURL:    util.StringToPointerIfNotEmpty(attachment.LinkImageUrl.String),
+			SiteName:    util.StringToPointerIfNotEmpty(attachment.LinkSiteName.String),
+		}
+	case persist.PostAttachmentTypeCommunity:
+		return &model.PostCommunityCard{
+			HelperPostCommunityCardData: model.HelperPostCommunityCardData{CommunityID: attachment.CommunityID},
+			Community:                   nil, // handled by dedicated resolver
+		}
+	default:
+		panic(fmt.Sprintf("unknown post attachment type: %d", attachment.AttachmentType))
+	}
+}
+
+func postPollToModel(poll *db.PostAttachment) *model.PostPoll {
+	return &model.PostPoll{
+		Dbid:       poll.ID,
+		Options:    poll.PollOptions,
+		ClosesAt:   poll.PollClosesAt.Time,
+		IsClosed:   !time.Now().Before(poll.PollClosesAt.Time),
+		ViewerVote: nil, // handled by dedicated resolver
+		TotalVotes: nil, // handled by dedicated resolver
+		Results:    nil, // handled by dedicated resolver
+	}
+}
+
+func postRevisionToModel(revision db.PostRevision) *model.PostRevision {
+	var caption *string
+	if revision.Caption.Valid {
+		caption = util.ToPointer(html.UnescapeString(revision.Caption.String))
+	}
+
+	return &model.PostRevision{
+		Caption:      caption,
+		CreationTime: &revision.WrittenAt,
+		ReplacedAt:   &revision.CreatedAt,
+	}
+}
+
+func postDraftToModel(ctx context.Context, draft *db.PostDraft) *model.PostDraft {
+	mentions := make([]*model.Mention, len(draft.Mentions))
+	for i, m := range draft.Mentions {
+		mention := db.Mention{
+			UserID:      util.GetOptionalValue(m.UserID, ""),
+			CommunityID: util.GetOptionalValue(m.CommunityID, ""),
+		}
+		if m.Start != nil && m.Length != nil {
+			mention.Start = sql.NullInt32{Int32: *m.Start, Valid: true}
+			mention.Length = sql.NullInt32{Int32: *m.Length, Valid: true}
+		}
+		mentions[i] = mentionToModel(ctx, mention)
+	}
+
+	var caption *string
+	if draft.Caption.Valid {
+		caption = util.ToPointer(html.UnescapeString(draft.Caption.String))
+	}
+
+	var publishAt *time.Time
+	if draft.PublishAt.Valid {
+		publishAt = &draft.PublishAt.Time
+	}
+
+	return &model.PostDraft{
+		HelperPostDraftData: model.HelperPostDraftData{
+			TokenIDs: draft.TokenIds,
+		},
+		Dbid:         draft.ID,
+		CreationTime: &draft.CreatedAt,
+		LastUpdated:  &draft.LastUpdated,
+		Tokens:       nil, // handled by dedicated resolver
+		Caption:      caption,
+		Mentions:     mentions,
+		MintURL:      &draft.UserMintUrl.String,
+		PublishAt:    publishAt,
+	}
+}
+
+func repostToModel(repost *db.Repost) *model.Repost {
+	return &model.Repost{
+		HelperRepostData: model.HelperRepostData{
+			ReposterID: repost.ActorID,
+			PostID:     repost.PostID,
+		},
+		Dbid:         repost.ID,
+		Reposter:     nil, // handled by dedicated resolver
+		CreationTime: &repost.CreatedAt,
+		Post:         nil, // handled by dedicated resolver
 	}
 }
 
@@ -1924,6 +2157,134 @@ func usersToEdges(ctx context.Context, users []db.User) []*model.UserEdge {
 	return edges
 }
 
+func tokenProcessingDeadLettersToModels(deadLetters []db.TokenProcessingDeadLetter) []*model.TokenProcessingDeadLetter {
+	return util.MapWithoutError(deadLetters, tokenProcessingDeadLetterToModel)
+}
+
+func tokenProcessingDeadLetterToModel(d db.TokenProcessingDeadLetter) *model.TokenProcessingDeadLetter {
+	var pipelineMetadata *string
+	if d.ProcessingJobID != "" {
+		if b, err := json.Marshal(d.PipelineMetadata); err == nil {
+			pipelineMetadata = util.ToPointer(string(b))
+		}
+	}
+
+	return &model.TokenProcessingDeadLetter{
+		HelperTokenProcessingDeadLetterData: model.HelperTokenProcessingDeadLetterData{
+			TokenDefinitionID: d.TokenDefinitionID,
+			ContractID:        d.ContractID,
+		},
+		Dbid:             d.ID,
+		Reason:           &d.Reason,
+		Cause:            util.ToPointer(d.ProcessingCause.String()),
+		LastError:        util.ToPointer(d.LastError.String),
+		Attempts:         util.ToPointer(int(d.Attempts)),
+		PipelineMetadata: pipelineMetadata,
+		CreationTime:     &d.CreatedAt,
+		LastUpdated:      &d.LastUpdated,
+	}
+}
+
+func processingStateToModel(state publicapi.ProcessingState) *model.TokenProcessingState {
+	if state.Trace == nil {
+		return &model.TokenProcessingState{InFlight: state.InFlight}
+	}
+
+	trace := make([]*model.PipelineTraceStep, len(state.Trace))
+	for i, step := range state.Trace {
+		// Sort the attributes so that they're returned in a stable order
+		keys := make([]string, 0, len(step.Attributes))
+		for k := range step.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		attributes := make([]*model.PipelineTraceAttribute, len(keys))
+		for j, k := range keys {
+			attributes[j] = &model.PipelineTraceAttribute{Key: k, Value: step.Attributes[k]}
+		}
+
+		attempts := make([]*model.PipelineTraceAttempt, len(step.Attempts))
+		for j, attempt := range step.Attempts {
+			attempts[j] = &model.PipelineTraceAttempt{
+				Source:     attempt.Source,
+				DurationMs: int(attempt.DurationMS),
+				Error:      util.StringToPointerIfNotEmpty(attempt.Error),
+			}
+		}
+
+		trace[i] = &model.PipelineTraceStep{
+			Name:       step.Name,
+			Status:     string(step.Status),
+			DurationMs: int(step.DurationMS),
+			Attributes: attributes,
+			Attempts:   attempts,
+		}
+	}
+
+	return &model.TokenProcessingState{InFlight: state.InFlight, Trace: trace}
+}
+
+func customMetadataHandlerToModel(h db.CustomMetadataHandler) *model.CustomMetadataHandler {
+	var template *string
+	if len(h.Template) > 0 {
+		if b, err := json.Marshal(h.Template); err == nil {
+			template = util.ToPointer(string(b))
+		}
+	}
+
+	return &model.CustomMetadataHandler{
+		Dbid:              h.ID,
+		Chain:             &h.Chain,
+		ContractAddress:   &h.ContractAddress,
+		FunctionSignature: &h.FunctionSignature,
+		Format:            &h.Format,
+		Template:          template,
+		CreationTime:      &h.CreatedAt,
+		LastUpdated:       &h.LastUpdated,
+	}
+}
+
+func contractRefreshPolicyToModel(p db.ContractRefreshPolicy) *model.ContractRefreshPolicy {
+	var interval *int
+	if p.RefreshIntervalSeconds.Valid {
+		interval = util.ToPointer(int(p.RefreshIntervalSeconds.Int32))
+	}
+
+	var nextRefresh, lastRefreshed *time.Time
+	if p.NextRefreshAt.Valid {
+		nextRefresh = &p.NextRefreshAt.Time
+	}
+	if p.LastRefreshedAt.Valid {
+		lastRefreshed = &p.LastRefreshedAt.Time
+	}
+
+	return &model.ContractRefreshPolicy{
+		Dbid:                   p.ID,
+		ContractID:             &p.ContractID,
+		RefreshIntervalSeconds: interval,
+		IgnoreMetadataUpdates:  &p.IgnoreMetadataUpdates,
+		NextRefreshTime:        nextRefresh,
+		LastRefreshedTime:      lastRefreshed,
+		CreationTime:           &p.CreatedAt,
+		LastUpdated:            &p.LastUpdated,
+	}
+}
+
+func contractSpamScoreToModel(s db.ContractSpamScore) *model.ContractSpamScore {
+	reasons := s.Reasons
+	if reasons == nil {
+		reasons = persist.SpamReasons{}
+	}
+
+	return &model.ContractSpamScore{
+		HelperContractSpamScoreData: model.HelperContractSpamScoreData{ContractID: s.ContractID},
+		Score:                       s.Score,
+		Reasons:                     util.MapWithoutError(reasons, func(r persist.SpamReason) *persist.SpamReason { return &r }),
+		LastUpdated:                 &s.LastUpdated,
+	}
+}
+
 // admireToModel converts a db.Admire to a model.Admire
 func admireToModel(ctx context.Context, admire db.Admire) *model.Admire {
 	var data model.HelperAdmireData
@@ -2136,6 +2497,7 @@ func tokenToModel(ctx context.Context, token db.Token, collectionID *persist.DBI
 		LastUpdated:     &token.LastUpdated,
 		CollectorsNote:  util.ToPointer(html.UnescapeString(token.CollectorsNote.String)),
 		Quantity:        util.ToPointer(token.Quantity.String()),
+		OwnedQuantity:   util.ToPointer(token.Quantity.Count()),
 		Owner:           nil, // handled by dedicated resolver
 		OwnerIsHolder:   &token.IsHolderToken,
 		OwnerIsCreator:  &token.IsCreatorToken,
@@ -2342,6 +2704,13 @@ func pageInfoToModel(ctx context.Context, pageInfo publicapi.PageInfo) *model.Pa
 func resolveTokenMedia(ctx context.Context, td db.TokenDefinition, tokenMedia db.TokenMedia, highDef bool, darkMode persist.DarkMode) model.MediaSubtype {
 	// Media is found and is active.
 	if tokenMedia.ID != "" && tokenMedia.Active {
+		display, err := publicapi.For(ctx).Token.GetFlaggedMediaDisplay(ctx, tokenMedia.Media.Moderation)
+		if err != nil {
+			display = persist.FlaggedMediaDisplayBlur
+		}
+		if display != persist.FlaggedMediaDisplayShow {
+			return moderatedMediaToModel(ctx, tokenMedia, display, td.IsFxhash)
+		}
 		return mediaToModel(ctx, tokenMedia, td.FallbackMedia, highDef, td.IsFxhash, darkMode)
 	}
 
@@ -2351,7 +2720,7 @@ func resolveTokenMedia(ctx context.Context, td db.TokenDefinition, tokenMedia db
 		// In the worse case the processing message was dropped and the token never gets handled. To address that,
 		// we compare when the token was created to the current time. If it's longer than the grace period, we assume that the
 		// message was lost and set the media to invalid so it could be refreshed manually.
-		if inFlight, err := publicapi.For(ctx).Token.GetProcessingStateByTokenDefinitionID(ctx, td.ID); !inFlight || err != nil {
+		if state, err := publicapi.For(ctx).Token.GetProcessingStateByTokenDefinitionID(ctx, td.ID); !state.InFlight || err != nil {
 			if time.Since(td.CreatedAt) > time.Duration(1*time.Hour) {
 				tokenMedia.Media.MediaType = persist.MediaTypeInvalid
 			}
@@ -2361,7 +2730,7 @@ func resolveTokenMedia(ctx context.Context, td db.TokenDefinition, tokenMedia db
 
 	// If the media isn't valid, check if its still up for processing. If so, set the media as syncing.
 	if tokenMedia.Media.MediaType != persist.MediaTypeSyncing && !tokenMedia.Media.MediaType.IsValid() {
-		if inFlight, _ := publicapi.For(ctx).Token.GetProcessingStateByTokenDefinitionID(ctx, td.ID); inFlight {
+		if state, _ := publicapi.For(ctx).Token.GetProcessingStateByTokenDefinitionID(ctx, td.ID); state.InFlight {
 			tokenMedia.Media.MediaType = persist.MediaTypeSyncing
 		}
 	}
@@ -2461,6 +2830,69 @@ func ensProfileImageToModel(ctx context.Context, userID, walletID persist.DBID,
 	}, nil
 }
 
+// moderatedMediaToModel returns media that was flagged by moderation as a blurred still image. If the media
+// should be hidden or there isn't an image to blur, then invalid media without any URLs is returned instead.
+func moderatedMediaToModel(ctx context.Context, tokenMedia db.TokenMedia, display persist.FlaggedMediaDisplay, isFxHash bool) model.MediaSubtype {
+	media := tokenMedia.Media
+
+	url := media.ThumbnailURL.String()
+	if (media.MediaType == persist.MediaTypeImage || media.MediaType == persist.MediaTypeSVG || media.MediaType == persist.MediaTypeGIF) && url == "" {
+		url = rpc.RewriteURIToHTTP(media.MediaURL.String(), isFxHash)
+	}
+
+	if display == persist.FlaggedMediaDisplayHide || url == "" {
+		invalid := persist.MediaTypeInvalid
+		return model.InvalidMedia{MediaType: (*string)(&invalid)}
+	}
+
+	options := []mediamapper.Option{
+		mediamapper.WithBlur(flaggedMediaBlurAmount),
+		mediamapper.WithStaticImage(),
+		mediamapper.WithTimestamp(tokenMedia.LastUpdated),
+	}
+
+	// Don't expose the original URL anywhere in the response
+	blurred := mediamapper.For(ctx).GetLargeImageUrl(url, options...)
+	previews := previewURLs(ctx, url, nil, options...)
+	previews.Raw = &blurred
+
+	image := persist.MediaTypeImage
+	return model.ImageMedia{
+		PreviewURLs:      previews,
+		MediaURL:         &blurred,
+		MediaType:        (*string)(&image),
+		ContentRenderURL: &blurred,
+		Dimensions:       mediaToDimensions(media.Dimensions),
+	}
+}
+
+func resolveMediaModeration(ctx context.Context, moderation *persist.Moderation) (*model.MediaModeration, error) {
+	display, err := publicapi.For(ctx).Token.GetFlaggedMediaDisplay(ctx, moderation)
+	if err != nil {
+		return nil, err
+	}
+
+	if moderation == nil {
+		return &model.MediaModeration{Display: display}, nil
+	}
+
+	var label model.ModerationLabel
+	switch moderation.Label {
+	case persist.ModerationLabelNSFW:
+		label = model.ModerationLabelNsfw
+	case persist.ModerationLabelMalicious:
+		label = model.ModerationLabelMalicious
+	default:
+		label = model.ModerationLabelSafe
+	}
+
+	return &model.MediaModeration{
+		Label:   &label,
+		Display: display,
+		Reasons: moderation.Reasons,
+	}, nil
+}
+
 func previewURLsFromTokenMedia(ctx context.Context, tokenMedia db.TokenMedia, options ...mediamapper.Option) *model.PreviewURLSet {
 	return previewURLsFromMedia(ctx, tokenMedia.Media, tokenMedia.LastUpdated, options...)
 }
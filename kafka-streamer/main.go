@@ -490,6 +490,11 @@ func parseNumeric(s *string) (pgtype.Numeric, error) {
 	return n, nil
 }
 
+// holdsNone returns true if an owner's quantity is known and isn't positive
+func holdsNone(quantity pgtype.Numeric) bool {
+	return quantity.Status == pgtype.Present && !quantity.NaN && quantity.Int != nil && quantity.Int.Sign() <= 0
+}
+
 func parseNftID(nftID string) (contractAddress persist.Address, tokenID pgtype.Numeric, err error) {
 	// NftID is in the format: chain.contract_address.token_id
 	if strings.TrimSpace(nftID) == "" {
@@ -572,7 +577,9 @@ func parseOwnerMessage(ctx context.Context, deserializer *avro.GenericDeserializ
 
 	var params mirrordb.ProcessEthereumOwnerEntryParams
 
-	if actionType == "delete" {
+	// Owners that have transferred all of their editions of an ERC-1155 token are sent as updates with a quantity
+	// of zero rather than as deletes. They don't own the token anymore, so they're removed just like a delete.
+	if actionType == "delete" || ((actionType == "insert" || actionType == "update") && holdsNone(quantity)) {
 		params = mirrordb.ProcessEthereumOwnerEntryParams{
 			ShouldDelete:       true,
 			SimplehashKafkaKey: key,
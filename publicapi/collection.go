@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/persist/postgres"
 	"github.com/mikeydub/go-gallery/service/redis"
+	"github.com/mikeydub/go-gallery/service/task"
 	"github.com/mikeydub/go-gallery/util"
 	"github.com/mikeydub/go-gallery/validate"
 )
@@ -29,11 +31,12 @@ const (
 var ErrTokensNotOwnedByUser = errors.New("not all tokens are owned by user")
 
 type CollectionAPI struct {
-	repos     *postgres.Repositories
-	queries   *db.Queries
-	loaders   *dataloader.Loaders
-	validator *validator.Validate
-	ethClient *ethclient.Client
+	repos      *postgres.Repositories
+	queries    *db.Queries
+	loaders    *dataloader.Loaders
+	validator  *validator.Validate
+	ethClient  *ethclient.Client
+	taskClient *task.Client
 }
 
 func (api CollectionAPI) GetCollectionById(ctx context.Context, collectionID persist.DBID) (*db.Collection, error) {
@@ -217,6 +220,11 @@ func (api CollectionAPI) CreateCollection(ctx context.Context, galleryID persist
 		return nil, nil, ErrTokensNotOwnedByUser
 	}
 
+	tokens, layout, tokenSettings, err = groupEditions(ctx, api.queries, userID, tokens, layout, tokenSettings, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	collection := persist.CollectionDB{
 		OwnerUserID:    userID,
 		Tokens:         tokens,
@@ -270,11 +278,18 @@ func (api CollectionAPI) DeleteCollection(ctx context.Context, collectionID pers
 		return err
 	}
 
+	collection, err := api.queries.GetCollectionById(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+
 	err = api.repos.CollectionRepository.Delete(ctx, collectionID, userID)
 	if err != nil {
 		return err
 	}
 
+	repinGallery(ctx, api.taskClient, collection.GalleryID)
+
 	return nil
 }
 
@@ -373,6 +388,16 @@ func (api CollectionAPI) UpdateCollectionTokens(ctx context.Context, collectionI
 		return nil, ErrTokensNotOwnedByUser
 	}
 
+	curCol, err := api.queries.GetCollectionById(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, layout, tokenSettings, err = groupEditions(ctx, api.queries, userID, tokens, layout, tokenSettings, curCol.TokenSettings)
+	if err != nil {
+		return nil, err
+	}
+
 	update := persist.CollectionUpdateTokensInput{
 		Tokens:        tokens,
 		Layout:        layout,
@@ -422,6 +447,13 @@ func (api CollectionAPI) UpdateCollectionHidden(ctx context.Context, collectionI
 		return err
 	}
 
+	collection, err := api.queries.GetCollectionById(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+
+	repinGallery(ctx, api.taskClient, collection.GalleryID)
+
 	return nil
 }
 
@@ -498,5 +530,237 @@ func (api CollectionAPI) UpdateCollectionGallery(ctx context.Context, collection
 		return "", err
 	}
 
+	repinGallery(ctx, api.taskClient, curCol.GalleryID)
+	repinGallery(ctx, api.taskClient, galleryID)
+
 	return curCol.GalleryID, nil
 }
+
+// GetEditionCount returns the number of editions a token represents in a collection: its own quantity plus the
+// quantities of any editions grouped into it
+func (api CollectionAPI) GetEditionCount(ctx context.Context, tokenID persist.DBID, settings persist.CollectionTokenSettings) (int, error) {
+	editions, err := api.queries.GetTokenEditionsByTokenIDs(ctx, append([]persist.DBID{tokenID}, settings.GroupedTokenIDs...))
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, edition := range editions {
+		if count += edition.Quantity.Count(); count < 0 || count > math.MaxInt32 {
+			return math.MaxInt32, nil
+		}
+	}
+
+	return count, nil
+}
+
+// groupEditions folds tokens into an earlier token in the same section that shows its edition count, if they're
+// editions of the same piece. Grouped tokens are removed from the collection and recorded in the earlier token's
+// settings, and the layout is shifted to account for the removed tokens.
+//
+// Clients only send the tokens that are displayed, so the editions that were grouped into a token before, given by
+// previous, are put back after it and grouped again. If the token no longer shows its edition count, they're displayed
+// on their own. If the token was removed from the collection, so are they.
+func groupEditions(ctx context.Context, q *db.Queries, ownerID persist.DBID, tokens []persist.DBID, layout persist.TokenLayout, tokenSettings, previous map[persist.DBID]persist.CollectionTokenSettings) ([]persist.DBID, persist.TokenLayout, map[persist.DBID]persist.CollectionTokenSettings, error) {
+	settings := make(map[persist.DBID]persist.CollectionTokenSettings, len(tokenSettings))
+	for id, s := range tokenSettings {
+		// Grouped tokens are only ever set here
+		s.GroupedTokenIDs = nil
+		settings[id] = s
+	}
+
+	inCollection := make(map[persist.DBID]bool, len(tokens))
+	for _, id := range tokens {
+		inCollection[id] = true
+	}
+
+	// The editions that were grouped into each token that's still in the collection
+	groups := make(map[persist.DBID][]persist.DBID)
+	candidates := append([]persist.DBID{}, tokens...)
+	for _, id := range tokens {
+		for _, groupedID := range previous[id].GroupedTokenIDs {
+			if !inCollection[groupedID] {
+				inCollection[groupedID] = true
+				groups[id] = append(groups[id], groupedID)
+				candidates = append(candidates, groupedID)
+			}
+		}
+	}
+
+	var showsCount bool
+	for _, s := range settings {
+		showsCount = showsCount || s.ShowEditionCount
+	}
+
+	if !showsCount && len(groups) == 0 {
+		return tokens, layout, settings, nil
+	}
+
+	editions, err := q.GetTokenEditionsByTokenIDs(ctx, candidates)
+	if err != nil {
+		return nil, persist.TokenLayout{}, nil, err
+	}
+
+	keys := make(map[persist.DBID]editionKey, len(editions))
+	owned := make(map[persist.DBID]bool, len(editions))
+	for _, edition := range editions {
+		owned[edition.ID] = edition.OwnerUserID == ownerID
+		if key, ok := editionKeyOf(edition); ok {
+			keys[edition.ID] = key
+		}
+	}
+
+	// Editions that have since been transferred or deleted aren't put back
+	for id, group := range groups {
+		groups[id] = util.Filter(group, func(groupedID persist.DBID) bool { return owned[groupedID] }, false)
+	}
+
+	tokens, layout = insertAfter(tokens, layout, groups)
+	tokens, layout, settings = groupTokens(tokens, layout, settings, keys)
+
+	return tokens, layout, settings, nil
+}
+
+// insertAfter inserts tokens after the token they're grouped into, and shifts the layout to account for them
+func insertAfter(tokens []persist.DBID, layout persist.TokenLayout, groups map[persist.DBID][]persist.DBID) ([]persist.DBID, persist.TokenLayout) {
+	if len(groups) == 0 {
+		return tokens, layout
+	}
+
+	result := persist.TokenLayout{
+		Sections:      make([]int, len(layout.Sections)),
+		SectionLayout: make([]persist.CollectionSectionLayout, len(layout.SectionLayout)),
+	}
+	inserted := make([]persist.DBID, 0, len(tokens))
+
+	for i, start := range layout.Sections {
+		end := len(tokens)
+		if i+1 < len(layout.Sections) {
+			end = layout.Sections[i+1]
+		}
+
+		result.Sections[i] = len(inserted)
+
+		// The positions, relative to the start of the section, of the tokens that other tokens were inserted after,
+		// and the number inserted after each
+		var after, counts []int
+		for pos, tokenID := range tokens[start:end] {
+			inserted = append(inserted, tokenID)
+			if group := groups[tokenID]; len(group) > 0 {
+				inserted = append(inserted, group...)
+				after = append(after, pos)
+				counts = append(counts, len(group))
+			}
+		}
+
+		if i < len(layout.SectionLayout) {
+			section := layout.SectionLayout[i]
+			whitespace := make([]int, len(section.Whitespace))
+			for j, w := range section.Whitespace {
+				whitespace[j] = w
+				for k, pos := range after {
+					if pos < w {
+						whitespace[j] += counts[k]
+					}
+				}
+			}
+			section.Whitespace = whitespace
+			result.SectionLayout[i] = section
+		}
+	}
+
+	return inserted, result
+}
+
+// groupTokens folds tokens into an earlier token in the same section with the same edition key that shows its edition
+// count, and shifts the layout to account for the removed tokens
+func groupTokens(tokens []persist.DBID, layout persist.TokenLayout, settings map[persist.DBID]persist.CollectionTokenSettings, keys map[persist.DBID]editionKey) ([]persist.DBID, persist.TokenLayout, map[persist.DBID]persist.CollectionTokenSettings) {
+	grouped := persist.TokenLayout{
+		Sections:      make([]int, len(layout.Sections)),
+		SectionLayout: make([]persist.CollectionSectionLayout, len(layout.SectionLayout)),
+	}
+	kept := make([]persist.DBID, 0, len(tokens))
+
+	for i, start := range layout.Sections {
+		end := len(tokens)
+		if i+1 < len(layout.Sections) {
+			end = layout.Sections[i+1]
+		}
+
+		grouped.Sections[i] = len(kept)
+
+		// The positions, relative to the start of the section, of tokens that were grouped
+		var removed []int
+		leaders := make(map[editionKey]persist.DBID)
+
+		for pos, tokenID := range tokens[start:end] {
+			key, ok := keys[tokenID]
+			if !ok {
+				kept = append(kept, tokenID)
+				continue
+			}
+
+			if leaderID, ok := leaders[key]; ok {
+				leader := settings[leaderID]
+				leader.GroupedTokenIDs = append(leader.GroupedTokenIDs, tokenID)
+				settings[leaderID] = leader
+				delete(settings, tokenID)
+				removed = append(removed, pos)
+				continue
+			}
+
+			if settings[tokenID].ShowEditionCount {
+				leaders[key] = tokenID
+			}
+			kept = append(kept, tokenID)
+		}
+
+		if i < len(layout.SectionLayout) {
+			section := layout.SectionLayout[i]
+			whitespace := make([]int, len(section.Whitespace))
+			for j, w := range section.Whitespace {
+				whitespace[j] = w - countBelow(removed, w)
+			}
+			section.Whitespace = whitespace
+			grouped.SectionLayout[i] = section
+		}
+	}
+
+	return kept, grouped, settings
+}
+
+// editionKey identifies the piece that a token is an edition of
+type editionKey struct {
+	contractID   persist.DBID
+	name         string
+	description  string
+	imageURL     string
+	animationURL string
+}
+
+// editionKeyOf returns the piece that a token is an edition of. Tokens without a name or media can't be told apart
+// from unrelated tokens, so they aren't grouped.
+func editionKeyOf(edition db.GetTokenEditionsByTokenIDsRow) (editionKey, bool) {
+	if edition.Name.String == "" || (edition.ImageUrl == "" && edition.AnimationUrl == "") {
+		return editionKey{}, false
+	}
+	return editionKey{
+		contractID:   edition.ContractID,
+		name:         edition.Name.String,
+		description:  edition.Description.String,
+		imageURL:     edition.ImageUrl,
+		animationURL: edition.AnimationUrl,
+	}, true
+}
+
+// countBelow returns the number of sorted positions that are less than n
+func countBelow(positions []int, n int) int {
+	var count int
+	for _, p := range positions {
+		if p >= n {
+			break
+		}
+		count++
+	}
+	return count
+}
@@ -0,0 +1,155 @@
+package publicapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+func TestGroupTokens(t *testing.T) {
+	piece := editionKey{contractID: "contract", name: "Piece", imageURL: "ipfs://piece"}
+	other := editionKey{contractID: "contract", name: "Other", imageURL: "ipfs://other"}
+	keys := map[persist.DBID]editionKey{"a1": piece, "a2": piece, "a3": piece, "b1": other, "b2": other}
+	showCount := persist.CollectionTokenSettings{ShowEditionCount: true}
+
+	t.Run("groups editions into the first token of the section that shows its edition count", func(t *testing.T) {
+		tokens, layout, settings := groupTokens(
+			[]persist.DBID{"a1", "x", "a2", "a3"},
+			persist.TokenLayout{Sections: []int{0}, SectionLayout: []persist.CollectionSectionLayout{{Columns: 3}}},
+			map[persist.DBID]persist.CollectionTokenSettings{"a1": showCount, "a3": {RenderLive: true}},
+			keys,
+		)
+		assert.Equal(t, []persist.DBID{"a1", "x"}, tokens)
+		assert.Equal(t, []int{0}, layout.Sections)
+		assert.Equal(t, []persist.DBID{"a2", "a3"}, settings["a1"].GroupedTokenIDs)
+		assert.NotContains(t, settings, "a3")
+	})
+
+	t.Run("doesn't group tokens that don't show their edition count", func(t *testing.T) {
+		tokens, _, settings := groupTokens(
+			[]persist.DBID{"b1", "b2", "a1", "a2"},
+			persist.TokenLayout{Sections: []int{0}, SectionLayout: []persist.CollectionSectionLayout{{}}},
+			map[persist.DBID]persist.CollectionTokenSettings{"a1": showCount},
+			keys,
+		)
+		assert.Equal(t, []persist.DBID{"b1", "b2", "a1"}, tokens)
+		assert.Empty(t, settings["b1"].GroupedTokenIDs)
+		assert.Equal(t, []persist.DBID{"a2"}, settings["a1"].GroupedTokenIDs)
+	})
+
+	t.Run("doesn't group editions that come before the token that shows the count", func(t *testing.T) {
+		tokens, _, settings := groupTokens(
+			[]persist.DBID{"a1", "a2", "a3"},
+			persist.TokenLayout{Sections: []int{0}, SectionLayout: []persist.CollectionSectionLayout{{}}},
+			map[persist.DBID]persist.CollectionTokenSettings{"a2": showCount},
+			keys,
+		)
+		assert.Equal(t, []persist.DBID{"a1", "a2"}, tokens)
+		assert.Equal(t, []persist.DBID{"a3"}, settings["a2"].GroupedTokenIDs)
+	})
+
+	t.Run("doesn't group tokens without an edition key", func(t *testing.T) {
+		tokens, _, _ := groupTokens(
+			[]persist.DBID{"x", "y"},
+			persist.TokenLayout{Sections: []int{0}, SectionLayout: []persist.CollectionSectionLayout{{}}},
+			map[persist.DBID]persist.CollectionTokenSettings{"x": showCount},
+			keys,
+		)
+		assert.Equal(t, []persist.DBID{"x", "y"}, tokens)
+	})
+
+	t.Run("only groups editions in the same section and shifts the sections after removed tokens", func(t *testing.T) {
+		tokens, layout, settings := groupTokens(
+			[]persist.DBID{"a1", "a2", "b1", "b2", "a3"},
+			persist.TokenLayout{Sections: []int{0, 4}, SectionLayout: []persist.CollectionSectionLayout{{}, {}}},
+			map[persist.DBID]persist.CollectionTokenSettings{"a1": showCount, "b1": showCount},
+			keys,
+		)
+		assert.Equal(t, []persist.DBID{"a1", "b1", "a3"}, tokens)
+		assert.Equal(t, []int{0, 2}, layout.Sections)
+		assert.Equal(t, []persist.DBID{"a2"}, settings["a1"].GroupedTokenIDs)
+		assert.Equal(t, []persist.DBID{"b2"}, settings["b1"].GroupedTokenIDs)
+	})
+
+	t.Run("shifts whitespace after removed tokens", func(t *testing.T) {
+		// Whitespace is before the token at each position: before a2 and before x in the first section, and before
+		// a3 in the second
+		_, layout, _ := groupTokens(
+			[]persist.DBID{"a1", "a2", "b1", "x", "a3", "y"},
+			persist.TokenLayout{
+				Sections: []int{0, 4},
+				SectionLayout: []persist.CollectionSectionLayout{
+					{Columns: 2, Whitespace: []int{1, 3}},
+					{Columns: 1, Whitespace: []int{0, 2}},
+				},
+			},
+			map[persist.DBID]persist.CollectionTokenSettings{"a1": showCount},
+			keys,
+		)
+		assert.Equal(t, []int{0, 3}, layout.Sections)
+		assert.Equal(t, []persist.CollectionSectionLayout{
+			{Columns: 2, Whitespace: []int{1, 2}},
+			{Columns: 1, Whitespace: []int{0, 2}},
+		}, layout.SectionLayout)
+	})
+}
+
+func TestInsertAfter(t *testing.T) {
+	t.Run("inserts grouped tokens after their token and shifts the layout", func(t *testing.T) {
+		tokens, layout := insertAfter(
+			[]persist.DBID{"a1", "x", "b1", "y"},
+			persist.TokenLayout{
+				Sections: []int{0, 2},
+				SectionLayout: []persist.CollectionSectionLayout{
+					{Columns: 2, Whitespace: []int{0, 1, 2}},
+					{Columns: 1, Whitespace: []int{1}},
+				},
+			},
+			map[persist.DBID][]persist.DBID{"a1": {"a2", "a3"}, "b1": {"b2"}},
+		)
+		assert.Equal(t, []persist.DBID{"a1", "a2", "a3", "x", "b1", "b2", "y"}, tokens)
+		assert.Equal(t, []int{0, 4}, layout.Sections)
+		assert.Equal(t, []persist.CollectionSectionLayout{
+			{Columns: 2, Whitespace: []int{0, 3, 4}},
+			{Columns: 1, Whitespace: []int{2}},
+		}, layout.SectionLayout)
+	})
+
+	t.Run("keeps the layout if nothing is inserted", func(t *testing.T) {
+		layout := persist.TokenLayout{Sections: []int{0}, SectionLayout: []persist.CollectionSectionLayout{{Whitespace: []int{1}}}}
+		tokens, actual := insertAfter([]persist.DBID{"a", "b"}, layout, nil)
+		assert.Equal(t, []persist.DBID{"a", "b"}, tokens)
+		assert.Equal(t, layout, actual)
+	})
+}
+
+func TestRegroupEditions(t *testing.T) {
+	piece := editionKey{contractID: "contract", name: "Piece", imageURL: "ipfs://piece"}
+	keys := map[persist.DBID]editionKey{"a1": piece, "a2": piece, "a3": piece}
+	layout := persist.TokenLayout{Sections: []int{0}, SectionLayout: []persist.CollectionSectionLayout{{Columns: 3, Whitespace: []int{1, 2}}}}
+	groups := map[persist.DBID][]persist.DBID{"a1": {"a2", "a3"}}
+
+	t.Run("keeps the groups of a collection that's saved again as it's displayed", func(t *testing.T) {
+		restored, restoredLayout := insertAfter([]persist.DBID{"x", "a1", "y"}, layout, groups)
+		tokens, actualLayout, settings := groupTokens(restored, restoredLayout, map[persist.DBID]persist.CollectionTokenSettings{"a1": {ShowEditionCount: true}}, keys)
+		assert.Equal(t, []persist.DBID{"x", "a1", "y"}, tokens)
+		assert.Equal(t, layout, actualLayout)
+		assert.Equal(t, []persist.DBID{"a2", "a3"}, settings["a1"].GroupedTokenIDs)
+	})
+
+	t.Run("displays grouped editions on their own when the count is turned off", func(t *testing.T) {
+		restored, restoredLayout := insertAfter([]persist.DBID{"x", "a1", "y"}, layout, groups)
+		tokens, actualLayout, settings := groupTokens(restored, restoredLayout, map[persist.DBID]persist.CollectionTokenSettings{}, keys)
+		assert.Equal(t, []persist.DBID{"x", "a1", "a2", "a3", "y"}, tokens)
+		assert.Equal(t, []int{1, 4}, actualLayout.SectionLayout[0].Whitespace)
+		assert.Empty(t, settings["a1"].GroupedTokenIDs)
+	})
+}
+
+func TestCountBelow(t *testing.T) {
+	assert.Equal(t, 0, countBelow(nil, 3))
+	assert.Equal(t, 2, countBelow([]int{0, 2, 3}, 3))
+	assert.Equal(t, 3, countBelow([]int{0, 2, 3}, 4))
+}
@@ -3,10 +3,12 @@ package publicapi
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v4"
 
 	db "github.com/mikeydub/go-gallery/db/gen/coredb"
 	"github.com/mikeydub/go-gallery/graphql/dataloader"
@@ -14,6 +16,7 @@ import (
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/persist/postgres"
 	"github.com/mikeydub/go-gallery/service/task"
+	"github.com/mikeydub/go-gallery/service/traits"
 	"github.com/mikeydub/go-gallery/util"
 	"github.com/mikeydub/go-gallery/validate"
 )
@@ -121,6 +124,57 @@ func (api CommunityAPI) PaginateHoldersByCommunityID(ctx context.Context, commun
 	return paginator.Paginate(before, after, first, last)
 }
 
+// FollowCommunity adds the community's posts to the viewer's communities feed
+func (api CommunityAPI) FollowCommunity(ctx context.Context, communityID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"communityID": validate.WithTag(communityID, "required"),
+	}); err != nil {
+		return err
+	}
+
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.queries.FollowCommunity(ctx, db.FollowCommunityParams{
+		ID:          persist.GenerateID(),
+		UserID:      viewerID,
+		CommunityID: communityID,
+	})
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return persist.ErrCommunityNotFound{ID: communityID}
+	}
+	return err
+}
+
+// UnfollowCommunity removes the community's posts from the viewer's communities feed
+func (api CommunityAPI) UnfollowCommunity(ctx context.Context, communityID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"communityID": validate.WithTag(communityID, "required"),
+	}); err != nil {
+		return err
+	}
+
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return api.queries.UnfollowCommunity(ctx, db.UnfollowCommunityParams{UserID: viewerID, CommunityID: communityID})
+}
+
+// IsViewerFollowingCommunity returns false if the viewer isn't logged in
+func (api CommunityAPI) IsViewerFollowingCommunity(ctx context.Context, communityID persist.DBID) (bool, error) {
+	viewerID, _ := getAuthenticatedUserID(ctx)
+	if viewerID == "" {
+		return false, nil
+	}
+	return api.queries.IsFollowingCommunity(ctx, db.IsFollowingCommunityParams{UserID: viewerID, CommunityID: communityID})
+}
+
 func (api CommunityAPI) PaginatePostsByCommunityID(ctx context.Context, communityID persist.DBID, before, after *string, first, last *int) ([]db.Post, PageInfo, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
@@ -133,9 +187,13 @@ func (api CommunityAPI) PaginatePostsByCommunityID(ctx context.Context, communit
 		return nil, PageInfo{}, err
 	}
 
+	// Posts that the viewer muted are left out
+	viewerID, _ := getAuthenticatedUserID(ctx)
+
 	timeFunc := func(params TimeIDPagingParams) ([]db.Post, error) {
 		return api.loaders.PaginatePostsByCommunityID.Load(db.PaginatePostsByCommunityIDParams{
 			CommunityID:   communityID,
+			ViewerID:      viewerID,
 			Limit:         params.Limit,
 			CurBeforeTime: params.CursorBeforeTime,
 			CurBeforeID:   params.CursorBeforeID,
@@ -146,7 +204,10 @@ func (api CommunityAPI) PaginatePostsByCommunityID(ctx context.Context, communit
 	}
 
 	countFunc := func() (int, error) {
-		total, err := api.queries.CountPostsByCommunityID(ctx, communityID)
+		total, err := api.queries.CountPostsByCommunityID(ctx, db.CountPostsByCommunityIDParams{
+			CommunityID: communityID,
+			ViewerID:    viewerID,
+		})
 		return int(total), err
 	}
 
@@ -163,7 +224,9 @@ func (api CommunityAPI) PaginatePostsByCommunityID(ctx context.Context, communit
 	return paginator.Paginate(before, after, first, last)
 }
 
-func (api CommunityAPI) PaginateTokensByCommunityID(ctx context.Context, communityID persist.DBID, before, after *string, first, last *int) ([]db.Token, PageInfo, error) {
+// PaginateTokensByCommunityID pages through a community's tokens. If withTraits isn't empty, only tokens that have a
+// matching value for each of its trait types are returned. If sortByRarity is true, tokens are sorted rarest first.
+func (api CommunityAPI) PaginateTokensByCommunityID(ctx context.Context, communityID persist.DBID, withTraits []traits.Trait, sortByRarity bool, before, after *string, first, last *int) ([]db.Token, PageInfo, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
 		"communityID": validate.WithTag(communityID, "required"),
@@ -175,6 +238,10 @@ func (api CommunityAPI) PaginateTokensByCommunityID(ctx context.Context, communi
 		return nil, PageInfo{}, err
 	}
 
+	if len(withTraits) > 0 || sortByRarity {
+		return api.paginateTokensByCommunityIDWithTraits(ctx, communityID, withTraits, sortByRarity, before, after, first, last)
+	}
+
 	queryFunc := func(params TimeIDPagingParams) ([]db.Token, error) {
 		results, err := api.loaders.PaginateTokensByCommunityID.Load(db.PaginateTokensByCommunityIDParams{
 			CommunityID:   communityID,
@@ -206,6 +273,65 @@ func (api CommunityAPI) PaginateTokensByCommunityID(ctx context.Context, communi
 	return paginator.Paginate(before, after, first, last)
 }
 
+func (api CommunityAPI) paginateTokensByCommunityIDWithTraits(ctx context.Context, communityID persist.DBID, withTraits []traits.Trait, sortByRarity bool, before, after *string, first, last *int) ([]db.Token, PageInfo, error) {
+	traitTypes := util.MapWithoutError(withTraits, func(t traits.Trait) string { return t.TraitType })
+	traitValues := util.MapWithoutError(withTraits, func(t traits.Trait) string { return t.Value })
+
+	queryFunc := func(params intTimeIDPagingParams) ([]db.PaginateTokensByCommunityIDWithTraitsRow, error) {
+		return api.queries.PaginateTokensByCommunityIDWithTraits(ctx, db.PaginateTokensByCommunityIDWithTraitsParams{
+			CommunityID:   communityID,
+			TraitTypes:    traitTypes,
+			TraitValues:   traitValues,
+			SortByRarity:  sortByRarity,
+			Limit:         params.Limit,
+			CurBeforeInt:  params.CursorBeforeInt,
+			CurBeforeTime: params.CursorBeforeTime,
+			CurBeforeID:   params.CursorBeforeID,
+			CurAfterInt:   params.CursorAfterInt,
+			CurAfterTime:  params.CursorAfterTime,
+			CurAfterID:    params.CursorAfterID,
+			PagingForward: params.PagingForward,
+		})
+	}
+
+	countFunc := func() (int, error) {
+		total, err := api.queries.CountTokensByCommunityIDWithTraits(ctx, db.CountTokensByCommunityIDWithTraitsParams{
+			CommunityID: communityID,
+			TraitTypes:  traitTypes,
+			TraitValues: traitValues,
+		})
+		return int(total), err
+	}
+
+	cursorFunc := func(r db.PaginateTokensByCommunityIDWithTraitsRow) (int64, time.Time, persist.DBID, error) {
+		if !sortByRarity {
+			return 0, r.Token.CreatedAt, r.Token.ID, nil
+		}
+		return int64(r.RarityRank), r.Token.CreatedAt, r.Token.ID, nil
+	}
+
+	paginator := intTimeIDPaginator[db.PaginateTokensByCommunityIDWithTraitsRow]{
+		QueryFunc:  queryFunc,
+		CursorFunc: cursorFunc,
+		CountFunc:  countFunc,
+	}
+
+	results, pageInfo, err := paginator.paginate(before, after, first, last)
+	return util.MapWithoutError(results, func(r db.PaginateTokensByCommunityIDWithTraitsRow) db.Token { return r.Token }), pageInfo, err
+}
+
+// GetCommunityTraits returns the number of a community's tokens with each trait. It's empty if the community hasn't been indexed yet.
+func (api CommunityAPI) GetCommunityTraits(ctx context.Context, communityID persist.DBID) ([]db.GetCommunityTraitsByCommunityIDRow, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"communityID": validate.WithTag(communityID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	return api.queries.GetCommunityTraitsByCommunityID(ctx, communityID)
+}
+
 // GetFrameTokensByCommunityID is temporary and shouldn't be used outside of the TokensForFrame resolver
 func (api CommunityAPI) GetFrameTokensByCommunityID(ctx context.Context, communityID persist.DBID, limit int32) ([]db.Token, error) {
 	// Validate
@@ -3,6 +3,10 @@ package publicapi
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mikeydub/go-gallery/service/persist/postgres"
@@ -10,14 +14,19 @@ import (
 
 	db "github.com/mikeydub/go-gallery/db/gen/coredb"
 	"github.com/mikeydub/go-gallery/service/multichain"
+	"github.com/mikeydub/go-gallery/service/multichain/custom"
 	"github.com/mikeydub/go-gallery/service/task"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v4"
 	"github.com/mikeydub/go-gallery/graphql/dataloader"
 	"github.com/mikeydub/go-gallery/service/persist"
 )
 
+// minContractRefreshInterval is the shortest interval that a contract's tokens can be refreshed on
+const minContractRefreshInterval = time.Hour
+
 type ContractAPI struct {
 	repos              *postgres.Repositories
 	queries            *db.Queries
@@ -301,3 +310,146 @@ func (api ContractAPI) GetPreviewURLsByContractIDandUserID(ctx context.Context,
 		OwnerID:    userID,
 	})
 }
+
+// GetContractSpamScore returns the spam score of a contract and the signals that contributed to it, or nil if the
+// contract hasn't been scored yet
+func (api ContractAPI) GetContractSpamScore(ctx context.Context, contractID persist.DBID) (*db.ContractSpamScore, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"contractID": validate.WithTag(contractID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	score, err := api.queries.GetContractSpamScoreByContractID(ctx, contractID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &score, nil
+}
+
+// GetContractSpamScoresAboveThreshold returns the highest scoring contracts whose spam score is at least minScore
+func (api ContractAPI) GetContractSpamScoresAboveThreshold(ctx context.Context, minScore float64, limit *int) ([]db.ContractSpamScore, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"minScore": validate.WithTag(minScore, "gte=0,lte=1"),
+	}); err != nil {
+		return nil, err
+	}
+
+	l := 100
+	if limit != nil && *limit > 0 && *limit < l {
+		l = *limit
+	}
+
+	return api.queries.GetContractSpamScoresAboveThreshold(ctx, db.GetContractSpamScoresAboveThresholdParams{
+		MinScore: minScore,
+		Limit:    int32(l),
+	})
+}
+
+// GetCustomMetadataHandlers returns the custom metadata handlers that admins have defined
+func (api ContractAPI) GetCustomMetadataHandlers(ctx context.Context) ([]db.CustomMetadataHandler, error) {
+	return api.queries.GetCustomMetadataHandlers(ctx)
+}
+
+// UpsertCustomMetadataHandler creates or replaces the custom metadata handler of a contract. template is a JSON encoded object
+// whose string values are templates that are merged into the metadata that the handler returns.
+func (api ContractAPI) UpsertCustomMetadataHandler(ctx context.Context, chain persist.Chain, address persist.Address, functionSignature string, format persist.CustomMetadataFormat, template *string) (db.CustomMetadataHandler, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"address":           validate.WithTag(address, "required"),
+		"functionSignature": validate.WithTag(functionSignature, "required"),
+	}); err != nil {
+		return db.CustomMetadataHandler{}, err
+	}
+
+	handler := db.CustomMetadataHandler{
+		Chain:             chain,
+		ContractAddress:   persist.Address(chain.NormalizeAddress(address)),
+		FunctionSignature: strings.TrimSpace(functionSignature),
+		Format:            format,
+	}
+
+	if template != nil && *template != "" {
+		if err := json.Unmarshal([]byte(*template), &handler.Template); err != nil {
+			invalid := validate.ErrInvalidInput{}
+			invalid.Append("template", "template must be a JSON object")
+			return db.CustomMetadataHandler{}, invalid
+		}
+	}
+
+	if err := custom.ValidateHandler(handler); err != nil {
+		invalid := validate.ErrInvalidInput{}
+		invalid.Append("input", err.Error())
+		return db.CustomMetadataHandler{}, invalid
+	}
+
+	return api.queries.UpsertCustomMetadataHandler(ctx, db.UpsertCustomMetadataHandlerParams{
+		ID:                persist.GenerateID(),
+		Chain:             handler.Chain,
+		ContractAddress:   handler.ContractAddress,
+		FunctionSignature: handler.FunctionSignature,
+		Format:            handler.Format,
+		Template:          handler.Template,
+	})
+}
+
+// DeleteCustomMetadataHandler deletes a custom metadata handler. Tokens of the contract keep their metadata until they're refreshed.
+func (api ContractAPI) DeleteCustomMetadataHandler(ctx context.Context, handlerID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"handlerID": validate.WithTag(handlerID, "required"),
+	}); err != nil {
+		return err
+	}
+	return api.queries.DeleteCustomMetadataHandler(ctx, handlerID)
+}
+
+// GetContractRefreshPolicies returns the refresh policies that admins have defined
+func (api ContractAPI) GetContractRefreshPolicies(ctx context.Context) ([]db.ContractRefreshPolicy, error) {
+	return api.queries.GetContractRefreshPolicies(ctx)
+}
+
+// UpsertContractRefreshPolicy creates or replaces the refresh policy of a contract. Every token of the contract is refreshed every
+// refreshIntervalSeconds if it's set, and the contract's EIP-4906 metadata updates are ignored if ignoreMetadataUpdates is true.
+func (api ContractAPI) UpsertContractRefreshPolicy(ctx context.Context, contractID persist.DBID, refreshIntervalSeconds *int, ignoreMetadataUpdates bool) (db.ContractRefreshPolicy, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"contractID":             validate.WithTag(contractID, "required"),
+		"refreshIntervalSeconds": validate.WithTag(refreshIntervalSeconds, fmt.Sprintf("omitempty,min=%d", int(minContractRefreshInterval.Seconds()))),
+	}); err != nil {
+		return db.ContractRefreshPolicy{}, err
+	}
+
+	if _, err := api.loaders.GetContractsByIDs.Load(contractID.String()); err != nil {
+		return db.ContractRefreshPolicy{}, err
+	}
+
+	var interval sql.NullInt32
+	if refreshIntervalSeconds != nil {
+		interval = sql.NullInt32{Int32: int32(*refreshIntervalSeconds), Valid: true}
+	}
+
+	return api.queries.UpsertContractRefreshPolicy(ctx, db.UpsertContractRefreshPolicyParams{
+		ID:                     persist.GenerateID(),
+		ContractID:             contractID,
+		RefreshIntervalSeconds: interval,
+		IgnoreMetadataUpdates:  ignoreMetadataUpdates,
+	})
+}
+
+// DeleteContractRefreshPolicy deletes a refresh policy so that the contract is refreshed from its metadata updates only
+func (api ContractAPI) DeleteContractRefreshPolicy(ctx context.Context, policyID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"policyID": validate.WithTag(policyID, "required"),
+	}); err != nil {
+		return err
+	}
+	return api.queries.DeleteContractRefreshPolicy(ctx, policyID)
+}
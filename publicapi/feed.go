@@ -1,16 +1,19 @@
 package publicapi
 
 import (
-	heappkg "container/heap"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"math"
+	"net/url"
 	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v4"
 
 	db "github.com/mikeydub/go-gallery/db/gen/coredb"
 	"github.com/mikeydub/go-gallery/event"
@@ -21,43 +24,34 @@ import (
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/persist/postgres"
 	"github.com/mikeydub/go-gallery/service/recommend"
+	"github.com/mikeydub/go-gallery/service/recommend/feedrank"
 	"github.com/mikeydub/go-gallery/service/recommend/userpref"
 	"github.com/mikeydub/go-gallery/service/redis"
 	sentryutil "github.com/mikeydub/go-gallery/service/sentry"
 	"github.com/mikeydub/go-gallery/service/task"
+	"github.com/mikeydub/go-gallery/service/unfurl"
 	"github.com/mikeydub/go-gallery/util"
 	"github.com/mikeydub/go-gallery/util/retry"
 	"github.com/mikeydub/go-gallery/validate"
 )
 
-const trendingFeedCacheKey = "trending:feedEvents:all"
-
-var feedOpts = struct {
-	FreshnessFactor     float64 // extra weight added to a new post
-	FirstPostFactor     float64 // extra weight added to a first post
-	LookbackWindow      float64 // how far back to look for posts
-	FreshnessWindow     float64 // how long a post is considered new
-	PostHalfLife        float64 // controls the decay rate of posts
-	GalleryPostHalfLife float64 // controls the decay rate of Gallery posts
-	GalleryDecayPeriod  float64 // time it takes for a Gallery post to reach GalleryPostHalfLife from PostHalfLife
-	FetchSize           int     // number of posts to include on the feed
-	StreakThreshold     int     // number of posts before a streak is counted
-	StreakFactor        float64 // factor that controls the impact of each extra post
-	PostSpan            float64 // the max time between posts allowed for a post to be part of the same group
-}{
-	FreshnessFactor:     1.5,
-	FirstPostFactor:     2.0,
-	LookbackWindow:      time.Duration(4 * 24 * time.Hour).Minutes(),
-	FreshnessWindow:     time.Duration(3 * time.Hour).Minutes(),
-	PostHalfLife:        time.Duration(6 * time.Hour).Minutes(),
-	GalleryPostHalfLife: time.Duration(10 * time.Hour).Minutes(),
-	GalleryDecayPeriod:  time.Duration(4 * 24 * time.Hour).Minutes(),
-	FetchSize:           128,
-	StreakThreshold:     2,
-	StreakFactor:        1.8,
-	PostSpan:            time.Duration(time.Minute).Seconds(),
+// trendingFeedCacheKey is the key of a ranker's trending feed. Each ranker in the trending experiment has its own feed.
+func trendingFeedCacheKey(ranker feedrank.FeedRanker) string {
+	return "trending:feedEvents:all:" + ranker.Name()
 }
 
+// trendingFeedCacheKeys are the keys of every ranker's trending feed
+func trendingFeedCacheKeys() []string {
+	return util.MapWithoutError(feedrank.TrendingExperiment.Variants, func(v feedrank.Variant) string { return trendingFeedCacheKey(v.Ranker) })
+}
+
+const (
+	// communitiesFeedLookback is how far back the communities feed looks for posts
+	communitiesFeedLookback = 30 * 24 * time.Hour
+	// communitiesFeedSize is the most posts included on the communities feed
+	communitiesFeedSize = 256
+)
+
 type FeedAPI struct {
 	repos              *postgres.Repositories
 	queries            *db.Queries
@@ -80,7 +74,7 @@ func (api FeedAPI) BanUser(ctx context.Context, userId persist.DBID, reason pers
 		return err
 	}
 	// Re-calculate trending feed
-	return api.cache.Client().Del(ctx, trendingFeedCacheKey).Err()
+	return api.cache.Client().Del(ctx, trendingFeedCacheKeys()...).Err()
 }
 
 func (api FeedAPI) UnbanUser(ctx context.Context, userId persist.DBID) error {
@@ -96,7 +90,7 @@ func (api FeedAPI) UnbanUser(ctx context.Context, userId persist.DBID) error {
 		return err
 	}
 	// Re-calculate trending feed
-	return api.cache.Client().Del(ctx, trendingFeedCacheKey).Err()
+	return api.cache.Client().Del(ctx, trendingFeedCacheKeys()...).Err()
 }
 
 func (api FeedAPI) GetFeedEventById(ctx context.Context, feedEventID persist.DBID) (*db.FeedEvent, error) {
@@ -131,7 +125,7 @@ func (api FeedAPI) GetPostById(ctx context.Context, postID persist.DBID) (*db.Po
 	return &post, nil
 }
 
-func (api FeedAPI) PostTokens(ctx context.Context, tokenIDs []persist.DBID, mentions []*model.MentionInput, caption, mintURL *string) (persist.DBID, error) {
+func (api FeedAPI) PostTokens(ctx context.Context, tokenIDs []persist.DBID, mentions []*model.MentionInput, caption, mintURL *string, attachment *model.PostAttachmentInput) (persist.DBID, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
 		"tokenIDs": validate.WithTag(tokenIDs, "required"),
@@ -146,6 +140,17 @@ func (api FeedAPI) PostTokens(ctx context.Context, tokenIDs []persist.DBID, ment
 		return "", err
 	}
 
+	attachmentParams, err := api.preparePostAttachment(ctx, attachment)
+	if err != nil {
+		return "", err
+	}
+
+	return api.postTokens(ctx, actorID, tokenIDs, mentions, caption, mintURL, attachmentParams)
+}
+
+// postTokens creates a post on behalf of actorID. It's shared by posts made directly and scheduled drafts that are
+// published later, so callers are responsible for validating their input.
+func (api FeedAPI) postTokens(ctx context.Context, actorID persist.DBID, tokenIDs []persist.DBID, mentions []*model.MentionInput, caption, mintURL *string, attachment *db.InsertPostAttachmentParams) (persist.DBID, error) {
 	contracts, err := api.queries.GetContractsByTokenIDs(ctx, tokenIDs)
 	if err != nil {
 		return "", err
@@ -183,43 +188,23 @@ func (api FeedAPI) PostTokens(ctx context.Context, tokenIDs []persist.DBID, ment
 		return "", err
 	}
 
+	if attachment != nil {
+		attachment.ID = persist.GenerateID()
+		attachment.PostID = postID
+		err = q.InsertPostAttachment(ctx, *attachment)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	dbMentions, err := insertMentionsForPost(ctx, mentions, postID, q)
 	if err != nil {
 		return "", err
 	}
-	if len(dbMentions) > 0 {
-		for _, mention := range dbMentions {
-			switch {
-			case mention.UserID != "":
-				err = event.Dispatch(ctx, db.Event{
-					ActorID:        persist.DBIDToNullStr(actorID),
-					ResourceTypeID: persist.ResourceTypeUser,
-					SubjectID:      mention.UserID,
-					PostID:         postID,
-					UserID:         mention.UserID,
-					Action:         persist.ActionMentionUser,
-					MentionID:      mention.ID,
-				})
-				if err != nil {
-					return "", err
-				}
-			case mention.CommunityID != "":
-				err = event.Dispatch(ctx, db.Event{
-					ActorID:        persist.DBIDToNullStr(actorID),
-					ResourceTypeID: persist.ResourceTypeCommunity,
-					SubjectID:      mention.CommunityID,
-					PostID:         postID,
-					CommunityID:    mention.CommunityID,
-					Action:         persist.ActionMentionCommunity,
-					MentionID:      mention.ID,
-				})
-				if err != nil {
-					return "", err
-				}
-			default:
-				return "", fmt.Errorf("invalid mention type: %+v", mention)
-			}
-		}
+
+	err = dispatchPostMentionEvents(ctx, actorID, postID, dbMentions)
+	if err != nil {
+		return "", err
 	}
 
 	err = tx.Commit(ctx)
@@ -243,6 +228,218 @@ func (api FeedAPI) PostTokens(ctx context.Context, tokenIDs []persist.DBID, ment
 	return postID, nil
 }
 
+const (
+	// publishDraftsBatchSize is the most scheduled drafts that are published in a single run of the scheduler
+	publishDraftsBatchSize = 100
+	// publishDraftsLease is how long a claimed draft is left to its run of the scheduler before another run can claim it
+	publishDraftsLease = 10 * time.Minute
+)
+
+func (api FeedAPI) GetViewerPostDrafts(ctx context.Context) ([]db.PostDraft, error) {
+	userID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.queries.GetPostDraftsByActorID(ctx, userID)
+}
+
+// CreatePostDraft saves a post for later. If publishAt is set, the draft is published at that time, otherwise it's
+// kept until it's posted or deleted.
+func (api FeedAPI) CreatePostDraft(ctx context.Context, tokenIDs []persist.DBID, mentions []*model.MentionInput, caption, mintURL *string, publishAt *time.Time) (*db.PostDraft, error) {
+	if err := api.validatePostDraft(tokenIDs, caption, mintURL, publishAt); err != nil {
+		return nil, err
+	}
+
+	actorID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	draftMentions, err := mentionInputsToDraftMentions(ctx, mentions, api.queries)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := api.queries.InsertPostDraft(ctx, db.InsertPostDraftParams{
+		ID:          persist.GenerateID(),
+		ActorID:     actorID,
+		TokenIds:    tokenIDs,
+		Caption:     util.ToNullStringEmptyNull(util.GetOptionalValue(caption, "")),
+		Mentions:    draftMentions,
+		UserMintUrl: util.ToNullStringEmptyNull(util.GetOptionalValue(mintURL, "")),
+		PublishAt:   util.ToNullTime(publishAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+// UpdatePostDraft replaces the contents of a draft that hasn't been published yet. A draft that's being published
+// can't be updated, so it isn't found.
+func (api FeedAPI) UpdatePostDraft(ctx context.Context, draftID persist.DBID, tokenIDs []persist.DBID, mentions []*model.MentionInput, caption, mintURL *string, publishAt *time.Time) (*db.PostDraft, error) {
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"draftID": validate.WithTag(draftID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := api.validatePostDraft(tokenIDs, caption, mintURL, publishAt); err != nil {
+		return nil, err
+	}
+
+	actorID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	draftMentions, err := mentionInputsToDraftMentions(ctx, mentions, api.queries)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := api.queries.UpdatePostDraft(ctx, db.UpdatePostDraftParams{
+		ID:            draftID,
+		ActorID:       actorID,
+		TokenIds:      tokenIDs,
+		Caption:       util.ToNullStringEmptyNull(util.GetOptionalValue(caption, "")),
+		Mentions:      draftMentions,
+		UserMintUrl:   util.ToNullStringEmptyNull(util.GetOptionalValue(mintURL, "")),
+		PublishAt:     util.ToNullTime(publishAt),
+		ClaimedBefore: sql.NullTime{Time: time.Now().Add(-publishDraftsLease), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, persist.ErrPostDraftNotFoundByID{ID: draftID}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+// DeletePostDraft deletes a draft that hasn't been published yet. Like UpdatePostDraft, a draft that's being
+// published can't be deleted, so it isn't found.
+func (api FeedAPI) DeletePostDraft(ctx context.Context, draftID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"draftID": validate.WithTag(draftID, "required"),
+	}); err != nil {
+		return err
+	}
+
+	actorID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := api.queries.DeletePostDraft(ctx, db.DeletePostDraftParams{
+		ID:            draftID,
+		ActorID:       actorID,
+		ClaimedBefore: sql.NullTime{Time: time.Now().Add(-publishDraftsLease), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return persist.ErrPostDraftNotFoundByID{ID: draftID}
+	}
+
+	return nil
+}
+
+// PublishDuePostDrafts posts every scheduled draft whose publish time has passed and returns how many were posted.
+// Drafts that can't be posted are unscheduled so that their authors can fix them.
+func (api FeedAPI) PublishDuePostDrafts(ctx context.Context) (int, error) {
+	p := draftPublisher{
+		ClaimFunc: func(ctx context.Context) ([]db.PostDraft, error) {
+			return api.queries.ClaimDuePostDrafts(ctx, db.ClaimDuePostDraftsParams{
+				ClaimedBefore: sql.NullTime{Time: time.Now().Add(-publishDraftsLease), Valid: true},
+				Limit:         publishDraftsBatchSize,
+			})
+		},
+		PostFunc: func(ctx context.Context, draft db.PostDraft) (persist.DBID, error) {
+			// Drafts don't have attachments, since PostDraftInput doesn't accept them
+			return api.postTokens(ctx, draft.ActorID, draft.TokenIds, draftMentionsToMentionInputs(draft.Mentions), &draft.Caption.String, &draft.UserMintUrl.String, nil)
+		},
+		PublishedFunc: func(ctx context.Context, draftID, postID persist.DBID) error {
+			return api.queries.MarkPostDraftPublished(ctx, db.MarkPostDraftPublishedParams{ID: draftID, PostID: postID})
+		},
+		UnscheduleFunc: api.queries.UnschedulePostDraft,
+	}
+	return p.publish(ctx)
+}
+
+// draftPublisher posts the drafts that a run of the scheduler claims
+type draftPublisher struct {
+	ClaimFunc      func(ctx context.Context) ([]db.PostDraft, error)
+	PostFunc       func(ctx context.Context, draft db.PostDraft) (persist.DBID, error)
+	PublishedFunc  func(ctx context.Context, draftID, postID persist.DBID) error
+	UnscheduleFunc func(ctx context.Context, draftID persist.DBID) error
+}
+
+func (p draftPublisher) publish(ctx context.Context) (int, error) {
+	drafts, err := p.ClaimFunc(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var published int
+
+	for _, draft := range drafts {
+		postID, err := p.PostFunc(ctx, draft)
+		if err != nil {
+			err = fmt.Errorf("failed to publish draft %s: %w", draft.ID, err)
+			sentryutil.ReportError(ctx, err)
+			logger.For(ctx).Error(err)
+			if err := p.UnscheduleFunc(ctx, draft.ID); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		// A draft that's posted but not marked as published is posted again after its lease runs out, which is better
+		// than losing it
+		if err := p.PublishedFunc(ctx, draft.ID, postID); err != nil {
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+func (api FeedAPI) validatePostDraft(tokenIDs []persist.DBID, caption, mintURL *string, publishAt *time.Time) error {
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		// caption can be null but less than 2000 chars
+		"caption": validate.WithTag(caption, "omitempty,max=2000"),
+		"mintURL": validate.WithTag(mintURL, "omitempty,http"),
+	}); err != nil {
+		return err
+	}
+
+	if publishAt == nil {
+		return nil
+	}
+
+	// Scheduled drafts are published without their author around, so they have to be postable when they're saved
+	invalid := validate.ErrInvalidInput{}
+	if len(tokenIDs) == 0 {
+		invalid.Append("tokenIDs", "a scheduled post must have at least one token")
+	}
+	if !publishAt.After(time.Now()) {
+		invalid.Append("publishAt", "publishAt must be in the future")
+	}
+	if len(invalid.Parameters) > 0 {
+		return invalid
+	}
+
+	return nil
+}
+
 func (api FeedAPI) ReferralPostToken(ctx context.Context, t persist.TokenIdentifiers, caption, mintURL *string) (persist.DBID, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
@@ -300,89 +497,643 @@ func (api FeedAPI) ReferralPostToken(ctx context.Context, t persist.TokenIdentif
 		UserMintUrl: util.ToNullStringEmptyNull(util.GetOptionalValue(mintURL, "")),
 	})
 	if err != nil {
-		return postID, err
+		return postID, err
+	}
+
+	err = event.Dispatch(ctx, db.Event{
+		ActorID:        persist.DBIDToNullStr(userID),
+		Action:         persist.ActionUserPosted,
+		ResourceTypeID: persist.ResourceTypePost,
+		UserID:         userID,
+		SubjectID:      postID,
+		PostID:         postID,
+	})
+	if err != nil {
+		sentryutil.ReportError(ctx, fmt.Errorf("error dispatching event: %w", err))
+		logger.For(ctx).Errorf("error dispatching event: %v", err)
+	}
+
+	return postID, nil
+}
+
+func (api FeedAPI) ReferralPostPreflight(ctx context.Context, t persist.TokenIdentifiers) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"address": validate.WithTag(t.ContractAddress, "required"),
+		"tokenID": validate.WithTag(t.TokenID, "required"),
+	}); err != nil {
+		return err
+	}
+	userID, _ := getAuthenticatedUserID(ctx)
+	return api.taskClient.CreateTaskForPostPreflight(ctx, task.PostPreflightMessage{Token: t, UserID: userID})
+}
+
+func insertMentionsForPost(ctx context.Context, mentions []*model.MentionInput, postID persist.DBID, q *db.Queries) ([]db.Mention, error) {
+	dbMentions, err := mentionInputsToMentions(ctx, mentions, q)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]db.Mention, len(dbMentions))
+
+	for i, m := range dbMentions {
+		var user, community sql.NullString
+		if m.UserID != "" {
+			user = sql.NullString{
+				String: m.UserID.String(),
+				Valid:  true,
+			}
+		} else if m.CommunityID != "" {
+			community = sql.NullString{
+				String: m.CommunityID.String(),
+				Valid:  true,
+			}
+		}
+		mid, err := q.InsertPostMention(ctx, db.InsertPostMentionParams{
+			ID:        persist.GenerateID(),
+			User:      user,
+			Community: community,
+			PostID:    postID,
+			Start:     m.Start,
+			Length:    m.Length,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = mid
+	}
+
+	return result, nil
+}
+
+func dispatchPostMentionEvents(ctx context.Context, actorID persist.DBID, postID persist.DBID, mentions []db.Mention) error {
+	for _, mention := range mentions {
+		switch {
+		case mention.UserID != "":
+			err := event.Dispatch(ctx, db.Event{
+				ActorID:        persist.DBIDToNullStr(actorID),
+				ResourceTypeID: persist.ResourceTypeUser,
+				SubjectID:      mention.UserID,
+				PostID:         postID,
+				UserID:         mention.UserID,
+				Action:         persist.ActionMentionUser,
+				MentionID:      mention.ID,
+			})
+			if err != nil {
+				return err
+			}
+		case mention.CommunityID != "":
+			err := event.Dispatch(ctx, db.Event{
+				ActorID:        persist.DBIDToNullStr(actorID),
+				ResourceTypeID: persist.ResourceTypeCommunity,
+				SubjectID:      mention.CommunityID,
+				PostID:         postID,
+				CommunityID:    mention.CommunityID,
+				Action:         persist.ActionMentionCommunity,
+				MentionID:      mention.ID,
+			})
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid mention type: %+v", mention)
+		}
+	}
+	return nil
+}
+
+// mentionInputsToDraftMentions checks that the mentioned users and communities exist so that a draft can't be saved
+// with a mention that would fail when it's published
+func mentionInputsToDraftMentions(ctx context.Context, mentions []*model.MentionInput, q *db.Queries) (persist.DraftMentions, error) {
+	dbMentions, err := mentionInputsToMentions(ctx, mentions, q)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(persist.DraftMentions, len(dbMentions))
+
+	for i, m := range dbMentions {
+		if m.Start.Valid {
+			result[i].Start = &m.Start.Int32
+			result[i].Length = &m.Length.Int32
+		}
+		if m.UserID != "" {
+			result[i].UserID = util.ToPointer(m.UserID)
+		}
+		if m.CommunityID != "" {
+			result[i].CommunityID = util.ToPointer(m.CommunityID)
+		}
+	}
+
+	return result, nil
+}
+
+func draftMentionsToMentionInputs(mentions persist.DraftMentions) []*model.MentionInput {
+	result := make([]*model.MentionInput, len(mentions))
+
+	for i, m := range mentions {
+		result[i] = &model.MentionInput{UserID: m.UserID, CommunityID: m.CommunityID}
+		if m.Start != nil && m.Length != nil {
+			result[i].Interval = &model.IntervalInput{Start: int(*m.Start), Length: int(*m.Length)}
+		}
+	}
+
+	return result
+}
+
+func (api FeedAPI) DeletePostById(ctx context.Context, postID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"postID": validate.WithTag(postID, "required"),
+	}); err != nil {
+		return err
+	}
+
+	err := api.queries.DeletePostByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// postEditWindow is how long after a post is created that its author can edit it
+const postEditWindow = time.Hour
+
+var ErrOnlyEditOwnPost = errors.New("only the author of a post can edit it")
+var ErrPostEditWindowClosed = errors.New("the post can no longer be edited")
+
+// EditPost replaces the caption and mentions of one of the viewer's posts. The previous caption is kept as a revision,
+// and only users and communities that weren't mentioned before the edit are notified.
+func (api FeedAPI) EditPost(ctx context.Context, postID persist.DBID, caption *string, mentions []*model.MentionInput) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"postID": validate.WithTag(postID, "required"),
+		// caption can be null but less than 2000 chars
+		"caption": validate.WithTag(caption, "omitempty,max=2000"),
+	}); err != nil {
+		return err
+	}
+
+	actorID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The post and its mentions are read from the database rather than the loaders, which may have cached them before
+	// an earlier edit
+	post, err := api.queries.GetPostByID(ctx, postID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return persist.ErrPostNotFoundByID{ID: postID}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := checkPostEditable(post, actorID, time.Now()); err != nil {
+		return err
+	}
+
+	previousMentions, err := api.queries.GetMentionsByPostIDIncludingDeleted(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := api.repos.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := api.queries.WithTx(tx)
+
+	err = q.InsertPostRevision(ctx, db.InsertPostRevisionParams{ID: persist.GenerateID(), PostID: postID})
+	if err != nil {
+		return err
+	}
+
+	err = q.UpdatePostCaption(ctx, db.UpdatePostCaptionParams{
+		PostID:  postID,
+		Caption: util.ToNullStringEmptyNull(util.GetOptionalValue(caption, "")),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = q.DeletePostMentionsByPostID(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	dbMentions, err := insertMentionsForPost(ctx, mentions, postID, q)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return err
+	}
+
+	return dispatchPostMentionEvents(ctx, actorID, postID, newMentions(previousMentions, dbMentions))
+}
+
+// checkPostEditable returns an error if the actor can't edit the post
+func checkPostEditable(post db.Post, actorID persist.DBID, now time.Time) error {
+	if post.ActorID != actorID {
+		return ErrOnlyEditOwnPost
+	}
+	if now.Sub(post.CreatedAt) > postEditWindow {
+		return ErrPostEditWindowClosed
+	}
+	return nil
+}
+
+// newMentions returns the mentions of users and communities that weren't mentioned before, including by mentions
+// that were since removed
+func newMentions(previous, current []db.Mention) []db.Mention {
+	// Users and communities are mentioned by ID, so a single set covers both
+	mentioned := make(map[persist.DBID]bool)
+	for _, m := range previous {
+		mentioned[m.UserID+m.CommunityID] = true
+	}
+
+	return util.Filter(current, func(m db.Mention) bool {
+		return !mentioned[m.UserID+m.CommunityID]
+	}, false)
+}
+
+func (api FeedAPI) GetPostRevisionsByPostID(ctx context.Context, postID persist.DBID) ([]db.PostRevision, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"postID": validate.WithTag(postID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	return api.loaders.GetPostRevisionsByPostID.Load(postID)
+}
+
+const (
+	minPollOptions      = 2
+	maxPollOptions      = 4
+	maxPollOptionLength = 100
+	maxPollDuration     = 7 * 24 * time.Hour
+)
+
+var ErrPollClosed = errors.New("the poll has closed")
+var ErrAlreadyVotedOnPoll = errors.New("already voted on this poll")
+
+// preparePostAttachment validates an attachment before its post is created. Links are fetched here so that their
+// previews are saved with the post.
+func (api FeedAPI) preparePostAttachment(ctx context.Context, input *model.PostAttachmentInput) (*db.InsertPostAttachmentParams, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	invalid := validate.ErrInvalidInput{}
+
+	var kinds int
+	for _, set := range []bool{input.Poll != nil, input.LinkURL != nil, input.CommunityID != nil} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		invalid.Append("attachment", "exactly one kind of attachment must be set")
+		return nil, invalid
+	}
+
+	switch {
+	case input.Poll != nil:
+		options := make([]string, len(input.Poll.Options))
+		seen := make(map[string]bool)
+		for i, o := range input.Poll.Options {
+			options[i] = strings.TrimSpace(o)
+			if options[i] == "" || utf8.RuneCountInString(options[i]) > maxPollOptionLength {
+				invalid.Append("options", fmt.Sprintf("options must be between 1 and %d characters", maxPollOptionLength))
+				break
+			}
+			if seen[strings.ToLower(options[i])] {
+				invalid.Append("options", "options must be unique")
+				break
+			}
+			seen[strings.ToLower(options[i])] = true
+		}
+		if len(options) < minPollOptions || len(options) > maxPollOptions {
+			invalid.Append("options", fmt.Sprintf("a poll must have between %d and %d options", minPollOptions, maxPollOptions))
+		}
+		if now := time.Now(); !input.Poll.ClosesAt.After(now) {
+			invalid.Append("closesAt", "closesAt must be in the future")
+		} else if input.Poll.ClosesAt.After(now.Add(maxPollDuration)) {
+			invalid.Append("closesAt", "polls can be open for up to a week")
+		}
+		if len(invalid.Parameters) > 0 {
+			return nil, invalid
+		}
+
+		return &db.InsertPostAttachmentParams{
+			AttachmentType: int32(persist.PostAttachmentTypePoll),
+			PollOptions:    options,
+			PollClosesAt:   sql.NullTime{Time: input.Poll.ClosesAt, Valid: true},
+		}, nil
+
+	case input.LinkURL != nil:
+		u, err := url.Parse(strings.TrimSpace(*input.LinkURL))
+		if err == nil {
+			err = unfurl.CheckURL(u)
+		}
+		if err != nil {
+			invalid.Append("linkURL", "linkURL must be a public http or https URL")
+			return nil, invalid
+		}
+
+		preview, err := unfurl.Fetch(ctx, u.String())
+		if errors.Is(err, unfurl.ErrUnsafeURL) {
+			invalid.Append("linkURL", "linkURL must be a public http or https URL")
+			return nil, invalid
+		}
+		// A link is still attached when its preview can't be fetched, it just won't have one
+		if err != nil {
+			logger.For(ctx).Warnf("failed to fetch preview for %s: %s", u, err)
+			preview = unfurl.Preview{}
+		}
+
+		return &db.InsertPostAttachmentParams{
+			AttachmentType:  int32(persist.PostAttachmentTypeLink),
+			LinkUrl:         util.ToNullStringEmptyNull(u.String()),
+			LinkTitle:       util.ToNullStringEmptyNull(preview.Title),
+			LinkDescription: util.ToNullStringEmptyNull(preview.Description),
+			LinkImageUrl:    util.ToNullStringEmptyNull(preview.ImageURL),
+			LinkSiteName:    util.ToNullStringEmptyNull(preview.SiteName),
+		}, nil
+
+	default:
+		if _, err := api.loaders.GetCommunityByIDBatch.Load(*input.CommunityID); err != nil {
+			return nil, err
+		}
+
+		return &db.InsertPostAttachmentParams{
+			AttachmentType: int32(persist.PostAttachmentTypeCommunity),
+			CommunityID:    *input.CommunityID,
+		}, nil
+	}
+}
+
+// GetPostAttachmentByPostID returns a post's attachment, or nil if it doesn't have one
+func (api FeedAPI) GetPostAttachmentByPostID(ctx context.Context, postID persist.DBID) (*db.PostAttachment, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"postID": validate.WithTag(postID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	attachment, err := api.loaders.GetPostAttachmentByPostIDBatch.Load(postID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (api FeedAPI) GetPollByID(ctx context.Context, pollID persist.DBID) (*db.PostAttachment, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"pollID": validate.WithTag(pollID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	poll, err := api.queries.GetPostAttachmentByID(ctx, pollID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && poll.AttachmentType != int32(persist.PostAttachmentTypePoll)) {
+		return nil, persist.ErrPollNotFoundByID{ID: pollID}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &poll, nil
+}
+
+// VoteOnPoll votes for one of a poll's options. Votes can't be changed once they're cast.
+func (api FeedAPI) VoteOnPoll(ctx context.Context, pollID persist.DBID, optionIndex int) error {
+	userID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	poll, err := api.GetPollByID(ctx, pollID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkPollVote(*poll, optionIndex, time.Now()); err != nil {
+		return err
+	}
+
+	_, err = api.queries.InsertPollVote(ctx, db.InsertPollVoteParams{
+		ID:           persist.GenerateID(),
+		AttachmentID: pollID,
+		VoterID:      userID,
+		OptionIndex:  int32(optionIndex),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrAlreadyVotedOnPoll
+	}
+
+	return err
+}
+
+// checkPollVote returns an error if optionIndex can't be voted for on the poll at the given time
+func checkPollVote(poll db.PostAttachment, optionIndex int, now time.Time) error {
+	if !now.Before(poll.PollClosesAt.Time) {
+		return ErrPollClosed
+	}
+
+	if optionIndex < 0 || optionIndex >= len(poll.PollOptions) {
+		invalid := validate.ErrInvalidInput{}
+		invalid.Append("optionIndex", fmt.Sprintf("optionIndex must be between 0 and %d", len(poll.PollOptions)-1))
+		return invalid
+	}
+
+	return nil
+}
+
+// GetViewerPollVote returns the index of the option the viewer voted for, or nil if they haven't voted
+func (api FeedAPI) GetViewerPollVote(ctx context.Context, pollID persist.DBID) (*int, error) {
+	userID, _ := getAuthenticatedUserID(ctx)
+	if userID == "" {
+		return nil, nil
+	}
+
+	optionIndex, err := api.queries.GetPollVoteByVoterID(ctx, db.GetPollVoteByVoterIDParams{
+		AttachmentID: pollID,
+		VoterID:      userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return util.ToPointer(int(optionIndex)), nil
+}
+
+// GetPollVoteCounts returns the number of votes for each of a poll's options
+func (api FeedAPI) GetPollVoteCounts(ctx context.Context, pollID persist.DBID, numOptions int) ([]int, error) {
+	rows, err := api.loaders.CountPollVotesByOptionBatch.Load(pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, numOptions)
+	for _, r := range rows {
+		if int(r.OptionIndex) < numOptions {
+			counts[r.OptionIndex] = int(r.Count)
+		}
+	}
+
+	return counts, nil
+}
+
+// Repost shares a post with the viewer's followers. Reposting a post more than once returns the existing repost.
+func (api FeedAPI) Repost(ctx context.Context, postID persist.DBID) (persist.DBID, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"postID": validate.WithTag(postID, "required"),
+	}); err != nil {
+		return "", err
+	}
+
+	actorID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return "", err
 	}
 
-	err = event.Dispatch(ctx, db.Event{
-		ActorID:        persist.DBIDToNullStr(userID),
-		Action:         persist.ActionUserPosted,
-		ResourceTypeID: persist.ResourceTypePost,
-		UserID:         userID,
-		SubjectID:      postID,
-		PostID:         postID,
-	})
+	params := db.InsertRepostParams{
+		ID:      persist.GenerateID(),
+		ActorID: actorID,
+		PostID:  postID,
+	}
+
+	repostID, err := api.queries.InsertRepost(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", persist.ErrPostNotFoundByID{ID: postID}
+	}
 	if err != nil {
-		sentryutil.ReportError(ctx, fmt.Errorf("error dispatching event: %w", err))
-		logger.For(ctx).Errorf("error dispatching event: %v", err)
+		return "", err
 	}
 
-	return postID, nil
+	// Repost did not exist before, so dispatch event
+	if repostID == params.ID {
+		err = event.Dispatch(ctx, db.Event{
+			ActorID:        persist.DBIDToNullStr(actorID),
+			Action:         persist.ActionUserReposted,
+			ResourceTypeID: persist.ResourceTypePost,
+			SubjectID:      postID,
+			PostID:         postID,
+		})
+	}
+
+	return repostID, err
 }
 
-func (api FeedAPI) ReferralPostPreflight(ctx context.Context, t persist.TokenIdentifiers) error {
+// QuotePost creates a post that shares another post along with the viewer's caption
+func (api FeedAPI) QuotePost(ctx context.Context, postID persist.DBID, caption string) (persist.DBID, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
-		"address": validate.WithTag(t.ContractAddress, "required"),
-		"tokenID": validate.WithTag(t.TokenID, "required"),
+		"postID":  validate.WithTag(postID, "required"),
+		"caption": validate.WithTag(caption, "required,max=2000"),
 	}); err != nil {
-		return err
+		return "", err
 	}
-	userID, _ := getAuthenticatedUserID(ctx)
-	return api.taskClient.CreateTaskForPostPreflight(ctx, task.PostPreflightMessage{Token: t, UserID: userID})
-}
 
-func insertMentionsForPost(ctx context.Context, mentions []*model.MentionInput, postID persist.DBID, q *db.Queries) ([]db.Mention, error) {
-	dbMentions, err := mentionInputsToMentions(ctx, mentions, q)
+	actorID, err := getAuthenticatedUserID(ctx)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	result := make([]db.Mention, len(dbMentions))
 
-	for i, m := range dbMentions {
-		var user, community sql.NullString
-		if m.UserID != "" {
-			user = sql.NullString{
-				String: m.UserID.String(),
-				Valid:  true,
-			}
-		} else if m.CommunityID != "" {
-			community = sql.NullString{
-				String: m.CommunityID.String(),
-				Valid:  true,
-			}
-		}
-		mid, err := q.InsertPostMention(ctx, db.InsertPostMentionParams{
-			ID:        persist.GenerateID(),
-			User:      user,
-			Community: community,
-			PostID:    postID,
-			Start:     m.Start,
-			Length:    m.Length,
-		})
+	_, err = api.GetPostById(ctx, postID)
+	if err != nil {
+		return "", err
+	}
+
+	quoteID, err := api.queries.InsertPost(ctx, db.InsertPostParams{
+		ID:           persist.GenerateID(),
+		TokenIds:     persist.DBIDList{},
+		ContractIds:  persist.DBIDList{},
+		ActorID:      actorID,
+		Caption:      util.ToNullStringEmptyNull(caption),
+		QuotedPostID: postID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// A quote is a post of its own and also counts as a repost of the quoted post
+	for _, evt := range []db.Event{
+		{
+			ActorID:        persist.DBIDToNullStr(actorID),
+			Action:         persist.ActionUserPosted,
+			ResourceTypeID: persist.ResourceTypePost,
+			UserID:         actorID,
+			SubjectID:      quoteID,
+			PostID:         quoteID,
+		},
+		{
+			ActorID:        persist.DBIDToNullStr(actorID),
+			Action:         persist.ActionUserReposted,
+			ResourceTypeID: persist.ResourceTypePost,
+			SubjectID:      postID,
+			PostID:         postID,
+		},
+	} {
+		err = event.Dispatch(ctx, evt)
 		if err != nil {
-			return nil, err
+			sentryutil.ReportError(ctx, fmt.Errorf("error dispatching event: %w", err))
+			logger.For(ctx).Errorf("error dispatching event: %v", err)
 		}
+	}
 
-		result[i] = mid
+	return quoteID, nil
+}
+
+func (api FeedAPI) GetRepostById(ctx context.Context, repostID persist.DBID) (*db.Repost, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"repostID": validate.WithTag(repostID, "required"),
+	}); err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	repost, err := api.loaders.GetRepostByIdBatch.Load(repostID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repost, nil
 }
 
-func (api FeedAPI) DeletePostById(ctx context.Context, postID persist.DBID) error {
+// GetRepostCountByPostID counts both reposts and quotes of a post
+func (api FeedAPI) GetRepostCountByPostID(ctx context.Context, postID persist.DBID) (*int, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
 		"postID": validate.WithTag(postID, "required"),
 	}); err != nil {
-		return err
+		return nil, err
 	}
 
-	err := api.queries.DeletePostByID(ctx, postID)
+	count, err := api.loaders.CountRepostsByPostIDBatch.Load(postID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return util.ToPointer(int(count)), nil
 }
 
 func (api FeedAPI) GetRawEventById(ctx context.Context, eventID persist.DBID) (*db.Event, error) {
@@ -521,10 +1272,83 @@ func (api FeedAPI) GlobalFeed(ctx context.Context, before *string, after *string
 
 func fetchFeedEntityScores(ctx context.Context, q *db.Queries, viewerID persist.DBID) ([]db.GetFeedEntityScoresRow, error) {
 	return q.GetFeedEntityScores(ctx, db.GetFeedEntityScoresParams{
-		WindowEnd: time.Now().Add(-time.Minute * time.Duration(feedOpts.LookbackWindow)),
+		WindowEnd: time.Now().Add(-time.Minute * time.Duration(feedrank.Opts.LookbackWindow)),
 		ViewerID:  viewerID,
-		Span:      int32(feedOpts.PostSpan),
+		Span:      int32(feedrank.Opts.PostSpan),
+	})
+}
+
+// excludeFlaggedPosts removes posts that include tokens whose media was flagged by moderation. The trending feed is cached
+// and shared by every viewer, so flagged posts are excluded outright instead of following each viewer's display preference.
+func excludeFlaggedPosts(ctx context.Context, q *db.Queries, scores []db.GetFeedEntityScoresRow) ([]db.GetFeedEntityScoresRow, error) {
+	tokenIDs := make([]string, 0)
+	for _, s := range scores {
+		tokenIDs = append(tokenIDs, util.MapWithoutError(s.Post.TokenIds, func(id persist.DBID) string { return id.String() })...)
+	}
+
+	if len(tokenIDs) == 0 {
+		return scores, nil
+	}
+
+	flagged, err := q.GetFlaggedTokenIDs(ctx, db.GetFlaggedTokenIDsParams{
+		TokenIds: tokenIDs,
+		Labels:   []string{string(persist.ModerationLabelNSFW), string(persist.ModerationLabelMalicious)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(flagged) == 0 {
+		return scores, nil
+	}
+
+	flaggedSet := sliceToMapIndex(flagged)
+
+	return util.Filter(scores, func(s db.GetFeedEntityScoresRow) bool {
+		for _, id := range s.Post.TokenIds {
+			if _, ok := flaggedSet[id]; ok {
+				return false
+			}
+		}
+		return true
+	}, false), nil
+}
+
+// mutedPostIDs returns which of the posts the viewer muted, either by marking them as not interested or by muting a
+// word or community that they include
+func mutedPostIDs(ctx context.Context, q *db.Queries, viewerID persist.DBID, postIDs []persist.DBID) (map[persist.DBID]bool, error) {
+	if viewerID == "" || len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	muted, err := q.GetMutedPostIDs(ctx, db.GetMutedPostIDsParams{
+		ViewerID: viewerID,
+		PostIds:  util.MapWithoutError(postIDs, func(id persist.DBID) string { return id.String() }),
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	mutedSet := make(map[persist.DBID]bool, len(muted))
+	for _, id := range muted {
+		mutedSet[id] = true
+	}
+
+	return mutedSet, nil
+}
+
+// excludeMutedPosts removes posts that the viewer muted
+func excludeMutedPosts(ctx context.Context, q *db.Queries, viewerID persist.DBID, scores []db.GetFeedEntityScoresRow) ([]db.GetFeedEntityScoresRow, error) {
+	muted, err := mutedPostIDs(ctx, q, viewerID, util.MapWithoutError(scores, func(s db.GetFeedEntityScoresRow) persist.DBID { return s.Post.ID }))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(muted) == 0 {
+		return scores, nil
+	}
+
+	return util.Filter(scores, func(s db.GetFeedEntityScoresRow) bool { return !muted[s.Post.ID] }, false), nil
 }
 
 func (api FeedAPI) paginatorFromCursorStr(ctx context.Context, curStr string) (feedPaginator, error) {
@@ -557,14 +1381,46 @@ func (api FeedAPI) paginatorFromResults(ctx context.Context, c *feedPositionCurs
 
 func (api FeedAPI) paginatorWithQuery(c *feedPositionCursor, queryF func(positionPagingParams) ([]any, error)) feedPaginator {
 	var paginator feedPaginator
+	paginator.Variant = c.Variant
 	paginator.QueryFunc = queryF
-	paginator.CursorFunc = func(node any) (int64, []persist.FeedEntityType, []persist.DBID, error) {
+	paginator.CursorFunc = func(node any) (int64, []persist.FeedEntityType, []persist.DBID, string, error) {
 		_, id, err := feedCursor(node)
-		return c.Positions[id], c.EntityTypes, c.EntityIDs, err
+		return c.Positions[id], c.EntityTypes, c.EntityIDs, c.Variant, err
 	}
 	return paginator
 }
 
+// logFeedExposure records which posts of a ranked feed were served to a viewer and by which ranker, so that engagement
+// can be compared between rankers. Failing to log doesn't fail the request.
+func (api FeedAPI) logFeedExposure(ctx context.Context, feed string, p feedPaginator, entities []any) {
+	if len(entities) == 0 {
+		return
+	}
+
+	viewerID, _ := getAuthenticatedUserID(ctx)
+	params := db.InsertFeedExposureParams{
+		ID:       persist.GenerateID(),
+		ViewerID: viewerID.String(),
+		Feed:     feed,
+		Variant:  p.Variant,
+	}
+
+	for _, e := range entities {
+		pos, _, ids, _, err := p.CursorFunc(e)
+		if err != nil {
+			continue
+		}
+		_, id, _ := feedCursor(e)
+		params.PostIds = append(params.PostIds, id.String())
+		// Positions are stored from the bottom of the feed, so they're flipped to be a rank from the top
+		params.Positions = append(params.Positions, int32(len(ids)-1-int(pos)))
+	}
+
+	if err := api.queries.InsertFeedExposure(ctx, params); err != nil {
+		logger.For(ctx).Errorf("failed to log %s feed exposure: %s", feed, err)
+	}
+}
+
 func (api FeedAPI) TrendingFeed(ctx context.Context, before *string, after *string, first *int, last *int) ([]any, PageInfo, error) {
 	// Validate
 	if err := validatePaginationParams(api.validator, first, last); err != nil {
@@ -588,20 +1444,23 @@ func (api FeedAPI) TrendingFeed(ctx context.Context, before *string, after *stri
 		// Not currently paging, so we need to check the cache and possibly re-calculate the feed
 		var posts []db.Post
 
+		viewerID, _ := getAuthenticatedUserID(ctx)
+		ranker := feedrank.TrendingExperiment.Assign(viewerID)
+
 		// Func to calculate the feed. It's only called if the cache is empty.
 		cacheCalcFunc := func(ctx context.Context) ([]persist.FeedEntityType, []persist.DBID, error) {
-			viewerID, _ := getAuthenticatedUserID(ctx)
 			postScores, err := fetchFeedEntityScores(ctx, api.queries, viewerID)
 			if err != nil {
 				return nil, nil, err
 			}
 
-			now := time.Now()
+			postScores, err = excludeFlaggedPosts(ctx, api.queries, postScores)
+			if err != nil {
+				return nil, nil, err
+			}
 
-			scored := api.scoreFeedEntities(ctx, feedOpts.FetchSize, postScores, func(i int) float64 {
-				e := postScores[i]
-				return timeFactor(now.Sub(e.Post.CreatedAt).Minutes(), e.IsGalleryPost) * engagementFactor(float64(e.FeedEntityScore.Interactions))
-			})
+			scored := ranker.Rank(feedrank.RankInput{ViewerID: viewerID, Now: time.Now(), PostScores: postScores})
+			scored = feedrank.Diversify(scored, feedrank.FeedDiversity)
 
 			postIDs := make([]persist.DBID, len(scored))
 			posts = make([]db.Post, len(scored))
@@ -617,7 +1476,7 @@ func (api FeedAPI) TrendingFeed(ctx context.Context, before *string, after *stri
 			return postTypes, postIDs, nil
 		}
 
-		cache := newFeedCache(api.cache, cacheCalcFunc)
+		cache := newFeedCache(api.cache, trendingFeedCacheKey(ranker), cacheCalcFunc)
 
 		// Load from cache
 		postTypes, postIDs, err := cache.Load(ctx)
@@ -625,12 +1484,34 @@ func (api FeedAPI) TrendingFeed(ctx context.Context, before *string, after *stri
 			return nil, PageInfo{}, err
 		}
 
+		// The cached feed is shared by every viewer, so posts that the viewer muted are removed afterwards
+		muted, err := mutedPostIDs(ctx, api.queries, viewerID, postIDs)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+
+		if len(muted) > 0 {
+			keptTypes := make([]persist.FeedEntityType, 0, len(postIDs))
+			keptIDs := make([]persist.DBID, 0, len(postIDs))
+			for i, id := range postIDs {
+				if !muted[id] {
+					keptTypes = append(keptTypes, postTypes[i])
+					keptIDs = append(keptIDs, id)
+				}
+			}
+			postTypes, postIDs = keptTypes, keptIDs
+			if posts != nil {
+				posts = util.Filter(posts, func(p db.Post) bool { return !muted[p.ID] }, false)
+			}
+		}
+
 		// Create cursor from cached data
 		cursor := cursors.NewFeedPositionCursor()
 		cursor.CurrentPosition = 0
 		cursor.EntityTypes = postTypes
 		cursor.EntityIDs = postIDs
 		cursor.Positions = sliceToMapIndex(postIDs)
+		cursor.Variant = ranker.Name()
 
 		// We already did the work to fetch the posts when re-calculating the feed, so we can just return them here
 		if posts != nil {
@@ -640,7 +1521,13 @@ func (api FeedAPI) TrendingFeed(ctx context.Context, before *string, after *stri
 		}
 	}
 
-	return paginator.paginate(before, after, first, last)
+	entities, pageInfo, err := paginator.paginate(before, after, first, last)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	api.logFeedExposure(ctx, "trending", paginator, entities)
+	return entities, pageInfo, nil
 }
 
 func (api FeedAPI) ForYouFeed(ctx context.Context, before, after *string, first, last *int) ([]any, PageInfo, error) {
@@ -675,61 +1562,31 @@ func (api FeedAPI) ForYouFeed(ctx context.Context, before, after *string, first,
 			return nil, PageInfo{}, err
 		}
 
-		now := time.Now()
-		engagementScores := make(map[persist.DBID]float64)
-		personalizationScores := make(map[persist.DBID]float64)
-
-		for _, e := range postScores {
-			age := now.Sub(e.Post.CreatedAt).Minutes()
-			engagementScores[e.Post.ID] = scorePost(age, e.IsGalleryPost, e.Post.IsFirstPost, isFreshPost(age), int(e.Streak))
-			personalizationScores[e.Post.ID] = engagementScores[e.Post.ID]
-			engagementScores[e.Post.ID] *= engagementFactor(float64(e.FeedEntityScore.Interactions))
-			personalizationScores[e.Post.ID] *= userpref.For(ctx).RelevanceTo(viewerID, e.FeedEntityScore)
-		}
-
-		// Rank by engagement first, then by personalization
-		topNByPersonalization := api.scoreFeedEntities(ctx, feedOpts.FetchSize, postScores, func(i int) float64 { return engagementScores[postScores[i].Post.ID] })
-		topNByPersonalization = api.scoreFeedEntities(ctx, feedOpts.FetchSize, topNByPersonalization, func(i int) float64 { return personalizationScores[topNByPersonalization[i].Post.ID] })
-
-		// Rank by personalization, then by engagement
-		topNByEngagement := api.scoreFeedEntities(ctx, feedOpts.FetchSize, postScores, func(i int) float64 { return personalizationScores[postScores[i].Post.ID] })
-		topNByEngagement = api.scoreFeedEntities(ctx, feedOpts.FetchSize, topNByEngagement, func(i int) float64 { return engagementScores[topNByEngagement[i].Post.ID] })
-
-		// Get ranking of both
-		seen := make(map[persist.DBID]int)
-		combined := make([]db.GetFeedEntityScoresRow, 0)
-		engagementRank := make([]float64, 0)
-		blendedRank := make([]float64, 0)
-
-		for i, e := range topNByEngagement {
-			score := float64(len(topNByEngagement) - i)
-			combined = append(combined, e)
-			engagementRank = append(engagementRank, score)
-			blendedRank = append(blendedRank, score)
-			seen[e.Post.ID] = i
+		postScores, err = excludeMutedPosts(ctx, api.queries, viewerID, postScores)
+		if err != nil {
+			return nil, PageInfo{}, err
 		}
 
-		for i, e := range topNByPersonalization {
-			score := float64(len(topNByPersonalization) - i)
-			if idx, ok := seen[e.Post.ID]; ok {
-				blendedRank[idx] = (blendedRank[idx] + score) * 0.5
-			} else {
-				combined = append(combined, e)
-				engagementRank = append(engagementRank, 0)
-				// New posts tend to have no engagement, so don't over penalize it by halving it
-				blendedRank = append(blendedRank, score)
-			}
+		// Posts the viewer wasn't interested in make similar posts less relevant
+		feedback, err := userpref.ReadFeedback(ctx, api.queries, viewerID)
+		if err != nil {
+			return nil, PageInfo{}, err
 		}
 
-		interleaved := api.scoreFeedEntities(ctx, feedOpts.FetchSize, combined, func(i int) float64 {
-			if combined[i].Post.ActorID == viewerID {
-				return engagementRank[i]
-			}
-			return blendedRank[i]
+		ranker := feedrank.ForYouExperiment.Assign(viewerID)
+		interleaved := ranker.Rank(feedrank.RankInput{
+			ViewerID:   viewerID,
+			Now:        time.Now(),
+			PostScores: postScores,
+			Relevance:  userpref.For(ctx).RelevanceWithFeedback(feedback),
 		})
 
 		recommend.Shuffle(interleaved, 4)
 
+		// Spread out posts by the same creator and about the same collection after shuffling so that shuffling can't
+		// undo it
+		interleaved = feedrank.Diversify(interleaved, feedrank.FeedDiversity)
+
 		posts := make([]db.Post, len(interleaved))
 		postIDs := make([]persist.DBID, len(interleaved))
 		postTypes := make([]persist.FeedEntityType, len(interleaved))
@@ -748,10 +1605,75 @@ func (api FeedAPI) ForYouFeed(ctx context.Context, before, after *string, first,
 		cursor.EntityTypes = postTypes
 		cursor.EntityIDs = postIDs
 		cursor.Positions = positions
+		cursor.Variant = ranker.Name()
 
 		paginator = api.paginatorFromResults(ctx, cursor, posts)
 	}
 
+	entities, pageInfo, err := paginator.paginate(before, after, first, last)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	api.logFeedExposure(ctx, "forYou", paginator, entities)
+	return entities, pageInfo, nil
+}
+
+// CommunitiesFeed returns the newest posts from the communities that the viewer follows
+func (api FeedAPI) CommunitiesFeed(ctx context.Context, before, after *string, first, last *int) ([]any, PageInfo, error) {
+	// Validate
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	if err := validatePaginationParams(api.validator, first, last); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	var paginator feedPaginator
+
+	if before != nil {
+		paginator, err = api.paginatorFromCursorStr(ctx, *before)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+	} else if after != nil {
+		paginator, err = api.paginatorFromCursorStr(ctx, *after)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+	} else {
+		// Not currently paging, so the posts are fetched and stored in the cursor so that later pages are stable
+		posts, err := api.queries.GetFollowedCommunityPosts(ctx, db.GetFollowedCommunityPostsParams{
+			UserID:    viewerID,
+			WindowEnd: time.Now().Add(-communitiesFeedLookback),
+			Limit:     communitiesFeedSize,
+		})
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+
+		postIDs := make([]persist.DBID, len(posts))
+		postTypes := make([]persist.FeedEntityType, len(posts))
+		reversed := make([]db.Post, len(posts))
+
+		for i, p := range posts {
+			idx := len(posts) - i - 1
+			postIDs[idx] = p.ID
+			postTypes[idx] = persist.PostTypeTag
+			reversed[idx] = p
+		}
+
+		cursor := cursors.NewFeedPositionCursor()
+		cursor.CurrentPosition = 0
+		cursor.EntityTypes = postTypes
+		cursor.EntityIDs = postIDs
+		cursor.Positions = sliceToMapIndex(postIDs)
+
+		paginator = api.paginatorFromResults(ctx, cursor, reversed)
+	}
+
 	return paginator.paginate(before, after, first, last)
 }
 
@@ -796,6 +1718,8 @@ func feedCursor(i any) (time.Time, persist.DBID, error) {
 		return row.EventTime, row.ID, nil
 	case db.Post:
 		return row.CreatedAt, row.ID, nil
+	case db.Repost:
+		return row.CreatedAt, row.ID, nil
 	}
 	return time.Time{}, "", fmt.Errorf("node is not a feed entity: %T", i)
 }
@@ -820,10 +1744,13 @@ func loadFeedEntities(ctx context.Context, d *dataloader.Loaders, typs []persist
 	idToPosition := make(map[persist.DBID]int, len(ids))
 	eventsFetch := make([]persist.DBID, 0, len(ids))
 	postsFetch := make([]persist.DBID, 0, len(ids))
+	repostsFetch := make([]persist.DBID, 0, len(ids))
 	eventsDone := make(chan bool)
 	postsDone := make(chan bool)
+	repostsDone := make(chan bool)
 	eventsErr := make(chan int)
 	postsErr := make(chan int)
+	repostsErr := make(chan int)
 
 	for i := 0; i < len(typs); i++ {
 		id := ids[i]
@@ -833,6 +1760,8 @@ func loadFeedEntities(ctx context.Context, d *dataloader.Loaders, typs []persist
 			eventsFetch = append(eventsFetch, id)
 		case persist.PostTypeTag:
 			postsFetch = append(postsFetch, id)
+		case persist.RepostTypeTag:
+			repostsFetch = append(repostsFetch, id)
 		default:
 			logger.For(ctx).Warnf("unknown feed entity type %d", typs[i])
 		}
@@ -868,15 +1797,34 @@ func loadFeedEntities(ctx context.Context, d *dataloader.Loaders, typs []persist
 		close(postsErr)
 	}()
 
+	go func() {
+		batchResults, batchErrs := d.GetRepostByIdBatch.LoadAll(repostsFetch)
+		for i := 0; i < len(batchResults); i++ {
+			pos := idToPosition[repostsFetch[i]]
+			err := batchErrs[i]
+			entities[pos] = batchResults[i]
+			if err != nil && !util.ErrorIs[persist.ErrRepostNotFoundByID](err) {
+				logger.For(ctx).Errorf("failed to fetch repost %s: %s", repostsFetch[i], err)
+				repostsErr <- pos
+			}
+		}
+		close(repostsDone)
+		close(repostsErr)
+	}()
+
 	for pos := range eventsErr {
 		errored = append(errored, pos)
 	}
 	for pos := range postsErr {
 		errored = append(errored, pos)
 	}
+	for pos := range repostsErr {
+		errored = append(errored, pos)
+	}
 
 	<-eventsDone
 	<-postsDone
+	<-repostsDone
 
 	// Sort in descending order
 	sort.Slice(errored, func(i, j int) bool { return errored[i] > errored[j] })
@@ -897,112 +1845,11 @@ func loadFeedEntities(ctx context.Context, d *dataloader.Loaders, typs []persist
 	return entities, nil
 }
 
-func (api FeedAPI) scoreFeedEntities(ctx context.Context, n int, trendData []db.GetFeedEntityScoresRow, scoreF func(i int) float64) []db.GetFeedEntityScoresRow {
-	h := make(heap[entityScore], 0)
-
-	scores := make([]entityScore, len(trendData))
-
-	for i, event := range trendData {
-		score := scoreF(i)
-		scores[i] = entityScore{v: event, s: score}
-	}
-
-	for _, node := range scores {
-		// Add first n items in the heap
-		if h.Len() < n {
-			heappkg.Push(&h, node)
-			continue
-		}
-		// If the score is greater than the smallest score in the heap, replace it
-		if node.s > h[0].s {
-			heappkg.Pop(&h)
-			heappkg.Push(&h, node)
-		}
-	}
-
-	scoredEntities := make([]db.GetFeedEntityScoresRow, h.Len())
-
-	// Pop returns the smallest score first, so we reverse the order
-	// such that the highest score is first
-	i := h.Len() - 1
-	for h.Len() > 0 {
-		node := heappkg.Pop(&h)
-		scoredEntities[i] = node.(entityScore).v
-		i--
-	}
-
-	return scoredEntities
-}
-
-func isFreshPost(age float64) bool {
-	return age < feedOpts.FreshnessWindow
-}
-
-func scorePost(age float64, isGallery, isFirstPost, isFresh bool, streak int) (s float64) {
-	s = timeFactor(age, isGallery)
-	if streak > feedOpts.StreakThreshold {
-		s *= 1 / math.Pow(math.E, feedOpts.StreakFactor*float64(streak-feedOpts.StreakThreshold+1))
-	}
-	if isFirstPost && isFresh {
-		s *= feedOpts.FirstPostFactor
-	}
-	if !isFirstPost && streak < feedOpts.StreakThreshold && isFresh {
-		s *= feedOpts.FreshnessFactor
-	}
-	return s
-}
-
-func timeFactor(age float64, isGalleryPost bool) float64 {
-	if isGalleryPost {
-		return math.Pow(2, -(age / lerp(age, feedOpts.PostHalfLife, feedOpts.GalleryPostHalfLife, feedOpts.GalleryDecayPeriod)))
-	}
-	return math.Pow(2, -(age / feedOpts.PostHalfLife))
-}
-
-// lerp returns a linear interpolation between s and e (clamped to e) based on age
-// period controls the time it takes to reach e from s
-func lerp(age, s, e, period float64) float64 {
-	return math.Min(e, s+((e-s)/period)*age)
-}
-
-func engagementFactor(interactions float64) float64 {
-	// Add 2 because log(0) => undefined and log(1) => 0 and returning 0 will cancel out
-	// the effect of other terms this term may get multiplied with
-	return math.Log2(2 + interactions)
-}
-
-type entityScore struct {
-	v db.GetFeedEntityScoresRow
-	s float64
-}
-
-func (n entityScore) Less(a any) bool {
-	other, ok := a.(entityScore)
-	if !ok {
-		return false
-	}
-	return n.s < other.s
-}
-
-type lt interface{ Less(j any) bool }
-type heap[T lt] []T
-
-func (h heap[T]) Len() int           { return len(h) }
-func (h heap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
-func (h *heap[T]) Push(s any)        { *h = append(*h, s.(T)) }
-func (h heap[T]) Less(i, j int) bool { return h[i].Less(h[j]) }
-
-func (h *heap[T]) Pop() any {
-	old := *h
-	n := len(old)
-	item := old[n-1]
-	*h = old[:n-1]
-	return item
-}
-
 type feedPaginator struct {
 	QueryFunc  func(params positionPagingParams) ([]any, error)
-	CursorFunc func(node any) (pos int64, feedEntityType []persist.FeedEntityType, ids []persist.DBID, err error)
+	CursorFunc func(node any) (pos int64, feedEntityType []persist.FeedEntityType, ids []persist.DBID, variant string, err error)
+	// Variant is the name of the ranker that ordered the feed
+	Variant string
 }
 
 func (p *feedPaginator) paginate(before, after *string, first, last *int) ([]any, PageInfo, error) {
@@ -1041,11 +1888,11 @@ type feedCache struct {
 	CalcFunc func(context.Context) ([]persist.FeedEntityType, []persist.DBID, error)
 }
 
-func newFeedCache(cache *redis.Cache, f func(context.Context) ([]persist.FeedEntityType, []persist.DBID, error)) *feedCache {
+func newFeedCache(cache *redis.Cache, key string, f func(context.Context) ([]persist.FeedEntityType, []persist.DBID, error)) *feedCache {
 	return &feedCache{
 		LazyCache: &redis.LazyCache{
 			Cache: cache,
-			Key:   trendingFeedCacheKey,
+			Key:   key,
 			TTL:   time.Minute * 10,
 			CalcFunc: func(ctx context.Context) ([]byte, error) {
 				types, ids, err := f(ctx)
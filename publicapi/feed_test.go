@@ -0,0 +1,358 @@
+package publicapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/graphql/model"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/util"
+	"github.com/mikeydub/go-gallery/validate"
+)
+
+// draftStore records what happens to drafts as they're published
+type draftStore struct {
+	drafts      []db.PostDraft
+	failPosting map[persist.DBID]bool
+	posted      []persist.DBID
+	published   map[persist.DBID]persist.DBID
+	unscheduled []persist.DBID
+}
+
+func newDraftStore(draftIDs ...persist.DBID) *draftStore {
+	s := &draftStore{failPosting: make(map[persist.DBID]bool), published: make(map[persist.DBID]persist.DBID)}
+	for _, id := range draftIDs {
+		s.drafts = append(s.drafts, db.PostDraft{ID: id})
+	}
+	return s
+}
+
+func (s *draftStore) publisher() draftPublisher {
+	return draftPublisher{
+		ClaimFunc: func(context.Context) ([]db.PostDraft, error) { return s.drafts, nil },
+		PostFunc: func(_ context.Context, draft db.PostDraft) (persist.DBID, error) {
+			if s.failPosting[draft.ID] {
+				return "", errors.New("can't post")
+			}
+			s.posted = append(s.posted, draft.ID)
+			return "post-" + draft.ID, nil
+		},
+		PublishedFunc: func(_ context.Context, draftID, postID persist.DBID) error {
+			s.published[draftID] = postID
+			return nil
+		},
+		UnscheduleFunc: func(_ context.Context, draftID persist.DBID) error {
+			s.unscheduled = append(s.unscheduled, draftID)
+			return nil
+		},
+	}
+}
+
+func TestDraftPublisher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("posts each claimed draft and marks it as published with its post", func(t *testing.T) {
+		s := newDraftStore("a", "b")
+		published, err := s.publisher().publish(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []persist.DBID{"a", "b"}, s.posted)
+		assert.Equal(t, map[persist.DBID]persist.DBID{"a": "post-a", "b": "post-b"}, s.published)
+		assert.Empty(t, s.unscheduled)
+	})
+
+	t.Run("unschedules drafts that can't be posted and publishes the rest", func(t *testing.T) {
+		s := newDraftStore("a", "b", "c")
+		s.failPosting["b"] = true
+		published, err := s.publisher().publish(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []persist.DBID{"b"}, s.unscheduled)
+		assert.NotContains(t, s.published, persist.DBID("b"))
+	})
+
+	t.Run("stops if a draft can't be marked as published so that it's claimed again later", func(t *testing.T) {
+		s := newDraftStore("a", "b")
+		p := s.publisher()
+		p.PublishedFunc = func(context.Context, persist.DBID, persist.DBID) error { return errors.New("db is down") }
+		published, err := p.publish(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, 0, published)
+		assert.Equal(t, []persist.DBID{"a"}, s.posted)
+		assert.Empty(t, s.unscheduled)
+	})
+
+	t.Run("doesn't post anything if drafts can't be claimed", func(t *testing.T) {
+		s := newDraftStore("a")
+		p := s.publisher()
+		p.ClaimFunc = func(context.Context) ([]db.PostDraft, error) { return nil, errors.New("db is down") }
+		published, err := p.publish(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, 0, published)
+		assert.Empty(t, s.posted)
+	})
+}
+
+func TestValidatePostDraft(t *testing.T) {
+	api := FeedAPI{validator: validate.WithCustomValidators()}
+	tokens := []persist.DBID{"token"}
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		title     string
+		tokenIDs  []persist.DBID
+		caption   *string
+		mintURL   *string
+		publishAt *time.Time
+		invalid   []string
+	}{
+		{
+			title: "allows an empty draft that isn't scheduled",
+		},
+		{
+			title:     "allows a scheduled draft with tokens",
+			tokenIDs:  tokens,
+			caption:   util.ToPointer("caption"),
+			publishAt: &future,
+		},
+		{
+			title:     "requires tokens for a scheduled draft",
+			publishAt: &future,
+			invalid:   []string{"tokenIDs"},
+		},
+		{
+			title:     "requires a scheduled draft to be published in the future",
+			tokenIDs:  tokens,
+			publishAt: &past,
+			invalid:   []string{"publishAt"},
+		},
+		{
+			title:     "reports every invalid field of a scheduled draft",
+			publishAt: &past,
+			invalid:   []string{"tokenIDs", "publishAt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			err := api.validatePostDraft(tt.tokenIDs, tt.caption, tt.mintURL, tt.publishAt)
+			if len(tt.invalid) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var invalid validate.ErrInvalidInput
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, tt.invalid, invalid.Parameters)
+		})
+	}
+
+	t.Run("rejects a mint URL that isn't a URL", func(t *testing.T) {
+		assert.Error(t, api.validatePostDraft(nil, nil, util.ToPointer("not a url"), nil))
+	})
+
+	t.Run("rejects a caption that's too long", func(t *testing.T) {
+		caption := string(make([]rune, 2001))
+		assert.Error(t, api.validatePostDraft(nil, &caption, nil, nil))
+	})
+}
+
+func TestCheckPostEditable(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		title    string
+		post     db.Post
+		expected error
+	}{
+		{
+			title: "allows the author to edit a new post",
+			post:  db.Post{ActorID: "author", CreatedAt: now.Add(-time.Minute)},
+		},
+		{
+			title: "allows the author to edit a post at the end of the edit window",
+			post:  db.Post{ActorID: "author", CreatedAt: now.Add(-postEditWindow)},
+		},
+		{
+			title:    "doesn't allow edits after the edit window",
+			post:     db.Post{ActorID: "author", CreatedAt: now.Add(-postEditWindow - time.Second)},
+			expected: ErrPostEditWindowClosed,
+		},
+		{
+			title:    "only allows the author to edit a post",
+			post:     db.Post{ActorID: "someone else", CreatedAt: now},
+			expected: ErrOnlyEditOwnPost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, checkPostEditable(tt.post, "author", now))
+		})
+	}
+}
+
+func TestCheckPollVote(t *testing.T) {
+	now := time.Now()
+	options := []string{"yes", "no"}
+
+	tests := []struct {
+		title       string
+		closesAt    time.Time
+		optionIndex int
+		invalid     bool
+		expected    error
+	}{
+		{
+			title:       "allows a vote for an option of an open poll",
+			closesAt:    now.Add(time.Hour),
+			optionIndex: 1,
+		},
+		{
+			title:       "doesn't allow votes once the poll has closed",
+			closesAt:    now.Add(-time.Second),
+			optionIndex: 0,
+			expected:    ErrPollClosed,
+		},
+		{
+			title:       "doesn't allow votes at the moment the poll closes",
+			closesAt:    now,
+			optionIndex: 0,
+			expected:    ErrPollClosed,
+		},
+		{
+			title:       "rejects an option index past the last option",
+			closesAt:    now.Add(time.Hour),
+			optionIndex: len(options),
+			invalid:     true,
+		},
+		{
+			title:       "rejects a negative option index",
+			closesAt:    now.Add(time.Hour),
+			optionIndex: -1,
+			invalid:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			poll := db.PostAttachment{PollOptions: options, PollClosesAt: sql.NullTime{Time: tt.closesAt, Valid: true}}
+			err := checkPollVote(poll, tt.optionIndex, now)
+			if tt.invalid {
+				var invalid validate.ErrInvalidInput
+				require.ErrorAs(t, err, &invalid)
+				assert.Equal(t, []string{"optionIndex"}, invalid.Parameters)
+				return
+			}
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestPreparePollAttachment(t *testing.T) {
+	ctx := context.Background()
+	api := FeedAPI{}
+	closesAt := time.Now().Add(time.Hour)
+
+	t.Run("trims the options of a valid poll", func(t *testing.T) {
+		actual, err := api.preparePostAttachment(ctx, &model.PostAttachmentInput{
+			Poll: &model.PollInput{Options: []string{" yes ", "no"}, ClosesAt: closesAt},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(persist.PostAttachmentTypePoll), actual.AttachmentType)
+		assert.Equal(t, []string{"yes", "no"}, actual.PollOptions)
+		assert.Equal(t, sql.NullTime{Time: closesAt, Valid: true}, actual.PollClosesAt)
+	})
+
+	tests := []struct {
+		title    string
+		input    model.PostAttachmentInput
+		expected []string
+	}{
+		{
+			title:    "requires at least two options",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"yes"}, ClosesAt: closesAt}},
+			expected: []string{"options"},
+		},
+		{
+			title:    "allows at most four options",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"a", "b", "c", "d", "e"}, ClosesAt: closesAt}},
+			expected: []string{"options"},
+		},
+		{
+			title:    "requires options to be unique regardless of case",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"yes", "Yes "}, ClosesAt: closesAt}},
+			expected: []string{"options"},
+		},
+		{
+			title:    "rejects blank options",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"yes", " "}, ClosesAt: closesAt}},
+			expected: []string{"options"},
+		},
+		{
+			title:    "rejects options that are too long",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"yes", strings.Repeat("a", maxPollOptionLength+1)}, ClosesAt: closesAt}},
+			expected: []string{"options"},
+		},
+		{
+			title:    "requires the poll to close in the future",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"yes", "no"}, ClosesAt: time.Now().Add(-time.Hour)}},
+			expected: []string{"closesAt"},
+		},
+		{
+			title:    "rejects polls that are open for more than a week",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"yes", "no"}, ClosesAt: time.Now().Add(maxPollDuration + time.Hour)}},
+			expected: []string{"closesAt"},
+		},
+		{
+			title:    "reports every invalid field of a poll",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"yes"}, ClosesAt: time.Now().Add(-time.Hour)}},
+			expected: []string{"options", "closesAt"},
+		},
+		{
+			title:    "requires exactly one kind of attachment",
+			input:    model.PostAttachmentInput{Poll: &model.PollInput{Options: []string{"yes", "no"}, ClosesAt: closesAt}, LinkURL: util.ToPointer("https://example.com")},
+			expected: []string{"attachment"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			_, err := api.preparePostAttachment(ctx, &tt.input)
+			var invalid validate.ErrInvalidInput
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, tt.expected, invalid.Parameters)
+		})
+	}
+}
+
+func TestNewMentions(t *testing.T) {
+	user := func(id persist.DBID, deleted bool) db.Mention { return db.Mention{UserID: id, Deleted: deleted} }
+	community := func(id persist.DBID, deleted bool) db.Mention { return db.Mention{CommunityID: id, Deleted: deleted} }
+
+	t.Run("only returns users and communities that weren't mentioned before", func(t *testing.T) {
+		actual := newMentions(
+			[]db.Mention{user("alice", false), community("squiggles", false)},
+			[]db.Mention{user("alice", false), user("bob", false), community("squiggles", false), community("punks", false)},
+		)
+		assert.Equal(t, []db.Mention{user("bob", false), community("punks", false)}, actual)
+	})
+
+	t.Run("doesn't return mentions that were removed by an earlier edit and added back", func(t *testing.T) {
+		actual := newMentions([]db.Mention{user("alice", true), user("bob", false)}, []db.Mention{user("alice", false)})
+		assert.Empty(t, actual)
+	})
+
+	t.Run("returns every mention of a post that didn't have any", func(t *testing.T) {
+		current := []db.Mention{user("alice", false), community("punks", false)}
+		assert.Equal(t, current, newMentions(nil, current))
+	})
+}
@@ -15,8 +15,11 @@ import (
 	"github.com/mikeydub/go-gallery/graphql/dataloader"
 	"github.com/mikeydub/go-gallery/graphql/model"
 	"github.com/mikeydub/go-gallery/service/auth"
+	"github.com/mikeydub/go-gallery/service/logger"
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/persist/postgres"
+	sentryutil "github.com/mikeydub/go-gallery/service/sentry"
+	"github.com/mikeydub/go-gallery/service/task"
 	"github.com/mikeydub/go-gallery/util"
 	"github.com/mikeydub/go-gallery/validate"
 	"net"
@@ -26,11 +29,12 @@ import (
 const maxCollectionsPerGallery = 1000
 
 type GalleryAPI struct {
-	repos     *postgres.Repositories
-	queries   *db.Queries
-	loaders   *dataloader.Loaders
-	validator *validator.Validate
-	ethClient *ethclient.Client
+	repos      *postgres.Repositories
+	queries    *db.Queries
+	loaders    *dataloader.Loaders
+	validator  *validator.Validate
+	ethClient  *ethclient.Client
+	taskClient *task.Client
 }
 
 func (api GalleryAPI) CreateGallery(ctx context.Context, name, description *string, position string) (db.Gallery, error) {
@@ -109,16 +113,21 @@ func (api GalleryAPI) UpdateGallery(ctx context.Context, update model.UpdateGall
 	// create collections
 	mappedIDs := make(map[persist.DBID]persist.DBID)
 	for _, c := range update.CreatedCollections {
+		tokens, layout, settings, err := groupEditions(ctx, q, userID, c.Tokens, modelToTokenLayout(c.Layout), modelToTokenSettings(c.TokenSettings), nil)
+		if err != nil {
+			return db.Gallery{}, err
+		}
+
 		collectionID, err := q.CreateCollection(ctx, db.CreateCollectionParams{
 			ID:             persist.GenerateID(),
 			Name:           persist.StrPtrToNullStr(&c.Name),
 			CollectorsNote: persist.StrPtrToNullStr(&c.CollectorsNote),
 			OwnerUserID:    curGal.OwnerUserID,
 			GalleryID:      update.GalleryID,
-			Layout:         modelToTokenLayout(c.Layout),
+			Layout:         layout,
 			Hidden:         c.Hidden,
-			Nfts:           c.Tokens,
-			TokenSettings:  modelToTokenSettings(c.TokenSettings),
+			Nfts:           tokens,
+			TokenSettings:  settings,
 		})
 		if err != nil {
 			return db.Gallery{}, err
@@ -133,7 +142,7 @@ func (api GalleryAPI) UpdateGallery(ctx context.Context, update model.UpdateGall
 			CollectionID:   collectionID,
 			GalleryID:      update.GalleryID,
 			Data: persist.EventData{
-				CollectionTokenIDs:       c.Tokens,
+				CollectionTokenIDs:       tokens,
 				CollectionCollectorsNote: c.CollectorsNote,
 			},
 		})
@@ -229,6 +238,11 @@ func (api GalleryAPI) UpdateGallery(ctx context.Context, update model.UpdateGall
 		return db.Gallery{}, err
 	}
 
+	// Events only cover tokens that were added, so pins are updated here for collections that were removed or hidden
+	if len(update.DeletedCollections) > 0 || len(update.UpdatedCollections) > 0 {
+		repinGallery(ctx, api.taskClient, update.GalleryID)
+	}
+
 	return newGall, nil
 }
 
@@ -266,19 +280,32 @@ func updateCollectionsInfoAndTokens(ctx context.Context, q *db.Queries, actor, g
 		return nil, err
 	}
 
-	layouts, err := util.Map(update, func(u *model.UpdateCollectionInput) (pgtype.JSONB, error) {
-		return persist.ToJSONB(modelToTokenLayout(u.Layout))
-	})
-	if err != nil {
-		return nil, err
-	}
+	curCols := make([]db.Collection, len(update))
+	tokens := make([][]persist.DBID, len(update))
+	layouts := make([]pgtype.JSONB, len(update))
+	tokenSettings := make([]pgtype.JSONB, len(update))
 
-	tokenSettings, err := util.Map(update, func(u *model.UpdateCollectionInput) (pgtype.JSONB, error) {
-		settings := modelToTokenSettings(u.TokenSettings)
-		return persist.ToJSONB(settings)
-	})
-	if err != nil {
-		return nil, err
+	for i, u := range update {
+		curCols[i], err = q.GetCollectionById(ctx, u.Dbid)
+		if err != nil {
+			return nil, err
+		}
+
+		grouped, layout, settings, err := groupEditions(ctx, q, actor, u.Tokens, modelToTokenLayout(u.Layout), modelToTokenSettings(u.TokenSettings), curCols[i].TokenSettings)
+		if err != nil {
+			return nil, err
+		}
+		tokens[i] = grouped
+
+		layouts[i], err = persist.ToJSONB(layout)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenSettings[i], err = persist.ToJSONB(settings)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	hiddens, err := util.Map(update, func(u *model.UpdateCollectionInput) (bool, error) {
@@ -295,11 +322,8 @@ func updateCollectionsInfoAndTokens(ctx context.Context, q *db.Queries, actor, g
 		return nil, err
 	}
 
-	for _, collection := range update {
-		curCol, err := q.GetCollectionById(ctx, collection.Dbid)
-		if err != nil {
-			return nil, err
-		}
+	for i, collection := range update {
+		curCol := curCols[i]
 
 		// add event if collectors note updated
 		if collection.CollectorsNote != "" && collection.CollectorsNote != curCol.CollectorsNote.String {
@@ -329,7 +353,7 @@ func updateCollectionsInfoAndTokens(ctx context.Context, q *db.Queries, actor, g
 		return nil, err
 	}
 
-	for _, collection := range update {
+	for i, collection := range update {
 		curTokens, err := q.GetCollectionTokensByCollectionID(ctx, collection.Dbid)
 		if err != nil {
 			return nil, err
@@ -337,13 +361,13 @@ func updateCollectionsInfoAndTokens(ctx context.Context, q *db.Queries, actor, g
 
 		err = q.UpdateCollectionTokens(ctx, db.UpdateCollectionTokensParams{
 			ID:   collection.Dbid,
-			Nfts: collection.Tokens,
+			Nfts: tokens[i],
 		})
 		if err != nil {
 			return nil, err
 		}
 
-		diff := util.Difference(curTokens, collection.Tokens)
+		diff := util.Difference(curTokens, tokens[i])
 
 		if len(diff) > 0 {
 			events = append(events, db.Event{
@@ -383,6 +407,8 @@ func (api GalleryAPI) DeleteGallery(ctx context.Context, galleryID persist.DBID)
 		return err
 	}
 
+	repinGallery(ctx, api.taskClient, galleryID)
+
 	return nil
 }
 
@@ -551,6 +577,8 @@ func (api GalleryAPI) UpdateGalleryHidden(ctx context.Context, galleryID persist
 		return db.Gallery{}, err
 	}
 
+	repinGallery(ctx, api.taskClient, galleryID)
+
 	return gallery, nil
 }
 
@@ -744,6 +772,15 @@ func getPreviewMediaForCommunityGallery(ctx context.Context, communityTokenIDs [
 	return media, lastUpdated
 }
 
+// repinGallery updates the pinned media of a gallery after a change that removes tokens from its visible collections.
+// The change has already been saved, so a failure is reported rather than returned.
+func repinGallery(ctx context.Context, taskClient *task.Client, galleryID persist.DBID) {
+	if err := taskClient.CreateTaskForIPFSPinGallery(ctx, task.IPFSPinGalleryMessage{GalleryID: galleryID}); err != nil {
+		logger.For(ctx).WithError(err).Errorf("failed to create task to update pins of gallery %s", galleryID)
+		sentryutil.ReportError(ctx, err)
+	}
+}
+
 func getExternalID(ctx context.Context) *string {
 	gc := util.MustGetGinContext(ctx)
 	if ip := net.ParseIP(gc.ClientIP()); ip != nil && !ip.IsPrivate() {
@@ -773,7 +810,11 @@ func modelToTokenLayout(u *model.CollectionLayoutInput) persist.TokenLayout {
 func modelToTokenSettings(u []*model.CollectionTokenSettingsInput) map[persist.DBID]persist.CollectionTokenSettings {
 	settings := make(map[persist.DBID]persist.CollectionTokenSettings)
 	for _, tokenSetting := range u {
-		settings[tokenSetting.TokenID] = persist.CollectionTokenSettings{RenderLive: tokenSetting.RenderLive, HighDefinition: tokenSetting.HighDefinition}
+		settings[tokenSetting.TokenID] = persist.CollectionTokenSettings{
+			RenderLive:       tokenSetting.RenderLive,
+			HighDefinition:   tokenSetting.HighDefinition,
+			ShowEditionCount: util.FromPointer(tokenSetting.ShowEditionCount),
+		}
 	}
 	return settings
 }
@@ -25,6 +25,8 @@ import (
 
 var ErrOnlyRemoveOwnAdmire = errors.New("only the actor who created the admire can remove it")
 var ErrOnlyRemoveOwnComment = errors.New("only the actor who created the comment can remove it")
+var ErrOnlyPinOnOwnPost = errors.New("only the author of a post can pin its comments")
+var ErrOnlyPinTopLevelPostComments = errors.New("only top-level comments on posts can be pinned")
 
 type interactionType int
 
@@ -400,7 +402,8 @@ func (api InteractionAPI) PaginateCommentsByFeedEventID(ctx context.Context, fee
 	return paginator.Paginate(before, after, first, last)
 }
 
-func (api InteractionAPI) PaginateRepliesByCommentID(ctx context.Context, commentID persist.DBID, before *string, after *string, first *int, last *int) ([]db.Comment, PageInfo, error) {
+// PaginateRepliesByCommentID paginates the replies to a comment. Replies are sorted oldest first when sortBy is nil.
+func (api InteractionAPI) PaginateRepliesByCommentID(ctx context.Context, commentID persist.DBID, sortBy *model.CommentSort, before *string, after *string, first *int, last *int) ([]db.Comment, PageInfo, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
 		"commentID": validate.WithTag(commentID, "required"),
@@ -412,15 +415,17 @@ func (api InteractionAPI) PaginateRepliesByCommentID(ctx context.Context, commen
 		return nil, PageInfo{}, err
 	}
 
-	queryFunc := func(params TimeIDPagingParams) ([]db.Comment, error) {
+	queryFunc := func(params commentPagingParams) ([]db.PaginateRepliesByCommentIDBatchRow, error) {
 		return api.loaders.PaginateRepliesByCommentIDBatch.Load(db.PaginateRepliesByCommentIDBatchParams{
-			CommentID:     commentID,
-			Limit:         params.Limit,
-			CurBeforeTime: params.CursorBeforeTime,
-			CurBeforeID:   params.CursorBeforeID,
-			CurAfterTime:  params.CursorAfterTime,
-			CurAfterID:    params.CursorAfterID,
-			PagingForward: params.PagingForward,
+			SortBy:           string(commentSortOrDefault(sortBy)),
+			SnapshotTime:     params.SnapshotTime,
+			CommentID:        commentID,
+			CurBeforeSortKey: params.CursorBeforeSortKey,
+			CurBeforeID:      params.CursorBeforeID,
+			CurAfterSortKey:  params.CursorAfterSortKey,
+			CurAfterID:       params.CursorAfterID,
+			PagingForward:    params.PagingForward,
+			Limit:            params.Limit,
 		})
 	}
 
@@ -429,17 +434,41 @@ func (api InteractionAPI) PaginateRepliesByCommentID(ctx context.Context, commen
 		return int(total), err
 	}
 
-	cursorFunc := func(c db.Comment) (time.Time, persist.DBID, error) {
-		return c.CreatedAt, c.ID, nil
+	// Replies can't be pinned
+	cursorFunc := func(r db.PaginateRepliesByCommentIDBatchRow) (bool, float64, persist.DBID, error) {
+		return true, r.SortKey, r.Comment.ID, nil
 	}
 
-	paginator := TimeIDPaginator[db.Comment]{
+	paginator := commentPaginator[db.PaginateRepliesByCommentIDBatchRow]{
+		SortBy:     commentSortOrDefault(sortBy),
 		QueryFunc:  queryFunc,
 		CursorFunc: cursorFunc,
 		CountFunc:  countFunc,
 	}
 
-	return paginator.Paginate(before, after, first, last)
+	rows, pageInfo, err := paginator.paginate(before, after, first, last)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	return util.MapWithoutError(rows, func(r db.PaginateRepliesByCommentIDBatchRow) db.Comment { return r.Comment }), pageInfo, nil
+}
+
+// GetTotalRepliesByCommentID returns the number of replies in a comment's thread
+func (api InteractionAPI) GetTotalRepliesByCommentID(ctx context.Context, commentID persist.DBID) (*int, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"commentID": validate.WithTag(commentID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	count, err := api.loaders.CountRepliesByCommentIDBatch.Load(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.ToPointer(int(count)), nil
 }
 
 func (api InteractionAPI) GetTotalCommentsByPostID(ctx context.Context, postID persist.DBID) (*int, error) {
@@ -570,10 +599,12 @@ func (api InteractionAPI) PaginateAdmiresByTokenID(ctx context.Context, tokenID
 	return paginator.Paginate(before, after, first, last)
 }
 
-func (api InteractionAPI) PaginateCommentsByPostID(ctx context.Context, postID persist.DBID, before *string, after *string, first *int, last *int) ([]db.Comment, PageInfo, error) {
+// PaginateCommentsByPostID paginates the top-level comments on a post. The post's pinned comment comes first, followed
+// by the rest of the comments sorted by sortBy, or oldest first when sortBy is nil.
+func (api InteractionAPI) PaginateCommentsByPostID(ctx context.Context, postID persist.DBID, sortBy *model.CommentSort, before *string, after *string, first *int, last *int) ([]db.Comment, PageInfo, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
-		"feedEventID": validate.WithTag(postID, "required"),
+		"postID": validate.WithTag(postID, "required"),
 	}); err != nil {
 		return nil, PageInfo{}, err
 	}
@@ -582,15 +613,19 @@ func (api InteractionAPI) PaginateCommentsByPostID(ctx context.Context, postID p
 		return nil, PageInfo{}, err
 	}
 
-	queryFunc := func(params TimeIDPagingParams) ([]db.Comment, error) {
+	queryFunc := func(params commentPagingParams) ([]db.PaginateCommentsByPostIDBatchRow, error) {
 		return api.loaders.PaginateCommentsByPostIDBatch.Load(db.PaginateCommentsByPostIDBatchParams{
-			PostID:        postID,
-			Limit:         params.Limit,
-			CurBeforeTime: params.CursorBeforeTime,
-			CurBeforeID:   params.CursorBeforeID,
-			CurAfterTime:  params.CursorAfterTime,
-			CurAfterID:    params.CursorAfterID,
-			PagingForward: params.PagingForward,
+			SortBy:             string(commentSortOrDefault(sortBy)),
+			SnapshotTime:       params.SnapshotTime,
+			PostID:             postID,
+			CurBeforeNotPinned: params.CursorBeforeNotPinned,
+			CurBeforeSortKey:   params.CursorBeforeSortKey,
+			CurBeforeID:        params.CursorBeforeID,
+			CurAfterNotPinned:  params.CursorAfterNotPinned,
+			CurAfterSortKey:    params.CursorAfterSortKey,
+			CurAfterID:         params.CursorAfterID,
+			PagingForward:      params.PagingForward,
+			Limit:              params.Limit,
 		})
 	}
 
@@ -599,17 +634,121 @@ func (api InteractionAPI) PaginateCommentsByPostID(ctx context.Context, postID p
 		return int(total), err
 	}
 
-	cursorFunc := func(c db.Comment) (time.Time, persist.DBID, error) {
-		return c.CreatedAt, c.ID, nil
+	cursorFunc := func(r db.PaginateCommentsByPostIDBatchRow) (bool, float64, persist.DBID, error) {
+		return r.NotPinned, r.SortKey, r.Comment.ID, nil
 	}
 
-	paginator := TimeIDPaginator[db.Comment]{
+	paginator := commentPaginator[db.PaginateCommentsByPostIDBatchRow]{
+		SortBy:     commentSortOrDefault(sortBy),
 		QueryFunc:  queryFunc,
 		CursorFunc: cursorFunc,
 		CountFunc:  countFunc,
 	}
 
-	return paginator.Paginate(before, after, first, last)
+	rows, pageInfo, err := paginator.paginate(before, after, first, last)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	return util.MapWithoutError(rows, func(r db.PaginateCommentsByPostIDBatchRow) db.Comment { return r.Comment }), pageInfo, nil
+}
+
+// GetPinnedCommentByPostID returns the comment pinned to a post, or nil if the post doesn't have one
+func (api InteractionAPI) GetPinnedCommentByPostID(ctx context.Context, postID persist.DBID) (*db.Comment, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"postID": validate.WithTag(postID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	comment, err := api.loaders.GetPinnedCommentByPostIDBatch.Load(postID)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// PinComment pins a top-level comment to the top of its post's comments, replacing the post's pinned comment if
+// it has one. Only the post's author can pin comments.
+func (api InteractionAPI) PinComment(ctx context.Context, commentID persist.DBID) (db.Comment, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"commentID": validate.WithTag(commentID, "required"),
+	}); err != nil {
+		return db.Comment{}, err
+	}
+
+	userID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return db.Comment{}, err
+	}
+
+	comment, err := api.loaders.GetCommentByCommentIDBatch.Load(commentID)
+	if err != nil {
+		return db.Comment{}, err
+	}
+
+	if comment.PostID == "" || comment.ReplyTo != "" {
+		return db.Comment{}, ErrOnlyPinTopLevelPostComments
+	}
+
+	post, err := api.loaders.GetPostByIdBatch.Load(comment.PostID)
+	if err != nil {
+		return db.Comment{}, err
+	}
+
+	if post.ActorID != userID {
+		return db.Comment{}, ErrOnlyPinOnOwnPost
+	}
+
+	err = api.queries.PinComment(ctx, db.PinCommentParams{
+		ID:        persist.GenerateID(),
+		PostID:    post.ID,
+		CommentID: comment.ID,
+	})
+	if err != nil {
+		return db.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+// UnpinComment removes a post's pinned comment. Only the post's author can unpin comments.
+func (api InteractionAPI) UnpinComment(ctx context.Context, postID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"postID": validate.WithTag(postID, "required"),
+	}); err != nil {
+		return err
+	}
+
+	userID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	post, err := api.loaders.GetPostByIdBatch.Load(postID)
+	if err != nil {
+		return err
+	}
+
+	if post.ActorID != userID {
+		return ErrOnlyPinOnOwnPost
+	}
+
+	return api.queries.UnpinCommentByPostID(ctx, postID)
+}
+
+func commentSortOrDefault(sortBy *model.CommentSort) model.CommentSort {
+	if sortBy == nil {
+		return model.CommentSortOldest
+	}
+	return *sortBy
 }
 
 func (api InteractionAPI) GetAdmireByActorIDAndFeedEventID(ctx context.Context, actorID persist.DBID, feedEventID persist.DBID) (*db.Admire, error) {
@@ -1108,6 +1247,32 @@ func (api InteractionAPI) ReportPost(ctx context.Context, postID persist.DBID, r
 	return err
 }
 
+// MarkNotInterested hides the post from the viewer's feeds, and makes posts by the same creator and about the same
+// collections less relevant to the viewer
+func (api InteractionAPI) MarkNotInterested(ctx context.Context, postID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"postID": validate.WithTag(postID, "required"),
+	}); err != nil {
+		return err
+	}
+
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.queries.MarkPostNotInterested(ctx, db.MarkPostNotInterestedParams{
+		ID:     persist.GenerateID(),
+		UserID: viewerID,
+		PostID: postID,
+	})
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return persist.ErrPostNotFoundByID{ID: postID}
+	}
+	return err
+}
+
 func mentionInputsToMentions(ctx context.Context, ms []*model.MentionInput, queries *db.Queries) ([]db.Mention, error) {
 	res := make([]db.Mention, len(ms))
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/mikeydub/go-gallery/graphql/model"
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/validate"
 )
@@ -368,6 +369,105 @@ func (p *boolIDFloatIDPaginator[Node]) paginate(before *string, after *string, f
 	return paginator.paginate(before, after, first, last)
 }
 
+// commentPaginator paginates comments by a model.CommentSort. The sort and the time of the first page are encoded in the
+// cursor: later pages must use the same sort, and admires are counted as of the first page so that comments don't move
+// between pages as they're admired.
+type commentPaginator[Node any] struct {
+	// SortBy is the requested sort, which must match the sort of the cursors
+	SortBy model.CommentSort
+
+	// QueryFunc returns paginated results for the given paging parameters
+	QueryFunc func(params commentPagingParams) ([]Node, error)
+
+	// CursorFunc returns whether the node isn't pinned, its sort key, and its DBID, which will be encoded into a cursor string
+	CursorFunc func(node Node) (bool, float64, persist.DBID, error)
+
+	// CountFunc returns the total number of items that can be paginated. May be nil, in which
+	// case the resulting PageInfo will omit the total field.
+	CountFunc func() (count int, err error)
+}
+
+// commentPagingParams are the parameters used to paginate with a comment cursor
+type commentPagingParams struct {
+	Limit                 int32
+	SnapshotTime          time.Time
+	CursorBeforeNotPinned bool
+	CursorBeforeSortKey   float64
+	CursorBeforeID        persist.DBID
+	CursorAfterNotPinned  bool
+	CursorAfterSortKey    float64
+	CursorAfterID         persist.DBID
+	PagingForward         bool
+}
+
+func (p *commentPaginator[Node]) paginate(before *string, after *string, first *int, last *int) ([]Node, PageInfo, error) {
+	beforeCur := cursors.NewCommentCursor()
+	beforeCur.NotPinned = true
+	beforeCur.SortKey = defaultCursorBeforeFloat
+	beforeCur.ID = defaultCursorBeforeID
+	afterCur := cursors.NewCommentCursor()
+	afterCur.NotPinned = false
+	afterCur.SortKey = defaultCursorAfterFloat
+	afterCur.ID = defaultCursorAfterID
+
+	if before != nil {
+		if err := p.unpackCursor(beforeCur, *before); err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+
+	if after != nil {
+		if err := p.unpackCursor(afterCur, *after); err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+
+	snapshotTime := time.Now()
+	if after != nil && !afterCur.SnapshotTime.IsZero() {
+		snapshotTime = afterCur.SnapshotTime
+	} else if before != nil && !beforeCur.SnapshotTime.IsZero() {
+		snapshotTime = beforeCur.SnapshotTime
+	}
+
+	queryFunc := func(limit int32, pagingForward bool) ([]Node, error) {
+		return p.QueryFunc(commentPagingParams{
+			Limit:                 limit,
+			SnapshotTime:          snapshotTime,
+			CursorBeforeNotPinned: beforeCur.NotPinned,
+			CursorBeforeSortKey:   beforeCur.SortKey,
+			CursorBeforeID:        beforeCur.ID,
+			CursorAfterNotPinned:  afterCur.NotPinned,
+			CursorAfterSortKey:    afterCur.SortKey,
+			CursorAfterID:         afterCur.ID,
+			PagingForward:         pagingForward,
+		})
+	}
+
+	paginator := keysetPaginator[Node, *commentCursor]{
+		QueryFunc:  queryFunc,
+		Cursorable: newCommentCursor(p.SortBy, snapshotTime, p.CursorFunc),
+		CountFunc:  p.CountFunc,
+	}
+
+	return paginator.paginate(before, after, first, last)
+}
+
+func (p *commentPaginator[Node]) unpackCursor(cur *commentCursor, s string) error {
+	if s == "" {
+		return nil
+	}
+	if err := cur.Unpack(s); err != nil {
+		return err
+	}
+	if cur.SortBy != string(p.SortBy) {
+		return validate.ErrInvalidInput{
+			Parameters: []string{"sortBy"},
+			Reasons:    []string{fmt.Sprintf("cursor is for comments sorted by %s, not %s", cur.SortBy, p.SortBy)},
+		}
+	}
+	return nil
+}
+
 type sharedFollowersPaginator[Node any] struct{ TimeIDPaginator[Node] }
 
 func (p *sharedFollowersPaginator[Node]) paginate(before *string, after *string, first *int, last *int) ([]Node, PageInfo, error) {
@@ -890,6 +990,11 @@ func (d *cursorDecoder) readString() (string, error) {
 		return "", err
 	}
 
+	// Reading zero bytes at the end of the stream returns io.EOF, so empty strings are returned without a read
+	if strLen == 0 {
+		return "", nil
+	}
+
 	strBytes := make([]byte, strLen)
 	numRead, err := d.reader.Read(strBytes)
 	if err != nil {
@@ -1021,10 +1126,10 @@ func newPositionCursor[Node any](f func(Node) (int64, []persist.DBID, error)) cu
 	}
 }
 
-func newFeedPositionCursor[Node any](f func(Node) (int64, []persist.FeedEntityType, []persist.DBID, error)) cursorable[Node, *feedPositionCursor] {
+func newFeedPositionCursor[Node any](f func(Node) (int64, []persist.FeedEntityType, []persist.DBID, string, error)) cursorable[Node, *feedPositionCursor] {
 	return func(node Node) (c *feedPositionCursor, err error) {
 		c = cursors.NewFeedPositionCursor()
-		c.CurrentPosition, c.EntityTypes, c.EntityIDs, err = f(node)
+		c.CurrentPosition, c.EntityTypes, c.EntityIDs, c.Variant, err = f(node)
 		c.Positions = sliceToMapIndex(c.EntityIDs)
 		return c, err
 	}
@@ -1038,6 +1143,15 @@ func newBoolIDFloatIDCursor[Node any](f func(Node) (bool, persist.DBID, float64,
 	}
 }
 
+func newCommentCursor[Node any](sortBy model.CommentSort, snapshotTime time.Time, f func(Node) (bool, float64, persist.DBID, error)) cursorable[Node, *commentCursor] {
+	return func(node Node) (c *commentCursor, err error) {
+		c = cursors.NewCommentCursor()
+		c.SortBy, c.SnapshotTime = string(sortBy), snapshotTime
+		c.NotPinned, c.SortKey, c.ID, err = f(node)
+		return c, err
+	}
+}
+
 //------------------------------------------------------------------------------
 
 type timeIDCursor struct {
@@ -1120,6 +1234,8 @@ type feedPositionCursor struct {
 	EntityTypes     []persist.FeedEntityType
 	EntityIDs       []persist.DBID
 	Positions       map[persist.DBID]int64
+	// Variant is the name of the ranker that ordered the feed, so that later pages are attributed to it
+	Variant string
 }
 
 func (f *feedPositionCursor) Unpack(s string) error {
@@ -1133,7 +1249,16 @@ func (f *feedPositionCursor) Unpack(s string) error {
 
 func (cursorN) NewFeedPositionCursor() *feedPositionCursor {
 	c := feedPositionCursor{baseCursor: &baseCursor{}, Positions: make(map[persist.DBID]int64)}
-	initCursor(c.baseCursor, &c.CurrentPosition, &c.EntityTypes, &c.EntityIDs)
+	initCursor(c.baseCursor, &c.CurrentPosition, &c.EntityTypes, &c.EntityIDs, &c.Variant)
+	// Cursors created before the variant was added end after the entity IDs
+	unpackVariant := c.unpackFs[3]
+	c.unpackFs[3] = func() error {
+		if c.d.reader.Len() == 0 {
+			c.Variant = ""
+			return nil
+		}
+		return unpackVariant()
+	}
 	return &c
 }
 
@@ -1179,6 +1304,46 @@ func (cursorN) NewBoolIDFloatIDCursor() *boolIDFloatIDCursor {
 
 //------------------------------------------------------------------------------
 
+type commentCursor struct {
+	*baseCursor
+	SortBy       string
+	SnapshotTime time.Time
+	NotPinned    bool
+	SortKey      float64
+	ID           persist.DBID
+}
+
+func (c *commentCursor) Unpack(s string) error {
+	err := c.baseCursor.Unpack(s)
+	if err == nil && model.CommentSort(c.SortBy).IsValid() {
+		return nil
+	}
+
+	// Cursors created before comments could be sorted are time and ID cursors of comments sorted oldest first
+	legacy := cursors.NewTimeIDCursor()
+	if legacyErr := legacy.Unpack(s); legacyErr != nil {
+		if err == nil {
+			err = fmt.Errorf("invalid comment sort: %q", c.SortBy)
+		}
+		return err
+	}
+
+	c.SortBy = string(model.CommentSortOldest)
+	c.SnapshotTime = time.Time{}
+	c.NotPinned = true
+	c.SortKey = float64(legacy.Time.UnixMicro()) / 1e6
+	c.ID = legacy.ID
+	return nil
+}
+
+func (cursorN) NewCommentCursor() *commentCursor {
+	c := commentCursor{baseCursor: &baseCursor{}}
+	initCursor(c.baseCursor, &c.SortBy, &c.SnapshotTime, &c.NotPinned, &c.SortKey, &c.ID)
+	return &c
+}
+
+//------------------------------------------------------------------------------
+
 func initCursor(cur *baseCursor, vals ...any) {
 	cur.packVals = vals
 	d, _ := newCursorDecoder("")
@@ -5,8 +5,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/mikeydub/go-gallery/graphql/model"
 	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/validate"
 )
 
 func TestMain(t *testing.T) {
@@ -107,6 +110,38 @@ func TestMain(t *testing.T) {
 				assert.Equal(t, curA.EntityIDs, curB.EntityIDs)
 			})
 
+			t.Run("can decode feedPosition with a variant", func(t *testing.T) {
+				curA := cursors.NewFeedPositionCursor()
+				curA.CurrentPosition = 1
+				curA.EntityTypes = []persist.FeedEntityType{0}
+				curA.EntityIDs = []persist.DBID{"a", "b"}
+				curA.Variant = "ranker"
+				packed, err := curA.Pack()
+				assert.NoError(t, err)
+
+				curB := cursors.NewFeedPositionCursor()
+				assert.NoError(t, curB.Unpack(packed))
+				assert.Equal(t, curA.Variant, curB.Variant)
+				assert.Equal(t, curA.EntityIDs, curB.EntityIDs)
+			})
+
+			t.Run("can decode feedPosition without a variant", func(t *testing.T) {
+				position := int64(1)
+				entityTypes := []persist.FeedEntityType{0, 1}
+				entityIDs := []persist.DBID{"a", "b"}
+				legacy := &baseCursor{}
+				initCursor(legacy, &position, &entityTypes, &entityIDs)
+				packed, err := legacy.Pack()
+				assert.NoError(t, err)
+
+				cur := cursors.NewFeedPositionCursor()
+				assert.NoError(t, cur.Unpack(packed))
+				assert.Equal(t, position, cur.CurrentPosition)
+				assert.Equal(t, entityTypes, cur.EntityTypes)
+				assert.Equal(t, entityIDs, cur.EntityIDs)
+				assert.Empty(t, cur.Variant)
+			})
+
 			t.Run("can decode position", func(t *testing.T) {
 				curA := cursors.NewPositionCursor()
 				curA.CurrentPosition = 2
@@ -122,6 +157,51 @@ func TestMain(t *testing.T) {
 				assert.Equal(t, curA.CurrentPosition, curB.CurrentPosition)
 				assert.Equal(t, curA.IDs, curB.IDs)
 			})
+
+			t.Run("can decode comment", func(t *testing.T) {
+				curA := cursors.NewCommentCursor()
+				curA.SortBy = string(model.CommentSortTop)
+				curA.SnapshotTime = time.Now()
+				curA.NotPinned = true
+				curA.SortKey = -3
+				curA.ID = persist.GenerateID()
+				packed, err := curA.Pack()
+				assert.NoError(t, err)
+
+				curB := cursors.NewCommentCursor()
+				assert.NoError(t, curB.Unpack(packed))
+				assert.Equal(t, curA.SortBy, curB.SortBy)
+				assert.True(t, curA.SnapshotTime.Equal(curB.SnapshotTime))
+				assert.Equal(t, curA.NotPinned, curB.NotPinned)
+				assert.Equal(t, curA.SortKey, curB.SortKey)
+				assert.Equal(t, curA.ID, curB.ID)
+			})
+
+			t.Run("can decode comment from a timeID", func(t *testing.T) {
+				legacy := cursors.NewTimeIDCursor()
+				legacy.Time = time.Date(2023, 6, 1, 12, 30, 15, 123456000, time.UTC)
+				legacy.ID = persist.GenerateID()
+				packed, err := legacy.Pack()
+				assert.NoError(t, err)
+
+				cur := cursors.NewCommentCursor()
+				assert.NoError(t, cur.Unpack(packed))
+				assert.Equal(t, string(model.CommentSortOldest), cur.SortBy)
+				assert.True(t, cur.SnapshotTime.IsZero())
+				assert.True(t, cur.NotPinned)
+				assert.Equal(t, 1685622615.123456, cur.SortKey)
+				assert.Equal(t, legacy.ID, cur.ID)
+			})
+
+			t.Run("can't decode comment from another cursor", func(t *testing.T) {
+				other := cursors.NewStringIDCursor()
+				other.String = "NOT_A_SORT"
+				other.ID = persist.GenerateID()
+				packed, err := other.Pack()
+				assert.NoError(t, err)
+
+				assert.Error(t, cursors.NewCommentCursor().Unpack(packed))
+			})
 		})
 
 		t.Run("cursor pagination returns expected edges", func(t *testing.T) {
@@ -323,6 +403,105 @@ func TestMain(t *testing.T) {
 	})
 }
 
+func TestCommentPaginator(t *testing.T) {
+	first := 2
+	snapshotTime := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// The stub returns the pinned comment followed by the rest of the comments in order of their sort keys
+	nodes := []commentNode{{ID: "pinned", SortKey: -5}, {ID: "a", NotPinned: true, SortKey: -2}, {ID: "b", NotPinned: true, SortKey: -1}}
+
+	packCursor := func(t *testing.T, sortBy model.CommentSort, snapshotTime time.Time, node commentNode) string {
+		cur := cursors.NewCommentCursor()
+		cur.SortBy, cur.SnapshotTime, cur.NotPinned, cur.SortKey, cur.ID = string(sortBy), snapshotTime, node.NotPinned, node.SortKey, node.ID
+		packed, err := cur.Pack()
+		require.NoError(t, err)
+		return packed
+	}
+
+	t.Run("should return the pinned comment first and encode the sort in cursors", func(t *testing.T) {
+		p, _ := newStubCommentPaginator(model.CommentSortTop, nodes)
+		actual, pageInfo, err := p.paginate(nil, nil, &first, nil)
+		require.NoError(t, err)
+		assert.Equal(t, nodes[:2], actual)
+		assert.True(t, pageInfo.HasNextPage)
+
+		cur := cursors.NewCommentCursor()
+		require.NoError(t, cur.Unpack(pageInfo.StartCursor))
+		assert.Equal(t, string(model.CommentSortTop), cur.SortBy)
+		assert.False(t, cur.NotPinned)
+		assert.Equal(t, persist.DBID("pinned"), cur.ID)
+	})
+
+	t.Run("should query later pages as of the time of the first page", func(t *testing.T) {
+		p, params := newStubCommentPaginator(model.CommentSortTop, nodes[1:])
+		after := packCursor(t, model.CommentSortTop, snapshotTime, nodes[0])
+		_, pageInfo, err := p.paginate(nil, &after, &first, nil)
+		require.NoError(t, err)
+		assert.True(t, snapshotTime.Equal(params.SnapshotTime))
+		assert.False(t, params.CursorAfterNotPinned)
+		assert.Equal(t, -5.0, params.CursorAfterSortKey)
+		assert.Equal(t, persist.DBID("pinned"), params.CursorAfterID)
+
+		cur := cursors.NewCommentCursor()
+		require.NoError(t, cur.Unpack(pageInfo.EndCursor))
+		assert.True(t, snapshotTime.Equal(cur.SnapshotTime))
+	})
+
+	t.Run("should query the first page as of now", func(t *testing.T) {
+		p, params := newStubCommentPaginator(model.CommentSortTop, nodes)
+		before := time.Now()
+		_, _, err := p.paginate(nil, nil, &first, nil)
+		require.NoError(t, err)
+		assert.False(t, params.SnapshotTime.Before(before))
+	})
+
+	t.Run("should reject a cursor from a different sort", func(t *testing.T) {
+		p, _ := newStubCommentPaginator(model.CommentSortNewest, nodes)
+		after := packCursor(t, model.CommentSortTop, snapshotTime, nodes[0])
+		_, _, err := p.paginate(nil, &after, &first, nil)
+		var invalid validate.ErrInvalidInput
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, []string{"sortBy"}, invalid.Parameters)
+	})
+
+	t.Run("should continue from a cursor created before comments could be sorted", func(t *testing.T) {
+		legacy := cursors.NewTimeIDCursor()
+		legacy.Time = snapshotTime
+		legacy.ID = "a"
+		after, err := legacy.Pack()
+		require.NoError(t, err)
+
+		p, params := newStubCommentPaginator(model.CommentSortOldest, nodes[2:])
+		_, _, err = p.paginate(nil, &after, &first, nil)
+		require.NoError(t, err)
+		assert.True(t, params.CursorAfterNotPinned)
+		assert.Equal(t, float64(snapshotTime.Unix()), params.CursorAfterSortKey)
+		assert.Equal(t, persist.DBID("a"), params.CursorAfterID)
+		assert.False(t, params.SnapshotTime.IsZero())
+	})
+}
+
+type commentNode struct {
+	ID        persist.DBID
+	NotPinned bool
+	SortKey   float64
+}
+
+// newStubCommentPaginator returns a paginator that returns ret, and the params it was last queried with
+func newStubCommentPaginator(sortBy model.CommentSort, ret []commentNode) (commentPaginator[commentNode], *commentPagingParams) {
+	var params commentPagingParams
+	return commentPaginator[commentNode]{
+		SortBy: sortBy,
+		QueryFunc: func(p commentPagingParams) ([]commentNode, error) {
+			params = p
+			return ret, nil
+		},
+		CursorFunc: func(n commentNode) (bool, float64, persist.DBID, error) {
+			return n.NotPinned, n.SortKey, n.ID, nil
+		},
+	}, &params
+}
+
 type stubCursor struct{ ID string }
 
 func (p stubCursor) Pack() (string, error) { return p.ID, nil }
@@ -3,18 +3,21 @@ package publicapi
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gammazero/workerpool"
 	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v4"
 
 	db "github.com/mikeydub/go-gallery/db/gen/coredb"
 	"github.com/mikeydub/go-gallery/event"
 	"github.com/mikeydub/go-gallery/graphql/dataloader"
 	"github.com/mikeydub/go-gallery/service/auth"
 	"github.com/mikeydub/go-gallery/service/eth"
+	"github.com/mikeydub/go-gallery/service/logger"
 	"github.com/mikeydub/go-gallery/service/multichain"
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/persist/postgres"
@@ -251,10 +254,13 @@ func (api TokenAPI) GetNewTokensByFeedEventID(ctx context.Context, eventID persi
 	return tokens, nil
 }
 
-func (api TokenAPI) GetTokensByWalletID(ctx context.Context, walletID persist.DBID) ([]db.Token, error) {
+// GetTokensByWalletID returns the tokens held by a wallet. If maxSpamScore is provided, tokens of contracts that scored
+// above it are excluded unless the wallet's owner marked them as not spam.
+func (api TokenAPI) GetTokensByWalletID(ctx context.Context, walletID persist.DBID, maxSpamScore *float64) ([]db.Token, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
-		"walletID": validate.WithTag(walletID, "required"),
+		"walletID":     validate.WithTag(walletID, "required"),
+		"maxSpamScore": validate.WithTag(maxSpamScore, "omitempty,min=0,max=1"),
 	}); err != nil {
 		return nil, err
 	}
@@ -266,16 +272,22 @@ func (api TokenAPI) GetTokensByWalletID(ctx context.Context, walletID persist.DB
 
 	tokens := util.MapWithoutError(r, func(r db.GetTokensByWalletIdsBatchRow) db.Token { return r.Token })
 
+	if maxSpamScore != nil {
+		return api.filterTokensBySpamScore(ctx, tokens, *maxSpamScore)
+	}
+
 	return tokens, nil
 }
 
 // GetTokensByUserID returns all tokens owned by a user. ownershipFilter is optional and may be nil or empty,
 // which will cause all tokens to be returned. If filter values are provided, only the tokens matching the
-// filter will be returned.
-func (api TokenAPI) GetTokensByUserID(ctx context.Context, userID persist.DBID, ownershipFilter []persist.TokenOwnershipType) ([]db.Token, error) {
+// filter will be returned. If maxSpamScore is provided, tokens of contracts that scored above it are excluded
+// unless the user marked them as not spam.
+func (api TokenAPI) GetTokensByUserID(ctx context.Context, userID persist.DBID, ownershipFilter []persist.TokenOwnershipType, maxSpamScore *float64) ([]db.Token, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
-		"userID": validate.WithTag(userID, "required"),
+		"userID":       validate.WithTag(userID, "required"),
+		"maxSpamScore": validate.WithTag(maxSpamScore, "omitempty,min=0,max=1"),
 	}); err != nil {
 		return nil, err
 	}
@@ -302,9 +314,45 @@ func (api TokenAPI) GetTokensByUserID(ctx context.Context, userID persist.DBID,
 		return r.Token
 	})
 
+	if maxSpamScore != nil {
+		return api.filterTokensBySpamScore(ctx, tokens, *maxSpamScore)
+	}
+
 	return tokens, nil
 }
 
+// filterTokensBySpamScore removes the tokens of contracts whose spam score is above maxScore. Contracts that haven't been
+// scored are kept, and a user's own spam preference takes precedence over the score.
+func (api TokenAPI) filterTokensBySpamScore(ctx context.Context, tokens []db.Token, maxScore float64) ([]db.Token, error) {
+	contractIDs := util.Dedupe(util.MapWithoutError(tokens, func(t db.Token) string { return t.ContractID.String() }), false)
+
+	scores, err := api.queries.GetContractSpamScoresByContractIDs(ctx, contractIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreByContract := make(map[persist.DBID]float64, len(scores))
+	for _, s := range scores {
+		scoreByContract[s.ContractID] = s.Score
+	}
+
+	filtered := make([]db.Token, 0, len(tokens))
+	for _, t := range tokens {
+		if t.IsUserMarkedSpam.Valid {
+			if !t.IsUserMarkedSpam.Bool {
+				filtered = append(filtered, t)
+			}
+			continue
+		}
+		if score, ok := scoreByContract[t.ContractID]; ok && score > maxScore {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+
+	return filtered, nil
+}
+
 func (api TokenAPI) SyncTokensAdmin(ctx context.Context, chains []persist.Chain, userID persist.DBID) error {
 	if err := api.multichainProvider.SyncTokensByUserID(ctx, userID, chains); err != nil {
 		return ErrTokenRefreshFailed{Message: err.Error()}
@@ -563,6 +611,26 @@ func (api TokenAPI) GetMediaByMediaID(ctx context.Context, id persist.DBID) (db.
 	return api.loaders.GetMediaByMediaIdIgnoringStatusBatch.Load(id)
 }
 
+// GetFlaggedMediaDisplay returns how media with the given moderation result should be displayed to the viewer.
+// Malicious media is always hidden, and NSFW media follows the viewer's preference. Viewers that aren't logged in
+// have NSFW media blurred.
+func (api TokenAPI) GetFlaggedMediaDisplay(ctx context.Context, moderation *persist.Moderation) (persist.FlaggedMediaDisplay, error) {
+	if !moderation.IsFlagged() {
+		return persist.FlaggedMediaDisplayShow, nil
+	}
+
+	if moderation.Label == persist.ModerationLabelMalicious {
+		return persist.FlaggedMediaDisplayHide, nil
+	}
+
+	userID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return persist.FlaggedMediaDisplayBlur, nil
+	}
+
+	return flaggedMediaDisplayForUser(ctx, api.loaders, userID)
+}
+
 func (api TokenAPI) ViewToken(ctx context.Context, tokenID persist.DBID, collectionID persist.DBID) (db.Event, error) {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
@@ -610,9 +678,139 @@ func (api TokenAPI) ViewToken(ctx context.Context, tokenID persist.DBID, collect
 	return db.Event{}, nil
 }
 
-// GetProcessingStateByTokenDefinitionID returns true if a token is queued for processing, or is currently being processed.
-func (api TokenAPI) GetProcessingStateByTokenDefinitionID(ctx context.Context, id persist.DBID) (bool, error) {
-	return api.manager.Processing(ctx, id), nil
+// ProcessingState is the state of a token in the processing pipeline
+type ProcessingState struct {
+	// InFlight is true if the token is queued for processing, or is currently being processed
+	InFlight bool
+	// Trace is a summary of the steps of the run that produced the token's current media. It's only included for admins.
+	Trace persist.PipelineTrace
+}
+
+// GetProcessingStateByTokenDefinitionID returns whether a token is queued for processing, or is currently being processed.
+// Admins also get the trace of the run that produced the token's current media.
+func (api TokenAPI) GetProcessingStateByTokenDefinitionID(ctx context.Context, id persist.DBID) (ProcessingState, error) {
+	state := ProcessingState{InFlight: api.manager.Processing(ctx, id)}
+
+	if !isAdmin(ctx) {
+		return state, nil
+	}
+
+	td, err := api.loaders.GetTokenDefinitionByIdBatch.Load(id)
+	if err != nil {
+		return state, err
+	}
+
+	if td.TokenMediaID == "" {
+		return state, nil
+	}
+
+	media, err := api.loaders.GetMediaByMediaIdIgnoringStatusBatch.Load(td.TokenMediaID)
+	if err != nil {
+		return state, err
+	}
+
+	state.Trace = media.PipelineTrace
+	return state, nil
+}
+
+// PaginateTokenProcessingDeadLetters returns tokens that failed processing and won't be run again until they're replayed.
+// If contractID is provided, only tokens of that contract are returned.
+func (api TokenAPI) PaginateTokenProcessingDeadLetters(ctx context.Context, contractID *persist.DBID, before, after *string, first, last *int) ([]db.TokenProcessingDeadLetter, PageInfo, error) {
+	if err := validatePaginationParams(api.validator, first, last); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	var contract sql.NullString
+	if contractID != nil {
+		contract = util.ToNullStringEmptyNull(contractID.String())
+	}
+
+	queryFunc := func(params TimeIDPagingParams) ([]db.TokenProcessingDeadLetter, error) {
+		return api.queries.PaginateTokenProcessingDeadLetters(ctx, db.PaginateTokenProcessingDeadLettersParams{
+			Limit:         params.Limit,
+			ContractID:    contract,
+			CurBeforeTime: params.CursorBeforeTime,
+			CurBeforeID:   params.CursorBeforeID,
+			CurAfterTime:  params.CursorAfterTime,
+			CurAfterID:    params.CursorAfterID,
+			PagingForward: params.PagingForward,
+		})
+	}
+
+	cursorFunc := func(d db.TokenProcessingDeadLetter) (time.Time, persist.DBID, error) {
+		return d.CreatedAt, d.ID, nil
+	}
+
+	paginator := TimeIDPaginator[db.TokenProcessingDeadLetter]{
+		QueryFunc:  queryFunc,
+		CursorFunc: cursorFunc,
+	}
+
+	return paginator.Paginate(before, after, first, last)
+}
+
+// ReplayTokenProcessing requeues the given dead lettered tokens, or all dead lettered tokens of a contract, for processing
+func (api TokenAPI) ReplayTokenProcessing(ctx context.Context, deadLetterIDs []persist.DBID, contractID *persist.DBID) ([]db.TokenProcessingDeadLetter, error) {
+	// Validate
+	if len(deadLetterIDs) == 0 && contractID == nil {
+		err := validate.ErrInvalidInput{}
+		err.Append("deadLetterIDs", "deadLetterIDs or contractID is required")
+		return nil, err
+	}
+
+	var contract sql.NullString
+	if contractID != nil {
+		contract = util.ToNullStringEmptyNull(contractID.String())
+	}
+
+	ids := util.MapWithoutError(deadLetterIDs, func(id persist.DBID) string { return id.String() })
+
+	// Dead letters are claimed before their tokens are submitted, so that a token that fails again while it's being
+	// replayed gets a new dead letter instead of updating the one that's being replayed
+	deadLetters, err := api.queries.ClaimTokenProcessingDeadLettersToReplay(ctx, db.ClaimTokenProcessingDeadLettersToReplayParams{
+		Ids:        ids,
+		ContractID: contract,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deadLetters) == 0 {
+		return deadLetters, nil
+	}
+
+	if err := api.submitDeadLetters(ctx, deadLetters); err != nil {
+		claimedIDs := util.MapWithoutError(deadLetters, func(d db.TokenProcessingDeadLetter) string { return d.ID.String() })
+		if unclaimErr := api.queries.UnclaimTokenProcessingDeadLetters(ctx, claimedIDs); unclaimErr != nil {
+			logger.For(ctx).Errorf("failed to unclaim dead letters after replay failed: %s", unclaimErr)
+		}
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+func (api TokenAPI) submitDeadLetters(ctx context.Context, deadLetters []db.TokenProcessingDeadLetter) error {
+	// Replaying tokens of a paused contract is a signal that the contract should be run again, otherwise the tokens would
+	// go right back to the dead letter queue
+	pausedContractIDs := make([]persist.DBID, 0)
+	for _, d := range deadLetters {
+		if d.Reason == persist.DeadLetterReasonContractPaused {
+			pausedContractIDs = append(pausedContractIDs, d.ContractID)
+		}
+	}
+	for _, id := range util.Dedupe(pausedContractIDs, false) {
+		c, err := api.queries.GetContractByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := api.manager.ResumeContract(ctx, c.Chain, c.Address); err != nil {
+			return err
+		}
+	}
+
+	tokenDefinitionIDs := util.MapWithoutError(deadLetters, func(d db.TokenProcessingDeadLetter) persist.DBID { return d.TokenDefinitionID })
+	return api.manager.Submitter.SubmitNewTokens(ctx, util.Dedupe(tokenDefinitionIDs, false))
 }
 
 func (api TokenAPI) GetTokenDefinitionByID(ctx context.Context, id persist.DBID) (db.TokenDefinition, error) {
@@ -634,3 +832,41 @@ func (api TokenAPI) GetCommunitiesByTokenDefinitionID(ctx context.Context, token
 	}
 	return api.loaders.GetCommunitiesByTokenDefinitionID.Load(tokenDefinitionID)
 }
+
+// GetTokenRarity returns how rare a token definition is in a community, or nil if it hasn't been ranked there. If
+// communityID is empty, the token's rarity in its most specific community is returned.
+func (api TokenAPI) GetTokenRarity(ctx context.Context, tokenDefinitionID persist.DBID, communityID persist.DBID) (*db.GetTokenDefinitionRarityBatchRow, error) {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"tokenDefinitionID": validate.WithTag(tokenDefinitionID, "required"),
+	}); err != nil {
+		return nil, err
+	}
+
+	rarity, err := api.loaders.GetTokenDefinitionRarityBatch.Load(db.GetTokenDefinitionRarityBatchParams{
+		TokenDefinitionID: tokenDefinitionID,
+		CommunityID:       communityID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &rarity, nil
+}
+
+// GetTokenTraits returns a token definition's traits and how many tokens in a community share each of them. The
+// community is chosen the same way as GetTokenRarity's.
+func (api TokenAPI) GetTokenTraits(ctx context.Context, tokenDefinitionID persist.DBID, communityID persist.DBID) ([]db.GetTokenDefinitionTraitsBatchRow, error) {
+	rarity, err := api.GetTokenRarity(ctx, tokenDefinitionID, communityID)
+	if err != nil || rarity == nil {
+		return nil, err
+	}
+
+	return api.loaders.GetTokenDefinitionTraitsBatch.Load(db.GetTokenDefinitionTraitsBatchParams{
+		CommunityID:       rarity.CommunityID,
+		TokenDefinitionID: tokenDefinitionID,
+	})
+}
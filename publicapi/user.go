@@ -406,12 +406,7 @@ func (api *UserAPI) GetUserRolesByUserID(ctx context.Context, userID persist.DBI
 }
 
 func (api *UserAPI) UserIsAdmin(ctx context.Context) bool {
-	for _, role := range getUserRoles(ctx) {
-		if role == persist.RoleAdmin {
-			return true
-		}
-	}
-	return false
+	return isAdmin(ctx)
 }
 
 func (api UserAPI) PaginateUsersWithRole(ctx context.Context, role persist.Role, before *string, after *string, first *int, last *int) ([]db.User, PageInfo, error) {
@@ -1942,6 +1937,111 @@ func (api UserAPI) UnblockUser(ctx context.Context, userID persist.DBID) error {
 	return api.queries.UnblockUser(ctx, db.UnblockUserParams{UserID: viewerID, BlockedUserID: userID})
 }
 
+// MuteWord hides posts from the viewer's feeds if the word appears in their caption or comments. Words are matched
+// regardless of case, and only in full: muting "art" doesn't hide a post about a party.
+func (api UserAPI) MuteWord(ctx context.Context, word string) error {
+	word = normalizeMutedWord(word)
+
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"word": validate.WithTag(word, "required,max=100"),
+	}); err != nil {
+		return err
+	}
+
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return api.queries.MuteWord(ctx, db.MuteWordParams{
+		ID:     persist.GenerateID(),
+		UserID: viewerID,
+		Word:   word,
+	})
+}
+
+func (api UserAPI) UnmuteWord(ctx context.Context, word string) error {
+	word = normalizeMutedWord(word)
+
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"word": validate.WithTag(word, "required"),
+	}); err != nil {
+		return err
+	}
+
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return api.queries.UnmuteWord(ctx, db.UnmuteWordParams{UserID: viewerID, Word: word})
+}
+
+func (api UserAPI) GetMutedWords(ctx context.Context) ([]string, error) {
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.queries.GetMutedWordsByUserID(ctx, viewerID)
+}
+
+// MuteCommunity hides posts about the community from the viewer's feeds
+func (api UserAPI) MuteCommunity(ctx context.Context, communityID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"communityID": validate.WithTag(communityID, "required"),
+	}); err != nil {
+		return err
+	}
+
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.queries.MuteCommunity(ctx, db.MuteCommunityParams{
+		ID:          persist.GenerateID(),
+		UserID:      viewerID,
+		CommunityID: communityID,
+	})
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return persist.ErrCommunityNotFound{ID: communityID}
+	}
+	return err
+}
+
+func (api UserAPI) UnmuteCommunity(ctx context.Context, communityID persist.DBID) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"communityID": validate.WithTag(communityID, "required"),
+	}); err != nil {
+		return err
+	}
+
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return api.queries.UnmuteCommunity(ctx, db.UnmuteCommunityParams{UserID: viewerID, CommunityID: communityID})
+}
+
+func (api UserAPI) GetMutedCommunities(ctx context.Context) ([]db.Community, error) {
+	viewerID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return api.queries.GetMutedCommunitiesByUserID(ctx, viewerID)
+}
+
+// normalizeMutedWord lowercases the word and collapses its whitespace to single spaces, since muted_posts matches
+// words regardless of case and as whole words
+func normalizeMutedWord(word string) string {
+	return strings.ToLower(strings.Join(strings.Fields(word), " "))
+}
+
 func (api UserAPI) SetPersona(ctx context.Context, persona persist.Persona) error {
 	// Validate
 	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
@@ -1967,6 +2067,49 @@ func (api UserAPI) SetPersona(ctx context.Context, persona persist.Persona) erro
 	return nil
 }
 
+// GetFlaggedMediaDisplay returns how the authenticated user wants media that was flagged by moderation to be displayed
+func (api UserAPI) GetFlaggedMediaDisplay(ctx context.Context) (persist.FlaggedMediaDisplay, error) {
+	userID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return flaggedMediaDisplayForUser(ctx, api.loaders, userID)
+}
+
+func (api UserAPI) SetFlaggedMediaDisplay(ctx context.Context, display persist.FlaggedMediaDisplay) error {
+	// Validate
+	if err := validate.ValidateFields(api.validator, validate.ValidationMap{
+		"display": validate.WithTag(display, "required,oneof=show blur hide"),
+	}); err != nil {
+		return err
+	}
+
+	userID, err := getAuthenticatedUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.queries.UpsertMediaPreferences(ctx, db.UpsertMediaPreferencesParams{
+		ID:                  persist.GenerateID(),
+		UserID:              userID,
+		FlaggedMediaDisplay: display,
+	})
+
+	return err
+}
+
+// flaggedMediaDisplayForUser returns the user's preference for flagged media, defaulting to blurring it
+func flaggedMediaDisplayForUser(ctx context.Context, loaders *dataloader.Loaders, userID persist.DBID) (persist.FlaggedMediaDisplay, error) {
+	prefs, err := loaders.GetMediaPreferencesByUserIDBatch.Load(userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return persist.FlaggedMediaDisplayBlur, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return prefs.FlaggedMediaDisplay, nil
+}
+
 func uriFromRecord(ctx context.Context, mc *multichain.Provider, r eth.AvatarRecord) (uri string, err error) {
 	switch u := r.(type) {
 	case nil:
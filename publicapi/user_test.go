@@ -0,0 +1,26 @@
+package publicapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeMutedWord(t *testing.T) {
+	tests := []struct {
+		title    string
+		word     string
+		expected string
+	}{
+		{title: "lowercases the word", word: "GM", expected: "gm"},
+		{title: "trims surrounding whitespace", word: "  gm\n", expected: "gm"},
+		{title: "collapses whitespace between words", word: "free \t mint", expected: "free mint"},
+		{title: "keeps punctuation", word: "$ETH", expected: "$eth"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeMutedWord(tt.word))
+		})
+	}
+}
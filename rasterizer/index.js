@@ -3,6 +3,7 @@ const GIFEncoder = require('gifencoder');
 const pixelmatch = require('pixelmatch');
 const express = require('express');
 const { Cluster } = require('puppeteer-cluster');
+const { spawn } = require('child_process');
 const app = express();
 const fs = require('fs');
 
@@ -107,6 +108,37 @@ const args = [
     }
   });
 
+  app.get('/snapshot', async (req, res) => {
+    if (!req.query.url) {
+      res.status(400).send('no url provided');
+      return;
+    }
+
+    data = {
+      url: req.query.url,
+      chain: req.query.chain,
+      address: (req.query.address || '').toLowerCase(),
+      tokenId: req.query.tokenId,
+      renderTimeout: Math.min(parseInt(req.query.renderTimeout) || defaultRenderTimeout, maxRenderTimeout),
+    };
+
+    console.log(`snapshotting chain=${data.chain}; address=${data.address}; tokenId=${data.tokenId}; url=${data.url}`);
+
+    try {
+      const result = await cluster.execute(data, snapshotHTML);
+      const j = { png: result.png };
+      if (result.mp4) {
+        j['mp4'] = result.mp4;
+        console.log(`Returning ${j['mp4'].length} bytes for mp4: ${data.url}`);
+      }
+      console.log(`Returning ${j['png'].length} bytes for html: ${data.url}`);
+      res.status(200).send(j);
+    } catch (e) {
+      console.log(e);
+      res.status(400).send('error' + e);
+    }
+  });
+
   app.listen(3000, async () => {
     console.log('Listening on port 3000');
   });
@@ -117,6 +149,15 @@ const totalFrames = 10;
 // ideal delay between screenshots in ms
 const idealDelay = 30;
 
+// viewport used when snapshotting html
+const snapshotSize = 1080;
+// how long to wait for a render signal before snapshotting anyway in ms
+const defaultRenderTimeout = 15000;
+const maxRenderTimeout = 60000;
+// number of frames and frame rate of the live render loop
+const loopFrames = 60;
+const loopFPS = 12;
+
 process.on('unhandledRejection', (reason, p) => {
   console.error('Unhandled Rejection at:', p, 'reason:', reason);
   console.log('Unhandled Rejection at:', p, 'reason:', reason);
@@ -272,3 +313,130 @@ async function createAnimation(page, data) {
   // result is an array of base64 encoded strings, min length 1, max length 2, first element is always the png, second is the gif if it exists
   return result;
 }
+
+// snapshotHTML loads a html token in a locked down page, waits for the token to signal that it has finished
+// rendering (or for the render timeout to elapse), and captures a png and a short mp4 loop of the page.
+async function snapshotHTML({ page, data }) {
+  const origin = new URL(data.url).origin;
+
+  await page.setViewport({ width: snapshotSize, height: snapshotSize, deviceScaleFactor: 1 });
+  await page.setRequestInterception(true);
+  page.on('request', (req) => {
+    const url = req.url();
+    // Tokens can load whatever they need, but they can't navigate the page away from the token
+    if (req.isNavigationRequest() && req.frame() === page.mainFrame() && !url.startsWith(origin)) {
+      req.abort();
+      return;
+    }
+    if (!url.startsWith('http') && !url.startsWith('data:') && !url.startsWith('blob:')) {
+      req.abort();
+      return;
+    }
+    req.continue();
+  });
+  page.on('dialog', (dialog) => dialog.dismiss());
+
+  // Listen for the render signals used by the generative art platforms:
+  // fxhash dispatches a 'fxhash-preview' event (or posts a 'fxhash_preview' message in newer snippets) when fxpreview() is called,
+  // other tokens can call window.galleryRenderComplete() or set window.renderComplete.
+  await page.evaluateOnNewDocument(() => {
+    window.__galleryRendered = false;
+    const done = () => (window.__galleryRendered = true);
+    window.galleryRenderComplete = done;
+    window.addEventListener('fxhash-preview', done);
+    window.addEventListener('message', (e) => {
+      if (e.data === 'fxhash_preview' || (e.data && e.data.id === 'fxhash_preview')) done();
+    });
+    window.open = () => null;
+  });
+
+  await page.goto(data.url, { waitUntil: 'load', timeout: data.renderTimeout * 2 });
+
+  try {
+    await page.waitForFunction(() => window.__galleryRendered === true || window.renderComplete === true, {
+      timeout: data.renderTimeout,
+      polling: 100,
+    });
+  } catch (e) {
+    console.log(`No render signal for ${data.url} after ${data.renderTimeout}ms, snapshotting anyway`);
+  }
+
+  const frames = [];
+  for (let i = 0; i < loopFrames; i++) {
+    frames.push(await page.screenshot({ type: 'png' }));
+    await new Promise((resolve) => setTimeout(resolve, 1000 / loopFPS));
+  }
+
+  // the still is taken from the last frame so that the token has had as long as possible to render
+  const still = frames[frames.length - 1];
+  const result = { png: Buffer.from(still).toString('base64') };
+
+  if (isAnimated(frames)) {
+    console.log('Animated HTML detected for ' + data.url);
+    const mp4 = await encodeMP4(frames);
+    result.mp4 = mp4.toString('base64');
+  }
+
+  return result;
+}
+
+function isAnimated(frames) {
+  const first = PNG.sync.read(frames[0]);
+  for (let i = 1; i < frames.length; i++) {
+    const other = PNG.sync.read(frames[i]);
+    const diff = new PNG({ width: first.width, height: first.height });
+    const pixels = pixelmatch(first.data, other.data, diff.data, first.width, first.height, {
+      threshold: 0.1,
+    });
+    if (pixels > 0) {
+      return true;
+    }
+  }
+  return false;
+}
+
+// encodeMP4 pipes png frames to ffmpeg and returns the encoded mp4
+function encodeMP4(frames) {
+  return new Promise((resolve, reject) => {
+    const ffmpeg = spawn('ffmpeg', [
+      '-hide_banner',
+      '-loglevel',
+      'error',
+      '-f',
+      'image2pipe',
+      '-framerate',
+      `${loopFPS}`,
+      '-i',
+      '-',
+      '-vf',
+      'scale=720:trunc(ow/a/2)*2',
+      '-c:v',
+      'libx264',
+      '-pix_fmt',
+      'yuv420p',
+      '-movflags',
+      'frag_keyframe+empty_moov',
+      '-f',
+      'mp4',
+      'pipe:1',
+    ]);
+
+    const chunks = [];
+    const errChunks = [];
+    ffmpeg.stdout.on('data', (chunk) => chunks.push(chunk));
+    ffmpeg.stderr.on('data', (chunk) => errChunks.push(chunk));
+    ffmpeg.on('error', reject);
+    ffmpeg.on('close', (code) => {
+      if (code !== 0) {
+        reject(new Error(`ffmpeg exited with code ${code}: ${Buffer.concat(errChunks).toString()}`));
+        return;
+      }
+      resolve(Buffer.concat(chunks));
+    });
+
+    for (const frame of frames) {
+      ffmpeg.stdin.write(frame);
+    }
+    ffmpeg.stdin.end();
+  });
+}
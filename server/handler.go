@@ -55,6 +55,7 @@ func HandlersInit(router *gin.Engine, repos *postgres.Repositories, queries *db.
 		return api
 	}
 	GraphqlHandlersInit(router, queries, taskClient, pub, lock, apqCache, authRefreshCache, recommender, personalization, neynar, publicapiF)
+	PostsHandlersInit(router, queries, taskClient, pub, lock, neynar, publicapiF)
 	return router
 }
 
@@ -66,6 +67,33 @@ func GraphqlHandlersInit(router *gin.Engine, queries *db.Queries, taskClient *ta
 	graphqlGroup.GET("/playground", graphqlPlaygroundHandler())
 }
 
+func PostsHandlersInit(router *gin.Engine, queries *db.Queries, taskClient *task.Client, pub *pubsub.Client, lock *redislock.Client, neynar *farcaster.NeynarAPI, publicapiF func(ctx context.Context, disableDataloaderCaching bool) *publicapi.PublicAPI) {
+	postsGroup := router.Group("/glry/posts")
+	postsGroup.POST("/publish_scheduled", middleware.CloudSchedulerMiddleware, publishScheduledPostsHandler(queries, taskClient, pub, lock, neynar, publicapiF))
+}
+
+// publishScheduledPostsHandler publishes scheduled post drafts that are due. It's called periodically by Cloud Scheduler.
+func publishScheduledPostsHandler(queries *db.Queries, taskClient *task.Client, pub *pubsub.Client, lock *redislock.Client, neynar *farcaster.NeynarAPI, publicapiF func(ctx context.Context, disableDataloaderCaching bool) *publicapi.PublicAPI) gin.HandlerFunc {
+	notificationsHandler := notifications.New(queries, pub, taskClient, lock, false)
+
+	return func(c *gin.Context) {
+		disableDataloaderCaching := false
+
+		// Published drafts dispatch the same events as posts made through the API
+		event.AddTo(c, disableDataloaderCaching, notificationsHandler, queries, taskClient, neynar)
+		notifications.AddTo(c, notificationsHandler)
+		publicapi.AddTo(c, publicapiF(c.Request.Context(), disableDataloaderCaching))
+
+		published, err := publicapi.For(c).Feed.PublishDuePostDrafts(c)
+		if err != nil {
+			util.ErrResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"published": published})
+	}
+}
+
 func GraphQLHandler(queries *db.Queries, taskClient *task.Client, pub *pubsub.Client, lock *redislock.Client, recommender *recommend.Recommender, personalization *userpref.Personalization, neynar *farcaster.NeynarAPI, apqCache *apq.APQCache, publicapiF func(ctx context.Context, disableDataloaderCaching bool) *publicapi.PublicAPI) gin.HandlerFunc {
 	config := generated.Config{Resolvers: &graphql.Resolver{}}
 	config.Directives.AuthRequired = graphql.AuthRequiredDirectiveHandler()
@@ -180,6 +180,14 @@ func WithBackgroundColor(color string) Option {
 	}
 }
 
+// WithBlur will blur the media. The amount ranges from 0 to 2000.
+// See https://docs.imgix.com/apis/rendering/stylize/blur for more details.
+func WithBlur(amount int) Option {
+	return func(params *[]imgix.IxParam) {
+		*params = append(*params, imgix.Param("blur", strconv.Itoa(amount)))
+	}
+}
+
 func (u *MediaMapper) GetThumbnailImageUrl(sourceUrl string, options ...Option) string {
 	return u.buildPreviewImageUrl(sourceUrl, thumbnailWidth, u.thumbnailUrlParams, options...)
 }
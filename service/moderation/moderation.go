@@ -0,0 +1,138 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+// NSFWThreshold is the classifier score at or above which media is labeled as NSFW
+const NSFWThreshold = 0.8
+
+// Classifier scores image data for explicit content
+type Classifier interface {
+	// Classify returns a score between 0 and 1 of how likely the image is to be explicit
+	Classify(ctx context.Context, r io.Reader, contentType string) (float64, error)
+}
+
+// LocalClassifier is a Classifier backed by a classification model served over HTTP on the local network
+type LocalClassifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewLocalClassifier returns a Classifier that sends images to the model server at url. If url is empty, nil is returned
+// and images will not be scored.
+func NewLocalClassifier(url string, httpClient *http.Client) Classifier {
+	if url == "" {
+		return nil
+	}
+	return &LocalClassifier{url: url, httpClient: httpClient}
+}
+
+type classifyResponse struct {
+	NSFW float64 `json:"nsfw"`
+}
+
+func (c *LocalClassifier) Classify(ctx context.Context, r io.Reader, contentType string) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, r)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, util.ErrHTTP{URL: c.url, Status: resp.StatusCode}
+	}
+
+	var body classifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("failed to decode classifier response: %w", err)
+	}
+
+	return body.NSFW, nil
+}
+
+// Severity is how dangerous a finding in a markup document is
+type Severity int
+
+const (
+	// SeverityLow findings are common in generative art and aren't harmful on their own
+	SeverityLow Severity = iota
+	// SeverityHigh findings target the viewer and result in the media being labeled as malicious
+	SeverityHigh
+)
+
+// Finding is something notable found when scanning a markup document
+type Finding struct {
+	Reason   string
+	Severity Severity
+}
+
+type markupRule struct {
+	reason   string
+	severity Severity
+	pattern  *regexp.Regexp
+}
+
+var markupRules = []markupRule{
+	{"script:inline", SeverityLow, regexp.MustCompile(`(?i)<script\b`)},
+	{"script:event_handler", SeverityLow, regexp.MustCompile(`(?i)<[^>]+\son[a-z]+\s*=`)},
+	{"script:javascript_url", SeverityLow, regexp.MustCompile(`(?i)(href|src|action)\s*=\s*["']?\s*javascript:`)},
+	{"script:external_fetch", SeverityLow, regexp.MustCompile(`(?i)\b(fetch\s*\(|XMLHttpRequest|WebSocket\s*\(|EventSource\s*\(|navigator\.sendBeacon)`)},
+	{"script:external_resource", SeverityLow, regexp.MustCompile(`(?i)<(script|iframe|link|img|image|use|object|embed)\b[^>]*(src|href)\s*=\s*["']?(https?:)?//`)},
+	{"malicious:wallet_request", SeverityHigh, regexp.MustCompile(`(?i)(window\.)?ethereum\.(request|send|enable)\b|eth_(sendTransaction|sign|signTypedData|requestAccounts)|personal_sign`)},
+	{"malicious:cookie_access", SeverityHigh, regexp.MustCompile(`(?i)document\.cookie`)},
+	{"malicious:top_navigation", SeverityHigh, regexp.MustCompile(`(?i)(window\.)?top\.location|parent\.location`)},
+	{"malicious:crypto_miner", SeverityHigh, regexp.MustCompile(`(?i)coinhive|cryptonight|coin-?imp|webminer|minero\.cc`)},
+}
+
+// ScanMarkup scans an SVG or HTML document for scripts, external fetches and behavior that targets the viewer.
+// Findings are returned in a stable order.
+func ScanMarkup(content []byte) []Finding {
+	var findings []Finding
+	for _, rule := range markupRules {
+		if rule.pattern.Match(content) {
+			findings = append(findings, Finding{Reason: rule.reason, Severity: rule.severity})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Severity > findings[j].Severity })
+	return findings
+}
+
+// Label decides the moderation label for media given the highest classifier score and any markup findings.
+// Any high severity finding labels the media as malicious regardless of its score.
+func Label(nsfwScore float64, findings []Finding) persist.Moderation {
+	m := persist.Moderation{Label: persist.ModerationLabelSafe, NSFWScore: nsfwScore}
+
+	for _, f := range findings {
+		m.Reasons = append(m.Reasons, f.Reason)
+		if f.Severity == SeverityHigh {
+			m.Label = persist.ModerationLabelMalicious
+		}
+	}
+
+	if m.Label == persist.ModerationLabelMalicious {
+		return m
+	}
+
+	if nsfwScore >= NSFWThreshold {
+		m.Label = persist.ModerationLabelNSFW
+		m.Reasons = append(m.Reasons, "nsfw:classifier")
+	}
+
+	return m
+}
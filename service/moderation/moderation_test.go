@@ -0,0 +1,138 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+func TestScanMarkup(t *testing.T) {
+	tests := []struct {
+		title    string
+		content  string
+		expected []Finding
+	}{
+		{
+			title:   "doesn't find anything in a static svg",
+			content: `<svg xmlns="http://www.w3.org/2000/svg"><rect width="10" height="10" fill="red"/></svg>`,
+		},
+		{
+			title:    "finds inline scripts",
+			content:  `<svg><SCRIPT>draw()</SCRIPT></svg>`,
+			expected: []Finding{{"script:inline", SeverityLow}},
+		},
+		{
+			title:    "finds event handlers",
+			content:  `<svg onload="draw()"></svg>`,
+			expected: []Finding{{"script:event_handler", SeverityLow}},
+		},
+		{
+			title:    "finds javascript urls",
+			content:  `<a href="javascript:draw()">art</a>`,
+			expected: []Finding{{"script:javascript_url", SeverityLow}},
+		},
+		{
+			title:    "finds external fetches",
+			content:  `const ws = new WebSocket ('wss://example.com')`,
+			expected: []Finding{{"script:external_fetch", SeverityLow}},
+		},
+		{
+			title:    "finds external resources",
+			content:  `<image href="https://example.com/a.png"/>`,
+			expected: []Finding{{"script:external_resource", SeverityLow}},
+		},
+		{
+			title:    "finds protocol relative resources",
+			content:  `<iframe src="//example.com"></iframe>`,
+			expected: []Finding{{"script:external_resource", SeverityLow}},
+		},
+		{
+			title:    "finds wallet requests",
+			content:  `ethereum.request({method: 'eth_requestAccounts'})`,
+			expected: []Finding{{"malicious:wallet_request", SeverityHigh}},
+		},
+		{
+			title:    "finds cookie access",
+			content:  `var c = document.cookie`,
+			expected: []Finding{{"malicious:cookie_access", SeverityHigh}},
+		},
+		{
+			title:    "finds top navigation",
+			content:  `window.top.location = 'https://example.com'`,
+			expected: []Finding{{"malicious:top_navigation", SeverityHigh}},
+		},
+		{
+			title:    "finds crypto miners",
+			content:  `new CoinHive.Anonymous('key')`,
+			expected: []Finding{{"malicious:crypto_miner", SeverityHigh}},
+		},
+		{
+			title:   "returns high severity findings first",
+			content: `<script>fetch('/x'); document.cookie</script>`,
+			expected: []Finding{
+				{"malicious:cookie_access", SeverityHigh},
+				{"script:inline", SeverityLow},
+				{"script:external_fetch", SeverityLow},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ScanMarkup([]byte(tt.content)))
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	low := Finding{"script:inline", SeverityLow}
+	high := Finding{"malicious:cookie_access", SeverityHigh}
+
+	tests := []struct {
+		title    string
+		score    float64
+		findings []Finding
+		expected persist.Moderation
+	}{
+		{
+			title:    "labels media below the threshold as safe",
+			score:    NSFWThreshold - 0.01,
+			expected: persist.Moderation{Label: persist.ModerationLabelSafe, NSFWScore: NSFWThreshold - 0.01},
+		},
+		{
+			title:    "labels media at the threshold as nsfw",
+			score:    NSFWThreshold,
+			expected: persist.Moderation{Label: persist.ModerationLabelNSFW, NSFWScore: NSFWThreshold, Reasons: []string{"nsfw:classifier"}},
+		},
+		{
+			title:    "labels media above the threshold as nsfw",
+			score:    0.95,
+			expected: persist.Moderation{Label: persist.ModerationLabelNSFW, NSFWScore: 0.95, Reasons: []string{"nsfw:classifier"}},
+		},
+		{
+			title:    "keeps low severity findings as reasons for safe media",
+			score:    0.1,
+			findings: []Finding{low},
+			expected: persist.Moderation{Label: persist.ModerationLabelSafe, NSFWScore: 0.1, Reasons: []string{"script:inline"}},
+		},
+		{
+			title:    "labels media with a high severity finding as malicious",
+			findings: []Finding{high, low},
+			expected: persist.Moderation{Label: persist.ModerationLabelMalicious, Reasons: []string{"malicious:cookie_access", "script:inline"}},
+		},
+		{
+			title:    "labels malicious media as malicious even if it's nsfw",
+			score:    0.9,
+			findings: []Finding{high},
+			expected: persist.Moderation{Label: persist.ModerationLabelMalicious, NSFWScore: 0.9, Reasons: []string{"malicious:cookie_access"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Label(tt.score, tt.findings))
+		})
+	}
+}
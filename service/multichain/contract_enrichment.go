@@ -0,0 +1,383 @@
+package multichain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/everFinance/goar"
+	"github.com/gammazero/workerpool"
+	shell "github.com/ipfs/go-ipfs-api"
+
+	"github.com/mikeydub/go-gallery/contracts"
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/eth"
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/rpc"
+	sentryutil "github.com/mikeydub/go-gallery/service/sentry"
+	"github.com/mikeydub/go-gallery/service/task"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+const (
+	// contractEnrichmentTTL is how long values read from the chain are used before they're read again
+	contractEnrichmentTTL = 7 * 24 * time.Hour
+	// maxEnrichmentsPerTask is the most contracts that are read from the chain by a single task
+	maxEnrichmentsPerTask = 50
+	// enrichmentWorkers is the number of contracts that are read from the chain at once
+	enrichmentWorkers = 8
+	// enrichmentTimeout is how long reading a single contract can take
+	enrichmentTimeout = 15 * time.Second
+)
+
+// The fields of a contract that can be enriched
+const (
+	contractFieldName            = "name"
+	contractFieldSymbol          = "symbol"
+	contractFieldDescription     = "description"
+	contractFieldProfileImageURL = "profile_image_url"
+	contractFieldOwnerENSName    = "owner_ens_name"
+)
+
+// contractFieldPrecedence lists, for each field, the sources it's taken from in order of preference. The first source
+// with a usable value wins.
+//
+// Metadata from contractURI() is curated by the contract's creator for display, so it's preferred for the name,
+// description, and image. The symbol returned by the contract is authoritative because it's how the token trades.
+// Indexer data is the fallback for everything.
+var contractFieldPrecedence = map[string][]persist.ContractFieldSource{
+	contractFieldName:            {persist.ContractFieldSourceContractURI, persist.ContractFieldSourceRPC, persist.ContractFieldSourceIndexer},
+	contractFieldSymbol:          {persist.ContractFieldSourceRPC, persist.ContractFieldSourceContractURI, persist.ContractFieldSourceIndexer},
+	contractFieldDescription:     {persist.ContractFieldSourceContractURI, persist.ContractFieldSourceIndexer},
+	contractFieldProfileImageURL: {persist.ContractFieldSourceContractURI, persist.ContractFieldSourceIndexer},
+}
+
+// ContractEnricher fills in contract fields that indexers leave out or get wrong by reading the contract directly:
+// its contract-level metadata (ERC-7572), its name() and symbol(), and the ENS name of its owner
+type ContractEnricher struct {
+	ethClient     *ethclient.Client
+	ipfsClient    *shell.Shell
+	arweaveClient *goar.Client
+	queries       *db.Queries
+	taskClient    *task.Client
+}
+
+func NewContractEnricher(ethClient *ethclient.Client, ipfsClient *shell.Shell, arweaveClient *goar.Client, queries *db.Queries, taskClient *task.Client) *ContractEnricher {
+	return &ContractEnricher{
+		ethClient:     ethClient,
+		ipfsClient:    ipfsClient,
+		arweaveClient: arweaveClient,
+		queries:       queries,
+		taskClient:    taskClient,
+	}
+}
+
+// Enrich merges what was last read from the chain about each contract with the indexer's data in c, and records where
+// each field came from. It doesn't call the chain itself: contracts that haven't been read recently are sent to
+// tokenprocessing to be read by Refresh, so that syncing isn't held up by RPC calls. Contracts that can't be enriched
+// are returned unchanged.
+func (e *ContractEnricher) Enrich(ctx context.Context, chain persist.Chain, c []db.Contract) []db.Contract {
+	if e == nil || len(c) == 0 || chain != persist.ChainETH {
+		return c
+	}
+
+	byAddress, err := e.enrichmentsByAddress(ctx, chain, c)
+	if err != nil {
+		logger.For(ctx).Errorf("failed to get contract enrichments: %s", err)
+		return c
+	}
+
+	result := make([]db.Contract, len(c))
+	stale := make([]task.IndexedContract, 0, len(c))
+	for i, contract := range c {
+		enrichment, ok := byAddress[contract.Address]
+		if !ok || time.Since(enrichment.LastUpdated) >= contractEnrichmentTTL {
+			stale = append(stale, indexedContract(contract))
+		}
+		if !ok {
+			result[i] = contract
+			continue
+		}
+
+		merged, sources := mergeEnrichment(contract, enrichment)
+		result[i] = merged
+
+		if !sameSources(sources, enrichment.Sources) {
+			enrichment.Sources = sources
+			if err := e.save(ctx, enrichment); err != nil {
+				logger.For(ctx).Errorf("failed to save enrichment of contract %s: %s", contract.Address, err)
+			}
+		}
+	}
+
+	for _, chunk := range util.ChunkBy(stale, maxEnrichmentsPerTask) {
+		err := e.taskClient.CreateTaskForContractEnrichment(ctx, task.ContractEnrichmentMessage{Chain: chain, Contracts: chunk})
+		if err != nil {
+			logger.For(ctx).WithError(err).Error("failed to create task for contract enrichment")
+			sentryutil.ReportError(ctx, err)
+		}
+	}
+
+	return result
+}
+
+// Refresh reads contracts that haven't been read from the chain recently, saves what was read, and updates the
+// contracts with the values merged from what was read and the indexer's values in indexed. The persisted contracts
+// already have merged values, so they're only used to find what needs to be updated.
+func (e *ContractEnricher) Refresh(ctx context.Context, chain persist.Chain, indexed []task.IndexedContract) error {
+	if chain != persist.ChainETH {
+		return nil
+	}
+
+	contracts, err := e.queries.GetContractsByChainAddresses(ctx, db.GetContractsByChainAddressesParams{
+		Chain:     chain,
+		Addresses: util.MapWithoutError(indexed, func(c task.IndexedContract) string { return c.Address.String() }),
+	})
+	if err != nil {
+		return err
+	}
+
+	indexedByAddress := make(map[persist.Address]task.IndexedContract, len(indexed))
+	for _, c := range indexed {
+		indexedByAddress[c.Address] = c
+	}
+
+	existing, err := e.enrichmentsByAddress(ctx, chain, contracts)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var errs []error
+
+	wp := workerpool.New(enrichmentWorkers)
+	for _, contract := range contracts {
+		contract := contract
+		current, ok := existing[contract.Address]
+		if ok && time.Since(current.LastUpdated) < contractEnrichmentTTL {
+			continue
+		}
+		wp.Submit(func() {
+			err := e.refreshContract(ctx, chain, contract, indexedByAddress[contract.Address], current)
+			if err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, fmt.Errorf("failed to enrich contract %s: %w", contract.Address, err))
+			}
+		})
+	}
+	wp.StopWait()
+
+	if len(errs) > 0 {
+		return util.MultiErr(errs)
+	}
+	return nil
+}
+
+func (e *ContractEnricher) refreshContract(ctx context.Context, chain persist.Chain, contract db.Contract, indexed task.IndexedContract, current db.ContractEnrichment) error {
+	readCtx, cancel := context.WithTimeout(ctx, enrichmentTimeout)
+	defer cancel()
+
+	raw := contract
+	raw.Name = util.ToNullStringEmptyNull(indexed.Name)
+	raw.Symbol = util.ToNullStringEmptyNull(indexed.Symbol)
+	raw.Description = util.ToNullStringEmptyNull(indexed.Description)
+	raw.ProfileImageUrl = util.ToNullStringEmptyNull(indexed.ProfileImageURL)
+	if indexed.OwnerAddress != "" {
+		raw.OwnerAddress = indexed.OwnerAddress
+	}
+
+	enrichment := e.read(readCtx, chain, raw)
+	if current.ID != "" {
+		enrichment.ID = current.ID
+	}
+
+	merged, sources := mergeEnrichment(raw, enrichment)
+	enrichment.Sources = sources
+
+	if err := e.save(ctx, enrichment); err != nil {
+		return err
+	}
+
+	if merged.Name == contract.Name && merged.Symbol == contract.Symbol && merged.Description == contract.Description && merged.ProfileImageUrl == contract.ProfileImageUrl {
+		return nil
+	}
+
+	return e.queries.UpdateContractEnrichedFields(ctx, db.UpdateContractEnrichedFieldsParams{
+		ID:              contract.ID,
+		Name:            merged.Name,
+		Symbol:          merged.Symbol,
+		Description:     merged.Description,
+		ProfileImageUrl: merged.ProfileImageUrl,
+	})
+}
+
+func (e *ContractEnricher) enrichmentsByAddress(ctx context.Context, chain persist.Chain, c []db.Contract) (map[persist.Address]db.ContractEnrichment, error) {
+	enrichments, err := e.queries.GetContractEnrichmentsByAddresses(ctx, db.GetContractEnrichmentsByAddressesParams{
+		Chain:     chain,
+		Addresses: util.MapWithoutError(c, func(c db.Contract) string { return c.Address.String() }),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byAddress := make(map[persist.Address]db.ContractEnrichment, len(enrichments))
+	for _, enrichment := range enrichments {
+		byAddress[enrichment.Address] = enrichment
+	}
+
+	return byAddress, nil
+}
+
+// read reads a contract's metadata from the chain. Values that can't be read are left empty.
+func (e *ContractEnricher) read(ctx context.Context, chain persist.Chain, contract db.Contract) db.ContractEnrichment {
+	enrichment := db.ContractEnrichment{
+		ID:          persist.GenerateID(),
+		Chain:       chain,
+		Address:     contract.Address,
+		LastUpdated: time.Now(),
+	}
+
+	address := persist.EthereumAddress(contract.Address)
+
+	turi, err := rpc.GetContractURI(ctx, address, e.ethClient)
+	if err == nil && turi != "" {
+		enrichment.ContractUri = util.ToNullStringEmptyNull(turi.String())
+
+		metadata, err := rpc.GetMetadataFromURI(ctx, turi, e.ipfsClient, e.arweaveClient)
+		if err != nil {
+			logger.For(ctx).Debugf("failed to get contract metadata of %s from %s: %s", contract.Address, turi, err)
+		} else {
+			enrichment.UriName = metadataString(metadata, "name")
+			enrichment.UriSymbol = metadataString(metadata, "symbol")
+			enrichment.UriDescription = metadataString(metadata, "description")
+			enrichment.UriImageUrl = metadataString(metadata, "image")
+		}
+	}
+
+	caller, err := contracts.NewIERC721MetadataCaller(address.Address(), e.ethClient)
+	if err == nil {
+		if name, err := caller.Name(&bind.CallOpts{Context: ctx}); err == nil {
+			enrichment.RpcName = util.ToNullStringEmptyNull(cleanContractString(name))
+		}
+		if symbol, err := caller.Symbol(&bind.CallOpts{Context: ctx}); err == nil {
+			enrichment.RpcSymbol = util.ToNullStringEmptyNull(cleanContractString(symbol))
+		}
+	}
+
+	if contract.OwnerAddress != "" {
+		domain, err := eth.ReverseResolve(ctx, e.ethClient, persist.EthereumAddress(contract.OwnerAddress))
+		if err != nil && !errors.Is(err, eth.ErrNoResolution) {
+			logger.For(ctx).Debugf("failed to reverse resolve owner %s of %s: %s", contract.OwnerAddress, contract.Address, err)
+		}
+		enrichment.OwnerEnsName = util.ToNullStringEmptyNull(domain)
+	}
+
+	return enrichment
+}
+
+func (e *ContractEnricher) save(ctx context.Context, enrichment db.ContractEnrichment) error {
+	return e.queries.UpsertContractEnrichment(ctx, db.UpsertContractEnrichmentParams{
+		ID:             enrichment.ID,
+		Chain:          enrichment.Chain,
+		Address:        enrichment.Address,
+		ContractUri:    enrichment.ContractUri,
+		UriName:        enrichment.UriName,
+		UriSymbol:      enrichment.UriSymbol,
+		UriDescription: enrichment.UriDescription,
+		UriImageUrl:    enrichment.UriImageUrl,
+		RpcName:        enrichment.RpcName,
+		RpcSymbol:      enrichment.RpcSymbol,
+		OwnerEnsName:   enrichment.OwnerEnsName,
+		Sources:        enrichment.Sources,
+	})
+}
+
+// mergeEnrichment sets each of the contract's fields from the most preferred source that has a value, and returns the
+// source that each field was taken from
+func mergeEnrichment(contract db.Contract, enrichment db.ContractEnrichment) (db.Contract, persist.ContractFieldSources) {
+	candidates := map[string]map[persist.ContractFieldSource]sql.NullString{
+		contractFieldName: {
+			persist.ContractFieldSourceContractURI: enrichment.UriName,
+			persist.ContractFieldSourceRPC:         enrichment.RpcName,
+			persist.ContractFieldSourceIndexer:     contract.Name,
+		},
+		contractFieldSymbol: {
+			persist.ContractFieldSourceRPC:         enrichment.RpcSymbol,
+			persist.ContractFieldSourceContractURI: enrichment.UriSymbol,
+			persist.ContractFieldSourceIndexer:     contract.Symbol,
+		},
+		contractFieldDescription: {
+			persist.ContractFieldSourceContractURI: enrichment.UriDescription,
+			persist.ContractFieldSourceIndexer:     contract.Description,
+		},
+		contractFieldProfileImageURL: {
+			persist.ContractFieldSourceContractURI: enrichment.UriImageUrl,
+			persist.ContractFieldSourceIndexer:     contract.ProfileImageUrl,
+		},
+	}
+
+	sources := persist.ContractFieldSources{}
+	pick := func(field string) sql.NullString {
+		for _, source := range contractFieldPrecedence[field] {
+			v := candidates[field][source]
+			if v.String == "" || (field == contractFieldName && unknownContractNames[strings.ToLower(v.String)]) {
+				continue
+			}
+			sources[field] = source
+			return v
+		}
+		return candidates[field][persist.ContractFieldSourceIndexer]
+	}
+
+	contract.Name = pick(contractFieldName)
+	contract.Symbol = pick(contractFieldSymbol)
+	contract.Description = pick(contractFieldDescription)
+	contract.ProfileImageUrl = pick(contractFieldProfileImageURL)
+
+	if enrichment.OwnerEnsName.String != "" {
+		sources[contractFieldOwnerENSName] = persist.ContractFieldSourceENS
+	}
+
+	return contract, sources
+}
+
+// indexedContract returns the indexer's values of a contract that hasn't been merged with what was read from the chain
+func indexedContract(c db.Contract) task.IndexedContract {
+	return task.IndexedContract{
+		Address:         c.Address,
+		OwnerAddress:    c.OwnerAddress,
+		Name:            c.Name.String,
+		Symbol:          c.Symbol.String,
+		Description:     c.Description.String,
+		ProfileImageURL: c.ProfileImageUrl.String,
+	}
+}
+
+func sameSources(a, b persist.ContractFieldSources) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func metadataString(metadata persist.TokenMetadata, key string) sql.NullString {
+	s, _ := metadata[key].(string)
+	return util.ToNullStringEmptyNull(cleanContractString(s))
+}
+
+func cleanContractString(s string) string {
+	return strings.TrimSpace(strings.ReplaceAll(s, "\x00", ""))
+}
@@ -0,0 +1,125 @@
+package multichain
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+func TestMergeEnrichment(t *testing.T) {
+	indexed := db.Contract{
+		Name:            str("Indexed Name"),
+		Symbol:          str("IDX"),
+		Description:     str("Indexed description"),
+		ProfileImageUrl: str("https://indexer.com/image.png"),
+	}
+
+	tests := []struct {
+		title      string
+		contract   db.Contract
+		enrichment db.ContractEnrichment
+		expected   db.Contract
+		sources    persist.ContractFieldSources
+	}{
+		{
+			title:    "keeps the indexer's values if nothing was read from the chain",
+			contract: indexed,
+			expected: indexed,
+			sources: persist.ContractFieldSources{
+				contractFieldName:            persist.ContractFieldSourceIndexer,
+				contractFieldSymbol:          persist.ContractFieldSourceIndexer,
+				contractFieldDescription:     persist.ContractFieldSourceIndexer,
+				contractFieldProfileImageURL: persist.ContractFieldSourceIndexer,
+			},
+		},
+		{
+			title:    "prefers contract metadata for the name, description, and image, and the contract for the symbol",
+			contract: indexed,
+			enrichment: db.ContractEnrichment{
+				UriName:        str("URI Name"),
+				UriSymbol:      str("URI"),
+				UriDescription: str("URI description"),
+				UriImageUrl:    str("ipfs://image"),
+				RpcName:        str("RPC Name"),
+				RpcSymbol:      str("RPC"),
+			},
+			expected: db.Contract{
+				Name:            str("URI Name"),
+				Symbol:          str("RPC"),
+				Description:     str("URI description"),
+				ProfileImageUrl: str("ipfs://image"),
+			},
+			sources: persist.ContractFieldSources{
+				contractFieldName:            persist.ContractFieldSourceContractURI,
+				contractFieldSymbol:          persist.ContractFieldSourceRPC,
+				contractFieldDescription:     persist.ContractFieldSourceContractURI,
+				contractFieldProfileImageURL: persist.ContractFieldSourceContractURI,
+			},
+		},
+		{
+			title:      "falls back to the next source that has a value",
+			contract:   db.Contract{},
+			enrichment: db.ContractEnrichment{UriSymbol: str("URI"), RpcName: str("RPC Name"), RpcSymbol: str("")},
+			expected:   db.Contract{Name: str("RPC Name"), Symbol: str("URI")},
+			sources: persist.ContractFieldSources{
+				contractFieldName:   persist.ContractFieldSourceRPC,
+				contractFieldSymbol: persist.ContractFieldSourceContractURI,
+			},
+		},
+		{
+			title:      "skips names that are placeholders",
+			contract:   db.Contract{Name: str("Unknown Contract")},
+			enrichment: db.ContractEnrichment{UriName: str("unidentified contract"), RpcName: str("Real Name")},
+			expected:   db.Contract{Name: str("Real Name")},
+			sources:    persist.ContractFieldSources{contractFieldName: persist.ContractFieldSourceRPC},
+		},
+		{
+			title:      "keeps a placeholder name if no source has a better one",
+			contract:   db.Contract{Name: str("Unknown")},
+			enrichment: db.ContractEnrichment{RpcName: str("unknown contract")},
+			expected:   db.Contract{Name: str("Unknown")},
+			sources:    persist.ContractFieldSources{},
+		},
+		{
+			title:      "doesn't take the description or image from the contract's functions",
+			contract:   db.Contract{},
+			enrichment: db.ContractEnrichment{RpcName: str("RPC Name")},
+			expected:   db.Contract{Name: str("RPC Name")},
+			sources:    persist.ContractFieldSources{contractFieldName: persist.ContractFieldSourceRPC},
+		},
+		{
+			title:      "records the ens name of the contract's owner",
+			contract:   db.Contract{},
+			enrichment: db.ContractEnrichment{OwnerEnsName: str("artist.eth")},
+			expected:   db.Contract{},
+			sources:    persist.ContractFieldSources{contractFieldOwnerENSName: persist.ContractFieldSourceENS},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			merged, sources := mergeEnrichment(tt.contract, tt.enrichment)
+			assert.Equal(t, tt.expected.Name.String, merged.Name.String)
+			assert.Equal(t, tt.expected.Symbol.String, merged.Symbol.String)
+			assert.Equal(t, tt.expected.Description.String, merged.Description.String)
+			assert.Equal(t, tt.expected.ProfileImageUrl.String, merged.ProfileImageUrl.String)
+			assert.Equal(t, tt.sources, sources)
+		})
+	}
+}
+
+func TestSameSources(t *testing.T) {
+	a := persist.ContractFieldSources{contractFieldName: persist.ContractFieldSourceRPC}
+	assert.True(t, sameSources(a, persist.ContractFieldSources{contractFieldName: persist.ContractFieldSourceRPC}))
+	assert.False(t, sameSources(a, persist.ContractFieldSources{contractFieldName: persist.ContractFieldSourceIndexer}))
+	assert.False(t, sameSources(a, nil))
+	assert.True(t, sameSources(persist.ContractFieldSources{}, nil))
+}
+
+func str(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
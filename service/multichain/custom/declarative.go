@@ -0,0 +1,335 @@
+package custom
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/everFinance/goar"
+	shell "github.com/ipfs/go-ipfs-api"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/rpc"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+// declarativeHandlersTTL is how long handlers loaded from the database are used before they're reloaded, so that
+// changes made by admins take effect without a deploy
+const declarativeHandlersTTL = 5 * time.Minute
+
+// functionSignature matches the view functions that a declarative handler can call: no arguments, or the token ID
+var functionSignature = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\((uint256)?\)$`)
+
+// TemplateData is what the string values of a declarative handler's template are executed with, e.g. {{.TokenID}} or {{.Metadata.name}}
+type TemplateData struct {
+	// TokenID is the token ID in base 10
+	TokenID string
+	// TokenIDHex is the token ID in base 16
+	TokenIDHex      string
+	ContractAddress string
+	// Result is the string returned by the contract call. For SVGs it's a data URI of the SVG.
+	Result string
+	// Metadata is the metadata that was parsed from the result
+	Metadata persist.TokenMetadata
+}
+
+// IsBuiltinContract returns true if the contract has a custom metadata handler or renderer that's written in Go
+func IsBuiltinContract(c persist.ContractIdentifiers) bool {
+	if _, ok := DefaultRenderers().RendererFor(c); ok {
+		return true
+	}
+	switch c {
+	case EnsContract, CryptopunkContract, ZoraContract, OpenseaSharedStorefrontContract:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateHandler returns an error if the handler can't be run
+func ValidateHandler(h db.CustomMetadataHandler) error {
+	if !util.Contains(persist.EvmChains, h.Chain) {
+		return fmt.Errorf("custom metadata handlers can only call contracts on EVM chains, not %s", h.Chain)
+	}
+	if IsBuiltinContract(persist.NewContractIdentifiers(h.ContractAddress, h.Chain)) {
+		return fmt.Errorf("contract %s already has a built-in handler", h.ContractAddress)
+	}
+	if !functionSignature.MatchString(h.FunctionSignature) {
+		return fmt.Errorf("function signature %q must be a function that takes no arguments or a uint256 token ID, e.g. tokenHTML(uint256)", h.FunctionSignature)
+	}
+	switch h.Format {
+	case persist.CustomMetadataFormatSVG, persist.CustomMetadataFormatJSON, persist.CustomMetadataFormatURI:
+	default:
+		return fmt.Errorf("invalid format: %s", h.Format)
+	}
+	_, err := renderTemplate(h.Template, TemplateData{}, true)
+	return err
+}
+
+// newDeclarativeHandler returns a handler that calls the handler's view function, parses the result with its format, and applies its template
+func newDeclarativeHandler(ethClient *ethclient.Client, ipfsClient *shell.Shell, arweaveClient *goar.Client, h db.CustomMetadataHandler) metadataHandler {
+	return func(ctx context.Context, t persist.TokenIdentifiers, _ ...persist.TokenMetadata) (persist.TokenMetadata, error) {
+		result, err := callStringFunction(ctx, ethClient, t, h.FunctionSignature)
+		if err != nil {
+			return persist.TokenMetadata{}, err
+		}
+		return declarativeMetadata(ctx, ipfsClient, arweaveClient, h, t, result)
+	}
+}
+
+// declarativeMetadata parses the result of the handler's view function with its format and applies its template
+func declarativeMetadata(ctx context.Context, ipfsClient *shell.Shell, arweaveClient *goar.Client, h db.CustomMetadataHandler, t persist.TokenIdentifiers, result string) (persist.TokenMetadata, error) {
+	var m persist.TokenMetadata
+	var err error
+
+	switch h.Format {
+	case persist.CustomMetadataFormatSVG:
+		if !util.IsDataURI(result) {
+			result = fmt.Sprintf("data:image/svg+xml;base64,%s", base64.StdEncoding.EncodeToString([]byte(result)))
+		}
+		m = persist.TokenMetadata{"image": result}
+	case persist.CustomMetadataFormatJSON:
+		raw := []byte(result)
+		if util.IsDataURI(result) {
+			decoded, err := util.DecodeDataURI(result)
+			if err != nil {
+				return persist.TokenMetadata{}, err
+			}
+			raw = decoded.Data
+		}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return persist.TokenMetadata{}, fmt.Errorf("failed to parse %s result as json: %w", h.FunctionSignature, err)
+		}
+	case persist.CustomMetadataFormatURI:
+		m, err = rpc.GetMetadataFromURI(ctx, persist.TokenURI(result).ReplaceID(t.TokenID), ipfsClient, arweaveClient)
+		if err != nil {
+			return persist.TokenMetadata{}, err
+		}
+	default:
+		return persist.TokenMetadata{}, fmt.Errorf("invalid format: %s", h.Format)
+	}
+
+	if len(h.Template) == 0 {
+		return m, nil
+	}
+
+	rendered, err := renderTemplate(h.Template, TemplateData{
+		TokenID:         t.TokenID.Base10String(),
+		TokenIDHex:      t.TokenID.String(),
+		ContractAddress: t.ContractAddress.String(),
+		Result:          result,
+		Metadata:        m,
+	}, false)
+	if err != nil {
+		return persist.TokenMetadata{}, err
+	}
+
+	// Fields of the template take precedence over the fields of the result
+	if m == nil {
+		m = persist.TokenMetadata{}
+	}
+	for k, v := range rendered {
+		m[k] = v
+	}
+
+	return m, nil
+}
+
+// callStringFunction calls a view function that returns a string or bytes, passing the token ID if the function takes an argument
+func callStringFunction(ctx context.Context, ethClient *ethclient.Client, t persist.TokenIdentifiers, signature string) (string, error) {
+	data := crypto.Keccak256([]byte(signature))[:4]
+	if !strings.HasSuffix(signature, "()") {
+		data = append(data, ethcommon.LeftPadBytes(t.TokenID.BigInt().Bytes(), 32)...)
+	}
+
+	to := ethcommon.HexToAddress(t.ContractAddress.String())
+	out, err := ethClient.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := rpc.UnpackString(out)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode result of %s: %w", signature, err)
+	}
+
+	return result, nil
+}
+
+// renderTemplate executes each string in the template with data. If parseOnly is true, the templates are only checked for errors.
+func renderTemplate(tmpl persist.TokenMetadata, data TemplateData, parseOnly bool) (persist.TokenMetadata, error) {
+	rendered, err := renderObject("", tmpl, data, parseOnly)
+	if err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+// renderObject executes the strings of an object in the template. path is the key of the object, which errors are reported with.
+func renderObject(path string, obj map[string]any, data TemplateData, parseOnly bool) (map[string]any, error) {
+	rendered := make(map[string]any, len(obj))
+	for k, v := range obj {
+		key := k
+		if path != "" {
+			key = path + "." + k
+		}
+		r, err := renderValue(key, v, data, parseOnly)
+		if err != nil {
+			return nil, err
+		}
+		rendered[k] = r
+	}
+	return rendered, nil
+}
+
+func renderValue(key string, v any, data TemplateData, parseOnly bool) (any, error) {
+	switch v := v.(type) {
+	case string:
+		t, err := template.New(key).Option("missingkey=zero").Funcs(template.FuncMap{"orEmpty": orEmpty}).Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for %s: %w", key, err)
+		}
+		if parseOnly {
+			return v, nil
+		}
+		printMissingAsEmpty(t.Tree, t.Tree.Root)
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to execute template for %s: %w", key, err)
+		}
+		return buf.String(), nil
+	case map[string]any:
+		return renderObject(key, v, data, parseOnly)
+	case []any:
+		rendered := make([]any, len(v))
+		for i, e := range v {
+			r, err := renderValue(fmt.Sprintf("%s[%d]", key, i), e, data, parseOnly)
+			if err != nil {
+				return nil, err
+			}
+			rendered[i] = r
+		}
+		return rendered, nil
+	default:
+		return v, nil
+	}
+}
+
+// orEmpty returns an empty string in place of a missing value
+func orEmpty(v any) any {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+// printMissingAsEmpty pipes each action that prints a value to orEmpty so that fields that are missing from the metadata are
+// rendered as empty strings. Metadata is a map of interfaces, so with missingkey=zero a missing field is a nil interface, which
+// text/template would print as "<no value>".
+func printMissingAsEmpty(tree *parse.Tree, n parse.Node) {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			printMissingAsEmpty(tree, c)
+		}
+	case *parse.ActionNode:
+		// Actions that declare a variable don't print anything
+		if len(n.Pipe.Decl) == 0 {
+			n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+				NodeType: parse.NodeCommand,
+				Pos:      n.Pos,
+				Args:     []parse.Node{parse.NewIdentifier("orEmpty").SetTree(tree).SetPos(n.Pos)},
+			})
+		}
+	case *parse.IfNode:
+		printMissingAsEmpty(tree, n.List)
+		printMissingAsEmpty(tree, n.ElseList)
+	case *parse.RangeNode:
+		printMissingAsEmpty(tree, n.List)
+		printMissingAsEmpty(tree, n.ElseList)
+	case *parse.WithNode:
+		printMissingAsEmpty(tree, n.List)
+		printMissingAsEmpty(tree, n.ElseList)
+	}
+}
+
+// declarativeHandlers are the handlers that are stored in the database
+type declarativeHandlers struct {
+	queries *db.Queries
+	// clients are the RPC clients of the EVM chains that handlers can call contracts on
+	clients       map[persist.Chain]*ethclient.Client
+	ipfsClient    *shell.Shell
+	arweaveClient *goar.Client
+
+	mu       sync.Mutex
+	handlers map[persist.ContractIdentifiers]metadataHandler
+	loadedAt time.Time
+}
+
+// handlerFor returns the handler of the contract, or nil if it doesn't have one
+func (d *declarativeHandlers) handlerFor(ctx context.Context, c persist.ContractIdentifiers) metadataHandler {
+	if d == nil || d.queries == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.handlers == nil || time.Since(d.loadedAt) > declarativeHandlersTTL {
+		if err := d.load(ctx); err != nil {
+			// Keep using the handlers that were loaded last
+			logger.For(ctx).Errorf("failed to load custom metadata handlers: %s", err)
+		}
+	}
+
+	return d.handlers[c]
+}
+
+func (d *declarativeHandlers) load(ctx context.Context) error {
+	// Wait before trying again regardless of whether loading succeeds so that a database outage doesn't cause a query per token
+	d.loadedAt = time.Now()
+
+	rows, err := d.queries.GetCustomMetadataHandlers(ctx)
+	if err != nil {
+		return err
+	}
+
+	handlers := make(map[persist.ContractIdentifiers]metadataHandler, len(rows))
+	var errs util.MultiErr
+	for _, h := range rows {
+		if err := ValidateHandler(h); err != nil {
+			errs = append(errs, fmt.Errorf("handler %s: %w", h.ID, err))
+			continue
+		}
+		ethClient, ok := d.clients[h.Chain]
+		if !ok {
+			errs = append(errs, fmt.Errorf("handler %s: no RPC endpoint is configured for %s", h.ID, h.Chain))
+			continue
+		}
+		handlers[persist.NewContractIdentifiers(h.ContractAddress, h.Chain)] = newDeclarativeHandler(ethClient, d.ipfsClient, d.arweaveClient, h)
+	}
+
+	d.handlers = handlers
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package custom
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+func TestValidateHandler(t *testing.T) {
+	valid := db.CustomMetadataHandler{
+		Chain:             persist.ChainETH,
+		ContractAddress:   "0x0000000000000000000000000000000000000001",
+		FunctionSignature: "tokenHTML(uint256)",
+		Format:            persist.CustomMetadataFormatSVG,
+		Template:          persist.TokenMetadata{"name": "Token #{{.TokenID}}"},
+	}
+
+	tests := []struct {
+		title  string
+		modify func(h *db.CustomMetadataHandler)
+		err    string
+	}{
+		{title: "accepts a valid handler", modify: func(h *db.CustomMetadataHandler) {}},
+		{title: "accepts a function without arguments", modify: func(h *db.CustomMetadataHandler) { h.FunctionSignature = "render()" }},
+		{title: "accepts other EVM chains", modify: func(h *db.CustomMetadataHandler) { h.Chain = persist.ChainBase }},
+		{title: "rejects chains that aren't EVM chains", modify: func(h *db.CustomMetadataHandler) { h.Chain = persist.ChainTezos }, err: "can only call contracts on EVM chains"},
+		{title: "rejects contracts with a built-in handler", modify: func(h *db.CustomMetadataHandler) { h.ContractAddress = AutoglyphContract.ContractAddress }, err: "built-in handler"},
+		{title: "rejects functions that take other arguments", modify: func(h *db.CustomMetadataHandler) { h.FunctionSignature = "tokenHTML(address)" }, err: "function signature"},
+		{title: "rejects malformed signatures", modify: func(h *db.CustomMetadataHandler) { h.FunctionSignature = "tokenHTML" }, err: "function signature"},
+		{title: "rejects unknown formats", modify: func(h *db.CustomMetadataHandler) { h.Format = "xml" }, err: "invalid format"},
+		{title: "rejects invalid templates", modify: func(h *db.CustomMetadataHandler) { h.Template = persist.TokenMetadata{"name": "{{.TokenID"} }, err: "invalid template for name"},
+		{title: "rejects invalid nested templates", modify: func(h *db.CustomMetadataHandler) {
+			h.Template = persist.TokenMetadata{"attributes": []any{map[string]any{"value": "{{end}}"}}}
+		}, err: "invalid template for attributes[0].value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			h := valid
+			tt.modify(&h)
+			err := ValidateHandler(h)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	data := TemplateData{
+		TokenID:         "255",
+		TokenIDHex:      "ff",
+		ContractAddress: "0x0000000000000000000000000000000000000001",
+		Result:          "data:image/svg+xml;base64,PHN2Zy8+",
+		Metadata: persist.TokenMetadata{
+			"name":       "Original",
+			"edition":    float64(3),
+			"properties": map[string]any{"color": "red"},
+			"note":       "<no value>",
+		},
+	}
+
+	t.Run("renders fields of the token and its metadata", func(t *testing.T) {
+		rendered, err := renderTemplate(persist.TokenMetadata{
+			"name":         "{{.Metadata.name}} #{{.TokenID}}",
+			"external_url": "https://example.com/{{.ContractAddress}}/{{.TokenIDHex}}",
+			"image":        "{{.Result}}",
+			"description":  "Edition {{.Metadata.edition}} in {{.Metadata.properties.color}}",
+		}, data, false)
+		require.NoError(t, err)
+		assert.Equal(t, persist.TokenMetadata{
+			"name":         "Original #255",
+			"external_url": "https://example.com/0x0000000000000000000000000000000000000001/ff",
+			"image":        "data:image/svg+xml;base64,PHN2Zy8+",
+			"description":  "Edition 3 in red",
+		}, rendered)
+	})
+
+	t.Run("renders nested objects and arrays", func(t *testing.T) {
+		rendered, err := renderTemplate(persist.TokenMetadata{
+			"attributes": []any{
+				map[string]any{"trait_type": "Color", "value": "{{.Metadata.properties.color}}"},
+				map[string]any{"trait_type": "Edition", "value": float64(1)},
+			},
+		}, data, false)
+		require.NoError(t, err)
+		assert.Equal(t, persist.TokenMetadata{
+			"attributes": []any{
+				map[string]any{"trait_type": "Color", "value": "red"},
+				map[string]any{"trait_type": "Edition", "value": float64(1)},
+			},
+		}, rendered)
+	})
+
+	t.Run("renders missing fields as empty strings", func(t *testing.T) {
+		rendered, err := renderTemplate(persist.TokenMetadata{
+			"name":        "[{{.Metadata.title}}]",
+			"description": "[{{.Metadata.properties.size}}]",
+			"image":       "{{if .Metadata.image}}{{.Metadata.image}}{{else}}[{{.Metadata.image_url}}]{{end}}",
+		}, data, false)
+		require.NoError(t, err)
+		assert.Equal(t, persist.TokenMetadata{"name": "[]", "description": "[]", "image": "[]"}, rendered)
+	})
+
+	t.Run("keeps values that contain the text of a missing value", func(t *testing.T) {
+		rendered, err := renderTemplate(persist.TokenMetadata{"description": "{{.Metadata.note}}"}, data, false)
+		require.NoError(t, err)
+		assert.Equal(t, persist.TokenMetadata{"description": "<no value>"}, rendered)
+	})
+
+	t.Run("keeps templates as is when only parsing", func(t *testing.T) {
+		rendered, err := renderTemplate(persist.TokenMetadata{"name": "{{.TokenID}}"}, data, true)
+		require.NoError(t, err)
+		assert.Equal(t, persist.TokenMetadata{"name": "{{.TokenID}}"}, rendered)
+	})
+
+	t.Run("fails on fields that TemplateData doesn't have", func(t *testing.T) {
+		_, err := renderTemplate(persist.TokenMetadata{"name": "{{.Owner}}"}, data, false)
+		assert.ErrorContains(t, err, "failed to execute template for name")
+	})
+}
+
+func TestDeclarativeMetadata(t *testing.T) {
+	ctx := context.Background()
+	token := persist.NewTokenIdentifiers("0x0000000000000000000000000000000000000001", "a", persist.ChainETH)
+
+	t.Run("encodes an svg result as the image", func(t *testing.T) {
+		h := db.CustomMetadataHandler{FunctionSignature: "tokenSVG(uint256)", Format: persist.CustomMetadataFormatSVG}
+		m, err := declarativeMetadata(ctx, nil, nil, h, token, "<svg/>")
+		require.NoError(t, err)
+		assert.Equal(t, persist.TokenMetadata{"image": "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte("<svg/>"))}, m)
+	})
+
+	t.Run("keeps an svg result that's already a data uri", func(t *testing.T) {
+		h := db.CustomMetadataHandler{FunctionSignature: "tokenSVG(uint256)", Format: persist.CustomMetadataFormatSVG}
+		m, err := declarativeMetadata(ctx, nil, nil, h, token, "data:image/svg+xml;utf8,<svg/>")
+		require.NoError(t, err)
+		assert.Equal(t, persist.TokenMetadata{"image": "data:image/svg+xml;utf8,<svg/>"}, m)
+	})
+
+	t.Run("parses json and json data uris", func(t *testing.T) {
+		h := db.CustomMetadataHandler{FunctionSignature: "tokenJSON(uint256)", Format: persist.CustomMetadataFormatJSON}
+		for _, result := range []string{
+			`{"name":"Token"}`,
+			"data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(`{"name":"Token"}`)),
+		} {
+			m, err := declarativeMetadata(ctx, nil, nil, h, token, result)
+			require.NoError(t, err)
+			assert.Equal(t, persist.TokenMetadata{"name": "Token"}, m)
+		}
+	})
+
+	t.Run("fails on results that aren't json", func(t *testing.T) {
+		h := db.CustomMetadataHandler{FunctionSignature: "tokenJSON(uint256)", Format: persist.CustomMetadataFormatJSON}
+		_, err := declarativeMetadata(ctx, nil, nil, h, token, "not json")
+		assert.ErrorContains(t, err, "failed to parse tokenJSON(uint256) result as json")
+	})
+
+	t.Run("fields of the template take precedence over the result", func(t *testing.T) {
+		h := db.CustomMetadataHandler{
+			FunctionSignature: "tokenJSON(uint256)",
+			Format:            persist.CustomMetadataFormatJSON,
+			Template:          persist.TokenMetadata{"name": "{{.Metadata.name}} #{{.TokenID}}"},
+		}
+		m, err := declarativeMetadata(ctx, nil, nil, h, token, `{"name":"Token","image":"ipfs://image"}`)
+		require.NoError(t, err)
+		assert.Equal(t, persist.TokenMetadata{"name": "Token #10", "image": "ipfs://image"}, m)
+	})
+}
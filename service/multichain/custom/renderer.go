@@ -0,0 +1,158 @@
+package custom
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/redis"
+	"github.com/mikeydub/go-gallery/service/rpc"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+// renderCacheTTL is how long a rendered token is cached for. On-chain art rarely changes, but tokens are
+// re-rendered every so often in case the data that they're drawn from does.
+const renderCacheTTL = 24 * time.Hour
+
+// Rendered is the output of a Renderer
+type Rendered struct {
+	Media []byte `json:"media"`
+	// MediaType is the content type of Media, e.g. image/svg+xml
+	MediaType string `json:"media_type"`
+	// Metadata is any other metadata of the token, e.g. its name and description
+	Metadata persist.TokenMetadata `json:"metadata"`
+}
+
+// Renderer draws the media of a token from data that's stored on-chain. Renderers are registered in a RendererRegistry for the
+// contracts that they draw.
+type Renderer interface {
+	// Version identifies the output of the renderer. It must change whenever a change to the renderer changes what it draws
+	// so that cached renders aren't served.
+	Version() string
+	Render(ctx context.Context, t persist.TokenIdentifiers, r ContractReader) (Rendered, error)
+}
+
+// ContractReader reads the on-chain data that renderers draw from
+type ContractReader interface {
+	TokenURI(ctx context.Context, t persist.TokenIdentifiers) (string, error)
+}
+
+// RenderCache stores rendered tokens
+type RenderCache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+}
+
+// NewRenderCache returns the tokenprocessing cache that rendered tokens are stored in
+func NewRenderCache() *redis.Cache {
+	return redis.NewCache(redis.TokenProcessingRenderCache)
+}
+
+// RendererRegistry maps contracts to the renderers that draw their tokens
+type RendererRegistry struct {
+	renderers map[persist.ContractIdentifiers]Renderer
+}
+
+func NewRendererRegistry() *RendererRegistry {
+	return &RendererRegistry{renderers: make(map[persist.ContractIdentifiers]Renderer)}
+}
+
+// DefaultRenderers returns a registry with the renderers of the fully on-chain projects that we draw ourselves
+func DefaultRenderers() *RendererRegistry {
+	r := NewRendererRegistry()
+	r.Register(AutoglyphContract, AutoglyphRenderer{})
+	r.Register(ColorglyphContract, ColorglyphRenderer{})
+	return r
+}
+
+// Register sets the renderer of a contract, replacing any renderer that was registered before
+func (r *RendererRegistry) Register(c persist.ContractIdentifiers, renderer Renderer) {
+	r.renderers[c] = renderer
+}
+
+// RendererFor returns the renderer of the contract
+func (r *RendererRegistry) RendererFor(c persist.ContractIdentifiers) (Renderer, bool) {
+	renderer, ok := r.renderers[c]
+	return renderer, ok
+}
+
+// rpcContractReader reads contracts with an RPC client
+type rpcContractReader struct {
+	ethClient *ethclient.Client
+}
+
+func (r rpcContractReader) TokenURI(ctx context.Context, t persist.TokenIdentifiers) (string, error) {
+	uri, err := rpc.RetryGetTokenURI(ctx, "", persist.EthereumAddress(t.ContractAddress), t.TokenID, r.ethClient)
+	return uri.String(), err
+}
+
+// Render renders the token, or returns the cached render if it was rendered recently. cache may be nil to always render.
+func Render(ctx context.Context, renderer Renderer, reader ContractReader, cache RenderCache, t persist.TokenIdentifiers) (Rendered, error) {
+	key := renderCacheKey(renderer, t)
+
+	if cache != nil {
+		cached, err := cache.Get(ctx, key)
+		if err == nil {
+			var rendered Rendered
+			if err := json.Unmarshal(cached, &rendered); err == nil {
+				return rendered, nil
+			}
+		} else if !util.ErrorIs[redis.ErrKeyNotFound](err) {
+			logger.For(ctx).Warnf("failed to read cached render of %s: %s", t, err)
+		}
+	}
+
+	rendered, err := renderer.Render(ctx, t, reader)
+	if err != nil {
+		return Rendered{}, err
+	}
+
+	if cache != nil {
+		b, err := json.Marshal(rendered)
+		if err == nil {
+			err = cache.Set(ctx, key, b, renderCacheTTL)
+		}
+		if err != nil {
+			logger.For(ctx).Warnf("failed to cache render of %s: %s", t, err)
+		}
+	}
+
+	return rendered, nil
+}
+
+func renderCacheKey(renderer Renderer, t persist.TokenIdentifiers) string {
+	return fmt.Sprintf("%d:%s:%s:v%s", t.Chain, t.ContractAddress, t.TokenID, renderer.Version())
+}
+
+// newRendererHandler returns a handler that adds the rendered media to the token's metadata as a data URI
+func newRendererHandler(renderer Renderer, reader ContractReader, cache RenderCache) metadataHandler {
+	return func(ctx context.Context, t persist.TokenIdentifiers, _ ...persist.TokenMetadata) (persist.TokenMetadata, error) {
+		rendered, err := Render(ctx, renderer, reader, cache, t)
+		if err != nil {
+			return persist.TokenMetadata{}, err
+		}
+		return renderedToMetadata(rendered), nil
+	}
+}
+
+func renderedToMetadata(rendered Rendered) persist.TokenMetadata {
+	m := make(persist.TokenMetadata, len(rendered.Metadata)+1)
+	for k, v := range rendered.Metadata {
+		m[k] = v
+	}
+
+	key := "animation_url"
+	if strings.HasPrefix(rendered.MediaType, "image/") {
+		key = "image"
+	}
+	m[key] = fmt.Sprintf("data:%s;base64,%s", rendered.MediaType, base64.StdEncoding.EncodeToString(rendered.Media))
+
+	return m
+}
@@ -0,0 +1,166 @@
+package custom
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/redis"
+)
+
+// fixtureReader returns tokenURIs that were saved from the contracts to testdata
+type fixtureReader struct {
+	t     *testing.T
+	files map[persist.TokenIdentifiers]string
+	calls int
+}
+
+func (f *fixtureReader) TokenURI(ctx context.Context, t persist.TokenIdentifiers) (string, error) {
+	f.calls++
+	return readFixture(f.t, f.files[t]), nil
+}
+
+type mapCache map[string][]byte
+
+func (m mapCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	return nil, redis.ErrKeyNotFound{Key: key}
+}
+
+func (m mapCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	m[key] = value
+	return nil
+}
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return string(b)
+}
+
+var (
+	autoglyph1  = persist.NewTokenIdentifiers(AutoglyphContract.ContractAddress, "1", persist.ChainETH)
+	colorglyph1 = persist.NewTokenIdentifiers(ColorglyphContract.ContractAddress, "1", persist.ChainETH)
+)
+
+func newFixtureReader(t *testing.T) *fixtureReader {
+	return &fixtureReader{t: t, files: map[persist.TokenIdentifiers]string{
+		autoglyph1:  "autoglyph_1.txt",
+		colorglyph1: "colorglyph_1.txt",
+	}}
+}
+
+func TestRenderers_MatchFixtures(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		token    persist.TokenIdentifiers
+		renderer Renderer
+		golden   string
+	}{
+		{"autoglyph", autoglyph1, AutoglyphRenderer{}, "autoglyph_1.svg"},
+		{"colorglyph", colorglyph1, ColorglyphRenderer{}, "colorglyph_1.svg"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := tc.renderer.Render(context.Background(), tc.token, newFixtureReader(t))
+			require.NoError(t, err)
+			assert.Equal(t, "image/svg+xml", rendered.MediaType)
+			assert.Equal(t, readFixture(t, tc.golden), string(rendered.Media))
+			assert.NotEmpty(t, rendered.Metadata["name"])
+		})
+	}
+}
+
+func TestColorglyphRenderer_InvalidTokenURI(t *testing.T) {
+	for _, uri := range []string{
+		"data:text/plain;charset=utf-8,.O+X",
+		"data:text/plain;charset=utf-8,.O+X 5 b189f7",
+		"data:text/plain;charset=utf-8,.O+X 5 zz89f76323678e094d4996d182a792e52369c005",
+	} {
+		reader := &staticReader{uri: uri}
+		_, err := ColorglyphRenderer{}.Render(context.Background(), colorglyph1, reader)
+		assert.Error(t, err, uri)
+	}
+}
+
+type staticReader struct{ uri string }
+
+func (s *staticReader) TokenURI(context.Context, persist.TokenIdentifiers) (string, error) {
+	return s.uri, nil
+}
+
+func TestRender_CachesRenderedOutput(t *testing.T) {
+	ctx := context.Background()
+	reader := newFixtureReader(t)
+	cache := mapCache{}
+
+	first, err := Render(ctx, AutoglyphRenderer{}, reader, cache, autoglyph1)
+	require.NoError(t, err)
+	second, err := Render(ctx, AutoglyphRenderer{}, reader, cache, autoglyph1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, reader.calls)
+	assert.Equal(t, first, second)
+	assert.Len(t, cache, 1)
+}
+
+type versionedRenderer struct {
+	Renderer
+	version string
+}
+
+func (v versionedRenderer) Version() string { return v.version }
+
+func TestRender_NewVersionIsRerendered(t *testing.T) {
+	ctx := context.Background()
+	reader := newFixtureReader(t)
+	cache := mapCache{}
+
+	_, err := Render(ctx, versionedRenderer{AutoglyphRenderer{}, "1"}, reader, cache, autoglyph1)
+	require.NoError(t, err)
+	_, err = Render(ctx, versionedRenderer{AutoglyphRenderer{}, "2"}, reader, cache, autoglyph1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, reader.calls)
+	assert.Len(t, cache, 2)
+}
+
+func TestRendererHandler_SetsImageDataURI(t *testing.T) {
+	h := newRendererHandler(AutoglyphRenderer{}, newFixtureReader(t), nil)
+
+	m, err := h(context.Background(), autoglyph1)
+	require.NoError(t, err)
+
+	image, _ := m["image"].(string)
+	require.True(t, strings.HasPrefix(image, "data:image/svg+xml;base64,"))
+	svg, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(image, "data:image/svg+xml;base64,"))
+	require.NoError(t, err)
+	assert.Equal(t, readFixture(t, "autoglyph_1.svg"), string(svg))
+	assert.Equal(t, "Autoglyph #1", m["name"])
+}
+
+func TestDefaultRenderers(t *testing.T) {
+	r := DefaultRenderers()
+
+	renderer, ok := r.RendererFor(AutoglyphContract)
+	assert.True(t, ok)
+	assert.IsType(t, AutoglyphRenderer{}, renderer)
+
+	renderer, ok = r.RendererFor(ColorglyphContract)
+	assert.True(t, ok)
+	assert.IsType(t, ColorglyphRenderer{}, renderer)
+
+	_, ok = r.RendererFor(EnsContract)
+	assert.False(t, ok)
+	assert.True(t, IsBuiltinContract(AutoglyphContract))
+}
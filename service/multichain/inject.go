@@ -35,6 +35,7 @@ func NewMultichainProvider(context.Context, *postgres.Repositories, *db.Queries,
 		wire.Value(http.DefaultClient), // HTTP client shared between providers
 		wire.Struct(new(ChainProvider), "*"),
 		tokenProcessingSubmitterInjector,
+		contractEnricherInjector,
 		multichainProviderInjector,
 		ethInjector,
 		tezosInjector,
@@ -47,7 +48,7 @@ func NewMultichainProvider(context.Context, *postgres.Repositories, *db.Queries,
 	))
 }
 
-func multichainProviderInjector(ctx context.Context, repos *postgres.Repositories, q *db.Queries, chainProvider *ChainProvider, submitter *tokenmanage.TokenProcessingSubmitter) *Provider {
+func multichainProviderInjector(ctx context.Context, repos *postgres.Repositories, q *db.Queries, chainProvider *ChainProvider, submitter *tokenmanage.TokenProcessingSubmitter, enricher *ContractEnricher) *Provider {
 	panic(wire.Build(
 		wire.Struct(new(Provider), "*"),
 		wire.Bind(new(tokenmanage.Submitter), util.ToPointer(submitter)),
@@ -55,6 +56,14 @@ func multichainProviderInjector(ctx context.Context, repos *postgres.Repositorie
 	))
 }
 
+func contractEnricherInjector(ethClient *ethclient.Client, queries *db.Queries, taskClient *task.Client) *ContractEnricher {
+	panic(wire.Build(
+		NewContractEnricher,
+		ipfs.NewShell,
+		arweave.NewClient,
+	))
+}
+
 // New chains must be added here
 func newProviderLookup(p *ChainProvider) ProviderLookup {
 	return ProviderLookup{
@@ -69,15 +78,17 @@ func newProviderLookup(p *ChainProvider) ProviderLookup {
 	}
 }
 
-func customMetadataHandlersInjector(ethCleint *ethclient.Client) *custom.CustomMetadataHandlers {
+func customMetadataHandlersInjector(ethCleint *ethclient.Client, queries *db.Queries) *custom.CustomMetadataHandlers {
 	panic(wire.Build(
 		custom.NewCustomMetadataHandlers,
+		custom.NewRenderCache,
+		wire.Bind(new(custom.RenderCache), new(*redis.Cache)),
 		ipfs.NewShell,
 		arweave.NewClient,
 	))
 }
 
-func ethInjector(context.Context, *http.Client, *ethclient.Client) *EthereumProvider {
+func ethInjector(context.Context, *http.Client, *ethclient.Client, *db.Queries) *EthereumProvider {
 	panic(wire.Build(
 		wire.Value(persist.ChainETH),
 		ethProviderInjector,
@@ -119,6 +130,7 @@ func ethSyncPipelineInjector(
 	chain persist.Chain,
 	simplehashProvider *simplehash.Provider,
 	ethClient *ethclient.Client,
+	queries *db.Queries,
 ) *wrapper.SyncPipelineWrapper {
 	panic(wire.Build(
 		wire.Struct(new(wrapper.SyncPipelineWrapper), "*"),
@@ -159,7 +171,7 @@ func tezosProviderInjector(tezosProvider *tezos.Provider, simplehashProvider *si
 	))
 }
 
-func optimismInjector(context.Context, *http.Client, *ethclient.Client) *OptimismProvider {
+func optimismInjector(context.Context, *http.Client, *ethclient.Client, *db.Queries) *OptimismProvider {
 	panic(wire.Build(
 		wire.Value(persist.ChainOptimism),
 		simplehash.NewProvider,
@@ -193,6 +205,7 @@ func optimismSyncPipelineInjector(
 	chain persist.Chain,
 	simplehashProvider *simplehash.Provider,
 	ethClient *ethclient.Client,
+	queries *db.Queries,
 ) *wrapper.SyncPipelineWrapper {
 	panic(wire.Build(
 		wire.Struct(new(wrapper.SyncPipelineWrapper), "*"),
@@ -206,7 +219,7 @@ func optimismSyncPipelineInjector(
 	))
 }
 
-func arbitrumInjector(context.Context, *http.Client, *ethclient.Client) *ArbitrumProvider {
+func arbitrumInjector(context.Context, *http.Client, *ethclient.Client, *db.Queries) *ArbitrumProvider {
 	panic(wire.Build(
 		wire.Value(persist.ChainArbitrum),
 		simplehash.NewProvider,
@@ -240,6 +253,7 @@ func arbitrumSyncPipelineInjector(
 	chain persist.Chain,
 	simplehashProvider *simplehash.Provider,
 	ethClient *ethclient.Client,
+	queries *db.Queries,
 ) *wrapper.SyncPipelineWrapper {
 	panic(wire.Build(
 		wire.Struct(new(wrapper.SyncPipelineWrapper), "*"),
@@ -270,7 +284,7 @@ func poapProviderInjector(poapProvider *poap.Provider) *PoapProvider {
 	))
 }
 
-func zoraInjector(context.Context, *http.Client, *ethclient.Client) *ZoraProvider {
+func zoraInjector(context.Context, *http.Client, *ethclient.Client, *db.Queries) *ZoraProvider {
 	panic(wire.Build(
 		wire.Value(persist.ChainZora),
 		simplehash.NewProvider,
@@ -304,6 +318,7 @@ func zoraSyncPipelineInjector(
 	chain persist.Chain,
 	simplehashProvider *simplehash.Provider,
 	ethClient *ethclient.Client,
+	queries *db.Queries,
 ) *wrapper.SyncPipelineWrapper {
 	panic(wire.Build(
 		wire.Struct(new(wrapper.SyncPipelineWrapper), "*"),
@@ -317,7 +332,7 @@ func zoraSyncPipelineInjector(
 	))
 }
 
-func baseInjector(context.Context, *http.Client, *ethclient.Client) *BaseProvider {
+func baseInjector(context.Context, *http.Client, *ethclient.Client, *db.Queries) *BaseProvider {
 	panic(wire.Build(
 		wire.Value(persist.ChainBase),
 		simplehash.NewProvider,
@@ -352,6 +367,7 @@ func baseSyncPipelineInjector(
 	chain persist.Chain,
 	simplehashProvider *simplehash.Provider,
 	ethClient *ethclient.Client,
+	queries *db.Queries,
 ) *wrapper.SyncPipelineWrapper {
 	panic(wire.Build(
 		wire.Struct(new(wrapper.SyncPipelineWrapper), "*"),
@@ -365,7 +381,7 @@ func baseSyncPipelineInjector(
 	))
 }
 
-func polygonInjector(context.Context, *http.Client, *ethclient.Client) *PolygonProvider {
+func polygonInjector(context.Context, *http.Client, *ethclient.Client, *db.Queries) *PolygonProvider {
 	panic(wire.Build(
 		wire.Value(persist.ChainPolygon),
 		simplehash.NewProvider,
@@ -400,6 +416,7 @@ func polygonSyncPipelineInjector(
 	chain persist.Chain,
 	simplehashProvider *simplehash.Provider,
 	ethClient *ethclient.Client,
+	queries *db.Queries,
 ) *wrapper.SyncPipelineWrapper {
 	panic(wire.Build(
 		wire.Struct(new(wrapper.SyncPipelineWrapper), "*"),
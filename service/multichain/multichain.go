@@ -48,6 +48,8 @@ type Provider struct {
 	Queries   *db.Queries
 	Chains    ProviderLookup
 	Submitter tokenmanage.Submitter
+	// Enricher fills in what indexers provide with contract metadata read from the chain. Contracts aren't enriched if it's nil.
+	Enricher *ContractEnricher
 }
 
 type ErrProviderFailed struct{ Err error }
@@ -1077,8 +1079,10 @@ func (d *Provider) processContractCommunities(ctx context.Context, contracts []d
 // processContracts deduplicates contracts and upserts them into the database. If canOverwriteOwnerAddress is true, then
 // the owner address of an existing contract will be overwritten if the new contract provides a non-empty owner address.
 // An empty owner address will never overwrite an existing address, even if canOverwriteOwnerAddress is true.
+// Before they're upserted, contracts are enriched with the metadata that was last read from the chain.
 func (d *Provider) processContracts(ctx context.Context, chain persist.Chain, contracts []common.ChainAgnosticContract, canOverwriteOwnerAddress bool) (newContracts []db.Contract, err error) {
 	contractsToAdd := chainContractsToUpsertableContracts(chain, contracts)
+	contractsToAdd = d.Enricher.Enrich(ctx, chain, contractsToAdd)
 
 	addedContracts, err := d.Repos.ContractRepository.BulkUpsert(ctx, contractsToAdd, canOverwriteOwnerAddress)
 	if err != nil {
@@ -1213,7 +1217,7 @@ func chainTokensToUpsertableTokens(chain persist.Chain, tokens []common.ChainAgn
 				Identifiers: ti,
 				Token: db.Token{
 					OwnerUserID:    ownerUser.ID,
-					BlockNumber:    sql.NullInt64{Int64: token.BlockNumber.BigInt().Int64(), Valid: true},
+					BlockNumber:    sql.NullInt64{Int64: token.BlockNumber.BigInt().Int64(), Valid: token.BlockNumber != 0},
 					IsCreatorToken: createdContracts[persist.Address(normalizedAddress)],
 					ContractID:     contract.ID,
 				},
@@ -32,14 +32,14 @@ import (
 // ethClient.Client and task.Client are expensive to initialize so they're passed as an arg.
 func NewMultichainProvider(contextContext context.Context, repositories *postgres.Repositories, queries *coredb.Queries, client *ethclient.Client, taskClient *task.Client, cache *redis.Cache) *Provider {
 	httpClient := _wireClientValue
-	ethereumProvider := ethInjector(contextContext, httpClient, client)
+	ethereumProvider := ethInjector(contextContext, httpClient, client, queries)
 	tezosProvider := tezosInjector(httpClient)
-	optimismProvider := optimismInjector(contextContext, httpClient, client)
-	arbitrumProvider := arbitrumInjector(contextContext, httpClient, client)
+	optimismProvider := optimismInjector(contextContext, httpClient, client, queries)
+	arbitrumProvider := arbitrumInjector(contextContext, httpClient, client, queries)
 	poapProvider := poapInjector(httpClient)
-	zoraProvider := zoraInjector(contextContext, httpClient, client)
-	baseProvider := baseInjector(contextContext, httpClient, client)
-	polygonProvider := polygonInjector(contextContext, httpClient, client)
+	zoraProvider := zoraInjector(contextContext, httpClient, client, queries)
+	baseProvider := baseInjector(contextContext, httpClient, client, queries)
+	polygonProvider := polygonInjector(contextContext, httpClient, client, queries)
 	chainProvider := &ChainProvider{
 		Ethereum: ethereumProvider,
 		Tezos:    tezosProvider,
@@ -51,7 +51,8 @@ func NewMultichainProvider(contextContext context.Context, repositories *postgre
 		Polygon:  polygonProvider,
 	}
 	tokenProcessingSubmitter := tokenProcessingSubmitterInjector(contextContext, taskClient, cache)
-	provider := multichainProviderInjector(contextContext, repositories, queries, chainProvider, tokenProcessingSubmitter)
+	contractEnricher := contractEnricherInjector(client, queries, taskClient)
+	provider := multichainProviderInjector(contextContext, repositories, queries, chainProvider, tokenProcessingSubmitter, contractEnricher)
 	return provider
 }
 
@@ -59,28 +60,37 @@ var (
 	_wireClientValue = http.DefaultClient
 )
 
-func multichainProviderInjector(ctx context.Context, repos *postgres.Repositories, q *coredb.Queries, chainProvider *ChainProvider, submitter *tokenmanage.TokenProcessingSubmitter) *Provider {
+func multichainProviderInjector(ctx context.Context, repos *postgres.Repositories, q *coredb.Queries, chainProvider *ChainProvider, submitter *tokenmanage.TokenProcessingSubmitter, enricher *ContractEnricher) *Provider {
 	providerLookup := newProviderLookup(chainProvider)
 	provider := &Provider{
 		Repos:     repos,
 		Queries:   q,
 		Chains:    providerLookup,
 		Submitter: submitter,
+		Enricher:  enricher,
 	}
 	return provider
 }
 
-func customMetadataHandlersInjector(ethCleint *ethclient.Client) *custom.CustomMetadataHandlers {
+func contractEnricherInjector(ethClient *ethclient.Client, queries *coredb.Queries, taskClient *task.Client) *ContractEnricher {
 	shell := ipfs.NewShell()
 	client := arweave.NewClient()
-	customMetadataHandlers := custom.NewCustomMetadataHandlers(ethCleint, shell, client)
+	contractEnricher := NewContractEnricher(ethClient, shell, client, queries, taskClient)
+	return contractEnricher
+}
+
+func customMetadataHandlersInjector(ethCleint *ethclient.Client, queries *coredb.Queries) *custom.CustomMetadataHandlers {
+	shell := ipfs.NewShell()
+	client := arweave.NewClient()
+	cache := custom.NewRenderCache()
+	customMetadataHandlers := custom.NewCustomMetadataHandlers(ethCleint, shell, client, queries, cache)
 	return customMetadataHandlers
 }
 
-func ethInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client) *EthereumProvider {
+func ethInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client, queries *coredb.Queries) *EthereumProvider {
 	chain := _wireChainValue
 	provider := simplehash.NewProvider(chain, client)
-	syncPipelineWrapper := ethSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient)
+	syncPipelineWrapper := ethSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient, queries)
 	verifier := ethVerifierInjector(ethclientClient)
 	ethereumProvider := ethProviderInjector(contextContext, syncPipelineWrapper, verifier, provider)
 	return ethereumProvider
@@ -114,8 +124,8 @@ func ethProviderInjector(ctx context.Context, syncPipeline *wrapper.SyncPipeline
 	return ethereumProvider
 }
 
-func ethSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client) *wrapper.SyncPipelineWrapper {
-	customMetadataHandlers := customMetadataHandlersInjector(ethClient)
+func ethSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client, queries *coredb.Queries) *wrapper.SyncPipelineWrapper {
+	customMetadataHandlers := customMetadataHandlersInjector(ethClient, queries)
 	syncPipelineWrapper := &wrapper.SyncPipelineWrapper{
 		Chain:                            chain,
 		TokenIdentifierOwnerFetcher:      simplehashProvider,
@@ -158,10 +168,10 @@ func tezosProviderInjector(tezosProvider *tezos.Provider, simplehashProvider *si
 	return multichainTezosProvider
 }
 
-func optimismInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client) *OptimismProvider {
+func optimismInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client, queries *coredb.Queries) *OptimismProvider {
 	chain := _wireChainValue2
 	provider := simplehash.NewProvider(chain, client)
-	syncPipelineWrapper := optimismSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient)
+	syncPipelineWrapper := optimismSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient, queries)
 	optimismProvider := optimismProviderInjector(syncPipelineWrapper, provider)
 	return optimismProvider
 }
@@ -186,8 +196,8 @@ func optimismProviderInjector(syncPipeline *wrapper.SyncPipelineWrapper, simpleh
 	return optimismProvider
 }
 
-func optimismSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client) *wrapper.SyncPipelineWrapper {
-	customMetadataHandlers := customMetadataHandlersInjector(ethClient)
+func optimismSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client, queries *coredb.Queries) *wrapper.SyncPipelineWrapper {
+	customMetadataHandlers := customMetadataHandlersInjector(ethClient, queries)
 	syncPipelineWrapper := &wrapper.SyncPipelineWrapper{
 		Chain:                            chain,
 		TokenIdentifierOwnerFetcher:      simplehashProvider,
@@ -201,10 +211,10 @@ func optimismSyncPipelineInjector(ctx context.Context, httpClient *http.Client,
 	return syncPipelineWrapper
 }
 
-func arbitrumInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client) *ArbitrumProvider {
+func arbitrumInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client, queries *coredb.Queries) *ArbitrumProvider {
 	chain := _wireChainValue3
 	provider := simplehash.NewProvider(chain, client)
-	syncPipelineWrapper := arbitrumSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient)
+	syncPipelineWrapper := arbitrumSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient, queries)
 	arbitrumProvider := arbitrumProviderInjector(syncPipelineWrapper, provider)
 	return arbitrumProvider
 }
@@ -229,8 +239,8 @@ func arbitrumProviderInjector(syncPipeline *wrapper.SyncPipelineWrapper, simpleh
 	return arbitrumProvider
 }
 
-func arbitrumSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client) *wrapper.SyncPipelineWrapper {
-	customMetadataHandlers := customMetadataHandlersInjector(ethClient)
+func arbitrumSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client, queries *coredb.Queries) *wrapper.SyncPipelineWrapper {
+	customMetadataHandlers := customMetadataHandlersInjector(ethClient, queries)
 	syncPipelineWrapper := &wrapper.SyncPipelineWrapper{
 		Chain:                            chain,
 		TokenIdentifierOwnerFetcher:      simplehashProvider,
@@ -260,10 +270,10 @@ func poapProviderInjector(poapProvider *poap.Provider) *PoapProvider {
 	return multichainPoapProvider
 }
 
-func zoraInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client) *ZoraProvider {
+func zoraInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client, queries *coredb.Queries) *ZoraProvider {
 	chain := _wireChainValue4
 	provider := simplehash.NewProvider(chain, client)
-	syncPipelineWrapper := zoraSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient)
+	syncPipelineWrapper := zoraSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient, queries)
 	zoraProvider := zoraProviderInjector(syncPipelineWrapper, provider)
 	return zoraProvider
 }
@@ -288,8 +298,8 @@ func zoraProviderInjector(syncPipeline *wrapper.SyncPipelineWrapper, simplehashP
 	return zoraProvider
 }
 
-func zoraSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client) *wrapper.SyncPipelineWrapper {
-	customMetadataHandlers := customMetadataHandlersInjector(ethClient)
+func zoraSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client, queries *coredb.Queries) *wrapper.SyncPipelineWrapper {
+	customMetadataHandlers := customMetadataHandlersInjector(ethClient, queries)
 	syncPipelineWrapper := &wrapper.SyncPipelineWrapper{
 		Chain:                            chain,
 		TokenIdentifierOwnerFetcher:      simplehashProvider,
@@ -303,10 +313,10 @@ func zoraSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chai
 	return syncPipelineWrapper
 }
 
-func baseInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client) *BaseProvider {
+func baseInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client, queries *coredb.Queries) *BaseProvider {
 	chain := _wireChainValue5
 	provider := simplehash.NewProvider(chain, client)
-	syncPipelineWrapper := baseSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient)
+	syncPipelineWrapper := baseSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient, queries)
 	baseProvider := baseProvidersInjector(syncPipelineWrapper, provider, ethclientClient)
 	return baseProvider
 }
@@ -331,8 +341,8 @@ func baseProvidersInjector(syncPipeline *wrapper.SyncPipelineWrapper, simplehash
 	return baseProvider
 }
 
-func baseSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client) *wrapper.SyncPipelineWrapper {
-	customMetadataHandlers := customMetadataHandlersInjector(ethClient)
+func baseSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client, queries *coredb.Queries) *wrapper.SyncPipelineWrapper {
+	customMetadataHandlers := customMetadataHandlersInjector(ethClient, queries)
 	syncPipelineWrapper := &wrapper.SyncPipelineWrapper{
 		Chain:                            chain,
 		TokenIdentifierOwnerFetcher:      simplehashProvider,
@@ -346,10 +356,10 @@ func baseSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chai
 	return syncPipelineWrapper
 }
 
-func polygonInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client) *PolygonProvider {
+func polygonInjector(contextContext context.Context, client *http.Client, ethclientClient *ethclient.Client, queries *coredb.Queries) *PolygonProvider {
 	chain := _wireChainValue6
 	provider := simplehash.NewProvider(chain, client)
-	syncPipelineWrapper := polygonSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient)
+	syncPipelineWrapper := polygonSyncPipelineInjector(contextContext, client, chain, provider, ethclientClient, queries)
 	polygonProvider := polygonProvidersInjector(syncPipelineWrapper, provider, ethclientClient)
 	return polygonProvider
 }
@@ -374,8 +384,8 @@ func polygonProvidersInjector(syncPipeline *wrapper.SyncPipelineWrapper, simpleh
 	return polygonProvider
 }
 
-func polygonSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client) *wrapper.SyncPipelineWrapper {
-	customMetadataHandlers := customMetadataHandlersInjector(ethClient)
+func polygonSyncPipelineInjector(ctx context.Context, httpClient *http.Client, chain persist.Chain, simplehashProvider *simplehash.Provider, ethClient *ethclient.Client, queries *coredb.Queries) *wrapper.SyncPipelineWrapper {
+	customMetadataHandlers := customMetadataHandlersInjector(ethClient, queries)
 	syncPipelineWrapper := &wrapper.SyncPipelineWrapper{
 		Chain:                            chain,
 		TokenIdentifierOwnerFetcher:      simplehashProvider,
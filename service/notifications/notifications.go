@@ -44,6 +44,7 @@ var notificationTypeToGroupWindow = map[persist.Action]time.Duration{
 	persist.ActionAdmiredComment: 24 * time.Hour,
 	persist.ActionAdmiredPost:    24 * time.Hour,
 	persist.ActionAdmiredToken:   24 * time.Hour,
+	persist.ActionUserReposted:   24 * time.Hour,
 }
 
 type NotificationHandlers struct {
@@ -209,6 +210,7 @@ func New(queries *db.Queries, pub *pubsub.Client, taskClient *task.Client, lock
 	notifDispatcher.AddHandler(persist.ActionAdmiredFeedEvent, ownerGroupedHandler)
 	notifDispatcher.AddHandler(persist.ActionAdmiredPost, ownerGroupedHandler)
 	notifDispatcher.AddHandler(persist.ActionAdmiredComment, ownerGroupedHandler)
+	notifDispatcher.AddHandler(persist.ActionUserReposted, ownerGroupedHandler)
 
 	// single notification actions
 	notifDispatcher.AddHandler(persist.ActionCommentedOnFeedEvent, singleHandler)
@@ -219,6 +221,7 @@ func New(queries *db.Queries, pub *pubsub.Client, taskClient *task.Client, lock
 	notifDispatcher.AddHandler(persist.ActionUserPostedYourWork, singleHandler)
 	notifDispatcher.AddHandler(persist.ActionUserFromFarcasterJoined, singleHandler)
 	notifDispatcher.AddHandler(persist.ActionUserPostedFirstPost, singleHandler)
+	notifDispatcher.AddHandler(persist.ActionFollowedCommunityCreatorPosted, singleHandler)
 
 	// notification specifically for ensuring that top users don't get re-notified and users recently notified arent notified again
 	notifDispatcher.AddHandler(persist.ActionTopActivityBadgeReceived, topActivityHandler)
@@ -735,7 +738,7 @@ func createPushMessage(ctx context.Context, notif db.Notification, queries *db.Q
 			return task.PushNotificationMessage{}, err
 		}
 		return message, nil
-	case notif.Action == persist.ActionUserPostedYourWork:
+	case notif.Action == persist.ActionUserPostedYourWork || notif.Action == persist.ActionFollowedCommunityCreatorPosted:
 		post, err := queries.GetPostByID(ctx, notif.PostID)
 		if err != nil {
 			return task.PushNotificationMessage{}, err
@@ -748,6 +751,11 @@ func createPushMessage(ctx context.Context, notif db.Notification, queries *db.Q
 			return task.PushNotificationMessage{}, err
 		}
 		return message, nil
+	case notif.Action == persist.ActionUserReposted:
+		if err := limiter.tryFeedEntity(ctx, notif.Data.ReposterIDs[0], notif.OwnerID, notif.PostID); err != nil {
+			return task.PushNotificationMessage{}, err
+		}
+		return message, nil
 	case notif.Action == persist.ActionTopActivityBadgeReceived:
 		if err := limiter.tryUsers(ctx, notif.OwnerID); err != nil {
 			return task.PushNotificationMessage{}, err
@@ -823,6 +831,18 @@ func NotificationToUserFacingData(ctx context.Context, queries *coredb.Queries,
 			data.Actor = actorUser.Username.String
 		}
 		return data, nil
+	case persist.ActionUserReposted:
+		data := UserFacingNotificationData{Action: "reposted your post"}
+		if len(n.Data.ReposterIDs) > 1 {
+			data.Actor = fmt.Sprintf("%d collectors", len(n.Data.ReposterIDs))
+		} else {
+			actorUser, err := queries.GetUserById(ctx, n.Data.ReposterIDs[0])
+			if err != nil {
+				return UserFacingNotificationData{}, err
+			}
+			data.Actor = actorUser.Username.String
+		}
+		return data, nil
 	case persist.ActionUserFollowedUsers:
 		if len(n.Data.FollowerIDs) > 1 {
 			return UserFacingNotificationData{
@@ -1052,6 +1072,29 @@ func NotificationToUserFacingData(ctx context.Context, queries *coredb.Queries,
 			PreviewText:    util.TruncateWithEllipsis(post.Caption.String, 40),
 		}, nil
 
+	case persist.ActionFollowedCommunityCreatorPosted:
+		post, err := queries.GetPostByID(ctx, n.PostID)
+		if err != nil {
+			return UserFacingNotificationData{}, err
+		}
+		actor, err := queries.GetUserById(ctx, post.ActorID)
+		if err != nil {
+			return UserFacingNotificationData{}, err
+		}
+		if !actor.Username.Valid {
+			return UserFacingNotificationData{}, fmt.Errorf("user with ID=%s has no username", actor.ID)
+		}
+		community, err := queries.GetCommunityByID(ctx, n.CommunityID)
+		if err != nil {
+			return UserFacingNotificationData{}, err
+		}
+		return UserFacingNotificationData{
+			Actor:          actor.Username.String,
+			Action:         "posted in",
+			CollectionName: community.Name,
+			CollectionID:   community.ID,
+			PreviewText:    util.TruncateWithEllipsis(post.Caption.String, 40),
+		}, nil
 	case persist.ActionUserPostedFirstPost:
 		post, err := queries.GetPostByID(ctx, n.PostID)
 		if err != nil {
@@ -1121,6 +1164,10 @@ func actionSupportsPushNotifications(action persist.Action) bool {
 		return true
 	case persist.ActionUserPostedFirstPost:
 		return true
+	case persist.ActionFollowedCommunityCreatorPosted:
+		return true
+	case persist.ActionUserReposted:
+		return true
 	case persist.ActionTopActivityBadgeReceived:
 		return false // TODO -activity
 	case persist.ActionAnnouncement:
@@ -1249,6 +1296,8 @@ func updateAndPublishNotif(ctx context.Context, notif db.Notification, mostRecen
 		amount = int32(len(resultData.AuthedViewerIDs) + len(resultData.UnauthedViewerIDs))
 	case persist.ActionUserFollowedUsers:
 		amount = int32(len(resultData.FollowerIDs))
+	case persist.ActionUserReposted:
+		amount = int32(len(resultData.ReposterIDs))
 	case persist.ActionNewTokensReceived:
 		amount = int32(resultData.NewTokenQuantity.BigInt().Uint64())
 	default:
@@ -1417,7 +1466,7 @@ func addNotification(ctx context.Context, notif db.Notification, queries *db.Que
 			MentionID:   notif.MentionID,
 			CommunityID: notif.CommunityID,
 		})
-	case persist.ActionUserPostedYourWork:
+	case persist.ActionUserPostedYourWork, persist.ActionFollowedCommunityCreatorPosted:
 		return queries.CreateUserPostedYourWorkNotification(ctx, db.CreateUserPostedYourWorkNotificationParams{
 			ID:          id,
 			OwnerID:     notif.OwnerID,
@@ -1435,7 +1484,7 @@ func addNotification(ctx context.Context, notif db.Notification, queries *db.Que
 			Data:     notif.Data,
 			EventIds: notif.EventIds,
 		})
-	case persist.ActionUserPostedFirstPost:
+	case persist.ActionUserPostedFirstPost, persist.ActionUserReposted:
 		return queries.CreatePostNotification(ctx, db.CreatePostNotificationParams{
 			ID:       id,
 			OwnerID:  notif.OwnerID,
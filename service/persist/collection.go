@@ -105,8 +105,11 @@ type CollectionUpdateDeletedInput struct {
 
 // CollectionTokenSettings represents configurable token display options per collection
 type CollectionTokenSettings struct {
-	RenderLive     bool `json:"render_live"`
-	HighDefinition bool `json:"high_definition"`
+	RenderLive       bool `json:"render_live"`
+	HighDefinition   bool `json:"high_definition"`
+	ShowEditionCount bool `json:"show_edition_count"`
+	// GroupedTokenIDs are other editions of the token that are displayed as part of it rather than on their own
+	GroupedTokenIDs []DBID `json:"grouped_token_ids,omitempty"`
 }
 
 // CollectionRepository represents the interface for interacting with the collection persistence layer
@@ -3,7 +3,10 @@ package persist
 import (
 	"context"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 )
 
@@ -70,6 +73,129 @@ type ContractRepository interface {
 	MostRecentBlock(context.Context) (BlockNumber, error)
 }
 
+// CustomMetadataFormat is how the result of the contract call of a custom metadata handler is parsed
+type CustomMetadataFormat string
+
+const (
+	// CustomMetadataFormatSVG is an SVG document, or a data URI of one, that becomes the token's image
+	CustomMetadataFormatSVG CustomMetadataFormat = "svg"
+	// CustomMetadataFormatJSON is a JSON metadata document, or a data URI of one
+	CustomMetadataFormatJSON CustomMetadataFormat = "json"
+	// CustomMetadataFormatURI is a URI that the metadata is fetched from
+	CustomMetadataFormatURI CustomMetadataFormat = "uri"
+)
+
+// Scan implements the database/sql Scanner interface for the CustomMetadataFormat type
+func (f *CustomMetadataFormat) Scan(i interface{}) error {
+	if i == nil {
+		*f = ""
+		return nil
+	}
+	if it, ok := i.([]uint8); ok {
+		*f = CustomMetadataFormat(it)
+		return nil
+	}
+	*f = CustomMetadataFormat(i.(string))
+	return nil
+}
+
+// Value implements the database/sql driver Valuer interface for the CustomMetadataFormat type
+func (f CustomMetadataFormat) Value() (driver.Value, error) {
+	return string(f), nil
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface
+func (f *CustomMetadataFormat) UnmarshalGQL(v interface{}) error {
+	n, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("CustomMetadataFormat must be a string")
+	}
+
+	switch strings.ToLower(n) {
+	case "svg":
+		*f = CustomMetadataFormatSVG
+	case "json":
+		*f = CustomMetadataFormatJSON
+	case "uri":
+		*f = CustomMetadataFormatURI
+	default:
+		return fmt.Errorf("invalid CustomMetadataFormat: %s", n)
+	}
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (f CustomMetadataFormat) MarshalGQL(w io.Writer) {
+	switch f {
+	case CustomMetadataFormatSVG:
+		w.Write([]byte(`"SVG"`))
+	case CustomMetadataFormatJSON:
+		w.Write([]byte(`"JSON"`))
+	default:
+		w.Write([]byte(`"URI"`))
+	}
+}
+
+// SpamReason is a signal that contributed to the spam score of a contract
+type SpamReason struct {
+	// Signal identifies the signal, e.g. airdrop_fan_out
+	Signal string `json:"signal"`
+	// Weight is how much the signal moved the score. Signals that a contract isn't spam have a negative weight.
+	Weight float64 `json:"weight"`
+	// Detail is a human readable explanation of why the signal fired
+	Detail string `json:"detail"`
+}
+
+// SpamReasons are the signals that contributed to a spam score
+type SpamReasons []SpamReason
+
+func (r SpamReasons) Value() (driver.Value, error) {
+	if r == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(r)
+}
+
+func (r *SpamReasons) Scan(value interface{}) error {
+	if value == nil {
+		*r = nil
+		return nil
+	}
+	return json.Unmarshal(value.([]byte), r)
+}
+
+// ContractFieldSource is where the value of a contract's field came from
+type ContractFieldSource string
+
+const (
+	// ContractFieldSourceIndexer is a value reported by the indexer or data provider
+	ContractFieldSourceIndexer ContractFieldSource = "indexer"
+	// ContractFieldSourceContractURI is a value from the contract-level metadata returned by contractURI() (ERC-7572)
+	ContractFieldSourceContractURI ContractFieldSource = "contract_uri"
+	// ContractFieldSourceRPC is a value read from the contract, e.g. name() or symbol()
+	ContractFieldSourceRPC ContractFieldSource = "rpc"
+	// ContractFieldSourceENS is a value from the ENS reverse record of the contract's owner
+	ContractFieldSourceENS ContractFieldSource = "ens"
+)
+
+// ContractFieldSources maps a contract's field names to where their values came from
+type ContractFieldSources map[string]ContractFieldSource
+
+func (s ContractFieldSources) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(s)
+}
+
+func (s *ContractFieldSources) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+	return json.Unmarshal(value.([]byte), s)
+}
+
 var errContractNotFound ErrContractNotFound
 
 type ErrContractNotFound struct{}
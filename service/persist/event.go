@@ -24,6 +24,8 @@ const (
 	ActionUserPosted                      Action = "UserPosted"
 	ActionUserPostedYourWork              Action = "UserPostedYourWork"
 	ActionUserPostedFirstPost             Action = "UserPostedFirstPost"
+	ActionFollowedCommunityCreatorPosted  Action = "FollowedCommunityCreatorPosted"
+	ActionUserReposted                    Action = "UserReposted"
 	ActionCollectorsNoteAddedToToken      Action = "CollectorsNoteAddedToToken"
 	ActionCollectionCreated               Action = "CollectionCreated"
 	ActionCollectorsNoteAddedToCollection Action = "CollectorsNoteAddedToCollection"
@@ -124,6 +126,27 @@ func (e ErrPostNotFoundByID) Error() string {
 	return fmt.Sprintf("post not found by id=%s", e.ID)
 }
 
+type ErrRepostNotFoundByID struct{ ID DBID }
+
+func (e ErrRepostNotFoundByID) Unwrap() error { return errPostNotFound }
+func (e ErrRepostNotFoundByID) Error() string {
+	return fmt.Sprintf("repost not found by id=%s", e.ID)
+}
+
+type ErrPollNotFoundByID struct{ ID DBID }
+
+func (e ErrPollNotFoundByID) Unwrap() error { return errPostNotFound }
+func (e ErrPollNotFoundByID) Error() string {
+	return fmt.Sprintf("poll not found by id=%s", e.ID)
+}
+
+type ErrPostDraftNotFoundByID struct{ ID DBID }
+
+func (e ErrPostDraftNotFoundByID) Unwrap() error { return errPostNotFound }
+func (e ErrPostDraftNotFoundByID) Error() string {
+	return fmt.Sprintf("post draft not found by id=%s", e.ID)
+}
+
 type ErrUnknownAction struct {
 	Action Action
 }
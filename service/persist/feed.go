@@ -2,6 +2,7 @@ package persist
 
 import (
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"io"
 )
@@ -11,6 +12,15 @@ type FeedEntityType int
 const (
 	FeedEventTypeTag FeedEntityType = iota
 	PostTypeTag
+	RepostTypeTag
+)
+
+type PostAttachmentType int
+
+const (
+	PostAttachmentTypePoll PostAttachmentType = iota
+	PostAttachmentTypeLink
+	PostAttachmentTypeCommunity
 )
 
 const (
@@ -45,3 +55,31 @@ func (r *ReportReason) Scan(v any) error {
 	*r = ReportReason(v.(byte))
 	return nil
 }
+
+// DraftMention is a mention saved with a post draft. Mentions aren't inserted until the draft is published so that
+// nobody is notified about a post that doesn't exist yet.
+type DraftMention struct {
+	Start       *int32 `json:"start,omitempty"`
+	Length      *int32 `json:"length,omitempty"`
+	UserID      *DBID  `json:"user_id,omitempty"`
+	CommunityID *DBID  `json:"community_id,omitempty"`
+}
+
+type DraftMentions []DraftMention
+
+// Value implements the driver.Valuer interface for the DraftMentions type
+func (m DraftMentions) Value() (driver.Value, error) {
+	if m == nil {
+		m = DraftMentions{}
+	}
+	return json.Marshal(m)
+}
+
+// Scan implements the Scanner interface for the DraftMentions type
+func (m *DraftMentions) Scan(value interface{}) error {
+	if value == nil {
+		*m = DraftMentions{}
+		return nil
+	}
+	return json.Unmarshal(value.([]uint8), m)
+}
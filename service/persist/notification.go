@@ -83,6 +83,7 @@ type NotificationData struct {
 	UnauthedViewerIDs              []string                        `json:"unauthed_viewer_ids,omitempty"`
 	FollowerIDs                    []DBID                          `json:"follower_ids,omitempty"`
 	AdmirerIDs                     []DBID                          `json:"admirer_ids,omitempty"`
+	ReposterIDs                    []DBID                          `json:"reposter_ids,omitempty"`
 	FollowedBack                   NullBool                        `json:"followed_back,omitempty"`
 	Refollowed                     NullBool                        `json:"refollowed,omitempty"`
 	NewTokenID                     DBID                            `json:"new_token_id,omitempty"`
@@ -97,6 +98,7 @@ type NotificationData struct {
 func (n NotificationData) Validate() NotificationData {
 	result := NotificationData{}
 	result.AdmirerIDs = uniqueDBIDs(n.AdmirerIDs)
+	result.ReposterIDs = uniqueDBIDs(n.ReposterIDs)
 	result.FollowerIDs = uniqueDBIDs(n.FollowerIDs)
 	result.AuthedViewerIDs = uniqueDBIDs(n.AuthedViewerIDs)
 	result.UnauthedViewerIDs = uniqueStrings(n.UnauthedViewerIDs)
@@ -115,6 +117,7 @@ func (n NotificationData) Validate() NotificationData {
 func (n NotificationData) Concat(other NotificationData) NotificationData {
 	result := NotificationData{}
 	result.AdmirerIDs = append(other.AdmirerIDs, n.AdmirerIDs...)
+	result.ReposterIDs = append(other.ReposterIDs, n.ReposterIDs...)
 	result.FollowerIDs = append(other.FollowerIDs, n.FollowerIDs...)
 	result.AuthedViewerIDs = append(other.AuthedViewerIDs, n.AuthedViewerIDs...)
 	result.UnauthedViewerIDs = append(other.UnauthedViewerIDs, n.UnauthedViewerIDs...)
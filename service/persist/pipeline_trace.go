@@ -0,0 +1,164 @@
+package persist
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	// maxTraceSteps is the most steps kept in a trace. Steps after the limit are dropped so that the trace stays small.
+	maxTraceSteps = 100
+	// maxTraceAttempts is the most download attempts kept for a single step
+	maxTraceAttempts = 10
+	// maxTraceValueLen is the longest an attribute value or error is allowed to be, since URLs can be entire data URIs
+	maxTraceValueLen = 256
+)
+
+// PipelineTrace is a compact summary of the steps of a pipeline run in the order that they were started
+type PipelineTrace []PipelineTraceStep
+
+// PipelineTraceStep is a single step of a pipeline run
+type PipelineTraceStep struct {
+	Name       string                 `json:"name"`
+	Status     PipelineStepStatus     `json:"status"`
+	DurationMS int64                  `json:"duration_ms"`
+	Attributes map[string]string      `json:"attributes,omitempty"`
+	Attempts   []PipelineTraceAttempt `json:"attempts,omitempty"`
+}
+
+// PipelineTraceAttempt is a single attempt at downloading media during a step, e.g. a request to one of several IPFS gateways
+type PipelineTraceAttempt struct {
+	Source     string `json:"source"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (p PipelineTrace) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
+
+func (p *PipelineTrace) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	return json.Unmarshal(value.([]byte), p)
+}
+
+// PipelineTracer collects the steps of a pipeline run. Media sources are cached concurrently, so it's safe for concurrent use.
+type PipelineTracer struct {
+	mu    sync.Mutex
+	steps []*PipelineTraceStep
+}
+
+// Trace returns a copy of the steps traced so far
+func (t *PipelineTracer) Trace() PipelineTrace {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	trace := make(PipelineTrace, len(t.steps))
+	for i, s := range t.steps {
+		trace[i] = *s
+		trace[i].Attempts = append([]PipelineTraceAttempt{}, s.Attempts...)
+		if s.Attributes != nil {
+			trace[i].Attributes = make(map[string]string, len(s.Attributes))
+			for k, v := range s.Attributes {
+				trace[i].Attributes[k] = v
+			}
+		}
+	}
+	return trace
+}
+
+func (t *PipelineTracer) start(name string) *PipelineTraceStep {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.steps) >= maxTraceSteps {
+		return nil
+	}
+	step := &PipelineTraceStep{Name: name, Status: PipelineStepStatusStarted}
+	t.steps = append(t.steps, step)
+	return step
+}
+
+func (t *PipelineTracer) finish(step *PipelineTraceStep, status PipelineStepStatus, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	step.Status = status
+	step.DurationMS = d.Milliseconds()
+}
+
+type pipelineTracerContextKey struct{}
+type pipelineTraceStepContextKey struct{}
+
+// NewContextWithPipelineTracer returns a context that records the steps tracked with TrackStepStatus to the tracer
+func NewContextWithPipelineTracer(ctx context.Context, t *PipelineTracer) context.Context {
+	return context.WithValue(ctx, pipelineTracerContextKey{}, t)
+}
+
+func pipelineTracerFromContext(ctx context.Context) *PipelineTracer {
+	t, _ := ctx.Value(pipelineTracerContextKey{}).(*PipelineTracer)
+	return t
+}
+
+// startTraceStep records the start of a step if the context has a tracer. It returns a function that records the end of the step.
+func startTraceStep(ctx context.Context, name string) (context.Context, func(status PipelineStepStatus, d time.Duration)) {
+	t := pipelineTracerFromContext(ctx)
+	if t == nil {
+		return ctx, func(PipelineStepStatus, time.Duration) {}
+	}
+	step := t.start(name)
+	if step == nil {
+		return ctx, func(PipelineStepStatus, time.Duration) {}
+	}
+	ctx = context.WithValue(ctx, pipelineTraceStepContextKey{}, step)
+	return ctx, func(status PipelineStepStatus, d time.Duration) { t.finish(step, status, d) }
+}
+
+// AnnotateStep attaches a value to the step that is currently being tracked, e.g. the URL that was chosen or why a source was skipped
+func AnnotateStep(ctx context.Context, key, value string) {
+	t := pipelineTracerFromContext(ctx)
+	step, _ := ctx.Value(pipelineTraceStepContextKey{}).(*PipelineTraceStep)
+	if t == nil || step == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if step.Attributes == nil {
+		step.Attributes = make(map[string]string)
+	}
+	step.Attributes[key] = truncateTraceValue(value)
+}
+
+// RecordStepAttempt records an attempt at downloading media during the step that is currently being tracked
+func RecordStepAttempt(ctx context.Context, source string, d time.Duration, err error) {
+	t := pipelineTracerFromContext(ctx)
+	step, _ := ctx.Value(pipelineTraceStepContextKey{}).(*PipelineTraceStep)
+	if t == nil || step == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(step.Attempts) >= maxTraceAttempts {
+		return
+	}
+	attempt := PipelineTraceAttempt{Source: truncateTraceValue(source), DurationMS: d.Milliseconds()}
+	if err != nil {
+		attempt.Error = truncateTraceValue(err.Error())
+	}
+	step.Attempts = append(step.Attempts, attempt)
+}
+
+func truncateTraceValue(s string) string {
+	if len(s) <= maxTraceValueLen {
+		return s
+	}
+	return s[:maxTraceValueLen] + "..."
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/jackc/pgtype"
 	"io"
+	"math"
 	"math/big"
 	"net/url"
 	"strconv"
@@ -193,6 +194,8 @@ const (
 	URITypeBase64MP3 URIType = "base64mp3"
 	// URITypeSVG represents an SVG
 	URITypeSVG URIType = "svg"
+	// URITypeHTML represents an HTML document that isn't base64 encoded
+	URITypeHTML URIType = "html"
 	// URITypeENS represents an ENS domain
 	URITypeENS URIType = "ens"
 	// URITypeUnknown represents an unknown URI type
@@ -205,7 +208,7 @@ const (
 
 func (u URIType) IsRaw() bool {
 	switch u {
-	case URITypeBase64JSON, URITypeBase64HTML, URITypeBase64SVG, URITypeBase64BMP, URITypeBase64PNG, URITypeBase64JPEG, URITypeBase64GIF, URITypeBase64WAV, URITypeBase64MP3, URITypeJSON, URITypeSVG, URITypeENS:
+	case URITypeBase64JSON, URITypeBase64HTML, URITypeBase64SVG, URITypeBase64BMP, URITypeBase64PNG, URITypeBase64JPEG, URITypeBase64GIF, URITypeBase64WAV, URITypeBase64MP3, URITypeJSON, URITypeSVG, URITypeHTML, URITypeENS:
 		return true
 	default:
 		return false
@@ -222,7 +225,7 @@ func (u URIType) ToMediaType() MediaType {
 		return MediaTypeImage
 	case URITypeBase64PNG:
 		return MediaTypeImage
-	case URITypeBase64HTML:
+	case URITypeBase64HTML, URITypeHTML:
 		return MediaTypeHTML
 	case URITypeBase64JPEG:
 		return MediaTypeImage
@@ -642,24 +645,8 @@ func (uri TokenURI) Type() URIType {
 		return URITypeIPFS
 	case strings.HasPrefix(asString, "ar://"), strings.HasPrefix(asString, "arweave://"):
 		return URITypeArweave
-	case strings.HasPrefix(asString, "data:text/html;base64,"), strings.HasPrefix(asString, "data:text/html;charset=utf-8;base64,"), strings.HasPrefix(asString, "data:text/html") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64HTML
-	case strings.HasPrefix(asString, "data:application/json;base64,"), strings.HasPrefix(asString, "data:application/json;charset=utf-8;base64,"), strings.HasPrefix(asString, "data:application/json") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64JSON
-	case strings.HasPrefix(asString, "data:image/svg+xml;base64,"), strings.HasPrefix(asString, "data:image/svg xml;base64,"), strings.HasPrefix(asString, "data:image/svg+xml") && strings.Contains(asString, ";base64,"), strings.HasPrefix(asString, "data:image/svg xml") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64SVG
-	case strings.HasPrefix(asString, "data:image/bmp;base64,"), strings.HasPrefix(asString, "data:image/bmp;charset=utf-8;base64,"), strings.HasPrefix(asString, "data:image/bmp") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64BMP
-	case strings.HasPrefix(asString, "data:image/png;base64,"), strings.HasPrefix(asString, "data:image/png;charset=utf-8;base64,"), strings.HasPrefix(asString, "data:image/png") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64PNG
-	case strings.HasPrefix(asString, "data:image/jpeg;base64,"), strings.HasPrefix(asString, "data:image/jpeg;charset=utf-8;base64,"), strings.HasPrefix(asString, "data:image/jpeg") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64JPEG
-	case strings.HasPrefix(asString, "data:image/gif;base64,"), strings.HasPrefix(asString, "data:image/gif;charset=utf-8;base64,"), strings.HasPrefix(asString, "data:image/gif") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64GIF
-	case strings.HasPrefix(asString, "data:audio/wav;base64,"), strings.HasPrefix(asString, "data:audio/wav;charset=utf-8;base64,"), strings.HasPrefix(asString, "data:audio/wav") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64WAV
-	case strings.HasPrefix(asString, "data:audio/mpeg;base64,"), strings.HasPrefix(asString, "data:audio/mpeg;charset=utf-8;base64,"), strings.HasPrefix(asString, "data:audio/mpeg") && strings.Contains(asString, ";base64,"):
-		return URITypeBase64MP3
+	case util.IsDataURI(asString):
+		return dataURIType(asString)
 	case strings.Contains(asString, "ipfs.io/api"):
 		return URITypeIPFSAPI
 	case strings.Contains(asString, "/ipfs/"):
@@ -668,9 +655,9 @@ func (uri TokenURI) Type() URIType {
 		return URITypeArweaveGateway
 	case strings.HasPrefix(asString, "http"), strings.HasPrefix(asString, "https"):
 		return URITypeHTTP
-	case strings.HasPrefix(asString, "{"), strings.HasPrefix(asString, "["), strings.HasPrefix(asString, "data:application/json"), strings.HasPrefix(asString, "data:text/plain,{"):
+	case strings.HasPrefix(asString, "{"), strings.HasPrefix(asString, "["):
 		return URITypeJSON
-	case strings.HasPrefix(asString, "<svg"), strings.HasPrefix(asString, "data:image/svg+xml"), strings.HasPrefix(asString, "data:image/svg xml"):
+	case strings.HasPrefix(asString, "<svg"):
 		return URITypeSVG
 	case strings.HasSuffix(asString, ".ens"):
 		return URITypeENS
@@ -683,6 +670,55 @@ func (uri TokenURI) Type() URIType {
 	}
 }
 
+// dataURIType returns the type of a data URI from its media type. Data URIs are typed before any other check because their
+// payloads often contain links, e.g. metadata that points to an IPFS gateway.
+func dataURIType(uri string) URIType {
+	mediaType, isBase64, err := util.ParseDataURIHeader(uri)
+	if err != nil {
+		return URITypeInvalid
+	}
+
+	if isBase64 {
+		switch mediaType {
+		case "text/html":
+			return URITypeBase64HTML
+		case "application/json", "text/json":
+			return URITypeBase64JSON
+		case "image/svg+xml", "image/svg":
+			return URITypeBase64SVG
+		case "image/bmp":
+			return URITypeBase64BMP
+		case "image/png":
+			return URITypeBase64PNG
+		case "image/jpeg", "image/jpg":
+			return URITypeBase64JPEG
+		case "image/gif":
+			return URITypeBase64GIF
+		case "audio/wav", "audio/wave", "audio/x-wav":
+			return URITypeBase64WAV
+		case "audio/mpeg", "audio/mp3":
+			return URITypeBase64MP3
+		}
+		return URITypeUnknown
+	}
+
+	switch mediaType {
+	case "text/html":
+		return URITypeHTML
+	case "application/json", "text/json":
+		return URITypeJSON
+	case "image/svg+xml", "image/svg":
+		return URITypeSVG
+	case "text/plain":
+		// Plain text data URIs are only used for metadata
+		if payload := uri[strings.IndexByte(uri, ',')+1:]; strings.HasPrefix(strings.TrimSpace(payload), "{") || strings.HasPrefix(payload, "%7B") {
+			return URITypeJSON
+		}
+	}
+
+	return URITypeUnknown
+}
+
 // IsRenderable returns whether a frontend could render the given URI directly
 func (uri TokenURI) IsRenderable() bool {
 	return uri.IsHTTP() // || uri.IsIPFS() || uri.IsArweave()
@@ -963,6 +999,19 @@ func (hex HexString) BigInt() *big.Int {
 	return it
 }
 
+// Count returns the hex string as a number of items, e.g. the number of editions of a token that a user holds. Quantities
+// too large to count are capped at math.MaxInt32.
+func (hex HexString) Count() int {
+	it := hex.BigInt()
+	if it.Sign() <= 0 {
+		return 0
+	}
+	if !it.IsInt64() || it.Int64() > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(it.Int64())
+}
+
 // Add adds the given hex string to the current hex string
 func (hex HexString) Add(new HexString) HexString {
 	asInt := hex.BigInt()
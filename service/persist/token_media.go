@@ -9,12 +9,55 @@ import (
 
 // Media represents a token's media content with processed images from metadata
 type Media struct {
-	ThumbnailURL    NullString `json:"thumbnail_url,omitempty"`
-	LivePreviewURL  NullString `json:"live_preview_url,omitempty"`
-	ProfileImageURL NullString `json:"profile_image_url,omitempty"`
-	MediaURL        NullString `json:"media_url,omitempty"`
-	MediaType       MediaType  `json:"media_type"`
-	Dimensions      Dimensions `json:"dimensions"`
+	ThumbnailURL    NullString  `json:"thumbnail_url,omitempty"`
+	LivePreviewURL  NullString  `json:"live_preview_url,omitempty"`
+	ProfileImageURL NullString  `json:"profile_image_url,omitempty"`
+	MediaURL        NullString  `json:"media_url,omitempty"`
+	MediaType       MediaType   `json:"media_type"`
+	Dimensions      Dimensions  `json:"dimensions"`
+	Moderation      *Moderation `json:"moderation,omitempty"`
+	// SVGSanitization is set when the media's SVG was sanitized before it was cached
+	SVGSanitization *SVGSanitization `json:"svg_sanitization,omitempty"`
+}
+
+// SVGVariant is a version of an SVG that is stored by the token processing pipeline
+type SVGVariant string
+
+const (
+	SVGVariantOriginal  SVGVariant = "original"
+	SVGVariantSanitized SVGVariant = "sanitized"
+)
+
+// SVGSanitization records the result of sanitizing an SVG and which variant is served
+type SVGSanitization struct {
+	Served SVGVariant `json:"served"`
+	// OriginalURL is where the unmodified SVG is stored
+	OriginalURL NullString `json:"original_url,omitempty"`
+	// Removed lists the content that was stripped from the original, e.g. "element:script"
+	Removed []string `json:"removed,omitempty"`
+}
+
+// ModerationLabel is the outcome of running a token's media through moderation
+type ModerationLabel string
+
+const (
+	ModerationLabelSafe      ModerationLabel = "safe"
+	ModerationLabelNSFW      ModerationLabel = "nsfw"
+	ModerationLabelMalicious ModerationLabel = "malicious"
+)
+
+// Moderation records the result of moderating a token's media. A nil Moderation means the media hasn't been moderated.
+type Moderation struct {
+	Label ModerationLabel `json:"label"`
+	// NSFWScore is the highest score returned by the classifier across all of the frames that were checked
+	NSFWScore float64 `json:"nsfw_score,omitempty"`
+	// Reasons are the findings that contributed to the label, e.g. "script:external_fetch"
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// IsFlagged returns true if the media was labeled as anything other than safe
+func (m *Moderation) IsFlagged() bool {
+	return m != nil && m.Label != "" && m.Label != ModerationLabelSafe
 }
 
 // IsServable returns true if the token's Media has enough information to serve it's assets.
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -42,6 +43,58 @@ func (p *ProcessingCause) Scan(value interface{}) error {
 	return nil
 }
 
+// DeadLetterReason is why a token was moved to the dead letter queue instead of being processed again
+type DeadLetterReason string
+
+const (
+	DeadLetterReasonRetriesExhausted DeadLetterReason = "retries_exhausted"
+	DeadLetterReasonContractPaused   DeadLetterReason = "contract_paused"
+)
+
+func (d DeadLetterReason) String() string {
+	return string(d)
+}
+
+func (d DeadLetterReason) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d *DeadLetterReason) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	*d = DeadLetterReason(value.(string))
+	return nil
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface
+func (d *DeadLetterReason) UnmarshalGQL(v interface{}) error {
+	n, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("DeadLetterReason must be a string")
+	}
+
+	switch n {
+	case "RetriesExhausted":
+		*d = DeadLetterReasonRetriesExhausted
+	case "ContractPaused":
+		*d = DeadLetterReasonContractPaused
+	default:
+		return fmt.Errorf("invalid DeadLetterReason: %s", n)
+	}
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (d DeadLetterReason) MarshalGQL(w io.Writer) {
+	switch d {
+	case DeadLetterReasonContractPaused:
+		w.Write([]byte(`"ContractPaused"`))
+	default:
+		w.Write([]byte(`"RetriesExhausted"`))
+	}
+}
+
 type TokenProperties struct {
 	HasPrimaryMedia bool `json:"has_primary_media"`
 	HasThumbnail    bool `json:"has_thumbnail"`
@@ -107,47 +160,58 @@ type PipelineMetadata struct {
 	AnimationDetermineMediaTypeWithReader          PipelineStepStatus `json:"animation_determine_media_type_with_reader,omitempty"`
 	AnimationAnimationGzip                         PipelineStepStatus `json:"animation_animation_gzip,omitempty"`
 	AnimationSVGRasterize                          PipelineStepStatus `json:"animation_svg_rasterize,omitempty"`
+	AnimationSVGSanitize                           PipelineStepStatus `json:"animation_svg_sanitize,omitempty"`
 	AnimationStoreGCP                              PipelineStepStatus `json:"animation_store_gcp,omitempty"`
 	AnimationThumbnailGCP                          PipelineStepStatus `json:"animation_thumbnail_gcp,omitempty"`
 	AnimationLiveRenderGCP                         PipelineStepStatus `json:"animation_live_render_gcp,omitempty"`
+	AnimationHTMLSnapshot                          PipelineStepStatus `json:"animation_html_snapshot,omitempty"`
 	ImageContentHeaderValueRetrieval               PipelineStepStatus `json:"image_content_header_value_retrieval,omitempty"`
 	ImageReaderRetrieval                           PipelineStepStatus `json:"image_reader_retrieval,omitempty"`
 	ImageDetermineMediaTypeWithReader              PipelineStepStatus `json:"image_determine_media_type_with_reader,omitempty"`
 	ImageAnimationGzip                             PipelineStepStatus `json:"image_animation_gzip,omitempty"`
 	ImageSVGRasterize                              PipelineStepStatus `json:"image_svg_rasterize,omitempty"`
+	ImageSVGSanitize                               PipelineStepStatus `json:"image_svg_sanitize,omitempty"`
 	ImageStoreGCP                                  PipelineStepStatus `json:"image_store_gcp,omitempty"`
 	ImageThumbnailGCP                              PipelineStepStatus `json:"image_thumbnail_gcp,omitempty"`
 	ImageLiveRenderGCP                             PipelineStepStatus `json:"image_live_render_gcp,omitempty"`
+	ImageHTMLSnapshot                              PipelineStepStatus `json:"image_html_snapshot,omitempty"`
 	AlternateAnimationContentHeaderValueRetrieval  PipelineStepStatus `json:"alternate_animation_content_header_value_retrieval,omitempty"`
 	AlternateAnimationReaderRetrieval              PipelineStepStatus `json:"alternate_animation_reader_retrieval,omitempty"`
 	AlternateAnimationDetermineMediaTypeWithReader PipelineStepStatus `json:"alternate_animation_determine_media_type_with_reader,omitempty"`
 	AlternateAnimationAnimationGzip                PipelineStepStatus `json:"alternate_animation_animation_gzip,omitempty"`
 	AlternateAnimationSVGRasterize                 PipelineStepStatus `json:"alternate_animation_svg_rasterize,omitempty"`
+	AlternateAnimationSVGSanitize                  PipelineStepStatus `json:"alternate_animation_svg_sanitize,omitempty"`
 	AlternateAnimationStoreGCP                     PipelineStepStatus `json:"alternate_animation_store_gcp,omitempty"`
 	AlternateAnimationThumbnailGCP                 PipelineStepStatus `json:"alternate_animation_thumbnail_gcp,omitempty"`
 	AlternateAnimationLiveRenderGCP                PipelineStepStatus `json:"alternate_animation_live_render_gcp,omitempty"`
+	AlternateAnimationHTMLSnapshot                 PipelineStepStatus `json:"alternate_animation_html_snapshot,omitempty"`
 	AlternateImageContentHeaderValueRetrieval      PipelineStepStatus `json:"alternate_image_content_header_value_retrieval,omitempty"`
 	AlternateImageReaderRetrieval                  PipelineStepStatus `json:"alternate_image_reader_retrieval,omitempty"`
 	AlternateImageDetermineMediaTypeWithReader     PipelineStepStatus `json:"alternate_image_determine_media_type_with_reader,omitempty"`
 	AlternateImageAnimationGzip                    PipelineStepStatus `json:"alternate_image_animation_gzip,omitempty"`
 	AlternateImageSVGRasterize                     PipelineStepStatus `json:"alternate_image_svg_rasterize,omitempty"`
+	AlternateImageSVGSanitize                      PipelineStepStatus `json:"alternate_image_svg_sanitize,omitempty"`
 	AlternateImageStoreGCP                         PipelineStepStatus `json:"alternate_image_store_gcp,omitempty"`
 	AlternateImageThumbnailGCP                     PipelineStepStatus `json:"alternate_image_thumbnail_gcp,omitempty"`
 	AlternateImageLiveRenderGCP                    PipelineStepStatus `json:"alternate_image_live_render_gcp,omitempty"`
+	AlternateImageHTMLSnapshot                     PipelineStepStatus `json:"alternate_image_html_snapshot,omitempty"`
 	ProfileImageContentHeaderValueRetrieval        PipelineStepStatus `json:"pfp_content_header_value_retrieval,omitempty"`
 	ProfileImageReaderRetrieval                    PipelineStepStatus `json:"pfp_reader_retrieval,omitempty"`
 	ProfileImageDetermineMediaTypeWithReader       PipelineStepStatus `json:"pfp_determine_media_type_with_reader,omitempty"`
 	ProfileImageAnimationGzip                      PipelineStepStatus `json:"pfp_animation_gzip,omitempty"`
 	ProfileImageSVGRasterize                       PipelineStepStatus `json:"pfp_svg_rasterize,omitempty"`
+	ProfileImageSVGSanitize                        PipelineStepStatus `json:"pfp_svg_sanitize,omitempty"`
 	ProfileImageStoreGCP                           PipelineStepStatus `json:"pfp_store_gcp,omitempty"`
 	ProfileImageThumbnailGCP                       PipelineStepStatus `json:"pfp_thumbnail_gcp,omitempty"`
 	ProfileImageLiveRenderGCP                      PipelineStepStatus `json:"pfp_live_render_gcp,omitempty"`
+	ProfileImageHTMLSnapshot                       PipelineStepStatus `json:"pfp_html_snapshot,omitempty"`
 	NothingCachedWithErrors                        PipelineStepStatus `json:"nothing_cached_errors,omitempty"`
 	NothingCachedWithoutErrors                     PipelineStepStatus `json:"nothing_cached_no_errors,omitempty"`
 	CreateMedia                                    PipelineStepStatus `json:"create_media,omitempty"`
 	CreateMediaFromCachedObjects                   PipelineStepStatus `json:"create_media_from_cached_objects,omitempty"`
 	CreateRawMedia                                 PipelineStepStatus `json:"create_raw_media,omitempty"`
 	MediaResultComparison                          PipelineStepStatus `json:"media_result_comparison,omitempty"`
+	Moderation                                     PipelineStepStatus `json:"moderation,omitempty"`
 }
 
 func (p PipelineMetadata) Value() (driver.Value, error) {
@@ -177,6 +241,8 @@ func TrackStepStatus(ctx context.Context, status *PipelineStepStatus, name strin
 
 	startTime := time.Now()
 
+	ctx, finishTraceStep := startTraceStep(ctx, name)
+
 	if status == nil {
 		started := PipelineStepStatusStarted
 		status = &started
@@ -196,10 +262,12 @@ func TrackStepStatus(ctx context.Context, status *PipelineStepStatus, name strin
 	return func() {
 		defer tracing.FinishSpan(span)
 		if *status == PipelineStepStatusError {
+			finishTraceStep(PipelineStepStatusError, time.Since(startTime))
 			logger.For(ctx).Errorf("failed [%s] (took: %s)", name, time.Since(startTime))
 			return
 		}
 		*status = PipelineStepStatusSuccess
+		finishTraceStep(PipelineStepStatusSuccess, time.Since(startTime))
 		logger.For(ctx).Infof("succeeded [%s] (took: %s)", name, time.Since(startTime))
 	}, ctx
 }
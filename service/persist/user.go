@@ -418,6 +418,66 @@ func (p Persona) MarshalGQL(w io.Writer) {
 	}
 }
 
+// FlaggedMediaDisplay controls how media that was flagged by moderation is displayed to a user
+type FlaggedMediaDisplay string
+
+const (
+	FlaggedMediaDisplayShow FlaggedMediaDisplay = "show"
+	FlaggedMediaDisplayBlur FlaggedMediaDisplay = "blur"
+	FlaggedMediaDisplayHide FlaggedMediaDisplay = "hide"
+)
+
+// Scan implements the database/sql Scanner interface for the FlaggedMediaDisplay type
+func (f *FlaggedMediaDisplay) Scan(i interface{}) error {
+	if i == nil {
+		*f = FlaggedMediaDisplayBlur
+		return nil
+	}
+	if it, ok := i.([]uint8); ok {
+		*f = FlaggedMediaDisplay(it)
+		return nil
+	}
+	*f = FlaggedMediaDisplay(i.(string))
+	return nil
+}
+
+// Value implements the database/sql driver Valuer interface for the FlaggedMediaDisplay type
+func (f FlaggedMediaDisplay) Value() (driver.Value, error) {
+	return string(f), nil
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface
+func (f *FlaggedMediaDisplay) UnmarshalGQL(v interface{}) error {
+	n, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("FlaggedMediaDisplay must be a string")
+	}
+
+	switch strings.ToLower(n) {
+	case "show":
+		*f = FlaggedMediaDisplayShow
+	case "blur":
+		*f = FlaggedMediaDisplayBlur
+	case "hide":
+		*f = FlaggedMediaDisplayHide
+	default:
+		return fmt.Errorf("invalid FlaggedMediaDisplay: %s", n)
+	}
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface
+func (f FlaggedMediaDisplay) MarshalGQL(w io.Writer) {
+	switch f {
+	case FlaggedMediaDisplayShow:
+		w.Write([]byte(`"Show"`))
+	case FlaggedMediaDisplayHide:
+		w.Write([]byte(`"Hide"`))
+	default:
+		w.Write([]byte(`"Blur"`))
+	}
+}
+
 type ProfileImageSource string // ProfileImageSource represents the source of a profile image
 
 const (
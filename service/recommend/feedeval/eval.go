@@ -0,0 +1,201 @@
+package feedeval
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/recommend/feedrank"
+)
+
+// interactionGains is how much each kind of interaction counts towards a post's relevance. Commenting takes more effort
+// than admiring, so it's a stronger signal.
+var interactionGains = map[string]float64{
+	InteractionAdmire:  1,
+	InteractionComment: 2,
+}
+
+// Scorer ranks the posts of a snapshot for a viewer. viewerID is empty when ranking for a logged out viewer.
+type Scorer interface {
+	Name() string
+	Rank(viewerID persist.DBID, now time.Time, entities []db.GetFeedEntityScoresRow) []db.GetFeedEntityScoresRow
+}
+
+// RankerScorer scores with a feed ranker, so that every variant of a ranking experiment can be evaluated offline
+type RankerScorer struct {
+	Ranker feedrank.FeedRanker
+	// Relevance is passed to the ranker. Rankers treat relevance as neutral if it isn't set.
+	Relevance feedrank.RelevanceFunc
+	// Diversity reorders the ranking the same way the feeds do. The zero value leaves the ranking as is.
+	Diversity feedrank.DiversityConstraints
+}
+
+func (s RankerScorer) Name() string { return s.Ranker.Name() }
+
+func (s RankerScorer) Rank(viewerID persist.DBID, now time.Time, entities []db.GetFeedEntityScoresRow) []db.GetFeedEntityScoresRow {
+	ranked := s.Ranker.Rank(feedrank.RankInput{ViewerID: viewerID, Now: now, PostScores: entities, Relevance: s.Relevance})
+	return feedrank.Diversify(ranked, s.Diversity)
+}
+
+// NewScorer returns a scorer that ranks the top posts by the given score, which makes it easy to try out a new formula
+func NewScorer(name string, score func(viewerID persist.DBID, now time.Time, e db.GetFeedEntityScoresRow) float64) Scorer {
+	return funcScorer{name: name, score: score}
+}
+
+type funcScorer struct {
+	name  string
+	score func(viewerID persist.DBID, now time.Time, e db.GetFeedEntityScoresRow) float64
+}
+
+func (s funcScorer) Name() string { return s.name }
+
+func (s funcScorer) Rank(viewerID persist.DBID, now time.Time, entities []db.GetFeedEntityScoresRow) []db.GetFeedEntityScoresRow {
+	return feedrank.TopN(feedrank.Opts.FetchSize, entities, func(i int) float64 { return s.score(viewerID, now, entities[i]) })
+}
+
+// RecencyScorer ranks the newest posts first. It's a baseline for the other scorers.
+var RecencyScorer = NewScorer("recency", func(_ persist.DBID, now time.Time, e db.GetFeedEntityScoresRow) float64 {
+	return -now.Sub(e.Post.CreatedAt).Minutes()
+})
+
+// Options control how rankings are evaluated
+type Options struct {
+	// K is how many posts at the top of each ranking are measured
+	K int
+	// MaxViewers is the most viewers that rankings are evaluated for. Zero means every viewer.
+	MaxViewers int
+}
+
+// Result is how well a scorer ranked a snapshot
+type Result struct {
+	Scorer string
+	// Viewers is the number of viewers the scorer was evaluated for
+	Viewers int
+	// NDCG is the mean normalized discounted cumulative gain of the top K posts ranked for each viewer, where a post's
+	// gain is the viewer's later interactions with it
+	NDCG float64
+	// GlobalNDCG is the normalized discounted cumulative gain of the top K posts ranked for a logged out viewer, where a
+	// post's gain is everyone's later interactions with it
+	GlobalNDCG float64
+	// Diversity is the mean fraction of distinct creators among the top K posts ranked for each viewer
+	Diversity float64
+	// CreatorCoverage is the fraction of the snapshot's creators that appear in at least one viewer's top K posts
+	CreatorCoverage float64
+}
+
+// Evaluate ranks the snapshot with each scorer and measures the rankings against the interactions that followed.
+// Viewers are the people who interacted with someone else's post after the snapshot was taken.
+func Evaluate(s Snapshot, scorers []Scorer, opts Options) []Result {
+	viewerGains := make(map[persist.DBID]map[persist.DBID]float64)
+	globalGains := make(map[persist.DBID]float64)
+	actors := make(map[persist.DBID]persist.DBID, len(s.Entities))
+	for _, e := range s.Entities {
+		actors[e.Post.ID] = e.Post.ActorID
+	}
+
+	for _, i := range s.Interactions {
+		actorID, ok := actors[i.PostID]
+		if !ok {
+			continue
+		}
+		gain := interactionGains[i.Kind]
+		globalGains[i.PostID] += gain
+		if i.UserID == actorID {
+			continue
+		}
+		if viewerGains[i.UserID] == nil {
+			viewerGains[i.UserID] = make(map[persist.DBID]float64)
+		}
+		viewerGains[i.UserID][i.PostID] += gain
+	}
+
+	viewers := make([]persist.DBID, 0, len(viewerGains))
+	for viewerID := range viewerGains {
+		viewers = append(viewers, viewerID)
+	}
+	sort.Slice(viewers, func(i, j int) bool { return viewers[i] < viewers[j] })
+	if opts.MaxViewers > 0 && len(viewers) > opts.MaxViewers {
+		viewers = viewers[:opts.MaxViewers]
+	}
+
+	creators := make(map[persist.DBID]bool)
+	for _, actorID := range actors {
+		creators[actorID] = true
+	}
+
+	results := make([]Result, len(scorers))
+	for i, scorer := range scorers {
+		result := Result{Scorer: scorer.Name(), Viewers: len(viewers)}
+		covered := make(map[persist.DBID]bool)
+
+		for _, viewerID := range viewers {
+			top := topK(scorer.Rank(viewerID, s.AsOf, s.Entities), opts.K)
+			result.NDCG += ndcg(top, viewerGains[viewerID], opts.K)
+			result.Diversity += diversity(top)
+			for _, e := range top {
+				covered[e.Post.ActorID] = true
+			}
+		}
+
+		if len(viewers) > 0 {
+			result.NDCG /= float64(len(viewers))
+			result.Diversity /= float64(len(viewers))
+		}
+		if len(creators) > 0 {
+			result.CreatorCoverage = float64(len(covered)) / float64(len(creators))
+		}
+
+		result.GlobalNDCG = ndcg(topK(scorer.Rank("", s.AsOf, s.Entities), opts.K), globalGains, opts.K)
+		results[i] = result
+	}
+
+	return results
+}
+
+func topK(ranked []db.GetFeedEntityScoresRow, k int) []db.GetFeedEntityScoresRow {
+	if k > 0 && len(ranked) > k {
+		return ranked[:k]
+	}
+	return ranked
+}
+
+// ndcg is the discounted cumulative gain of the ranking divided by that of the best possible ranking
+func ndcg(ranked []db.GetFeedEntityScoresRow, gains map[persist.DBID]float64, k int) float64 {
+	var actual float64
+	for i, e := range ranked {
+		actual += gains[e.Post.ID] / math.Log2(float64(i+2))
+	}
+
+	ideal := make([]float64, 0, len(gains))
+	for _, gain := range gains {
+		ideal = append(ideal, gain)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(ideal)))
+	if k > 0 && len(ideal) > k {
+		ideal = ideal[:k]
+	}
+
+	var best float64
+	for i, gain := range ideal {
+		best += gain / math.Log2(float64(i+2))
+	}
+
+	if best == 0 {
+		return 0
+	}
+	return actual / best
+}
+
+// diversity is the fraction of posts in the ranking that are by distinct creators
+func diversity(ranked []db.GetFeedEntityScoresRow) float64 {
+	if len(ranked) == 0 {
+		return 0
+	}
+	actors := make(map[persist.DBID]bool, len(ranked))
+	for _, e := range ranked {
+		actors[e.Post.ActorID] = true
+	}
+	return float64(len(actors)) / float64(len(ranked))
+}
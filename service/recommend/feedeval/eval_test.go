@@ -0,0 +1,132 @@
+package feedeval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+// post is a post for tests, created the given time before the snapshot
+type post struct {
+	id    string
+	actor string
+	age   time.Duration
+}
+
+func newPosts(asOf time.Time, ps ...post) []db.GetFeedEntityScoresRow {
+	rows := make([]db.GetFeedEntityScoresRow, len(ps))
+	for i, p := range ps {
+		rows[i] = db.GetFeedEntityScoresRow{
+			Post: db.Post{ID: persist.DBID(p.id), ActorID: persist.DBID(p.actor), CreatedAt: asOf.Add(-p.age)},
+		}
+	}
+	return rows
+}
+
+func TestNDCG(t *testing.T) {
+	gains := map[persist.DBID]float64{"a": 3, "b": 2, "c": 1}
+
+	tests := []struct {
+		title    string
+		ranked   []post
+		gains    map[persist.DBID]float64
+		k        int
+		expected float64
+	}{
+		{
+			title:    "scores a perfect ranking as 1",
+			ranked:   []post{{id: "a"}, {id: "b"}, {id: "c"}},
+			gains:    gains,
+			expected: 1,
+		},
+		{
+			title:    "scores a reversed ranking by its discounted gain",
+			ranked:   []post{{id: "c"}, {id: "b"}, {id: "a"}},
+			gains:    gains,
+			expected: 0.7899980042460358,
+		},
+		{
+			title:    "only compares against the best top k",
+			ranked:   []post{{id: "b"}},
+			gains:    gains,
+			k:        1,
+			expected: 2.0 / 3.0,
+		},
+		{
+			title:    "scores a ranking without gains as 0",
+			ranked:   []post{{id: "a"}, {id: "b"}},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, ndcg(newPosts(time.Now(), tt.ranked...), tt.gains, tt.k), 1e-9)
+		})
+	}
+}
+
+func TestDiversity(t *testing.T) {
+	tests := []struct {
+		title    string
+		ranked   []post
+		expected float64
+	}{
+		{
+			title:    "scores posts by the same creator as one over the number of posts",
+			ranked:   []post{{id: "a", actor: "x"}, {id: "b", actor: "x"}, {id: "c", actor: "x"}, {id: "d", actor: "x"}},
+			expected: 0.25,
+		},
+		{
+			title:    "scores posts by different creators as 1",
+			ranked:   []post{{id: "a", actor: "x"}, {id: "b", actor: "y"}},
+			expected: 1,
+		},
+		{
+			title:    "scores an empty ranking as 0",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diversity(newPosts(time.Now(), tt.ranked...)))
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := Snapshot{
+		AsOf: asOf,
+		Entities: newPosts(asOf,
+			post{id: "p1", actor: "a", age: time.Hour},
+			post{id: "p2", actor: "b", age: 2 * time.Hour},
+			post{id: "p3", actor: "b", age: 3 * time.Hour},
+			post{id: "p4", actor: "c", age: 4 * time.Hour},
+		),
+		Interactions: []Interaction{
+			{UserID: "viewer", PostID: "p2", Kind: InteractionAdmire},
+			{UserID: "viewer", PostID: "p3", Kind: InteractionComment},
+			// Creators interacting with their own posts only count towards the global ranking
+			{UserID: "a", PostID: "p1", Kind: InteractionAdmire},
+			// Posts that weren't in the snapshot are ignored
+			{UserID: "viewer", PostID: "p5", Kind: InteractionComment},
+		},
+	}
+
+	results := Evaluate(snapshot, []Scorer{RecencyScorer}, Options{K: 2})
+
+	// The top 2 are p1 and p2, but the viewer's best posts were p3 (gain 2) and p2 (gain 1)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "recency", results[0].Scorer)
+	assert.Equal(t, 1, results[0].Viewers)
+	assert.InDelta(t, 0.23981246656813146, results[0].NDCG, 1e-9)
+	assert.InDelta(t, 0.6199062332840657, results[0].GlobalNDCG, 1e-9)
+	assert.Equal(t, 1.0, results[0].Diversity)
+	assert.InDelta(t, 2.0/3.0, results[0].CreatorCoverage, 1e-9)
+}
@@ -0,0 +1,102 @@
+// Package feedeval measures feed rankings offline. A snapshot records the posts that were eligible for the feed at a
+// point in time along with the interactions they went on to receive, so that rankers can be compared by how well they
+// would have ordered posts that people later engaged with.
+package feedeval
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/recommend/feedrank"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+// The kinds of interactions that are recorded in a snapshot
+const (
+	InteractionAdmire  = "admire"
+	InteractionComment = "comment"
+)
+
+// Snapshot is the feed as it was at a point in time, and the interactions with its posts that followed
+type Snapshot struct {
+	// AsOf is when the feed is ranked
+	AsOf time.Time `json:"as_of"`
+	// Horizon is how long after AsOf interactions were collected for
+	Horizon time.Duration `json:"horizon"`
+	// Entities are the posts that were eligible for the feed, with the interactions they had received by AsOf
+	Entities []db.GetFeedEntityScoresRow `json:"entities"`
+	// Interactions are the admires and comments the posts received between AsOf and AsOf+Horizon
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is an admire or comment on a post
+type Interaction struct {
+	UserID    persist.DBID `json:"user_id"`
+	PostID    persist.DBID `json:"post_id"`
+	Kind      string       `json:"kind"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// TakeSnapshot rebuilds the feed as it was at asOf and collects the interactions with its posts over the following
+// horizon. asOf+horizon should be in the past for the database being read, otherwise later interactions are missing.
+func TakeSnapshot(ctx context.Context, q *db.Queries, asOf time.Time, horizon time.Duration) (Snapshot, error) {
+	rows, err := q.GetFeedEntityScoresAsOf(ctx, db.GetFeedEntityScoresAsOfParams{
+		WindowEnd: asOf.Add(-time.Minute * time.Duration(feedrank.Opts.LookbackWindow)),
+		AsOf:      asOf,
+		Span:      int32(feedrank.Opts.PostSpan),
+	})
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	entities := util.MapWithoutError(rows, func(r db.GetFeedEntityScoresAsOfRow) db.GetFeedEntityScoresRow {
+		return db.GetFeedEntityScoresRow{
+			FeedEntityScore: db.FeedEntityScore{
+				ID:             r.Post.ID,
+				CreatedAt:      r.Post.CreatedAt,
+				ActorID:        r.Post.ActorID,
+				ContractIds:    r.Post.ContractIds,
+				Interactions:   r.Interactions,
+				FeedEntityType: int32(persist.PostTypeTag),
+				LastUpdated:    asOf,
+			},
+			Post:          r.Post,
+			Streak:        r.Streak,
+			IsGalleryPost: r.IsGalleryPost,
+		}
+	})
+
+	interactions, err := q.GetFeedInteractionsByPostIDs(ctx, db.GetFeedInteractionsByPostIDsParams{
+		PostIds: util.MapWithoutError(rows, func(r db.GetFeedEntityScoresAsOfRow) persist.DBID { return r.Post.ID }),
+		After:   asOf,
+		Before:  asOf.Add(horizon),
+	})
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return Snapshot{
+		AsOf:     asOf,
+		Horizon:  horizon,
+		Entities: entities,
+		Interactions: util.MapWithoutError(interactions, func(i db.GetFeedInteractionsByPostIDsRow) Interaction {
+			return Interaction{UserID: i.ActorID, PostID: i.PostID, Kind: i.Kind, CreatedAt: i.CreatedAt}
+		}),
+	}, nil
+}
+
+// Write writes the snapshot as JSON
+func (s Snapshot) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// ReadSnapshot reads a snapshot written by Write
+func ReadSnapshot(r io.Reader) (Snapshot, error) {
+	var s Snapshot
+	err := json.NewDecoder(r).Decode(&s)
+	return s, err
+}
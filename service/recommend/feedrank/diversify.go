@@ -0,0 +1,105 @@
+package feedrank
+
+import (
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+// DiversityConstraints limit how alike neighboring entities on a feed can be. A zero value disables a constraint.
+type DiversityConstraints struct {
+	// MaxPerActor is the most entities by the same actor in any ActorWindow consecutive entities
+	MaxPerActor int
+	ActorWindow int
+	// MaxPerContract is the most entities that include the same contract in any ContractWindow consecutive entities
+	MaxPerContract int
+	ContractWindow int
+	// EventSpacing is the least number of entities between two feed events, such as gallery updates, so they're spread
+	// out among posts
+	EventSpacing int
+}
+
+// FeedDiversity are the constraints applied to the trending and for you feeds
+var FeedDiversity = DiversityConstraints{
+	MaxPerActor:    1,
+	ActorWindow:    5,
+	MaxPerContract: 2,
+	ContractWindow: 6,
+	EventSpacing:   3,
+}
+
+// Diversify reorders ranked entities so that they meet the constraints, moving each entity as little as possible. At
+// each position, the highest ranked entity that keeps the feed within the constraints is placed next. If none can,
+// the highest ranked entity is placed anyway, so no entity is ever dropped from the feed.
+func Diversify(ranked []db.GetFeedEntityScoresRow, c DiversityConstraints) []db.GetFeedEntityScoresRow {
+	remaining := make([]db.GetFeedEntityScoresRow, len(ranked))
+	copy(remaining, ranked)
+
+	placed := make([]db.GetFeedEntityScoresRow, 0, len(ranked))
+
+	for len(remaining) > 0 {
+		next := 0
+		for i, e := range remaining {
+			if c.allows(placed, e) {
+				next = i
+				break
+			}
+		}
+		placed = append(placed, remaining[next])
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+
+	return placed
+}
+
+// allows returns true if e can be placed after the entities that are already placed
+func (c DiversityConstraints) allows(placed []db.GetFeedEntityScoresRow, e db.GetFeedEntityScoresRow) bool {
+	if c.MaxPerActor > 0 && c.ActorWindow > 0 {
+		var count int
+		for _, p := range lastN(placed, c.ActorWindow-1) {
+			if p.FeedEntityScore.ActorID == e.FeedEntityScore.ActorID {
+				count++
+			}
+		}
+		if count >= c.MaxPerActor {
+			return false
+		}
+	}
+
+	if c.MaxPerContract > 0 && c.ContractWindow > 0 && len(e.FeedEntityScore.ContractIds) > 0 {
+		counts := make(map[persist.DBID]int, len(e.FeedEntityScore.ContractIds))
+		for _, p := range lastN(placed, c.ContractWindow-1) {
+			for _, id := range p.FeedEntityScore.ContractIds {
+				counts[id]++
+			}
+		}
+		for _, id := range e.FeedEntityScore.ContractIds {
+			if counts[id] >= c.MaxPerContract {
+				return false
+			}
+		}
+	}
+
+	if c.EventSpacing > 0 && isFeedEvent(e) {
+		for _, p := range lastN(placed, c.EventSpacing) {
+			if isFeedEvent(p) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isFeedEvent(e db.GetFeedEntityScoresRow) bool {
+	return persist.FeedEntityType(e.FeedEntityScore.FeedEntityType) == persist.FeedEventTypeTag
+}
+
+func lastN[T any](s []T, n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	if len(s) <= n {
+		return s
+	}
+	return s[len(s)-n:]
+}
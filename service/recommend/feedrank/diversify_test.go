@@ -0,0 +1,160 @@
+package feedrank
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+// entity is a feed entity for tests. Its ID is its rank before diversifying.
+type entity struct {
+	actor     string
+	contracts []string
+	event     bool
+}
+
+func newEntities(es ...entity) []db.GetFeedEntityScoresRow {
+	rows := make([]db.GetFeedEntityScoresRow, len(es))
+	for i, e := range es {
+		entityType := persist.PostTypeTag
+		if e.event {
+			entityType = persist.FeedEventTypeTag
+		}
+		contracts := make(persist.DBIDList, len(e.contracts))
+		for j, c := range e.contracts {
+			contracts[j] = persist.DBID(c)
+		}
+		rows[i] = db.GetFeedEntityScoresRow{
+			FeedEntityScore: db.FeedEntityScore{
+				ID:             persist.DBID(fmt.Sprint(i)),
+				ActorID:        persist.DBID(e.actor),
+				ContractIds:    contracts,
+				FeedEntityType: int32(entityType),
+			},
+		}
+	}
+	return rows
+}
+
+func ids(rows []db.GetFeedEntityScoresRow) []string {
+	out := make([]string, len(rows))
+	for i, r := range rows {
+		out[i] = r.FeedEntityScore.ID.String()
+	}
+	return out
+}
+
+func TestDiversify_NoConstraintsKeepsOrder(t *testing.T) {
+	ranked := newEntities(entity{actor: "a"}, entity{actor: "a"}, entity{actor: "a"}, entity{actor: "b"})
+	assert.Equal(t, []string{"0", "1", "2", "3"}, ids(Diversify(ranked, DiversityConstraints{})))
+}
+
+func TestDiversify_MaxPerActor(t *testing.T) {
+	ranked := newEntities(
+		entity{actor: "a"},
+		entity{actor: "a"},
+		entity{actor: "a"},
+		entity{actor: "b"},
+		entity{actor: "c"},
+		entity{actor: "d"},
+	)
+	actual := Diversify(ranked, DiversityConstraints{MaxPerActor: 1, ActorWindow: 3})
+	assert.Equal(t, []string{"0", "3", "4", "1", "5", "2"}, ids(actual))
+}
+
+func TestDiversify_MaxPerActorAllowsSeveralPerWindow(t *testing.T) {
+	ranked := newEntities(
+		entity{actor: "a"},
+		entity{actor: "a"},
+		entity{actor: "a"},
+		entity{actor: "b"},
+	)
+	actual := Diversify(ranked, DiversityConstraints{MaxPerActor: 2, ActorWindow: 4})
+	assert.Equal(t, []string{"0", "1", "3", "2"}, ids(actual))
+}
+
+func TestDiversify_MaxPerContract(t *testing.T) {
+	ranked := newEntities(
+		entity{actor: "a", contracts: []string{"x"}},
+		entity{actor: "b", contracts: []string{"x", "y"}},
+		entity{actor: "c", contracts: []string{"y"}},
+		entity{actor: "d"},
+		entity{actor: "e", contracts: []string{"z"}},
+	)
+	actual := Diversify(ranked, DiversityConstraints{MaxPerContract: 1, ContractWindow: 2})
+	assert.Equal(t, []string{"0", "2", "3", "1", "4"}, ids(actual))
+}
+
+func TestDiversify_PostsWithoutContractsAreUnconstrained(t *testing.T) {
+	ranked := newEntities(entity{actor: "a"}, entity{actor: "b"}, entity{actor: "c"})
+	actual := Diversify(ranked, DiversityConstraints{MaxPerContract: 1, ContractWindow: 3})
+	assert.Equal(t, []string{"0", "1", "2"}, ids(actual))
+}
+
+func TestDiversify_EventSpacing(t *testing.T) {
+	ranked := newEntities(
+		entity{actor: "a", event: true},
+		entity{actor: "b", event: true},
+		entity{actor: "c"},
+		entity{actor: "d"},
+		entity{actor: "e", event: true},
+		entity{actor: "f"},
+	)
+	actual := Diversify(ranked, DiversityConstraints{EventSpacing: 2})
+	assert.Equal(t, []string{"0", "2", "3", "1", "5", "4"}, ids(actual))
+}
+
+func TestDiversify_CombinedConstraints(t *testing.T) {
+	ranked := newEntities(
+		entity{actor: "a", contracts: []string{"x"}},
+		entity{actor: "a", contracts: []string{"y"}},
+		entity{actor: "b", contracts: []string{"x"}},
+		entity{actor: "c", contracts: []string{"z"}, event: true},
+		entity{actor: "d", contracts: []string{"x"}},
+		entity{actor: "e", event: true},
+	)
+	actual := Diversify(ranked, DiversityConstraints{
+		MaxPerActor:    1,
+		ActorWindow:    2,
+		MaxPerContract: 1,
+		ContractWindow: 2,
+		EventSpacing:   1,
+	})
+	assert.Equal(t, []string{"0", "3", "1", "2", "5", "4"}, ids(actual))
+}
+
+func TestDiversify_RelaxesWhenUnsatisfiable(t *testing.T) {
+	ranked := newEntities(entity{actor: "a"}, entity{actor: "a"}, entity{actor: "b"}, entity{actor: "a"})
+	actual := Diversify(ranked, DiversityConstraints{MaxPerActor: 1, ActorWindow: 3})
+	// b is placed as soon as it's allowed, after which only a's posts remain and are placed in rank order
+	assert.Equal(t, []string{"0", "2", "1", "3"}, ids(actual))
+}
+
+func TestDiversify_KeepsEveryEntity(t *testing.T) {
+	var es []entity
+	for i := 0; i < 50; i++ {
+		es = append(es, entity{actor: fmt.Sprint(i % 3), contracts: []string{fmt.Sprint(i % 4)}, event: i%5 == 0})
+	}
+	ranked := newEntities(es...)
+
+	actual := Diversify(ranked, FeedDiversity)
+
+	assert.ElementsMatch(t, ids(ranked), ids(actual))
+}
+
+func TestDiversify_DoesNotModifyInput(t *testing.T) {
+	ranked := newEntities(entity{actor: "a"}, entity{actor: "a"}, entity{actor: "b"})
+	before := ids(ranked)
+
+	Diversify(ranked, DiversityConstraints{MaxPerActor: 1, ActorWindow: 2})
+
+	assert.Equal(t, before, ids(ranked))
+}
+
+func TestDiversify_Empty(t *testing.T) {
+	assert.Empty(t, Diversify(nil, FeedDiversity))
+}
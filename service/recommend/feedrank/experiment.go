@@ -0,0 +1,61 @@
+package feedrank
+
+import (
+	"hash/fnv"
+
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+// The experiments that choose the ranker of each feed. The first variant of each is the control.
+var (
+	TrendingExperiment = Experiment{
+		Name:     "trending-ranker",
+		Variants: []Variant{{Ranker: TrendingRanker{}, Weight: 100}},
+	}
+	ForYouExperiment = Experiment{
+		Name:     "for-you-ranker",
+		Variants: []Variant{{Ranker: ForYouRanker{}, Weight: 100}},
+	}
+)
+
+// Experiment splits viewers between rankers
+type Experiment struct {
+	// Name salts the assignment, so viewers are bucketed independently in each experiment. Changing it reshuffles
+	// every viewer.
+	Name     string
+	Variants []Variant
+}
+
+// Variant is a ranker and the share of viewers that are assigned to it, relative to the other variants' weights
+type Variant struct {
+	Ranker FeedRanker
+	Weight int
+}
+
+// Assign returns the ranker a viewer is bucketed into. Assignment is deterministic, so a viewer sees the same ranker
+// on every request for as long as the experiment's name and variants stay the same. Logged out viewers get the control.
+func (e Experiment) Assign(viewerID persist.DBID) FeedRanker {
+	var total int
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+
+	if viewerID == "" || total <= 0 {
+		return e.Variants[0].Ranker
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(e.Name))
+	h.Write([]byte{':'})
+	h.Write([]byte(viewerID))
+	bucket := int(h.Sum32() % uint32(total))
+
+	for _, v := range e.Variants {
+		if bucket < v.Weight {
+			return v.Ranker
+		}
+		bucket -= v.Weight
+	}
+
+	return e.Variants[0].Ranker
+}
@@ -0,0 +1,250 @@
+// Package feedrank ranks the posts on the trending and for you feeds.
+package feedrank
+
+import (
+	heappkg "container/heap"
+	"math"
+	"time"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+var Opts = struct {
+	FreshnessFactor     float64 // extra weight added to a new post
+	FirstPostFactor     float64 // extra weight added to a first post
+	LookbackWindow      float64 // how far back to look for posts
+	FreshnessWindow     float64 // how long a post is considered new
+	PostHalfLife        float64 // controls the decay rate of posts
+	GalleryPostHalfLife float64 // controls the decay rate of Gallery posts
+	GalleryDecayPeriod  float64 // time it takes for a Gallery post to reach GalleryPostHalfLife from PostHalfLife
+	FetchSize           int     // number of posts to include on the feed
+	StreakThreshold     int     // number of posts before a streak is counted
+	StreakFactor        float64 // factor that controls the impact of each extra post
+	PostSpan            float64 // the max time between posts allowed for a post to be part of the same group
+}{
+	FreshnessFactor:     1.5,
+	FirstPostFactor:     2.0,
+	LookbackWindow:      time.Duration(4 * 24 * time.Hour).Minutes(),
+	FreshnessWindow:     time.Duration(3 * time.Hour).Minutes(),
+	PostHalfLife:        time.Duration(6 * time.Hour).Minutes(),
+	GalleryPostHalfLife: time.Duration(10 * time.Hour).Minutes(),
+	GalleryDecayPeriod:  time.Duration(4 * 24 * time.Hour).Minutes(),
+	FetchSize:           128,
+	StreakThreshold:     2,
+	StreakFactor:        1.8,
+	PostSpan:            time.Duration(time.Minute).Seconds(),
+}
+
+// RelevanceFunc scores how relevant a feed entity is to a viewer, where 1 is neutral
+type RelevanceFunc func(viewerID persist.DBID, e db.FeedEntityScore) float64
+
+// RankInput is what a ranker needs to order a feed
+type RankInput struct {
+	// ViewerID is empty for logged out viewers
+	ViewerID   persist.DBID
+	Now        time.Time
+	PostScores []db.GetFeedEntityScoresRow
+	// Relevance is nil when personalization isn't available
+	Relevance RelevanceFunc
+}
+
+// FeedRanker orders the posts that are eligible for a feed, best first
+type FeedRanker interface {
+	// Name identifies the ranker in experiment assignments, cursors, and exposure logs. It should change whenever the
+	// ranker's behavior does so that engagement can be attributed to the right version.
+	Name() string
+	Rank(in RankInput) []db.GetFeedEntityScoresRow
+}
+
+// TrendingRanker ranks posts by recency and engagement
+type TrendingRanker struct{}
+
+func (TrendingRanker) Name() string { return "trending-v1" }
+
+func (TrendingRanker) Rank(in RankInput) []db.GetFeedEntityScoresRow {
+	return RankTrending(in.Now, in.PostScores)
+}
+
+// ForYouRanker blends rankings by engagement and by relevance to the viewer
+type ForYouRanker struct{}
+
+func (ForYouRanker) Name() string { return "for-you-v1" }
+
+func (ForYouRanker) Rank(in RankInput) []db.GetFeedEntityScoresRow {
+	relevance := in.Relevance
+	if relevance == nil {
+		relevance = neutralRelevance
+	}
+	return RankForYou(in.ViewerID, in.Now, in.PostScores, relevance)
+}
+
+func neutralRelevance(persist.DBID, db.FeedEntityScore) float64 { return 1 }
+
+// RankTrending returns the top posts by recency and engagement, best first
+func RankTrending(now time.Time, postScores []db.GetFeedEntityScoresRow) []db.GetFeedEntityScoresRow {
+	return TopN(Opts.FetchSize, postScores, func(i int) float64 {
+		e := postScores[i]
+		return TimeFactor(now.Sub(e.Post.CreatedAt).Minutes(), e.IsGalleryPost) * EngagementFactor(float64(e.FeedEntityScore.Interactions))
+	})
+}
+
+// RankForYou returns the top posts for a viewer, best first. Posts are ranked by engagement and by their relevance
+// to the viewer, and the two rankings are blended.
+func RankForYou(viewerID persist.DBID, now time.Time, postScores []db.GetFeedEntityScoresRow, relevance RelevanceFunc) []db.GetFeedEntityScoresRow {
+	engagementScores := make(map[persist.DBID]float64)
+	personalizationScores := make(map[persist.DBID]float64)
+
+	for _, e := range postScores {
+		age := now.Sub(e.Post.CreatedAt).Minutes()
+		engagementScores[e.Post.ID] = ScorePost(age, e.IsGalleryPost, e.Post.IsFirstPost, IsFreshPost(age), int(e.Streak))
+		personalizationScores[e.Post.ID] = engagementScores[e.Post.ID]
+		engagementScores[e.Post.ID] *= EngagementFactor(float64(e.FeedEntityScore.Interactions))
+		personalizationScores[e.Post.ID] *= relevance(viewerID, e.FeedEntityScore)
+	}
+
+	// Rank by engagement first, then by personalization
+	topNByPersonalization := TopN(Opts.FetchSize, postScores, func(i int) float64 { return engagementScores[postScores[i].Post.ID] })
+	topNByPersonalization = TopN(Opts.FetchSize, topNByPersonalization, func(i int) float64 { return personalizationScores[topNByPersonalization[i].Post.ID] })
+
+	// Rank by personalization, then by engagement
+	topNByEngagement := TopN(Opts.FetchSize, postScores, func(i int) float64 { return personalizationScores[postScores[i].Post.ID] })
+	topNByEngagement = TopN(Opts.FetchSize, topNByEngagement, func(i int) float64 { return engagementScores[topNByEngagement[i].Post.ID] })
+
+	// Get ranking of both
+	seen := make(map[persist.DBID]int)
+	combined := make([]db.GetFeedEntityScoresRow, 0)
+	engagementRank := make([]float64, 0)
+	blendedRank := make([]float64, 0)
+
+	for i, e := range topNByEngagement {
+		score := float64(len(topNByEngagement) - i)
+		combined = append(combined, e)
+		engagementRank = append(engagementRank, score)
+		blendedRank = append(blendedRank, score)
+		seen[e.Post.ID] = i
+	}
+
+	for i, e := range topNByPersonalization {
+		score := float64(len(topNByPersonalization) - i)
+		if idx, ok := seen[e.Post.ID]; ok {
+			blendedRank[idx] = (blendedRank[idx] + score) * 0.5
+		} else {
+			combined = append(combined, e)
+			engagementRank = append(engagementRank, 0)
+			// New posts tend to have no engagement, so don't over penalize it by halving it
+			blendedRank = append(blendedRank, score)
+		}
+	}
+
+	return TopN(Opts.FetchSize, combined, func(i int) float64 {
+		if combined[i].Post.ActorID == viewerID {
+			return engagementRank[i]
+		}
+		return blendedRank[i]
+	})
+}
+
+// TopN returns the n highest scoring entities, best first
+func TopN(n int, trendData []db.GetFeedEntityScoresRow, scoreF func(i int) float64) []db.GetFeedEntityScoresRow {
+	h := make(heap[entityScore], 0)
+
+	scores := make([]entityScore, len(trendData))
+
+	for i, event := range trendData {
+		score := scoreF(i)
+		scores[i] = entityScore{v: event, s: score}
+	}
+
+	for _, node := range scores {
+		// Add first n items in the heap
+		if h.Len() < n {
+			heappkg.Push(&h, node)
+			continue
+		}
+		// If the score is greater than the smallest score in the heap, replace it
+		if node.s > h[0].s {
+			heappkg.Pop(&h)
+			heappkg.Push(&h, node)
+		}
+	}
+
+	scoredEntities := make([]db.GetFeedEntityScoresRow, h.Len())
+
+	// Pop returns the smallest score first, so we reverse the order
+	// such that the highest score is first
+	i := h.Len() - 1
+	for h.Len() > 0 {
+		node := heappkg.Pop(&h)
+		scoredEntities[i] = node.(entityScore).v
+		i--
+	}
+
+	return scoredEntities
+}
+
+func IsFreshPost(age float64) bool {
+	return age < Opts.FreshnessWindow
+}
+
+func ScorePost(age float64, isGallery, isFirstPost, isFresh bool, streak int) (s float64) {
+	s = TimeFactor(age, isGallery)
+	if streak > Opts.StreakThreshold {
+		s *= 1 / math.Pow(math.E, Opts.StreakFactor*float64(streak-Opts.StreakThreshold+1))
+	}
+	if isFirstPost && isFresh {
+		s *= Opts.FirstPostFactor
+	}
+	if !isFirstPost && streak < Opts.StreakThreshold && isFresh {
+		s *= Opts.FreshnessFactor
+	}
+	return s
+}
+
+func TimeFactor(age float64, isGalleryPost bool) float64 {
+	if isGalleryPost {
+		return math.Pow(2, -(age / lerp(age, Opts.PostHalfLife, Opts.GalleryPostHalfLife, Opts.GalleryDecayPeriod)))
+	}
+	return math.Pow(2, -(age / Opts.PostHalfLife))
+}
+
+// lerp returns a linear interpolation between s and e (clamped to e) based on age
+// period controls the time it takes to reach e from s
+func lerp(age, s, e, period float64) float64 {
+	return math.Min(e, s+((e-s)/period)*age)
+}
+
+func EngagementFactor(interactions float64) float64 {
+	// Add 2 because log(0) => undefined and log(1) => 0 and returning 0 will cancel out
+	// the effect of other terms this term may get multiplied with
+	return math.Log2(2 + interactions)
+}
+
+type entityScore struct {
+	v db.GetFeedEntityScoresRow
+	s float64
+}
+
+func (n entityScore) Less(a any) bool {
+	other, ok := a.(entityScore)
+	if !ok {
+		return false
+	}
+	return n.s < other.s
+}
+
+type lt interface{ Less(j any) bool }
+type heap[T lt] []T
+
+func (h heap[T]) Len() int           { return len(h) }
+func (h heap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *heap[T]) Push(s any)        { *h = append(*h, s.(T)) }
+func (h heap[T]) Less(i, j int) bool { return h[i].Less(h[j]) }
+
+func (h *heap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,72 @@
+package userpref
+
+import (
+	"context"
+	"math"
+	"time"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+const (
+	// feedbackLookback is how far back a viewer's feedback is considered
+	feedbackLookback = 90 * 24 * time.Hour
+	// notInterestedActorDiscount is applied to a post's relevance for each post by the same actor that the viewer
+	// wasn't interested in
+	notInterestedActorDiscount = 0.5
+	// notInterestedContractDiscount is applied to a post's relevance for each post about the same contract that the
+	// viewer wasn't interested in
+	notInterestedContractDiscount = 0.75
+)
+
+// Feedback is what a viewer has told us they don't want to see. It's read when the feed is ranked rather than built
+// into the personalization matrices so that it takes effect right away.
+type Feedback struct {
+	actors    map[persist.DBID]int
+	contracts map[persist.DBID]int
+}
+
+// ReadFeedback reads the viewer's recent feedback
+func ReadFeedback(ctx context.Context, q *db.Queries, viewerID persist.DBID) (Feedback, error) {
+	f := Feedback{actors: make(map[persist.DBID]int), contracts: make(map[persist.DBID]int)}
+
+	signals, err := q.GetNotInterestedSignalsByUserID(ctx, db.GetNotInterestedSignalsByUserIDParams{
+		UserID: viewerID,
+		Since:  time.Now().Add(-feedbackLookback),
+	})
+	if err != nil {
+		return f, err
+	}
+
+	for _, s := range signals {
+		f.actors[s.ActorID]++
+		for _, contractID := range s.ContractIds {
+			f.contracts[contractID]++
+		}
+	}
+
+	return f, nil
+}
+
+// Discount returns how much the viewer's feedback lowers the relevance of an entity, where 1 is no change
+func (f Feedback) Discount(e db.FeedEntityScore) float64 {
+	discount := math.Pow(notInterestedActorDiscount, float64(f.actors[e.ActorID]))
+
+	// Only the contract with the most feedback counts so that posts with many tokens aren't penalized more
+	var contractSignals int
+	for _, contractID := range e.ContractIds {
+		if n := f.contracts[contractID]; n > contractSignals {
+			contractSignals = n
+		}
+	}
+
+	return discount * math.Pow(notInterestedContractDiscount, float64(contractSignals))
+}
+
+// RelevanceWithFeedback scores relevance like RelevanceTo, discounted by the viewer's feedback
+func (p *Personalization) RelevanceWithFeedback(f Feedback) func(userID persist.DBID, e db.FeedEntityScore) float64 {
+	return func(userID persist.DBID, e db.FeedEntityScore) float64 {
+		return p.RelevanceTo(userID, e) * f.Discount(e)
+	}
+}
@@ -0,0 +1,64 @@
+package userpref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+func TestFeedbackDiscount(t *testing.T) {
+	f := Feedback{
+		actors:    map[persist.DBID]int{"alice": 1, "bob": 2},
+		contracts: map[persist.DBID]int{"c1": 1, "c2": 3},
+	}
+
+	tests := []struct {
+		title    string
+		entity   db.FeedEntityScore
+		expected float64
+	}{
+		{
+			title:    "doesn't discount entities without feedback",
+			entity:   db.FeedEntityScore{ActorID: "dave", ContractIds: persist.DBIDList{"c3"}},
+			expected: 1,
+		},
+		{
+			title:    "discounts entities by an actor the viewer wasn't interested in",
+			entity:   db.FeedEntityScore{ActorID: "alice"},
+			expected: notInterestedActorDiscount,
+		},
+		{
+			title:    "compounds the discount for each post by the actor",
+			entity:   db.FeedEntityScore{ActorID: "bob"},
+			expected: notInterestedActorDiscount * notInterestedActorDiscount,
+		},
+		{
+			title:    "discounts entities about a contract the viewer wasn't interested in",
+			entity:   db.FeedEntityScore{ActorID: "dave", ContractIds: persist.DBIDList{"c1"}},
+			expected: notInterestedContractDiscount,
+		},
+		{
+			title:    "only discounts by the contract with the most feedback",
+			entity:   db.FeedEntityScore{ActorID: "dave", ContractIds: persist.DBIDList{"c1", "c2", "c3"}},
+			expected: notInterestedContractDiscount * notInterestedContractDiscount * notInterestedContractDiscount,
+		},
+		{
+			title:    "combines the actor and contract discounts",
+			entity:   db.FeedEntityScore{ActorID: "alice", ContractIds: persist.DBIDList{"c1"}},
+			expected: notInterestedActorDiscount * notInterestedContractDiscount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, f.Discount(tt.entity), 1e-9)
+		})
+	}
+}
+
+func TestFeedbackDiscountWithoutFeedback(t *testing.T) {
+	assert.Equal(t, 1.0, Feedback{}.Discount(db.FeedEntityScore{ActorID: "alice", ContractIds: persist.DBIDList{"c1"}}))
+}
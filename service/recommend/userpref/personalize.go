@@ -189,6 +189,13 @@ func NewPersonalization(ctx context.Context, q *db.Queries, c *storage.Client) *
 	return k
 }
 
+// NewPersonalizationFromDB builds the personalization matrices from the database rather than reading them from
+// storage. The matrices aren't updated afterwards, so it's meant for offline tools rather than the server.
+func NewPersonalizationFromDB(ctx context.Context, q *db.Queries) *Personalization {
+	m := ReadMatrices(ctx, q)
+	return &Personalization{q: q, pM: &m}
+}
+
 func (p *Personalization) RelevanceTo(userID persist.DBID, e db.FeedEntityScore) float64 {
 	// We don't have personalization data for this user yet
 	_, vOK := p.pM.uL[userID]
@@ -0,0 +1,378 @@
+package ipfs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// maxBlockSize is the largest block that will be read from a CAR. The IPFS spec limits blocks to 2MB, and chunkers default to 256KB.
+const maxBlockSize = 4 << 20
+
+// UnixFS data types. https://github.com/ipfs/specs/blob/main/UNIXFS.md
+const (
+	unixfsRaw       = 0
+	unixfsDirectory = 1
+	unixfsFile      = 2
+	unixfsHAMTShard = 5
+)
+
+var (
+	errIsDirectory        = errors.New("path is a directory")
+	errShardedDirectory   = errors.New("resolving paths through sharded directories is not supported")
+	errUnsupportedCodec   = errors.New("unsupported codec")
+	errBlockTooLarge      = errors.New("block is larger than the maximum block size")
+	errNotTrustlessFormat = errors.New("gateway did not respond with a CAR")
+)
+
+// ErrCIDMismatch is returned when the bytes of a block don't hash to the CID they were served as
+type ErrCIDMismatch struct {
+	Expected cid.Cid
+	Actual   cid.Cid
+}
+
+func (e ErrCIDMismatch) Error() string {
+	return fmt.Sprintf("block does not match cid: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// ErrUnexpectedBlock is returned when a CAR doesn't have the blocks of a DAG in depth-first order
+type ErrUnexpectedBlock struct {
+	Expected cid.Cid
+	Actual   cid.Cid
+}
+
+func (e ErrUnexpectedBlock) Error() string {
+	return fmt.Sprintf("unexpected block in car: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// ErrPathNotFound is returned when a path segment isn't a link of the directory it's resolved from
+type ErrPathNotFound struct {
+	Directory cid.Cid
+	Name      string
+}
+
+func (e ErrPathNotFound) Error() string {
+	return fmt.Sprintf("%s not found in directory %s", e.Name, e.Directory)
+}
+
+// carBlockReader reads the blocks of a CARv1 stream and verifies each block against its CID as it's read
+type carBlockReader struct {
+	r *bufio.Reader
+}
+
+func newCARBlockReader(r io.Reader) (*carBlockReader, error) {
+	c := &carBlockReader{r: bufio.NewReader(r)}
+	// The header only lists the roots that were requested, which are checked as the DAG is walked
+	header, err := c.readSection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read car header: %w", err)
+	}
+	if len(header) == 0 {
+		return nil, errNotTrustlessFormat
+	}
+	return c, nil
+}
+
+func (c *carBlockReader) readSection() ([]byte, error) {
+	size, err := binary.ReadUvarint(c.r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxBlockSize {
+		return nil, errBlockTooLarge
+	}
+	section := make([]byte, size)
+	_, err = io.ReadFull(c.r, section)
+	return section, err
+}
+
+// next returns the next block of the CAR after checking that it hashes to its CID
+func (c *carBlockReader) next() (cid.Cid, []byte, error) {
+	section, err := c.readSection()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return cid.Undef, nil, io.ErrUnexpectedEOF
+		}
+		return cid.Undef, nil, err
+	}
+	n, blockCID, err := cid.CidFromBytes(section)
+	if err != nil {
+		return cid.Undef, nil, err
+	}
+	data := section[n:]
+	if err := verifyBlock(blockCID, data); err != nil {
+		return cid.Undef, nil, err
+	}
+	return blockCID, data, nil
+}
+
+func verifyBlock(c cid.Cid, data []byte) error {
+	actual, err := c.Prefix().Sum(data)
+	if err != nil {
+		return err
+	}
+	if !actual.Equals(c) {
+		return ErrCIDMismatch{Expected: c, Actual: actual}
+	}
+	return nil
+}
+
+// dagReader streams the content of a UnixFS file from a CAR that has its blocks in depth-first order. Every block is verified
+// against the CID that links to it, so the content is exactly what the root CID and path refer to.
+type dagReader struct {
+	blocks *carBlockReader
+	// pending are the blocks that haven't been read yet, with the next block at the end
+	pending []cid.Cid
+	buf     []byte
+}
+
+// newDAGReader resolves the path from the root and returns a reader of the file it points to
+func newDAGReader(r io.Reader, root cid.Cid, segments []string) (*dagReader, error) {
+	blocks, err := newCARBlockReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &dagReader{blocks: blocks}
+
+	target := root
+	for _, name := range segments {
+		target, err = d.resolve(target, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d.pending = []cid.Cid{target}
+
+	// Read the first block so that a gateway that serves the wrong content fails before it's used
+	if err := d.fill(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *dagReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if err := d.fill(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+// fill reads the next block of the file into the buffer
+func (d *dagReader) fill() error {
+	if len(d.pending) == 0 {
+		return io.EOF
+	}
+
+	c := d.pending[len(d.pending)-1]
+	d.pending = d.pending[:len(d.pending)-1]
+
+	data, err := d.block(c)
+	if err != nil {
+		return err
+	}
+
+	switch c.Type() {
+	case cid.Raw:
+		d.buf = data
+		return nil
+	case cid.DagProtobuf:
+		node, err := decodePBNode(data)
+		if err != nil {
+			return err
+		}
+		fsType, fsData, err := decodeUnixFSData(node.data)
+		if err != nil {
+			return err
+		}
+		if fsType != unixfsFile && fsType != unixfsRaw {
+			return errIsDirectory
+		}
+		d.buf = fsData
+		// Push the children in reverse so that the first child is read next
+		for i := len(node.links) - 1; i >= 0; i-- {
+			d.pending = append(d.pending, node.links[i].hash)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: 0x%x", errUnsupportedCodec, c.Type())
+	}
+}
+
+// block returns the data of the block. Blocks with identity hashes carry their data in the CID and aren't sent in the CAR.
+func (d *dagReader) block(c cid.Cid) ([]byte, error) {
+	if c.Prefix().MhType == multihash.IDENTITY {
+		decoded, err := multihash.Decode(c.Hash())
+		if err != nil {
+			return nil, err
+		}
+		return decoded.Digest, nil
+	}
+
+	actual, data, err := d.blocks.next()
+	if err != nil {
+		return nil, err
+	}
+	if !actual.Equals(c) {
+		return nil, ErrUnexpectedBlock{Expected: c, Actual: actual}
+	}
+	return data, nil
+}
+
+// resolve returns the CID of the named link of a UnixFS directory
+func (d *dagReader) resolve(dir cid.Cid, name string) (cid.Cid, error) {
+	if dir.Type() != cid.DagProtobuf {
+		return cid.Undef, ErrPathNotFound{Directory: dir, Name: name}
+	}
+
+	data, err := d.block(dir)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	node, err := decodePBNode(data)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	fsType, _, err := decodeUnixFSData(node.data)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if fsType == unixfsHAMTShard {
+		return cid.Undef, errShardedDirectory
+	}
+	if fsType != unixfsDirectory {
+		return cid.Undef, ErrPathNotFound{Directory: dir, Name: name}
+	}
+
+	for _, link := range node.links {
+		if link.name == name {
+			return link.hash, nil
+		}
+	}
+
+	return cid.Undef, ErrPathNotFound{Directory: dir, Name: name}
+}
+
+type pbLink struct {
+	hash cid.Cid
+	name string
+}
+
+type pbNode struct {
+	links []pbLink
+	data  []byte
+}
+
+// decodePBNode decodes a dag-pb node. https://ipld.io/specs/codecs/dag-pb/spec/
+func decodePBNode(b []byte) (pbNode, error) {
+	var node pbNode
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			node.data = v
+			return n, nil
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			link, err := decodePBLink(v)
+			if err != nil {
+				return 0, err
+			}
+			node.links = append(node.links, link)
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	return node, err
+}
+
+func decodePBLink(b []byte) (pbLink, error) {
+	var link pbLink
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			c, err := cid.Cast(v)
+			if err != nil {
+				return 0, err
+			}
+			link.hash = c
+			return n, nil
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			link.name = string(v)
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	if err == nil && !link.hash.Defined() {
+		err = errors.New("dag-pb link is missing its hash")
+	}
+	return link, err
+}
+
+// decodeUnixFSData decodes the type and the file data of a UnixFS node
+func decodeUnixFSData(b []byte) (uint64, []byte, error) {
+	var (
+		fsType uint64
+		fsData []byte
+	)
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			fsType = v
+			return n, nil
+		case num == 2 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			fsData = v
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	return fsType, fsData, err
+}
+
+// consumeFields calls f with each field of a protobuf message. f returns the length of the field's value or a negative
+// length if the value couldn't be parsed.
+func consumeFields(b []byte, f func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n, err := f(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package ipfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var updateFixtures = flag.Bool("update", false, "rewrite the CAR fixtures in testdata")
+
+// carFixture is a CAR in testdata along with the root and path that it's read from
+type carFixture struct {
+	file     string
+	root     string
+	segments []string
+	build    func() []byte
+}
+
+var (
+	helloWorld = rawBlock([]byte("hello world"))
+
+	chunks       = []testBlock{rawBlock([]byte("chunk one, ")), rawBlock([]byte("chunk two, ")), rawBlock([]byte("chunk three"))}
+	chunkedFile  = fileBlock(nil, chunks...)
+	catFile      = fileBlock([]byte("a cat"))
+	imagesDir    = dirBlock(map[string]testBlock{"cat.txt": catFile})
+	rootDir      = dirBlock(map[string]testBlock{"images": imagesDir, "other.txt": helloWorld})
+	tamperedLeaf = testBlock{cid: helloWorld.cid, data: []byte("hello w0rld")}
+)
+
+var (
+	rawLeafFixture = carFixture{
+		file:  "raw-leaf.car",
+		root:  "bafkreifzjut3te2nhyekklss27nh3k72ysco7y32koao5eei66wof36n5e",
+		build: func() []byte { return writeCAR(helloWorld.cid, helloWorld) },
+	}
+	chunkedFileFixture = carFixture{
+		file:  "chunked-file.car",
+		root:  chunkedFile.cid.String(),
+		build: func() []byte { return writeCAR(chunkedFile.cid, chunkedFile, chunks[0], chunks[1], chunks[2]) },
+	}
+	directoryPathFixture = carFixture{
+		file:     "directory-path.car",
+		root:     rootDir.cid.String(),
+		segments: []string{"images", "cat.txt"},
+		build:    func() []byte { return writeCAR(rootDir.cid, rootDir, imagesDir, catFile) },
+	}
+	cidMismatchFixture = carFixture{
+		file:  "cid-mismatch.car",
+		root:  helloWorld.cid.String(),
+		build: func() []byte { return writeCAR(helloWorld.cid, tamperedLeaf) },
+	}
+	outOfOrderFixture = carFixture{
+		file:  "out-of-order.car",
+		root:  chunkedFile.cid.String(),
+		build: func() []byte { return writeCAR(chunkedFile.cid, chunkedFile, chunks[1], chunks[0], chunks[2]) },
+	}
+)
+
+var allFixtures = []carFixture{rawLeafFixture, chunkedFileFixture, directoryPathFixture, cidMismatchFixture, outOfOrderFixture}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if *updateFixtures {
+		for _, f := range allFixtures {
+			if err := os.WriteFile(filepath.Join("testdata", f.file), f.build(), 0644); err != nil {
+				panic(err)
+			}
+		}
+	}
+	os.Exit(m.Run())
+}
+
+func TestFixtureEncoding(t *testing.T) {
+	// The fixtures are built with the helpers below, so check them against blocks whose CIDs are well known
+	emptyDir := pbBlock(0, 1, nil, nil)
+	assert.Equal(t, "QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn", emptyDir.cid.String())
+	emptyFile := pbBlock(0, 2, nil, nil)
+	assert.Equal(t, "QmbFMke1KXqnYyBBWxB74N4c5SBnJMVAiMNRcGu6x1AwQH", emptyFile.cid.String())
+
+	for _, f := range allFixtures {
+		t.Run(f.file, func(t *testing.T) {
+			assert.Equal(t, f.build(), readFixture(t, f), "fixture is out of date, run the tests with -update")
+		})
+	}
+}
+
+func TestDAGReader(t *testing.T) {
+	t.Run("reads a raw leaf", func(t *testing.T) {
+		content, err := readDAG(t, rawLeafFixture)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", content)
+	})
+
+	t.Run("reads a chunked file in order", func(t *testing.T) {
+		content, err := readDAG(t, chunkedFileFixture)
+		require.NoError(t, err)
+		assert.Equal(t, "chunk one, chunk two, chunk three", content)
+	})
+
+	t.Run("resolves a path through directories", func(t *testing.T) {
+		content, err := readDAG(t, directoryPathFixture)
+		require.NoError(t, err)
+		assert.Equal(t, "a cat", content)
+	})
+
+	t.Run("fails on a block that doesn't match its cid", func(t *testing.T) {
+		_, err := readDAG(t, cidMismatchFixture)
+		var mismatch ErrCIDMismatch
+		require.True(t, errors.As(err, &mismatch), "expected a cid mismatch, got %v", err)
+		assert.Equal(t, helloWorld.cid, mismatch.Expected)
+	})
+
+	t.Run("fails on blocks that are out of order", func(t *testing.T) {
+		_, err := readDAG(t, outOfOrderFixture)
+		var unexpected ErrUnexpectedBlock
+		require.True(t, errors.As(err, &unexpected), "expected an unexpected block, got %v", err)
+		assert.Equal(t, chunks[0].cid, unexpected.Expected)
+		assert.Equal(t, chunks[1].cid, unexpected.Actual)
+	})
+
+	t.Run("fails on a path that isn't in the directory", func(t *testing.T) {
+		f := directoryPathFixture
+		f.segments = []string{"images", "dog.txt"}
+		_, err := readDAG(t, f)
+		var notFound ErrPathNotFound
+		require.True(t, errors.As(err, &notFound), "expected path not found, got %v", err)
+		assert.Equal(t, "dog.txt", notFound.Name)
+	})
+
+	t.Run("fails on a path to a directory", func(t *testing.T) {
+		f := directoryPathFixture
+		f.segments = []string{"images"}
+		_, err := readDAG(t, f)
+		assert.ErrorIs(t, err, errIsDirectory)
+	})
+
+	t.Run("fails on a truncated car", func(t *testing.T) {
+		car := readFixture(t, chunkedFileFixture)
+		_, err := io.ReadAll(mustDAGReader(t, bytes.NewReader(car[:len(car)-5]), chunkedFileFixture))
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func readFixture(t *testing.T, f carFixture) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", f.file))
+	require.NoError(t, err)
+	return b
+}
+
+// readDAG reads the fixture's content, returning the first error from either resolving its path or reading its blocks
+func readDAG(t *testing.T, f carFixture) (string, error) {
+	t.Helper()
+	root, err := cid.Decode(f.root)
+	require.NoError(t, err)
+	d, err := newDAGReader(bytes.NewReader(readFixture(t, f)), root, f.segments)
+	if err != nil {
+		return "", err
+	}
+	content, err := io.ReadAll(d)
+	return string(content), err
+}
+
+func mustDAGReader(t *testing.T, r io.Reader, f carFixture) *dagReader {
+	t.Helper()
+	root, err := cid.Decode(f.root)
+	require.NoError(t, err)
+	d, err := newDAGReader(r, root, f.segments)
+	require.NoError(t, err)
+	return d
+}
+
+type testBlock struct {
+	cid  cid.Cid
+	data []byte
+}
+
+func rawBlock(data []byte) testBlock {
+	c, err := cid.V1Builder{Codec: cid.Raw, MhType: multihash.SHA2_256}.Sum(data)
+	if err != nil {
+		panic(err)
+	}
+	return testBlock{cid: c, data: data}
+}
+
+type testLink struct {
+	name  string
+	block testBlock
+}
+
+// fileBlock returns a UnixFS file node with the data inline and the blocks as its children
+func fileBlock(data []byte, children ...testBlock) testBlock {
+	links := make([]testLink, len(children))
+	for i, c := range children {
+		links[i] = testLink{block: c}
+	}
+	return pbBlock(1, unixfsFile, data, links)
+}
+
+// dirBlock returns a UnixFS directory node. Links are sorted by name as they are in directories made by IPFS.
+func dirBlock(entries map[string]testBlock) testBlock {
+	var links []testLink
+	for name, b := range entries {
+		links = append(links, testLink{name: name, block: b})
+	}
+	for i := 1; i < len(links); i++ {
+		for j := i; j > 0 && links[j].name < links[j-1].name; j-- {
+			links[j], links[j-1] = links[j-1], links[j]
+		}
+	}
+	return pbBlock(1, unixfsDirectory, nil, links)
+}
+
+// pbBlock encodes a dag-pb node with UnixFS data as version 0 or version 1 CID
+func pbBlock(version uint64, fsType uint64, data []byte, links []testLink) testBlock {
+	var fs []byte
+	fs = protowire.AppendTag(fs, 1, protowire.VarintType)
+	fs = protowire.AppendVarint(fs, fsType)
+	if data != nil {
+		fs = protowire.AppendTag(fs, 2, protowire.BytesType)
+		fs = protowire.AppendBytes(fs, data)
+	}
+	if fsType == unixfsFile {
+		size := uint64(len(data))
+		for _, l := range links {
+			size += uint64(len(l.block.data))
+		}
+		fs = protowire.AppendTag(fs, 3, protowire.VarintType)
+		fs = protowire.AppendVarint(fs, size)
+		for _, l := range links {
+			fs = protowire.AppendTag(fs, 4, protowire.VarintType)
+			fs = protowire.AppendVarint(fs, uint64(len(l.block.data)))
+		}
+	}
+
+	// Links are encoded before the data in canonical dag-pb
+	var node []byte
+	for _, l := range links {
+		var link []byte
+		link = protowire.AppendTag(link, 1, protowire.BytesType)
+		link = protowire.AppendBytes(link, l.block.cid.Bytes())
+		link = protowire.AppendTag(link, 2, protowire.BytesType)
+		link = protowire.AppendBytes(link, []byte(l.name))
+		link = protowire.AppendTag(link, 3, protowire.VarintType)
+		link = protowire.AppendVarint(link, uint64(len(l.block.data)))
+		node = protowire.AppendTag(node, 2, protowire.BytesType)
+		node = protowire.AppendBytes(node, link)
+	}
+	node = protowire.AppendTag(node, 1, protowire.BytesType)
+	node = protowire.AppendBytes(node, fs)
+
+	var (
+		c   cid.Cid
+		err error
+	)
+	if version == 0 {
+		c, err = cid.V0Builder{}.Sum(node)
+	} else {
+		c, err = cid.V1Builder{Codec: cid.DagProtobuf, MhType: multihash.SHA2_256}.Sum(node)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return testBlock{cid: c, data: node}
+}
+
+// writeCAR encodes a CARv1 with the blocks in the order given. https://ipld.io/specs/transport/car/carv1/
+func writeCAR(root cid.Cid, blocks ...testBlock) []byte {
+	rootBytes := append([]byte{0}, root.Bytes()...)
+
+	// The header is the dag-cbor map {"roots": [root], "version": 1}
+	header := []byte{0xa2, 0x65}
+	header = append(header, "roots"...)
+	header = append(header, 0x81, 0xd8, 0x2a, 0x58, byte(len(rootBytes)))
+	header = append(header, rootBytes...)
+	header = append(header, 0x67)
+	header = append(header, "version"...)
+	header = append(header, 0x01)
+
+	var car []byte
+	car = binary.AppendUvarint(car, uint64(len(header)))
+	car = append(car, header...)
+	for _, b := range blocks {
+		c := b.cid.Bytes()
+		car = binary.AppendUvarint(car, uint64(len(c)+len(b.data)))
+		car = append(car, c...)
+		car = append(car, b.data...)
+	}
+	return car
+}
@@ -0,0 +1,338 @@
+package ipfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-ipfs-api"
+	"github.com/mikeydub/go-gallery/env"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+// carAccept asks a gateway for a verifiable response with the blocks in the order that they're read and with repeated blocks included.
+// https://specs.ipfs.tech/http-gateways/trustless-gateway/
+const carAccept = "application/vnd.ipld.car; version=1; order=dfs; dups=y"
+
+// Fetcher reads IPFS content by racing a set of gateways and the IPFS node against each other. Gateway responses are
+// requested as CARs and verified against the CID, so a gateway can't serve content other than what was asked for.
+type Fetcher struct {
+	gateways []HTTPReader
+	shell    *IPFSReader
+	stats    *GatewayStats
+}
+
+// NewFetcher returns a Fetcher that races the gateway hosts and the IPFS node. shell may be nil to only use gateways.
+func NewFetcher(httpClient *http.Client, sh *shell.Shell, gatewayHosts ...string) *Fetcher {
+	f := &Fetcher{stats: newGatewayStats()}
+	for _, host := range gatewayHosts {
+		f.gateways = append(f.gateways, HTTPReader{Host: strings.TrimSuffix(host, "/"), Client: httpClient})
+	}
+	if sh != nil {
+		f.shell = &IPFSReader{Client: sh}
+	}
+	return f
+}
+
+var (
+	defaultFetcher     *Fetcher
+	defaultFetcherOnce sync.Once
+)
+
+// DefaultFetcher returns the Fetcher used by GetResponse. The gateways are read from IPFS_GATEWAYS as a comma-separated
+// list of hosts, or default to the gateways that were used before gateways could be configured.
+func DefaultFetcher() *Fetcher {
+	defaultFetcherOnce.Do(func() {
+		var hosts []string
+		for _, host := range strings.Split(env.GetString("IPFS_GATEWAYS"), ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				hosts = append(hosts, host)
+			}
+		}
+		if len(hosts) == 0 {
+			httpClient := defaultHTTPClient()
+			for _, node := range []func(*http.Client, *shell.Shell) HTTPReader{nodeGallery, nodeIpfsIO, nodePinata, nodeNftStorage, nodeCloudFlare} {
+				hosts = append(hosts, node(httpClient, nil).Host)
+			}
+		}
+		defaultFetcher = NewFetcher(defaultHTTPClient(), NewShell(), hosts...)
+	})
+	return defaultFetcher
+}
+
+// Stats returns the success rates of each gateway, sorted by host
+func (f *Fetcher) Stats() []GatewayStat {
+	return f.stats.snapshot()
+}
+
+// Fetch returns the content at the IPFS path from whichever source returns a valid response first. Paths that don't start
+// with a CID can't be verified, so they're read from the gateways as is.
+func (f *Fetcher) Fetch(ctx context.Context, path string) (io.ReadCloser, error) {
+	root, segments, err := parsePath(path)
+	if err != nil {
+		return f.race(ctx, path, func(ctx context.Context, g HTTPReader) (io.ReadCloser, error) {
+			return g.do(ctx, path)
+		})
+	}
+	return f.race(ctx, path, func(ctx context.Context, g HTTPReader) (io.ReadCloser, error) {
+		return g.getVerified(ctx, root, segments)
+	})
+}
+
+// race starts a request to every source and returns the first response. The remaining requests are canceled, and any
+// responses that arrive afterwards are closed.
+func (f *Fetcher) race(ctx context.Context, path string, fromGateway func(context.Context, HTTPReader) (io.ReadCloser, error)) (io.ReadCloser, error) {
+	type result struct {
+		i      int
+		source string
+		body   io.ReadCloser
+		err    error
+	}
+
+	var runs []func(context.Context) (string, io.ReadCloser, error)
+	for _, g := range f.gateways {
+		g := g
+		runs = append(runs, func(ctx context.Context) (string, io.ReadCloser, error) {
+			start := time.Now()
+			body, err := fromGateway(ctx, g)
+			if !errors.Is(err, context.Canceled) {
+				persist.RecordStepAttempt(ctx, g.Host, time.Since(start), err)
+			}
+			return g.Host, body, err
+		})
+	}
+	if f.shell != nil {
+		// The IPFS node verifies the blocks that it fetches, so it's trusted as is
+		runs = append(runs, func(ctx context.Context) (string, io.ReadCloser, error) {
+			body, err := f.shell.Do(ctx, path)
+			return "ipfs-api", body, err
+		})
+	}
+
+	if len(runs) == 0 {
+		return nil, errors.New("no ipfs sources are configured")
+	}
+
+	results := make(chan result, len(runs))
+	cancels := make([]context.CancelFunc, len(runs))
+	for i, run := range runs {
+		runCtx, cancel := context.WithCancel(ctx)
+		cancels[i] = cancel
+		i, run := i, run
+		go func() {
+			source, body, err := run(runCtx)
+			results <- result{i: i, source: source, body: body, err: err}
+		}()
+	}
+
+	var errs util.MultiErr
+	for remaining := len(runs); remaining > 0; remaining-- {
+		r := <-results
+		if r.err != nil {
+			cancels[r.i]()
+			f.stats.recordFailure(r.source, r.err)
+			errs = append(errs, fmt.Errorf("%s: %w", r.source, r.err))
+			continue
+		}
+
+		// Cancel the requests that are still in flight and close any responses that come back anyway. The winner's
+		// request is canceled when its body is closed.
+		for i, cancel := range cancels {
+			if i != r.i {
+				cancel()
+			}
+		}
+		go func(remaining int) {
+			for ; remaining > 0; remaining-- {
+				if late := <-results; late.err == nil {
+					late.body.Close()
+				}
+			}
+		}(remaining - 1)
+
+		return &sourceReadCloser{ReadCloser: r.body, source: r.source, stats: f.stats, cancel: cancels[r.i]}, nil
+	}
+
+	return nil, errs
+}
+
+// sourceReadCloser records the outcome of a source once its content has been read. A source can fail after it has
+// started responding, e.g. when a block doesn't match its CID, so it's only counted as a success once the content has
+// been read to the end.
+type sourceReadCloser struct {
+	io.ReadCloser
+	source   string
+	stats    *GatewayStats
+	cancel   context.CancelFunc
+	recorded bool
+}
+
+func (s *sourceReadCloser) Read(p []byte) (int, error) {
+	n, err := s.ReadCloser.Read(p)
+	if err != nil && !s.recorded {
+		switch {
+		case errors.Is(err, io.EOF):
+			s.recorded = true
+			s.stats.recordSuccess(s.source)
+		case !errors.Is(err, context.Canceled):
+			s.recorded = true
+			s.stats.recordFailure(s.source, err)
+		}
+	}
+	return n, err
+}
+
+func (s *sourceReadCloser) Close() error {
+	defer s.cancel()
+	return s.ReadCloser.Close()
+}
+
+// getVerified requests the file at the path as a CAR and returns a reader that verifies each block as it's read
+func (r HTTPReader) getVerified(ctx context.Context, root cid.Cid, segments []string) (io.ReadCloser, error) {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = url.PathEscape(s)
+	}
+	u := fmt.Sprintf("%s/ipfs/%s", r.Host, strings.Join(append([]string{root.String()}, escaped...), "/"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+"?dag-scope=entity", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", carAccept)
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if isInfura(u) && resp.StatusCode == http.StatusTooManyRequests {
+		resp.Body.Close()
+		return nil, ErrInfuraQuotaExceeded{Err: util.ErrHTTP{Status: resp.StatusCode, URL: u}}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, util.ErrHTTP{Status: resp.StatusCode, URL: u}
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "application/vnd.ipld.car" {
+		resp.Body.Close()
+		return nil, errNotTrustlessFormat
+	}
+
+	dag, err := newDAGReader(resp.Body, root, segments)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{dag, resp.Body}, nil
+}
+
+// parsePath splits an IPFS path of the form <cid>/<path>?<params> into its root CID and path segments
+func parsePath(path string) (cid.Cid, []string, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "/")
+	path = strings.TrimPrefix(path, "ipfs/")
+	if i := strings.IndexAny(path, "?#"); i != -1 {
+		path = path[:i]
+	}
+
+	parts := strings.Split(path, "/")
+
+	root, err := cid.Decode(parts[0])
+	if err != nil {
+		return cid.Undef, nil, err
+	}
+
+	var segments []string
+	for _, p := range parts[1:] {
+		if p == "" {
+			continue
+		}
+		s, err := url.PathUnescape(p)
+		if err != nil {
+			return cid.Undef, nil, err
+		}
+		segments = append(segments, s)
+	}
+
+	return root, segments, nil
+}
+
+// GatewayStat is how often a source returned valid content
+type GatewayStat struct {
+	Source string
+	// Successes is the number of times the content the source returned was read to the end
+	Successes int64
+	// Failures is the number of times the source errored, including when its content didn't match the CID
+	Failures int64
+	// Mismatches is the number of times the source served content that didn't match the CID
+	Mismatches int64
+}
+
+// SuccessRate is the fraction of completed requests that were successful
+func (s GatewayStat) SuccessRate() float64 {
+	total := s.Successes + s.Failures
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Successes) / float64(total)
+}
+
+// GatewayStats tracks the success rates of the sources of a Fetcher
+type GatewayStats struct {
+	mu    sync.Mutex
+	stats map[string]*GatewayStat
+}
+
+func newGatewayStats() *GatewayStats {
+	return &GatewayStats{stats: make(map[string]*GatewayStat)}
+}
+
+func (g *GatewayStats) get(source string) *GatewayStat {
+	s, ok := g.stats[source]
+	if !ok {
+		s = &GatewayStat{Source: source}
+		g.stats[source] = s
+	}
+	return s
+}
+
+func (g *GatewayStats) recordSuccess(source string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.get(source).Successes++
+}
+
+func (g *GatewayStats) recordFailure(source string, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := g.get(source)
+	s.Failures++
+	var mismatch ErrCIDMismatch
+	if errors.As(err, &mismatch) {
+		s.Mismatches++
+	}
+}
+
+func (g *GatewayStats) snapshot() []GatewayStat {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	stats := make([]GatewayStat, 0, len(g.stats))
+	for _, s := range g.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Source < stats[j].Source })
+	return stats
+}
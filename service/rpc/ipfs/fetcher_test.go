@@ -0,0 +1,122 @@
+package ipfs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// carGateway serves a fixture as a trustless gateway would
+func carGateway(t *testing.T, f carFixture) *httptest.Server {
+	car := readFixture(t, f)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.ipld.car; version=1")
+		w.Write(car)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestFetcherFetch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads verified content from a gateway", func(t *testing.T) {
+		g := carGateway(t, directoryPathFixture)
+		f := NewFetcher(g.Client(), nil, g.URL)
+
+		body, err := f.Fetch(ctx, "/ipfs/"+directoryPathFixture.root+"/images/cat.txt")
+		require.NoError(t, err)
+		defer body.Close()
+
+		content, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "a cat", string(content))
+		assert.Equal(t, []GatewayStat{{Source: g.URL, Successes: 1}}, f.Stats())
+	})
+
+	t.Run("prefers a gateway with valid content over one that serves the wrong content", func(t *testing.T) {
+		bad := carGateway(t, cidMismatchFixture)
+		good := carGateway(t, rawLeafFixture)
+		f := NewFetcher(http.DefaultClient, nil, bad.URL, good.URL)
+
+		body, err := f.Fetch(ctx, rawLeafFixture.root)
+		require.NoError(t, err)
+		defer body.Close()
+
+		content, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(content))
+	})
+
+	t.Run("records content that doesn't match the cid as a mismatch", func(t *testing.T) {
+		bad := carGateway(t, cidMismatchFixture)
+		f := NewFetcher(bad.Client(), nil, bad.URL)
+
+		_, err := f.Fetch(ctx, cidMismatchFixture.root)
+		require.Error(t, err)
+		assert.Equal(t, []GatewayStat{{Source: bad.URL, Failures: 1, Mismatches: 1}}, f.Stats())
+	})
+
+	t.Run("records blocks that fail while reading", func(t *testing.T) {
+		g := carGateway(t, outOfOrderFixture)
+		f := NewFetcher(g.Client(), nil, g.URL)
+
+		body, err := f.Fetch(ctx, outOfOrderFixture.root)
+		require.NoError(t, err)
+		defer body.Close()
+
+		_, err = io.ReadAll(body)
+		var unexpected ErrUnexpectedBlock
+		assert.ErrorAs(t, err, &unexpected)
+		assert.Equal(t, []GatewayStat{{Source: g.URL, Failures: 1}}, f.Stats())
+	})
+
+	t.Run("records a gateway that stops responding partway through as a failure", func(t *testing.T) {
+		car := readFixture(t, chunkedFileFixture)
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/vnd.ipld.car; version=1")
+			w.Header().Set("Content-Length", strconv.Itoa(len(car)))
+			w.Write(car[:len(car)-4])
+		}))
+		defer s.Close()
+		f := NewFetcher(s.Client(), nil, s.URL)
+
+		body, err := f.Fetch(ctx, chunkedFileFixture.root)
+		require.NoError(t, err)
+		defer body.Close()
+		assert.Empty(t, f.Stats())
+
+		_, err = io.ReadAll(body)
+		assert.Error(t, err)
+		assert.Equal(t, []GatewayStat{{Source: s.URL, Failures: 1}}, f.Stats())
+	})
+
+	t.Run("rejects responses that aren't cars", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, carAccept, r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("hello world"))
+		}))
+		defer s.Close()
+		f := NewFetcher(s.Client(), nil, s.URL)
+
+		_, err := f.Fetch(ctx, rawLeafFixture.root)
+		assert.ErrorContains(t, err, errNotTrustlessFormat.Error())
+	})
+}
+
+func TestParsePath(t *testing.T) {
+	root, segments, err := parsePath("/ipfs/" + directoryPathFixture.root + "/images/cat%20photo.txt?filename=x")
+	require.NoError(t, err)
+	assert.Equal(t, directoryPathFixture.root, root.String())
+	assert.Equal(t, []string{"images", "cat photo.txt"}, segments)
+
+	_, _, err = parsePath("not-a-cid/file.txt")
+	assert.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ipfs/go-ipfs-api"
 	"github.com/mikeydub/go-gallery/env"
+	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/tracing"
 	"github.com/mikeydub/go-gallery/util"
 )
@@ -36,6 +37,13 @@ type HTTPReader struct {
 }
 
 func (r HTTPReader) Do(ctx context.Context, path string) (io.ReadCloser, error) {
+	start := time.Now()
+	body, err := r.do(ctx, path)
+	persist.RecordStepAttempt(ctx, r.Host, time.Since(start), err)
+	return body, err
+}
+
+func (r HTTPReader) do(ctx context.Context, path string) (io.ReadCloser, error) {
 	path = pathURL(r.Host, path)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -77,11 +85,16 @@ type IPFSReader struct {
 }
 
 func (r IPFSReader) Do(ctx context.Context, path string) (io.ReadCloser, error) {
+	start := time.Now()
 	reader, err := r.Client.Cat(path)
 	if err != nil && isInfura(path) && strings.Contains(err.Error(), "transfer quota reached") {
-		return nil, ErrInfuraQuotaExceeded{Err: err}
+		err = ErrInfuraQuotaExceeded{Err: err}
+	}
+	persist.RecordStepAttempt(ctx, "ipfs-api", time.Since(start), err)
+	if err != nil {
+		return nil, err
 	}
-	return reader, err
+	return reader, nil
 }
 
 // NewShell returns an IPFS shell with default configuration
@@ -115,29 +128,9 @@ var (
 	}
 )
 
+// GetResponse returns the content at the IPFS path, verified against its CID when the path starts with one
 func GetResponse(ctx context.Context, path string) (io.ReadCloser, error) {
-	httpClient := defaultHTTPClient()
-	ipfsClient := NewShell()
-	return util.FirstNonErrorWithValue(ctx, false, nil,
-		func(ctx context.Context) (io.ReadCloser, error) {
-			return nodeGallery(httpClient, ipfsClient).Do(ctx, path)
-		},
-		func(ctx context.Context) (io.ReadCloser, error) {
-			return nodeIPFS(httpClient, ipfsClient).Do(ctx, path)
-		},
-		func(ctx context.Context) (io.ReadCloser, error) {
-			return nodeIpfsIO(httpClient, ipfsClient).Do(ctx, path)
-		},
-		func(ctx context.Context) (io.ReadCloser, error) {
-			return nodePinata(httpClient, ipfsClient).Do(ctx, path)
-		},
-		func(ctx context.Context) (io.ReadCloser, error) {
-			return nodeNftStorage(httpClient, ipfsClient).Do(ctx, path)
-		},
-		func(ctx context.Context) (io.ReadCloser, error) {
-			return nodeCloudFlare(httpClient, ipfsClient).Do(ctx, path)
-		},
-	)
+	return DefaultFetcher().Fetch(ctx, path)
 }
 
 func GetHeader(ctx context.Context, path string) (http.Header, error) {
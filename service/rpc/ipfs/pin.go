@@ -0,0 +1,350 @@
+package ipfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ipfs/go-ipfs-api"
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/env"
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/persist/postgres"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+const (
+	pinStatusPinned   = "pinned"
+	pinStatusUnpinned = "unpinned"
+	pinStatusFailed   = "failed"
+)
+
+// Pinner pins content so that it stays available after every other node stops providing it
+type Pinner interface {
+	// Pin requests that the CID is pinned. It returns an identifier of the request if the pinner keeps track of pins by request.
+	Pin(ctx context.Context, cid string) (string, error)
+	// Unpin removes the pin of the CID. requestID is the identifier that was returned by Pin, if any.
+	Unpin(ctx context.Context, cid string, requestID string) error
+}
+
+// NewPinner returns a Pinner for the remote pinning service at IPFS_PINNING_SERVICE_URL if it's set, otherwise
+// content is pinned to the IPFS node
+func NewPinner(httpClient *http.Client) Pinner {
+	if endpoint := env.GetString("IPFS_PINNING_SERVICE_URL"); endpoint != "" {
+		return NewRemotePinner(endpoint, env.GetString("IPFS_PINNING_SERVICE_TOKEN"), httpClient)
+	}
+	return NewKuboPinner(NewShell())
+}
+
+// KuboPinner pins content to a Kubo node
+type KuboPinner struct {
+	shell *shell.Shell
+}
+
+func NewKuboPinner(sh *shell.Shell) *KuboPinner {
+	return &KuboPinner{shell: sh}
+}
+
+func (k *KuboPinner) Pin(ctx context.Context, cid string) (string, error) {
+	return "", k.shell.Request("pin/add", cid).Option("recursive", true).Exec(ctx, nil)
+}
+
+func (k *KuboPinner) Unpin(ctx context.Context, cid string, requestID string) error {
+	err := k.shell.Request("pin/rm", cid).Option("recursive", true).Exec(ctx, nil)
+	if err != nil && strings.Contains(err.Error(), "not pinned") {
+		return nil
+	}
+	return err
+}
+
+// RemotePinner pins content with a service that implements the IPFS Pinning Service API. https://ipfs.github.io/pinning-services-api-spec/
+type RemotePinner struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+func NewRemotePinner(endpoint, token string, httpClient *http.Client) *RemotePinner {
+	return &RemotePinner{endpoint: strings.TrimSuffix(endpoint, "/"), token: token, httpClient: httpClient}
+}
+
+type remotePinStatus struct {
+	RequestID string `json:"requestid"`
+	Status    string `json:"status"`
+}
+
+type remotePinResults struct {
+	Count   int               `json:"count"`
+	Results []remotePinStatus `json:"results"`
+}
+
+func (r *RemotePinner) Pin(ctx context.Context, cid string) (string, error) {
+	body, err := json.Marshal(map[string]string{"cid": cid, "name": cid})
+	if err != nil {
+		return "", err
+	}
+
+	var status remotePinStatus
+	if err := r.do(ctx, http.MethodPost, r.endpoint+"/pins", bytes.NewReader(body), &status); err != nil {
+		return "", err
+	}
+
+	if status.Status == pinStatusFailed {
+		return status.RequestID, fmt.Errorf("pinning service failed to pin %s", cid)
+	}
+
+	return status.RequestID, nil
+}
+
+func (r *RemotePinner) Unpin(ctx context.Context, cid string, requestID string) error {
+	requestIDs := []string{requestID}
+
+	// Look up the pin requests for the CID if the request that pinned it isn't known
+	if requestID == "" {
+		var results remotePinResults
+		if err := r.do(ctx, http.MethodGet, r.endpoint+"/pins?cid="+url.QueryEscape(cid), nil, &results); err != nil {
+			return err
+		}
+		requestIDs = util.MapWithoutError(results.Results, func(s remotePinStatus) string { return s.RequestID })
+	}
+
+	for _, id := range requestIDs {
+		err := r.do(ctx, http.MethodDelete, r.endpoint+"/pins/"+url.PathEscape(id), nil, nil)
+		var httpErr util.ErrHTTP
+		if errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *RemotePinner) do(ctx context.Context, method, u string, body io.Reader, into any) error {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return util.ErrHTTP{URL: u, Status: resp.StatusCode}
+	}
+
+	if into == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(into)
+}
+
+// PinService keeps the media of tokens displayed in galleries pinned. Each gallery holds a reference to the CIDs of the tokens
+// in its visible collections, and a CID is unpinned once no gallery references it.
+type PinService struct {
+	repos   *postgres.Repositories
+	queries *db.Queries
+	pinner  Pinner
+}
+
+func NewPinService(repos *postgres.Repositories, queries *db.Queries, pinner Pinner) *PinService {
+	return &PinService{repos: repos, queries: queries, pinner: pinner}
+}
+
+// PinGallery replaces the gallery's references with cids, pins any that aren't pinned yet, and unpins the CIDs
+// that the gallery no longer references if no other gallery does either.
+//
+// The references are updated in a transaction that holds a lock on each CID involved, and the pinning service is called
+// once that's committed so that a slow pin doesn't hold the locks. A CID that's referenced again while it's being
+// unpinned is pinned again.
+func (p *PinService) PinGallery(ctx context.Context, galleryID persist.DBID, cids []string) error {
+	toPin, toUnpin, err := p.updateReferences(ctx, galleryID, util.Dedupe(cids, false))
+	if err != nil {
+		return err
+	}
+
+	for _, c := range toPin {
+		if err := p.pin(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	for _, pin := range toUnpin {
+		if err := p.unpin(ctx, pin); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateReferences replaces the gallery's references with cids. It returns the CIDs that need to be pinned and the pins
+// that aren't referenced by any gallery anymore.
+func (p *PinService) updateReferences(ctx context.Context, galleryID persist.DBID, cids []string) ([]string, []db.IpfsPin, error) {
+	tx, err := p.repos.BeginTx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := p.queries.WithTx(tx)
+
+	if err := q.LockGalleryPinReferences(ctx, galleryID.String()); err != nil {
+		return nil, nil, err
+	}
+
+	err = q.LockIPFSPinsForGallery(ctx, db.LockIPFSPinsForGalleryParams{
+		Cids:      cids,
+		GalleryID: galleryID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	removed, err := q.DeleteGalleryPinReferencesExcept(ctx, db.DeleteGalleryPinReferencesExceptParams{
+		GalleryID: galleryID,
+		Cids:      cids,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var toPin []string
+	if len(cids) > 0 {
+		err = q.InsertGalleryPinReferences(ctx, db.InsertGalleryPinReferencesParams{
+			GalleryID: galleryID,
+			Cids:      cids,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pins, err := q.GetIPFSPinsByCIDs(ctx, cids)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pinned := make(map[string]bool, len(pins))
+		for _, pin := range pins {
+			pinned[pin.Cid] = pin.Status == pinStatusPinned
+		}
+
+		for _, c := range cids {
+			if !pinned[c] {
+				toPin = append(toPin, c)
+			}
+		}
+	}
+
+	var toUnpin []db.IpfsPin
+	if len(removed) > 0 {
+		toUnpin, err = q.GetUnreferencedIPFSPinsByCIDs(ctx, removed)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return toPin, toUnpin, tx.Commit(ctx)
+}
+
+// pin pins the CID and records whether it was pinned
+func (p *PinService) pin(ctx context.Context, c string) error {
+	params := db.UpsertIPFSPinParams{ID: persist.GenerateID(), Cid: c, Status: pinStatusPinned}
+
+	requestID, err := p.pinner.Pin(ctx, c)
+	params.RemoteRequest = util.ToNullStringEmptyNull(requestID)
+	if err != nil {
+		// Keep going so that one unavailable CID doesn't keep the rest of the gallery from being pinned
+		logger.For(ctx).Warnf("failed to pin %s: %s", c, err)
+		params.Status = pinStatusFailed
+		params.LastError = util.ToNullStringEmptyNull(err.Error())
+	}
+
+	return p.queries.UpsertIPFSPin(ctx, params)
+}
+
+// unpin unpins the CID and records it as unpinned, or pins it again if a gallery referenced it in the meantime
+func (p *PinService) unpin(ctx context.Context, pin db.IpfsPin) error {
+	if err := p.pinner.Unpin(ctx, pin.Cid, pin.RemoteRequest.String); err != nil {
+		logger.For(ctx).Warnf("failed to unpin %s: %s", pin.Cid, err)
+		return p.queries.UpsertIPFSPin(ctx, db.UpsertIPFSPinParams{
+			ID:            pin.ID,
+			Cid:           pin.Cid,
+			Status:        pin.Status,
+			RemoteRequest: pin.RemoteRequest,
+			LastError:     util.ToNullStringEmptyNull(err.Error()),
+		})
+	}
+
+	unreferenced, err := p.recordUnpinned(ctx, pin)
+	if err != nil {
+		return err
+	}
+
+	if !unreferenced {
+		return p.pin(ctx, pin.Cid)
+	}
+
+	return nil
+}
+
+// recordUnpinned records the pin as unpinned if it's still unreferenced, and returns whether it was
+func (p *PinService) recordUnpinned(ctx context.Context, pin db.IpfsPin) (bool, error) {
+	tx, err := p.repos.BeginTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := p.queries.WithTx(tx)
+
+	if err := q.LockIPFSPins(ctx, []string{pin.Cid}); err != nil {
+		return false, err
+	}
+
+	referenced, err := q.IsIPFSPinReferenced(ctx, pin.Cid)
+	if err != nil {
+		return false, err
+	}
+
+	if referenced {
+		return false, nil
+	}
+
+	err = q.UpsertIPFSPin(ctx, db.UpsertIPFSPinParams{ID: pin.ID, Cid: pin.Cid, Status: pinStatusUnpinned})
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// CIDFromURL returns the root CID of an IPFS URL, or false if the URL isn't an IPFS URL
+func CIDFromURL(u string) (string, bool) {
+	if !IsIpfsURL(u) {
+		return "", false
+	}
+	root, _, err := parsePath(uriFrom(u))
+	if err != nil {
+		return "", false
+	}
+	return root.String(), true
+}
@@ -5,9 +5,9 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"image"
 	"image/gif"
 	"image/jpeg"
 	"image/png"
@@ -24,8 +24,11 @@ import (
 	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/everFinance/goar"
@@ -82,13 +85,36 @@ func (e ErrTokenURINotFound) Error() string {
 
 // NewEthClient returns an ethclient.Client
 func NewEthClient() *ethclient.Client {
+	return NewEthClientWithEndpoint(env.GetString("RPC_URL"))
+}
+
+// NewEVMClients returns a client for each EVM chain. Ethereum uses the default RPC endpoint, and other chains
+// are only included if their endpoint is configured.
+func NewEVMClients(ethClient *ethclient.Client) map[persist.Chain]*ethclient.Client {
+	clients := map[persist.Chain]*ethclient.Client{persist.ChainETH: ethClient}
+	for chain, key := range map[persist.Chain]string{
+		persist.ChainArbitrum: "ARBITRUM_RPC_URL",
+		persist.ChainBase:     "BASE_RPC_URL",
+		persist.ChainOptimism: "OPTIMISM_RPC_URL",
+		persist.ChainPolygon:  "POLYGON_RPC_URL",
+		persist.ChainZora:     "ZORA_RPC_URL",
+	} {
+		if endpoint := env.GetString(key); endpoint != "" {
+			clients[chain] = NewEthClientWithEndpoint(endpoint)
+		}
+	}
+	return clients
+}
+
+// NewEthClientWithEndpoint returns an ethclient.Client for the RPC endpoint of an EVM chain
+func NewEthClientWithEndpoint(endpoint string) *ethclient.Client {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	var client *rpc.Client
 	var err error
 
-	if endpoint := env.GetString("RPC_URL"); strings.HasPrefix(endpoint, "https://") {
+	if strings.HasPrefix(endpoint, "https://") {
 		client, err = rpc.DialHTTPWithClient(endpoint, defaultHTTPClient)
 		if err != nil {
 			panic(err)
@@ -210,99 +236,23 @@ func GetDataFromURIAsReader(ctx context.Context, turi persist.TokenURI, mediaTyp
 		logger.For(ctx).Infof("Getting data from URI: %s -timeout: %s -type: %s", turi.String(), time.Until(d), turi.Type())
 		asString := turi.String()
 
-		switch turi.Type() {
-		case persist.URITypeBase64JSON, persist.URITypeBase64SVG, persist.URITypeBase64HTML, persist.URITypeBase64WAV, persist.URITypeBase64MP3:
-			// decode the base64 encoded json
-			b64data := asString[strings.IndexByte(asString, ',')+1:]
-			decoded, err := util.Base64Decode(b64data, base64.RawStdEncoding, base64.StdEncoding, base64.RawURLEncoding, base64.URLEncoding)
+		if util.IsDataURI(asString) {
+			data, err := decodeDataURI(asString)
 			if err != nil {
-				errChan <- fmt.Errorf("error decoding base64 data: %s \n\n%s", err, b64data)
+				errChan <- err
 				return
 			}
+			readerChan <- util.NewFileHeaderReader(bytes.NewReader(data), bufSize)
+			return
+		}
 
-			buf := bytes.NewBuffer(util.RemoveBOM(decoded))
-
-			readerChan <- util.NewFileHeaderReader(buf, bufSize)
-		case persist.URITypeBase64BMP:
-			b64data := asString[strings.IndexByte(asString, ',')+1:]
-			decoded, err := util.Base64Decode(b64data, base64.RawStdEncoding, base64.StdEncoding, base64.RawURLEncoding, base64.URLEncoding)
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding base64 bmp data: %s \n\n%s", err, b64data)
-				return
-			}
-			img, err := bmp.Decode(bytes.NewReader(decoded))
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding bmp data: %s \n\n%s", err, b64data)
-				return
-			}
-			newImage := bytes.NewBuffer(nil)
-			err = jpeg.Encode(newImage, img, nil)
-			if err != nil {
-				errChan <- fmt.Errorf("error encoding jpeg data: %s \n\n%s", err, b64data)
-				return
-			}
-			readerChan <- util.NewFileHeaderReader(newImage, bufSize)
-		case persist.URITypeBase64PNG:
-			b64data := asString[strings.IndexByte(asString, ',')+1:]
-			decoded, err := util.Base64Decode(b64data, base64.RawStdEncoding, base64.StdEncoding, base64.RawURLEncoding, base64.URLEncoding)
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding base64 png data: %s \n\n%s", err, b64data)
-				return
-			}
-			img, err := png.Decode(bytes.NewReader(decoded))
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding png data: %s \n\n%s", err, b64data)
-				return
-			}
-			newImage := bytes.NewBuffer(nil)
-			err = png.Encode(newImage, img)
-			if err != nil {
-				errChan <- fmt.Errorf("error encoding png data: %s \n\n%s", err, b64data)
-				return
-			}
-			readerChan <- util.NewFileHeaderReader(newImage, bufSize)
-		case persist.URITypeBase64JPEG:
-			b64data := asString[strings.IndexByte(asString, ',')+1:]
-			decoded, err := util.Base64Decode(b64data, base64.RawStdEncoding, base64.StdEncoding, base64.RawURLEncoding, base64.URLEncoding)
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding base64 jpeg data: %s \n\n%s", err, b64data)
-				return
-			}
-			img, err := jpeg.Decode(bytes.NewReader(decoded))
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding jpeg data: %s \n\n%s", err, b64data)
-				return
-			}
-			newImage := bytes.NewBuffer(nil)
-			err = jpeg.Encode(newImage, img, nil)
-			if err != nil {
-				errChan <- fmt.Errorf("error encoding jpeg data: %s \n\n%s", err, b64data)
-				return
-			}
-			readerChan <- util.NewFileHeaderReader(newImage, bufSize)
-		case persist.URITypeBase64GIF:
-			b64data := asString[strings.IndexByte(asString, ',')+1:]
-			decoded, err := util.Base64Decode(b64data, base64.RawStdEncoding, base64.StdEncoding, base64.RawURLEncoding, base64.URLEncoding)
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding base64 gif data: %s \n\n%s", err, b64data)
-				return
-			}
-			img, err := gif.Decode(bytes.NewReader(decoded))
-			if err != nil {
-				errChan <- fmt.Errorf("error decoding gif data: %s \n\n%s", err, b64data)
-				return
-			}
-			newGif := bytes.NewBuffer(nil)
-			err = gif.Encode(newGif, img, nil)
-			if err != nil {
-				errChan <- fmt.Errorf("error encoding gif data: %s \n\n%s", err, b64data)
-				return
-			}
-			readerChan <- util.NewFileHeaderReader(newGif, bufSize)
+		switch turi.Type() {
 		case persist.URITypeArweave, persist.URITypeArweaveGateway:
 			path := util.GetURIPath(asString, true)
 
+			start := time.Now()
 			resp, err := GetArweaveDataHTTPReader(ctx, path)
+			persist.RecordStepAttempt(ctx, "arweave", time.Since(start), err)
 			if err != nil {
 				errChan <- err
 				return
@@ -334,7 +284,13 @@ func GetDataFromURIAsReader(ctx context.Context, turi persist.TokenURI, mediaTyp
 				errChan <- fmt.Errorf("error creating request: %s", err)
 				return
 			}
+			start := time.Now()
 			resp, err := defaultHTTPClient.Do(req)
+			if err == nil && (resp.StatusCode > 399 || resp.StatusCode < 200) {
+				persist.RecordStepAttempt(ctx, req.URL.Host, time.Since(start), util.ErrHTTP{Status: resp.StatusCode, URL: asString})
+			} else {
+				persist.RecordStepAttempt(ctx, req.URL.Host, time.Since(start), err)
+			}
 			if err != nil {
 				if dnsErr, ok := err.(*net.DNSError); ok {
 					errChan <- dnsErr
@@ -376,14 +332,6 @@ func GetDataFromURIAsReader(ctx context.Context, turi persist.TokenURI, mediaTyp
 					asString = escaped
 				}
 			}
-			if strings.HasPrefix(asString, "data:") {
-				idx := strings.IndexByte(asString, ',')
-				if idx != -1 {
-					buf := bytes.NewBuffer(util.RemoveBOM([]byte(asString[idx+1:])))
-					readerChan <- util.NewFileHeaderReader(buf, bufSize)
-					return
-				}
-			}
 			buf := bytes.NewBuffer(util.RemoveBOM([]byte(asString)))
 			readerChan <- util.NewFileHeaderReader(buf, bufSize)
 		default:
@@ -420,6 +368,51 @@ func GetDataFromURIAsReader(ctx context.Context, turi persist.TokenURI, mediaTyp
 	}
 }
 
+// decodeDataURI decodes a data URI returned by a contract. BMPs are converted to JPEGs since they aren't widely supported,
+// and other images are re-encoded to make sure that they're valid.
+func decodeDataURI(uri string) ([]byte, error) {
+	decoded, err := util.DecodeDataURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		img     image.Image
+		encoded = bytes.NewBuffer(nil)
+	)
+
+	switch decoded.MediaType {
+	case "image/bmp":
+		if img, err = bmp.Decode(bytes.NewReader(decoded.Data)); err != nil {
+			return nil, fmt.Errorf("error decoding bmp data: %s", err)
+		}
+		err = jpeg.Encode(encoded, img, nil)
+	case "image/png":
+		if img, err = png.Decode(bytes.NewReader(decoded.Data)); err != nil {
+			return nil, fmt.Errorf("error decoding png data: %s", err)
+		}
+		err = png.Encode(encoded, img)
+	case "image/jpeg", "image/jpg":
+		if img, err = jpeg.Decode(bytes.NewReader(decoded.Data)); err != nil {
+			return nil, fmt.Errorf("error decoding jpeg data: %s", err)
+		}
+		err = jpeg.Encode(encoded, img, nil)
+	case "image/gif":
+		if img, err = gif.Decode(bytes.NewReader(decoded.Data)); err != nil {
+			return nil, fmt.Errorf("error decoding gif data: %s", err)
+		}
+		err = gif.Encode(encoded, img, nil)
+	default:
+		return decoded.Data, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s data: %s", decoded.MediaType, err)
+	}
+
+	return encoded.Bytes(), nil
+}
+
 func needsUnescape(str string) bool {
 	// Regex to match percent-encoded characters
 	re := regexp.MustCompile(`%[0-9a-fA-F]{2}`)
@@ -456,6 +449,44 @@ func GetHTTPHeaders(ctx context.Context, url string) (http.Header, error) {
 	)
 }
 
+// contractURISelector is the selector of contractURI(), which returns the URI of a contract's metadata (ERC-7572)
+var contractURISelector = crypto.Keccak256([]byte("contractURI()"))[:4]
+
+var stringResult = func() abi.Arguments {
+	t, err := abi.NewType("string", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{{Type: t}}
+}()
+
+// UnpackString decodes the result of a contract call that returns a string. string and bytes have the same encoding,
+// so a bytes result can be read as well.
+func UnpackString(out []byte) (string, error) {
+	unpacked, err := stringResult.Unpack(out)
+	if err != nil {
+		return "", err
+	}
+	return unpacked[0].(string), nil
+}
+
+// GetContractURI returns the URI of a contract's contract-level metadata (ERC-7572)
+func GetContractURI(ctx context.Context, pContractAddress persist.EthereumAddress, ethClient *ethclient.Client) (persist.TokenURI, error) {
+	contract := pContractAddress.Address()
+
+	out, err := ethClient.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: contractURISelector}, nil)
+	if err != nil {
+		return "", ErrEthClient{err}
+	}
+
+	uri, err := UnpackString(out)
+	if err != nil {
+		return "", ErrTokenURINotFound{err}
+	}
+
+	return persist.TokenURI(strings.ReplaceAll(uri, "\x00", "")), nil
+}
+
 // GetTokenURI returns metadata URI for a given token address.
 func GetTokenURI(ctx context.Context, pTokenType persist.TokenType, pContractAddress persist.EthereumAddress, pTokenID persist.HexTokenID, ethClient *ethclient.Client) (persist.TokenURI, error) {
 
@@ -0,0 +1,273 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+// DefaultThreshold is the score at and above which a contract is treated as spam when a caller doesn't choose a threshold
+const DefaultThreshold = 0.5
+
+// The signals that contribute to a contract's score
+const (
+	SignalProviderMarked = "provider_marked"
+	SignalAirdropFanOut  = "airdrop_fan_out"
+	SignalNamePattern    = "name_pattern"
+	SignalMetadataURL    = "metadata_url"
+	SignalUserReports    = "user_reports"
+	SignalUserOverrides  = "user_overrides"
+)
+
+const (
+	providerMarkedWeight = 0.6
+	airdropWeight        = 0.6
+	namePatternWeight    = 0.4
+	metadataURLWeight    = 0.35
+	userReportsWeight    = 0.8
+	userOverridesWeight  = 0.9
+
+	// airdropMinReceivers is the fewest holders that must have received the contract's tokens in the same block for it to look like an airdrop
+	airdropMinReceivers = 25
+	// minReports is the number of users that must agree before reports count fully, so that a single user can't flag a contract on their own
+	minReports = 3
+	// samplesPerContract is the number of token definitions that are checked for spam URLs
+	samplesPerContract = 20
+	// rescoreAfter is how long a score is used before the contract is scored again
+	rescoreAfter = 24 * time.Hour
+)
+
+var (
+	// spamNamePatterns are patterns of the names and symbols of spam contracts, by what they describe
+	spamNamePatterns = []struct {
+		description string
+		pattern     *regexp.Regexp
+	}{
+		{"a giveaway", regexp.MustCompile(`(?i)\b(claim|claimable|reward|rewards|airdrop|voucher|bonus|giveaway|free\s*mint)\b`)},
+		{"an instruction to visit a site", regexp.MustCompile(`(?i)\b(visit|go\s+to)\b.+\.[a-z]{2,}`)},
+		{"a dollar amount", regexp.MustCompile(`(?i)\$\s?\d[\d,.]*`)},
+		{"a domain", regexp.MustCompile(`(?i)\b[a-z0-9-]+\.(xyz|site|live|fun|gift|top|click|claims?|tk|ml)\b`)},
+		{"a link", regexp.MustCompile(`(?i)(https?://|www\.|t\.me/)`)},
+		{"a lens follower collection", regexp.MustCompile(`(?i)\.lens-follower$`)},
+	}
+	urlPattern = regexp.MustCompile(`(?i)(https?://|www\.)[^\s"'<>]+`)
+	// spamURLPattern matches the links to phishing and giveaway sites that spam tokens lead to
+	spamURLPattern = regexp.MustCompile(`(?i)(claim|reward|airdrop|voucher|bonus|giveaway|redeem|mint-?now|\.(xyz|site|live|fun|gift|top|click|tk|ml)(/|$)|bit\.ly|tinyurl|t\.me/)`)
+)
+
+// Inputs are the facts about a contract that it's scored from
+type Inputs struct {
+	Name                 string
+	Symbol               string
+	IsProviderMarkedSpam bool
+	// Holders is the number of users who hold the contract's tokens
+	Holders int
+	// MaxHoldersInBlock is the most holders that received the contract's tokens in a single block
+	MaxHoldersInBlock int
+	// SpamReports is the number of holders that marked the contract's tokens as spam
+	SpamReports int
+	// NotSpamReports is the number of holders that marked the contract's tokens as not spam
+	NotSpamReports int
+	// Texts are the names, descriptions, and external URLs of a sample of the contract's tokens
+	Texts []string
+}
+
+// Score is the likelihood that a contract is spam, from 0 to 1, and the signals that contributed to it
+type Score struct {
+	Score   float64
+	Reasons persist.SpamReasons
+}
+
+// IsSpam returns true if the score is at or above the threshold
+func (s Score) IsSpam(threshold float64) bool {
+	return s.Score >= threshold
+}
+
+// Evaluate scores a contract. Each signal that fires is independent evidence that the contract is spam, so the positive signals
+// are combined as 1 - Π(1 - weight). Users who mark the contract's tokens as not spam then discount the combined score.
+func Evaluate(in Inputs) Score {
+	var reasons persist.SpamReasons
+
+	if in.IsProviderMarkedSpam {
+		reasons = append(reasons, persist.SpamReason{
+			Signal: SignalProviderMarked,
+			Weight: providerMarkedWeight,
+			Detail: "marked as spam by a data provider",
+		})
+	}
+
+	if in.MaxHoldersInBlock >= airdropMinReceivers && in.Holders > 0 {
+		share := math.Min(1, float64(in.MaxHoldersInBlock)/float64(in.Holders))
+		reasons = append(reasons, persist.SpamReason{
+			Signal: SignalAirdropFanOut,
+			Weight: airdropWeight * share,
+			Detail: fmt.Sprintf("%d of %d holders received tokens in the same block", in.MaxHoldersInBlock, in.Holders),
+		})
+	}
+
+	if description, text, ok := matchName(in.Name, in.Symbol); ok {
+		reasons = append(reasons, persist.SpamReason{
+			Signal: SignalNamePattern,
+			Weight: namePatternWeight,
+			Detail: fmt.Sprintf("%q looks like %s", text, description),
+		})
+	}
+
+	if url, ok := matchURL(in.Texts); ok {
+		reasons = append(reasons, persist.SpamReason{
+			Signal: SignalMetadataURL,
+			Weight: metadataURLWeight,
+			Detail: fmt.Sprintf("token metadata links to %s", url),
+		})
+	}
+
+	if in.SpamReports > 0 {
+		reasons = append(reasons, persist.SpamReason{
+			Signal: SignalUserReports,
+			Weight: userReportsWeight * agreement(in.SpamReports, in.Holders),
+			Detail: fmt.Sprintf("%d of %d holders marked the contract's tokens as spam", in.SpamReports, in.Holders),
+		})
+	}
+
+	notSpam := 1.0
+	for _, r := range reasons {
+		notSpam *= 1 - r.Weight
+	}
+	score := 1 - notSpam
+
+	if in.NotSpamReports > 0 {
+		discount := userOverridesWeight * agreement(in.NotSpamReports, in.Holders)
+		reasons = append(reasons, persist.SpamReason{
+			Signal: SignalUserOverrides,
+			Weight: -discount,
+			Detail: fmt.Sprintf("%d of %d holders marked the contract's tokens as not spam", in.NotSpamReports, in.Holders),
+		})
+		score *= 1 - discount
+	}
+
+	return Score{Score: math.Max(0, math.Min(1, score)), Reasons: reasons}
+}
+
+// agreement is how much weight a number of reports carries: the share of holders that reported, limited until minReports users agree
+func agreement(reports, holders int) float64 {
+	if holders < reports {
+		holders = reports
+	}
+	return math.Min(float64(reports)/float64(holders), float64(reports)/minReports)
+}
+
+func matchName(name, symbol string) (string, string, bool) {
+	for _, text := range []string{name, symbol} {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		for _, p := range spamNamePatterns {
+			if p.pattern.MatchString(text) {
+				return p.description, text, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func matchURL(texts []string) (string, bool) {
+	for _, text := range texts {
+		for _, url := range urlPattern.FindAllString(text, -1) {
+			if spamURLPattern.MatchString(url) {
+				return url, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Scorer scores contracts and stores their scores
+type Scorer struct {
+	queries *db.Queries
+}
+
+func NewScorer(queries *db.Queries) *Scorer {
+	return &Scorer{queries: queries}
+}
+
+// ScoreContract scores a contract from its current signals and stores the score
+func (s *Scorer) ScoreContract(ctx context.Context, contractID persist.DBID) (Score, error) {
+	in, err := s.inputs(ctx, contractID)
+	if err != nil {
+		return Score{}, err
+	}
+
+	score := Evaluate(in)
+
+	err = s.queries.UpsertContractSpamScore(ctx, db.UpsertContractSpamScoreParams{
+		ContractID: contractID,
+		Score:      score.Score,
+		Reasons:    score.Reasons,
+	})
+
+	return score, err
+}
+
+// ScoreStale scores up to limit contracts that haven't been scored or whose score is out of date, returning the number scored
+func (s *Scorer) ScoreStale(ctx context.Context, limit int) (int, error) {
+	contractIDs, err := s.queries.GetContractsToScoreForSpam(ctx, db.GetContractsToScoreForSpamParams{
+		ScoredBefore: time.Now().Add(-rescoreAfter),
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var scored int
+	for _, id := range contractIDs {
+		score, err := s.ScoreContract(ctx, id)
+		if err != nil {
+			return scored, err
+		}
+		if score.IsSpam(DefaultThreshold) {
+			logger.For(ctx).Infof("contract %s scored %.2f as spam", id, score.Score)
+		}
+		scored++
+	}
+
+	return scored, nil
+}
+
+func (s *Scorer) inputs(ctx context.Context, contractID persist.DBID) (Inputs, error) {
+	signals, err := s.queries.GetContractSpamSignals(ctx, contractID)
+	if err != nil {
+		return Inputs{}, err
+	}
+
+	samples, err := s.queries.GetTokenDefinitionSpamSamplesByContractID(ctx, db.GetTokenDefinitionSpamSamplesByContractIDParams{
+		ContractID: contractID,
+		Limit:      samplesPerContract,
+	})
+	if err != nil {
+		return Inputs{}, err
+	}
+
+	texts := make([]string, 0, len(samples)*3)
+	for _, sample := range samples {
+		texts = append(texts, sample.Name.String, sample.Description.String, sample.ExternalUrl.String)
+	}
+
+	return Inputs{
+		Name:                 signals.Name.String,
+		Symbol:               signals.Symbol.String,
+		IsProviderMarkedSpam: signals.IsProviderMarkedSpam,
+		Holders:              int(signals.Holders),
+		MaxHoldersInBlock:    int(signals.MaxHoldersInBlock),
+		SpamReports:          int(signals.SpamReports),
+		NotSpamReports:       int(signals.NotSpamReports),
+		Texts:                texts,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package spam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		title   string
+		in      Inputs
+		score   float64
+		signals []string
+	}{
+		{
+			title: "doesn't score a contract without signals",
+			in:    Inputs{Name: "Chromie Squiggle", Symbol: "SQUIGGLE", Holders: 1000, MaxHoldersInBlock: 3, Texts: []string{"https://artblocks.io"}},
+		},
+		{
+			title:   "scores a contract marked by a provider",
+			in:      Inputs{IsProviderMarkedSpam: true, Holders: 10},
+			score:   providerMarkedWeight,
+			signals: []string{SignalProviderMarked},
+		},
+		{
+			title:   "scores an airdrop by the share of holders that received it",
+			in:      Inputs{Holders: 100, MaxHoldersInBlock: 50},
+			score:   airdropWeight * 0.5,
+			signals: []string{SignalAirdropFanOut},
+		},
+		{
+			title: "doesn't score a mint to fewer holders than an airdrop",
+			in:    Inputs{Holders: airdropMinReceivers - 1, MaxHoldersInBlock: airdropMinReceivers - 1},
+		},
+		{
+			title:   "scores a name that looks like a giveaway",
+			in:      Inputs{Name: "Claim your reward", Holders: 10},
+			score:   namePatternWeight,
+			signals: []string{SignalNamePattern},
+		},
+		{
+			title:   "scores a symbol that looks like a domain",
+			in:      Inputs{Name: "Tokens", Symbol: "gifts.xyz", Holders: 10},
+			score:   namePatternWeight,
+			signals: []string{SignalNamePattern},
+		},
+		{
+			title:   "scores metadata that links to a spam site",
+			in:      Inputs{Holders: 10, Texts: []string{"Redeem at https://free-tokens.site/now"}},
+			score:   metadataURLWeight,
+			signals: []string{SignalMetadataURL},
+		},
+		{
+			title:   "scores reports in proportion to the holders that agree",
+			in:      Inputs{Holders: 10, SpamReports: 5},
+			score:   userReportsWeight * 0.5,
+			signals: []string{SignalUserReports},
+		},
+		{
+			title:   "limits the weight of reports until enough users agree",
+			in:      Inputs{Holders: 1, SpamReports: 1},
+			score:   userReportsWeight / minReports,
+			signals: []string{SignalUserReports},
+		},
+		{
+			title:   "combines signals as independent evidence",
+			in:      Inputs{IsProviderMarkedSpam: true, Name: "Free mint", Holders: 10},
+			score:   1 - (1-providerMarkedWeight)*(1-namePatternWeight),
+			signals: []string{SignalProviderMarked, SignalNamePattern},
+		},
+		{
+			title:   "discounts the score by the holders that marked the tokens as not spam",
+			in:      Inputs{IsProviderMarkedSpam: true, Holders: 10, NotSpamReports: 5},
+			score:   providerMarkedWeight * (1 - userOverridesWeight*0.5),
+			signals: []string{SignalProviderMarked, SignalUserOverrides},
+		},
+		{
+			title:   "counts reports from more users than holders as every holder",
+			in:      Inputs{IsProviderMarkedSpam: true, Holders: 2, NotSpamReports: 4},
+			score:   providerMarkedWeight * (1 - userOverridesWeight),
+			signals: []string{SignalProviderMarked, SignalUserOverrides},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			actual := Evaluate(tt.in)
+			assert.InDelta(t, tt.score, actual.Score, 1e-9)
+			var signals []string
+			for _, r := range actual.Reasons {
+				signals = append(signals, r.Signal)
+			}
+			assert.Equal(t, tt.signals, signals)
+		})
+	}
+}
+
+func TestEvaluateReasons(t *testing.T) {
+	score := Evaluate(Inputs{Holders: 10, NotSpamReports: 10, IsProviderMarkedSpam: true})
+	require.Len(t, score.Reasons, 2)
+	assert.Positive(t, score.Reasons[0].Weight)
+	assert.Negative(t, score.Reasons[1].Weight, "signals that a contract isn't spam have a negative weight")
+	assert.Equal(t, "10 of 10 holders marked the contract's tokens as not spam", score.Reasons[1].Detail)
+	assert.False(t, score.IsSpam(DefaultThreshold))
+}
+
+func TestScoreIsSpam(t *testing.T) {
+	assert.True(t, Score{Score: 0.5}.IsSpam(0.5))
+	assert.False(t, Score{Score: 0.49}.IsSpam(0.5))
+}
@@ -48,6 +48,8 @@ type FeedbotSlackPostMessage struct {
 type TokenProcessingBatchMessage struct {
 	BatchID            persist.DBID   `json:"batch_id" binding:"required"`
 	TokenDefinitionIDs []persist.DBID `json:"token_definition_ids" binding:"required"`
+	// RefreshMetadata re-fetches the metadata of each token instead of using the metadata that was found during sync
+	RefreshMetadata bool `json:"refresh_metadata"`
 }
 
 type TokenProcessingContractTokensMessage struct {
@@ -60,6 +62,25 @@ type TokenProcessingTokenMessage struct {
 	Attempts          int          `json:"attempts" binding:"required"`
 }
 
+type ContractEnrichmentMessage struct {
+	Chain     persist.Chain     `json:"chain"`
+	Contracts []IndexedContract `json:"contracts" binding:"required"`
+}
+
+// IndexedContract is a contract as the indexer returned it, before it was merged with what was read from the chain
+type IndexedContract struct {
+	Address         persist.Address `json:"address"`
+	OwnerAddress    persist.Address `json:"owner_address"`
+	Name            string          `json:"name"`
+	Symbol          string          `json:"symbol"`
+	Description     string          `json:"description"`
+	ProfileImageURL string          `json:"profile_image_url"`
+}
+
+type IPFSPinGalleryMessage struct {
+	GalleryID persist.DBID `json:"gallery_id" binding:"required"`
+}
+
 type AddEmailToMailingListMessage struct {
 	UserID persist.DBID `json:"user_id" binding:"required"`
 }
@@ -191,6 +212,25 @@ func (c *Client) CreateTaskForWalletRemoval(ctx context.Context, message TokenPr
 	return c.submitTask(ctx, queue, url, withJSON(message), withTrace(span))
 }
 
+func (c *Client) CreateTaskForContractEnrichment(ctx context.Context, message ContractEnrichmentMessage) error {
+	span, ctx := tracing.StartSpan(ctx, "cloudtask.create", "createTaskForContractEnrichment")
+	defer tracing.FinishSpan(span)
+	tracing.AddEventDataToSpan(span, map[string]any{"Chain": message.Chain, "Contracts": len(message.Contracts)})
+	queue := env.GetString("TOKEN_PROCESSING_QUEUE")
+	url := fmt.Sprintf("%s/contracts/enrich", env.GetString("TOKEN_PROCESSING_URL"))
+	return c.submitTask(ctx, queue, url, withJSON(message), withTrace(span))
+}
+
+func (c *Client) CreateTaskForIPFSPinGallery(ctx context.Context, message IPFSPinGalleryMessage) error {
+	span, ctx := tracing.StartSpan(ctx, "cloudtask.create", "createTaskForIPFSPinGallery")
+	defer tracing.FinishSpan(span)
+	tracing.AddEventDataToSpan(span, map[string]any{"Gallery ID": message.GalleryID})
+	queue := env.GetString("TOKEN_PROCESSING_QUEUE")
+	url := fmt.Sprintf("%s/media/ipfs/pin/gallery", env.GetString("TOKEN_PROCESSING_URL"))
+	// Delay the task so that a burst of edits to the same gallery settles before its tokens are pinned
+	return c.submitTask(ctx, queue, url, withJSON(message), withTrace(span), WithDelay(time.Minute))
+}
+
 func (c *Client) CreateTaskTokenProcessingForOpenseaStreamer(ctx context.Context, message persist.OpenSeaWebhookInput) error {
 	span, ctx := tracing.StartSpan(ctx, "cloudtask.create", "createTaskTokenProcessingForOpenseaStreamer")
 	defer tracing.FinishSpan(span)
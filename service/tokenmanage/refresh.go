@@ -0,0 +1,330 @@
+package tokenmanage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/jackc/pgx/v4"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+// EIP-4906 events that contracts emit when the metadata of their tokens changes. https://eips.ethereum.org/EIPS/eip-4906
+var (
+	metadataUpdateTopic      = crypto.Keccak256Hash([]byte("MetadataUpdate(uint256)"))
+	batchMetadataUpdateTopic = crypto.Keccak256Hash([]byte("BatchMetadataUpdate(uint256,uint256)"))
+)
+
+const (
+	// refreshConfirmations is how far behind the head of the chain logs are read from to avoid reading logs that are reorged out
+	refreshConfirmations = 12
+	// refreshBlockWindow is the number of blocks that logs are requested for at a time
+	refreshBlockWindow = 500
+	// refreshMaxBlocksPerRun is the most blocks that are read in a single run so that a run that falls behind catches up over several runs
+	refreshMaxBlocksPerRun = 5000
+	// refreshMaxPoliciesPerRun is the most scheduled contract refreshes that are started in a single run
+	refreshMaxPoliciesPerRun = 50
+	// refreshBatchSize is the number of tokens that are sent to tokenprocessing in each batch
+	refreshBatchSize = 50
+)
+
+// RefreshSummary describes the refreshes that were enqueued by a run of the scheduler
+type RefreshSummary struct {
+	Logs             []LogRefreshSummary `json:"logs"`
+	ScheduledRefresh int                 `json:"scheduled_refreshes"`
+	Tokens           int                 `json:"tokens"`
+}
+
+// LogRefreshSummary describes the metadata update logs that were read from a chain
+type LogRefreshSummary struct {
+	Chain           persist.Chain `json:"chain"`
+	FromBlock       uint64        `json:"from_block"`
+	ToBlock         uint64        `json:"to_block"`
+	MetadataUpdates int           `json:"metadata_updates"`
+	Tokens          int           `json:"tokens"`
+}
+
+// RefreshScheduler keeps the metadata of dynamic tokens current. It enqueues metadata refreshes for tokens that contracts announce
+// have changed with EIP-4906 events, and for contracts that have a refresh policy that refreshes them on an interval.
+type RefreshScheduler struct {
+	queries   *db.Queries
+	clients   map[persist.Chain]*ethclient.Client
+	submitter *TokenProcessingSubmitter
+}
+
+// NewRefreshScheduler returns a scheduler that reads metadata update logs from each chain in clients
+func NewRefreshScheduler(queries *db.Queries, clients map[persist.Chain]*ethclient.Client, submitter *TokenProcessingSubmitter) *RefreshScheduler {
+	return &RefreshScheduler{queries: queries, clients: clients, submitter: submitter}
+}
+
+// Run enqueues refreshes for the metadata updates that were logged since the last run and for the contracts that are due to be refreshed.
+// A chain whose logs can't be read doesn't keep the other chains or the refresh policies from being handled.
+func (r *RefreshScheduler) Run(ctx context.Context) (RefreshSummary, error) {
+	var summary RefreshSummary
+	var errs util.MultiErr
+
+	chains := util.MapKeys(r.clients)
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+
+	for _, chain := range chains {
+		logSummary, err := r.refreshFromLogs(ctx, chain, r.clients[chain])
+		summary.Logs = append(summary.Logs, logSummary)
+		summary.Tokens += logSummary.Tokens
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to refresh from the logs of chain=%d: %w", chain, err))
+		}
+	}
+
+	scheduled, tokens, err := r.refreshFromPolicies(ctx)
+	summary.ScheduledRefresh = scheduled
+	summary.Tokens += tokens
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return summary, errs
+	}
+
+	return summary, nil
+}
+
+// refreshFromLogs reads the EIP-4906 logs after the chain's cursor and enqueues refreshes for the tokens that they reference
+func (r *RefreshScheduler) refreshFromLogs(ctx context.Context, chain persist.Chain, client *ethclient.Client) (LogRefreshSummary, error) {
+	summary := LogRefreshSummary{Chain: chain}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return summary, err
+	}
+	if head < refreshConfirmations {
+		return summary, nil
+	}
+	head -= refreshConfirmations
+
+	from := head
+	last, err := r.queries.GetMetadataUpdateCursor(ctx, chain)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return summary, err
+	}
+	if err == nil {
+		from = uint64(last) + 1
+	}
+	if from > head {
+		return summary, nil
+	}
+
+	to := head
+	if to-from+1 > refreshMaxBlocksPerRun {
+		to = from + refreshMaxBlocksPerRun - 1
+	}
+
+	summary.FromBlock, summary.ToBlock = from, to
+
+	updates := make(map[persist.Address][]tokenRange)
+
+	for start := from; start <= to; start += refreshBlockWindow {
+		end := start + refreshBlockWindow - 1
+		if end > to {
+			end = to
+		}
+
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Topics:    [][]common.Hash{{metadataUpdateTopic, batchMetadataUpdateTopic}},
+		})
+		if err != nil {
+			return summary, err
+		}
+
+		for _, l := range logs {
+			tr, err := parseMetadataUpdate(l)
+			if err != nil {
+				logger.For(ctx).Warnf("skipping metadata update log in tx=%s: %s", l.TxHash, err)
+				continue
+			}
+			address := persist.Address(chain.NormalizeAddress(persist.Address(l.Address.Hex())))
+			updates[address] = append(updates[address], tr)
+			summary.MetadataUpdates++
+		}
+	}
+
+	if len(updates) > 0 {
+		tokens, err := r.refreshUpdated(ctx, chain, updates)
+		summary.Tokens += tokens
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, r.queries.UpsertMetadataUpdateCursor(ctx, db.UpsertMetadataUpdateCursorParams{
+		Chain:     chain,
+		LastBlock: int64(to),
+	})
+}
+
+// refreshUpdated enqueues refreshes for the tokens of the contracts that we know about that were updated
+func (r *RefreshScheduler) refreshUpdated(ctx context.Context, chain persist.Chain, updates map[persist.Address][]tokenRange) (int, error) {
+	addresses := make([]string, 0, len(updates))
+	for address := range updates {
+		addresses = append(addresses, string(address))
+	}
+
+	contracts, err := r.queries.GetMetadataUpdateContractsByAddresses(ctx, db.GetMetadataUpdateContractsByAddressesParams{
+		Chain:     chain,
+		Addresses: addresses,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var tokenDefinitionIDs []persist.DBID
+
+	for _, c := range contracts {
+		if c.IgnoreMetadataUpdates {
+			continue
+		}
+
+		ids, err := r.tokenDefinitionsInRanges(ctx, c.ID, updates[c.Address])
+		if err != nil {
+			return 0, err
+		}
+
+		tokenDefinitionIDs = append(tokenDefinitionIDs, ids...)
+	}
+
+	return len(tokenDefinitionIDs), r.submit(ctx, tokenDefinitionIDs)
+}
+
+// tokenDefinitionsInRanges returns the token definitions of the contract that are in any of the ranges
+func (r *RefreshScheduler) tokenDefinitionsInRanges(ctx context.Context, contractID persist.DBID, ranges []tokenRange) ([]persist.DBID, error) {
+	if tokenIDs, ok := singleTokenIDs(ranges); ok {
+		return r.queries.GetTokenDefinitionIDsByContractIDAndTokenIDs(ctx, db.GetTokenDefinitionIDsByContractIDAndTokenIDsParams{
+			ContractID: contractID,
+			TokenIds:   tokenIDs,
+		})
+	}
+
+	// A batch update can span every token of a contract, e.g. 0 to type(uint256).max, so the contract's tokens are
+	// filtered by range instead of enumerating the range
+	tokens, err := r.queries.GetTokenDefinitionTokenIDsByContractID(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokensInRanges(tokens, ranges), nil
+}
+
+// singleTokenIDs returns the token IDs of the ranges if each range is a single token
+func singleTokenIDs(ranges []tokenRange) ([]string, bool) {
+	tokenIDs := make([]string, 0, len(ranges))
+	for _, tr := range ranges {
+		if tr.from.Cmp(tr.to) != 0 {
+			return nil, false
+		}
+		tokenIDs = append(tokenIDs, persist.HexTokenID(tr.from.Text(16)).String())
+	}
+	return util.Dedupe(tokenIDs, false), true
+}
+
+// tokensInRanges returns the IDs of the tokens that are in any of the ranges
+func tokensInRanges(tokens []db.GetTokenDefinitionTokenIDsByContractIDRow, ranges []tokenRange) []persist.DBID {
+	var ids []persist.DBID
+	for _, t := range tokens {
+		tokenID := t.TokenID.BigInt()
+		for _, tr := range ranges {
+			if tr.contains(tokenID) {
+				ids = append(ids, t.ID)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// refreshFromPolicies enqueues refreshes for every token of the contracts whose refresh policy is due
+func (r *RefreshScheduler) refreshFromPolicies(ctx context.Context) (int, int, error) {
+	policies, err := r.queries.GetDueContractRefreshPolicies(ctx, refreshMaxPoliciesPerRun)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var total int
+
+	for _, p := range policies {
+		tokens, err := r.queries.GetTokenDefinitionTokenIDsByContractID(ctx, p.ContractID)
+		if err != nil {
+			return 0, total, err
+		}
+
+		ids := util.MapWithoutError(tokens, func(t db.GetTokenDefinitionTokenIDsByContractIDRow) persist.DBID { return t.ID })
+		if err := r.submit(ctx, ids); err != nil {
+			return 0, total, err
+		}
+		total += len(ids)
+
+		if err := r.queries.UpdateContractRefreshPolicyRefreshed(ctx, p.ID); err != nil {
+			return 0, total, err
+		}
+	}
+
+	return len(policies), total, nil
+}
+
+func (r *RefreshScheduler) submit(ctx context.Context, tokenDefinitionIDs []persist.DBID) error {
+	for _, batch := range util.Chunk(util.Dedupe(tokenDefinitionIDs, false), refreshBatchSize) {
+		if err := r.submitter.SubmitRefreshTokens(ctx, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tokenRange is an inclusive range of token IDs
+type tokenRange struct {
+	from *big.Int
+	to   *big.Int
+}
+
+func (t tokenRange) contains(tokenID *big.Int) bool {
+	return t.from.Cmp(tokenID) <= 0 && tokenID.Cmp(t.to) <= 0
+}
+
+// parseMetadataUpdate returns the token IDs of a MetadataUpdate or BatchMetadataUpdate log. The token IDs aren't indexed, so
+// they're read from the log's data.
+func parseMetadataUpdate(l types.Log) (tokenRange, error) {
+	if len(l.Topics) == 0 {
+		return tokenRange{}, errors.New("log has no topics")
+	}
+	switch l.Topics[0] {
+	case metadataUpdateTopic:
+		if len(l.Data) != 32 {
+			return tokenRange{}, fmt.Errorf("MetadataUpdate has %d bytes of data, expected 32", len(l.Data))
+		}
+		tokenID := new(big.Int).SetBytes(l.Data)
+		return tokenRange{from: tokenID, to: tokenID}, nil
+	case batchMetadataUpdateTopic:
+		if len(l.Data) != 64 {
+			return tokenRange{}, fmt.Errorf("BatchMetadataUpdate has %d bytes of data, expected 64", len(l.Data))
+		}
+		tr := tokenRange{from: new(big.Int).SetBytes(l.Data[:32]), to: new(big.Int).SetBytes(l.Data[32:])}
+		if tr.from.Cmp(tr.to) > 0 {
+			tr.from, tr.to = tr.to, tr.from
+		}
+		return tr, nil
+	default:
+		return tokenRange{}, fmt.Errorf("unexpected topic %s", l.Topics[0])
+	}
+}
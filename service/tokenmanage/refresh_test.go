@@ -0,0 +1,125 @@
+package tokenmanage
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+func TestParseMetadataUpdate(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	tests := []struct {
+		title    string
+		log      types.Log
+		expected tokenRange
+		err      bool
+	}{
+		{
+			title:    "reads the token of a metadata update",
+			log:      types.Log{Topics: []common.Hash{metadataUpdateTopic}, Data: common.LeftPadBytes([]byte{0x2a}, 32)},
+			expected: tokenRange{from: big.NewInt(42), to: big.NewInt(42)},
+		},
+		{
+			title:    "reads the range of a batch metadata update",
+			log:      types.Log{Topics: []common.Hash{batchMetadataUpdateTopic}, Data: append(common.LeftPadBytes([]byte{0x01}, 32), common.LeftPadBytes([]byte{0x0a}, 32)...)},
+			expected: tokenRange{from: big.NewInt(1), to: big.NewInt(10)},
+		},
+		{
+			title:    "reads a batch update of every token",
+			log:      types.Log{Topics: []common.Hash{batchMetadataUpdateTopic}, Data: append(make([]byte, 32), common.LeftPadBytes(maxUint256.Bytes(), 32)...)},
+			expected: tokenRange{from: big.NewInt(0), to: maxUint256},
+		},
+		{
+			title:    "orders a batch range that's reversed",
+			log:      types.Log{Topics: []common.Hash{batchMetadataUpdateTopic}, Data: append(common.LeftPadBytes([]byte{0x0a}, 32), common.LeftPadBytes([]byte{0x01}, 32)...)},
+			expected: tokenRange{from: big.NewInt(1), to: big.NewInt(10)},
+		},
+		{
+			title: "fails on a metadata update with the wrong amount of data",
+			log:   types.Log{Topics: []common.Hash{metadataUpdateTopic}, Data: make([]byte, 64)},
+			err:   true,
+		},
+		{
+			title: "fails on a batch metadata update with the wrong amount of data",
+			log:   types.Log{Topics: []common.Hash{batchMetadataUpdateTopic}, Data: make([]byte, 32)},
+			err:   true,
+		},
+		{
+			title: "fails on a log without topics",
+			log:   types.Log{Data: make([]byte, 32)},
+			err:   true,
+		},
+		{
+			title: "fails on a different event",
+			log:   types.Log{Topics: []common.Hash{common.HexToHash("0x01")}, Data: make([]byte, 32)},
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			actual, err := parseMetadataUpdate(tt.log)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Zero(t, tt.expected.from.Cmp(actual.from), "from: expected %s, got %s", tt.expected.from, actual.from)
+			assert.Zero(t, tt.expected.to.Cmp(actual.to), "to: expected %s, got %s", tt.expected.to, actual.to)
+		})
+	}
+}
+
+func TestSingleTokenIDs(t *testing.T) {
+	t.Run("returns the token IDs of single token ranges", func(t *testing.T) {
+		tokenIDs, ok := singleTokenIDs([]tokenRange{singleToken(10), singleToken(255), singleToken(10)})
+		assert.True(t, ok)
+		assert.Equal(t, []string{persist.HexTokenID("a").String(), persist.HexTokenID("ff").String()}, tokenIDs)
+	})
+
+	t.Run("doesn't return token IDs if any range spans several tokens", func(t *testing.T) {
+		_, ok := singleTokenIDs([]tokenRange{singleToken(10), {from: big.NewInt(1), to: big.NewInt(2)}})
+		assert.False(t, ok)
+	})
+}
+
+func TestTokensInRanges(t *testing.T) {
+	tokens := []db.GetTokenDefinitionTokenIDsByContractIDRow{
+		{ID: "zero", TokenID: persist.HexTokenID("0")},
+		{ID: "five", TokenID: persist.HexTokenID("5")},
+		{ID: "ten", TokenID: persist.HexTokenID("a")},
+		{ID: "huge", TokenID: persist.HexTokenID("ffffffffffffffffffffffffffffffffff")},
+	}
+
+	t.Run("includes the tokens at the bounds of a range", func(t *testing.T) {
+		ids := tokensInRanges(tokens, []tokenRange{{from: big.NewInt(5), to: big.NewInt(10)}})
+		assert.Equal(t, []persist.DBID{"five", "ten"}, ids)
+	})
+
+	t.Run("includes tokens in any of the ranges once", func(t *testing.T) {
+		ids := tokensInRanges(tokens, []tokenRange{singleToken(0), {from: big.NewInt(0), to: big.NewInt(5)}, singleToken(10)})
+		assert.Equal(t, []persist.DBID{"zero", "five", "ten"}, ids)
+	})
+
+	t.Run("includes token IDs that don't fit in 64 bits", func(t *testing.T) {
+		ids := tokensInRanges(tokens, []tokenRange{{from: big.NewInt(11), to: new(big.Int).Lsh(big.NewInt(1), 256)}})
+		assert.Equal(t, []persist.DBID{"huge"}, ids)
+	})
+
+	t.Run("returns nothing if no token is in a range", func(t *testing.T) {
+		ids := tokensInRanges(tokens, []tokenRange{{from: big.NewInt(6), to: big.NewInt(9)}})
+		assert.Empty(t, ids)
+	})
+}
+
+func singleToken(id int64) tokenRange {
+	return tokenRange{from: big.NewInt(id), to: big.NewInt(id)}
+}
@@ -2,6 +2,7 @@ package tokenmanage
 
 import (
 	"context"
+	"database/sql"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -83,6 +84,21 @@ func (t *TokenProcessingSubmitter) SubmitNewTokens(ctx context.Context, tokenDef
 	return t.TaskClient.CreateTaskTokenProcessingSyncBatch(ctx, msg)
 }
 
+// SubmitRefreshTokens enqueues a batch of tokens that should have their metadata re-fetched
+func (t *TokenProcessingSubmitter) SubmitRefreshTokens(ctx context.Context, tokenDefinitionIDs []persist.DBID) error {
+	if len(tokenDefinitionIDs) == 0 {
+		return nil
+	}
+
+	batchID := persist.GenerateID()
+	msg := task.TokenProcessingBatchMessage{BatchID: batchID, TokenDefinitionIDs: tokenDefinitionIDs, RefreshMetadata: true}
+	ctx = logger.NewContextWithFields(ctx, logrus.Fields{"batchID": msg.BatchID})
+
+	logger.For(ctx).Infof("enqueueing refresh batch: %s (size=%d)", batchID, len(tokenDefinitionIDs))
+	t.Registry.setManyEnqueue(ctx, tokenDefinitionIDs)
+	return t.TaskClient.CreateTaskTokenProcessingSyncBatch(ctx, msg)
+}
+
 func (t *TokenProcessingSubmitter) SubmitTokenForRetry(ctx context.Context, tokenDefinitionID persist.DBID, attempt int, delayFor time.Duration) error {
 	msg := task.TokenProcessingTokenMessage{TokenDefinitionID: tokenDefinitionID, Attempts: attempt}
 	return t.TaskClient.CreateTaskTokenProcessingRetryToken(ctx, msg, delayFor)
@@ -99,9 +115,10 @@ type Manager struct {
 	tickTokenF     TickTokenF
 	metricReporter metric.MetricReporter
 	maxRetries     func(db.TokenDefinition) int
+	queries        *db.Queries
 }
 
-func New(ctx context.Context, taskClient *task.Client, cache *redis.Cache, tickTokenF TickTokenF) *Manager {
+func New(ctx context.Context, taskClient *task.Client, cache *redis.Cache, queries *db.Queries, tickTokenF TickTokenF) *Manager {
 	registry := &Registry{cache}
 	submitter := &TokenProcessingSubmitter{taskClient, registry}
 	return &Manager{
@@ -111,11 +128,12 @@ func New(ctx context.Context, taskClient *task.Client, cache *redis.Cache, tickT
 		metricReporter: metric.NewLogMetricReporter(),
 		errorCounter:   limiters.NewKeyRateLimiter(ctx, cache, "errorCount", flakingAmount, flakingSpan),
 		tickTokenF:     tickTokenF,
+		queries:        queries,
 	}
 }
 
-func NewWithRetries(ctx context.Context, taskClient *task.Client, cache *redis.Cache, maxRetries func(db.TokenDefinition) int, tickTokenF TickTokenF) *Manager {
-	m := New(ctx, taskClient, cache, tickTokenF)
+func NewWithRetries(ctx context.Context, taskClient *task.Client, cache *redis.Cache, queries *db.Queries, maxRetries func(db.TokenDefinition) int, tickTokenF TickTokenF) *Manager {
+	m := New(ctx, taskClient, cache, queries, tickTokenF)
 	m.maxRetries = maxRetries
 	return m
 }
@@ -132,6 +150,11 @@ func (m Manager) Paused(ctx context.Context, td db.TokenDefinition) bool {
 	return p
 }
 
+// ResumeContract lifts a pause on runs of a contract before it would otherwise expire.
+func (m Manager) ResumeContract(ctx context.Context, chain persist.Chain, address persist.Address) error {
+	return m.Registry.resumeContract(ctx, chain, address)
+}
+
 // StartProcessing marks a token as processing. It returns a callback that must be called when work on the token is finished in order to mark
 // it as finished. If withRetry is true, the callback will attempt to reenqueue the token if an error is passed. attemps is ignored when MaxRetries
 // is set to the default value of 0.
@@ -140,6 +163,7 @@ func (m Manager) StartProcessing(ctx context.Context, td db.TokenDefinition, att
 		recordPipelinePaused(ctx, m.metricReporter, td.Chain, td.ContractAddress, cause)
 		err := ErrContractPaused{Chain: td.Chain, Contract: td.ContractAddress}
 		sentryutil.ReportError(ctx, err)
+		m.deadLetter(ctx, td, "", persist.DeadLetterReasonContractPaused, err, attempts, cause)
 		return nil, err
 	}
 
@@ -173,7 +197,7 @@ func (m Manager) StartProcessing(ctx context.Context, td db.TokenDefinition, att
 		<-done
 		m.tickTokenF(td) // mark that the token ran so if an error occured tryRetry delays the next run appropriately
 		m.recordError(ctx, td, err)
-		m.tryRetry(ctx, td, err, attempts)
+		m.tryRetry(ctx, td, tm, err, attempts, cause)
 		m.throttle.Unlock(ctx, "lock:"+td.ID.String())
 		recordMetrics(ctx, m.metricReporter, td.Chain, tm.Media.MediaType, err, time.Since(start), cause)
 		return nil
@@ -216,7 +240,7 @@ func (m Manager) recordError(ctx context.Context, td db.TokenDefinition, origina
 	}
 }
 
-func (m Manager) tryRetry(ctx context.Context, td db.TokenDefinition, err error, attempts int) error {
+func (m Manager) tryRetry(ctx context.Context, td db.TokenDefinition, tm db.TokenMedia, err error, attempts int, cause persist.ProcessingCause) error {
 	// Only retry intermittent errors related to the token e.g. missing metadata
 	if !util.ErrorIs[ErrBadToken](err) {
 		m.Registry.finish(ctx, td.ID)
@@ -225,11 +249,15 @@ func (m Manager) tryRetry(ctx context.Context, td db.TokenDefinition, err error,
 
 	if m.Paused(ctx, td) {
 		m.Registry.finish(ctx, td.ID)
+		m.deadLetter(ctx, td, tm.ProcessingJobID, persist.DeadLetterReasonContractPaused, err, attempts, cause)
 		return nil
 	}
 
 	if err == nil || m.maxRetries == nil || attempts >= m.maxRetries(td) {
 		m.Registry.finish(ctx, td.ID)
+		if m.maxRetries != nil {
+			m.deadLetter(ctx, td, tm.ProcessingJobID, persist.DeadLetterReasonRetriesExhausted, err, attempts, cause)
+		}
 		return nil
 	}
 
@@ -244,6 +272,33 @@ func (m Manager) tryRetry(ctx context.Context, td db.TokenDefinition, err error,
 	return m.Submitter.SubmitTokenForRetry(ctx, td.ID, attempts+1, delay)
 }
 
+// deadLetter persists a token that won't be run again on its own so that it can be inspected and replayed later
+func (m Manager) deadLetter(ctx context.Context, td db.TokenDefinition, jobID persist.DBID, reason persist.DeadLetterReason, originalErr error, attempts int, cause persist.ProcessingCause) {
+	if m.queries == nil {
+		return
+	}
+
+	var lastErr sql.NullString
+	if originalErr != nil {
+		lastErr = sql.NullString{String: originalErr.Error(), Valid: true}
+	}
+
+	err := m.queries.UpsertTokenProcessingDeadLetter(ctx, db.UpsertTokenProcessingDeadLetterParams{
+		ID:                persist.GenerateID(),
+		TokenDefinitionID: td.ID,
+		ContractID:        td.ContractID,
+		ProcessingJobID:   util.ToNullStringEmptyNull(jobID.String()),
+		Reason:            reason,
+		ProcessingCause:   cause,
+		LastError:         lastErr,
+		Attempts:          int32(attempts),
+	})
+	if err != nil {
+		logger.For(ctx).Errorf("failed to add token to dead letter queue: %s", err)
+		sentryutil.ReportError(ctx, err)
+	}
+}
+
 // Registry handles the storing of object state managed by Manager
 type Registry struct{ Cache *redis.Cache }
 
@@ -279,6 +334,10 @@ func (r Registry) pauseContract(ctx context.Context, chain persist.Chain, addres
 	return r.Cache.SetNX(ctx, pauseContractKey(chain, address), b, ttl)
 }
 
+func (r Registry) resumeContract(ctx context.Context, chain persist.Chain, address persist.Address) error {
+	return r.Cache.Delete(ctx, pauseContractKey(chain, address))
+}
+
 func (r Registry) keepAlive(ctx context.Context, tDefID persist.DBID) error {
 	return r.Cache.Set(ctx, processingKey(tDefID), []byte("processing"), time.Minute)
 }
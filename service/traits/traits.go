@@ -0,0 +1,307 @@
+package traits
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	db "github.com/mikeydub/go-gallery/db/gen/coredb"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/persist/postgres"
+)
+
+// reindexAfter is how long a community's index is used before it's computed again
+const reindexAfter = 24 * time.Hour
+
+// numericDisplayTypes are the OpenSea display types of attributes that are numbers rather than categories. They're
+// left out of the index because nearly every value would be unique.
+var numericDisplayTypes = map[string]bool{
+	"number":           true,
+	"boost_number":     true,
+	"boost_percentage": true,
+	"date":             true,
+}
+
+// Trait is a category and value that describes a token, e.g. Background: Blue
+type Trait struct {
+	TraitType string
+	Value     string
+}
+
+// ParseTraits returns the traits in a token's metadata. Traits are usually a list of attributes in the OpenSea format,
+// e.g. [{"trait_type": "Background", "value": "Blue"}], but some contracts use an object of trait types to values instead.
+func ParseTraits(metadata persist.TokenMetadata) []Trait {
+	attributes, ok := metadata["attributes"]
+	if !ok {
+		attributes = metadata["traits"]
+	}
+
+	var traits []Trait
+	seen := make(map[Trait]bool)
+	add := func(traitType string, value any) {
+		t := Trait{TraitType: strings.TrimSpace(traitType), Value: strings.TrimSpace(valueString(value))}
+		if t.TraitType == "" || t.Value == "" || seen[t] {
+			return
+		}
+		seen[t] = true
+		traits = append(traits, t)
+	}
+
+	switch a := attributes.(type) {
+	case []any:
+		for _, attribute := range a {
+			m, ok := attribute.(map[string]any)
+			if !ok {
+				continue
+			}
+			if displayType, _ := m["display_type"].(string); numericDisplayTypes[displayType] {
+				continue
+			}
+			traitType, _ := m["trait_type"].(string)
+			if traitType == "" {
+				traitType, _ = m["key"].(string)
+			}
+			add(traitType, m["value"])
+		}
+	case map[string]any:
+		for traitType, value := range a {
+			add(traitType, value)
+		}
+	}
+
+	sort.Slice(traits, func(i, j int) bool {
+		if traits[i].TraitType != traits[j].TraitType {
+			return traits[i].TraitType < traits[j].TraitType
+		}
+		return traits[i].Value < traits[j].Value
+	})
+
+	return traits
+}
+
+func valueString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%g", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Rarity is how rare a token is within its community
+type Rarity struct {
+	// StatisticalScore is the information content of the token's traits: the sum of -ln(frequency) over each trait type,
+	// which is -ln of the probability of a token having all of its traits. Higher is rarer.
+	StatisticalScore float64
+	// NormalizedScore is the sum of 1/frequency over each trait type, with each term divided by the number of values of
+	// its trait type so that types with many values don't outweigh the rest. Higher is rarer.
+	NormalizedScore float64
+	// Rank is the token's position when the community's tokens are sorted rarest first, starting at 1
+	Rank int
+}
+
+// Index is the trait counts and rarities of a community's tokens
+type Index struct {
+	// Total is the number of token definitions in the community
+	Total int
+	// Counts is the number of token definitions with each trait
+	Counts map[Trait]int
+	// Traits are the traits of each token definition
+	Traits map[persist.DBID][]Trait
+	// Rarities are the rarities of each token definition. It's empty if no token definitions have traits.
+	Rarities map[persist.DBID]Rarity
+}
+
+// Compute indexes the traits of a community's token definitions. A token definition that doesn't have a trait type is
+// treated as having a "none" value for it, so that missing a common trait makes a token rarer.
+func Compute(traits map[persist.DBID][]Trait) Index {
+	index := Index{
+		Total:    len(traits),
+		Counts:   make(map[Trait]int),
+		Traits:   traits,
+		Rarities: make(map[persist.DBID]Rarity),
+	}
+
+	// The number of token definitions with each trait type, and the number of values of each type
+	typeCounts := make(map[string]int)
+	valueCounts := make(map[string]int)
+	for _, ts := range traits {
+		for _, t := range ts {
+			if index.Counts[t] == 0 {
+				valueCounts[t.TraitType]++
+			}
+			index.Counts[t]++
+		}
+	}
+	for _, ts := range traits {
+		types := make(map[string]bool, len(ts))
+		for _, t := range ts {
+			types[t.TraitType] = true
+		}
+		for traitType := range types {
+			typeCounts[traitType]++
+		}
+	}
+
+	if len(typeCounts) == 0 {
+		return index
+	}
+
+	total := float64(index.Total)
+	ids := make([]persist.DBID, 0, len(traits))
+
+	for id, ts := range traits {
+		ids = append(ids, id)
+
+		byType := make(map[string][]Trait, len(ts))
+		for _, t := range ts {
+			byType[t.TraitType] = append(byType[t.TraitType], t)
+		}
+
+		var r Rarity
+		for traitType, typeCount := range typeCounts {
+			values := valueCounts[traitType]
+			if typeCount < index.Total {
+				values++ // the "none" value
+			}
+
+			count := float64(index.Total - typeCount)
+			if matching := byType[traitType]; len(matching) > 0 {
+				// A token can have several values of one type. It's as rare as its rarest value.
+				count = total
+				for _, t := range matching {
+					count = math.Min(count, float64(index.Counts[t]))
+				}
+			}
+
+			frequency := count / total
+			r.StatisticalScore += -math.Log(frequency)
+			r.NormalizedScore += (1 / frequency) / float64(values)
+		}
+		index.Rarities[id] = r
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := index.Rarities[ids[i]], index.Rarities[ids[j]]
+		if a.NormalizedScore != b.NormalizedScore {
+			return a.NormalizedScore > b.NormalizedScore
+		}
+		if a.StatisticalScore != b.StatisticalScore {
+			return a.StatisticalScore > b.StatisticalScore
+		}
+		return ids[i] < ids[j]
+	})
+	for i, id := range ids {
+		r := index.Rarities[id]
+		r.Rank = i + 1
+		index.Rarities[id] = r
+	}
+
+	return index
+}
+
+// Indexer computes the trait indexes of communities and stores them
+type Indexer struct {
+	repos   *postgres.Repositories
+	queries *db.Queries
+}
+
+func NewIndexer(repos *postgres.Repositories, queries *db.Queries) *Indexer {
+	return &Indexer{repos: repos, queries: queries}
+}
+
+// IndexCommunity computes a community's index from the current metadata of its tokens and replaces its stored index
+func (i *Indexer) IndexCommunity(ctx context.Context, communityID persist.DBID) (Index, error) {
+	definitions, err := i.queries.GetTokenDefinitionMetadataByCommunityID(ctx, communityID)
+	if err != nil {
+		return Index{}, err
+	}
+
+	traits := make(map[persist.DBID][]Trait, len(definitions))
+	for _, td := range definitions {
+		traits[td.ID] = ParseTraits(td.Metadata)
+	}
+
+	index := Compute(traits)
+
+	counts := db.ReplaceCommunityTraitsParams{CommunityID: communityID}
+	for t, count := range index.Counts {
+		counts.TraitTypes = append(counts.TraitTypes, t.TraitType)
+		counts.TraitValues = append(counts.TraitValues, t.Value)
+		counts.TokenCounts = append(counts.TokenCounts, int32(count))
+	}
+
+	tokenTraits := db.ReplaceTokenDefinitionTraitsParams{CommunityID: communityID}
+	for id, ts := range index.Traits {
+		for _, t := range ts {
+			tokenTraits.TokenDefinitionIds = append(tokenTraits.TokenDefinitionIds, id.String())
+			tokenTraits.TraitTypes = append(tokenTraits.TraitTypes, t.TraitType)
+			tokenTraits.TraitValues = append(tokenTraits.TraitValues, t.Value)
+		}
+	}
+
+	rarities := db.ReplaceTokenDefinitionRaritiesParams{CommunityID: communityID}
+	for id, r := range index.Rarities {
+		rarities.TokenDefinitionIds = append(rarities.TokenDefinitionIds, id.String())
+		rarities.StatisticalScores = append(rarities.StatisticalScores, r.StatisticalScore)
+		rarities.NormalizedScores = append(rarities.NormalizedScores, r.NormalizedScore)
+		rarities.Ranks = append(rarities.Ranks, int32(r.Rank))
+	}
+
+	// The parts of the index are replaced together so that readers never see counts that don't match the rarities
+	tx, err := i.repos.BeginTx(ctx)
+	if err != nil {
+		return Index{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := i.queries.WithTx(tx)
+
+	if err := q.ReplaceCommunityTraits(ctx, counts); err != nil {
+		return Index{}, err
+	}
+	if err := q.ReplaceTokenDefinitionTraits(ctx, tokenTraits); err != nil {
+		return Index{}, err
+	}
+	if err := q.ReplaceTokenDefinitionRarities(ctx, rarities); err != nil {
+		return Index{}, err
+	}
+
+	err = q.UpsertCommunityTraitIndex(ctx, db.UpsertCommunityTraitIndexParams{
+		CommunityID: communityID,
+		TokenCount:  int32(index.Total),
+	})
+	if err != nil {
+		return Index{}, err
+	}
+
+	return index, tx.Commit(ctx)
+}
+
+// IndexStale indexes up to limit communities that haven't been indexed or whose index is out of date, returning the number indexed
+func (i *Indexer) IndexStale(ctx context.Context, limit int) (int, error) {
+	communityIDs, err := i.queries.GetCommunitiesToIndexTraits(ctx, db.GetCommunitiesToIndexTraitsParams{
+		IndexedBefore: time.Now().Add(-reindexAfter),
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var indexed int
+	for _, id := range communityIDs {
+		if _, err := i.IndexCommunity(ctx, id); err != nil {
+			return indexed, err
+		}
+		indexed++
+	}
+
+	return indexed, nil
+}
@@ -0,0 +1,135 @@
+package traits
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikeydub/go-gallery/service/persist"
+)
+
+func TestParseTraits(t *testing.T) {
+	tests := []struct {
+		title    string
+		metadata persist.TokenMetadata
+		expected []Trait
+	}{
+		{
+			title: "reads opensea attributes sorted by type and value",
+			metadata: persist.TokenMetadata{"attributes": []any{
+				map[string]any{"trait_type": "Hat", "value": "Cap"},
+				map[string]any{"trait_type": "Background", "value": "Blue"},
+			}},
+			expected: []Trait{{"Background", "Blue"}, {"Hat", "Cap"}},
+		},
+		{
+			title:    "reads an object of trait types to values",
+			metadata: persist.TokenMetadata{"attributes": map[string]any{"Eyes": "Laser", "Level": float64(3)}},
+			expected: []Trait{{"Eyes", "Laser"}, {"Level", "3"}},
+		},
+		{
+			title:    "reads traits if there are no attributes",
+			metadata: persist.TokenMetadata{"traits": []any{map[string]any{"trait_type": "Fur", "value": "Gold"}}},
+			expected: []Trait{{"Fur", "Gold"}},
+		},
+		{
+			title:    "reads the type from key if there's no trait_type",
+			metadata: persist.TokenMetadata{"attributes": []any{map[string]any{"key": "Mouth", "value": "Grin"}}},
+			expected: []Trait{{"Mouth", "Grin"}},
+		},
+		{
+			title: "leaves out numeric attributes",
+			metadata: persist.TokenMetadata{"attributes": []any{
+				map[string]any{"trait_type": "Power", "value": float64(90), "display_type": "boost_number"},
+				map[string]any{"trait_type": "Born", "value": float64(1546360800), "display_type": "date"},
+				map[string]any{"trait_type": "Generation", "value": float64(2)},
+			}},
+			expected: []Trait{{"Generation", "2"}},
+		},
+		{
+			title: "trims values and skips empty and duplicate traits",
+			metadata: persist.TokenMetadata{"attributes": []any{
+				map[string]any{"trait_type": " Hat ", "value": " Cap "},
+				map[string]any{"trait_type": "Hat", "value": "Cap"},
+				map[string]any{"trait_type": "Hat", "value": ""},
+				map[string]any{"trait_type": "", "value": "Cap"},
+				map[string]any{"value": "Untyped"},
+				map[string]any{"trait_type": "Shoes", "value": nil},
+				"not an attribute",
+			}},
+			expected: []Trait{{"Hat", "Cap"}},
+		},
+		{
+			title: "keeps several values of the same type",
+			metadata: persist.TokenMetadata{"attributes": []any{
+				map[string]any{"trait_type": "Accessory", "value": "Ring"},
+				map[string]any{"trait_type": "Accessory", "value": "Chain"},
+				map[string]any{"trait_type": "Legendary", "value": true},
+			}},
+			expected: []Trait{{"Accessory", "Chain"}, {"Accessory", "Ring"}, {"Legendary", "true"}},
+		},
+		{
+			title:    "returns nothing if there are no attributes",
+			metadata: persist.TokenMetadata{"name": "Token", "attributes": "Blue"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseTraits(tt.metadata))
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	blue := Trait{"Background", "Blue"}
+	red := Trait{"Background", "Red"}
+	hat := Trait{"Hat", "Cap"}
+
+	t.Run("scores tokens by the frequency of their traits", func(t *testing.T) {
+		index := Compute(map[persist.DBID][]Trait{
+			"a": {blue, hat},
+			"b": {blue},
+			"c": {red},
+			"d": {blue},
+		})
+
+		assert.Equal(t, 4, index.Total)
+		assert.Equal(t, map[Trait]int{blue: 3, red: 1, hat: 1}, index.Counts)
+
+		// Background has two values. Hat has Cap and "none", which three of the tokens have. a and c are equally rare,
+		// as are b and d, so they're ranked by ID.
+		assertRarity(t, Rarity{StatisticalScore: math.Log(4.0/3) + math.Log(4), NormalizedScore: (4.0/3)/2 + 4.0/2, Rank: 1}, index.Rarities["a"])
+		assertRarity(t, Rarity{StatisticalScore: math.Log(4.0/3) * 2, NormalizedScore: (4.0/3)/2 + (4.0/3)/2, Rank: 3}, index.Rarities["b"])
+		assertRarity(t, Rarity{StatisticalScore: math.Log(4) + math.Log(4.0/3), NormalizedScore: 4.0/2 + (4.0/3)/2, Rank: 2}, index.Rarities["c"])
+		assertRarity(t, Rarity{StatisticalScore: math.Log(4.0/3) * 2, NormalizedScore: (4.0/3)/2 + (4.0/3)/2, Rank: 4}, index.Rarities["d"])
+	})
+
+	t.Run("doesn't count none for a type that every token has", func(t *testing.T) {
+		index := Compute(map[persist.DBID][]Trait{"a": {blue}, "b": {red}, "c": {red}})
+		assertRarity(t, Rarity{StatisticalScore: math.Log(3), NormalizedScore: 3.0 / 2, Rank: 1}, index.Rarities["a"])
+		assertRarity(t, Rarity{StatisticalScore: math.Log(3.0 / 2), NormalizedScore: (3.0 / 2) / 2, Rank: 2}, index.Rarities["b"])
+	})
+
+	t.Run("scores a token with several values of a type by its rarest value", func(t *testing.T) {
+		index := Compute(map[persist.DBID][]Trait{"a": {blue, red}, "b": {blue}})
+		assertRarity(t, Rarity{StatisticalScore: math.Log(2), NormalizedScore: 2.0 / 2, Rank: 1}, index.Rarities["a"])
+		assertRarity(t, Rarity{StatisticalScore: 0, NormalizedScore: 1.0 / 2, Rank: 2}, index.Rarities["b"])
+	})
+
+	t.Run("doesn't score tokens if none have traits", func(t *testing.T) {
+		index := Compute(map[persist.DBID][]Trait{"a": nil, "b": nil})
+		assert.Equal(t, 2, index.Total)
+		assert.Empty(t, index.Counts)
+		assert.Empty(t, index.Rarities)
+	})
+}
+
+func assertRarity(t *testing.T, expected, actual Rarity) {
+	t.Helper()
+	require.Equal(t, expected.Rank, actual.Rank)
+	assert.InDelta(t, expected.StatisticalScore, actual.StatisticalScore, 1e-9)
+	assert.InDelta(t, expected.NormalizedScore, actual.NormalizedScore, 1e-9)
+}
@@ -0,0 +1,272 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// fetchTimeout is how long a link has to respond with its preview
+	fetchTimeout = 5 * time.Second
+	// maxRedirects is the most redirects that are followed before giving up on a link
+	maxRedirects = 3
+	// maxBodySize is the most of a page that's read looking for its preview. Preview tags are in the head so they're
+	// almost always well within the limit.
+	maxBodySize = 1 << 20
+)
+
+var (
+	ErrUnsafeURL = errors.New("url is not allowed")
+	ErrNoPreview = errors.New("link has no preview")
+)
+
+// blockedNetworks are address ranges that aren't covered by the net.IP helpers but still shouldn't be reachable
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // "this" network
+	"100.64.0.0/10", // carrier-grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"240.0.0.0/4",   // reserved
+	"64:ff9b::/96",  // NAT64, which can be used to reach IPv4 addresses
+)
+
+var defaultClient = NewClient()
+
+// Preview is what a link looks like when it's shared
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// NewClient returns an http.Client for fetching user-provided links. Addresses are checked when connecting rather than
+// when the URL is parsed so that a hostname can't resolve to a public address when it's checked and to an internal one
+// when it's fetched.
+func NewClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: fetchTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrUnsafeURL, address)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: fetchTimeout,
+		Transport: &http.Transport{
+			// Proxies are ignored so that the dialer sees the address that's actually being connected to
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: fetchTimeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return CheckURL(req.URL)
+		},
+	}
+}
+
+// CheckURL returns an error if a URL shouldn't be fetched. Only web URLs on standard ports are allowed.
+func CheckURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme must be http or https", ErrUnsafeURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials aren't allowed", ErrUnsafeURL)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("%w: missing host", ErrUnsafeURL)
+	}
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		return fmt.Errorf("%w: port %s isn't allowed", ErrUnsafeURL, port)
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s isn't a public address", ErrUnsafeURL, ip)
+	}
+	return nil
+}
+
+// IsPublicIP returns true if an address is reachable on the public internet
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range blockedNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Fetch fetches a link and reads its OpenGraph preview
+func Fetch(ctx context.Context, rawURL string) (Preview, error) {
+	return FetchWithClient(ctx, defaultClient, rawURL)
+}
+
+// FetchWithClient is like Fetch but uses the provided client. The client is responsible for refusing unsafe addresses.
+func FetchWithClient(ctx context.Context, client *http.Client, rawURL string) (Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	if err := CheckURL(u); err != nil {
+		return Preview{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Preview{}, err
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "GalleryBot/1.0 (+https://gallery.so)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Preview{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Preview{}, fmt.Errorf("unexpected status fetching link: %s", resp.Status)
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err != nil || mediaType != "text/html" {
+		return Preview{}, ErrNoPreview
+	}
+
+	p := Parse(io.LimitReader(resp.Body, maxBodySize), resp.Request.URL)
+	if p.Title == "" {
+		return Preview{}, ErrNoPreview
+	}
+
+	return p, nil
+}
+
+// Parse reads a page's preview from its OpenGraph tags, falling back to its title and description. base is the URL
+// the page was served from, which relative image URLs are resolved against.
+func Parse(r io.Reader, base *url.URL) Preview {
+	p := Preview{URL: base.String()}
+	var title, description string
+
+	z := html.NewTokenizer(r)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return finish(p, title, description, base)
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				return finish(p, title, description, base)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "title":
+				if z.Next() == html.TextToken {
+					title = strings.TrimSpace(string(z.Text()))
+				}
+			case "meta":
+				if !hasAttr {
+					continue
+				}
+				key, content := metaAttrs(z)
+				switch key {
+				case "og:title":
+					p.Title = content
+				case "og:description":
+					p.Description = content
+				case "og:image", "og:image:url", "og:image:secure_url":
+					if p.ImageURL == "" {
+						p.ImageURL = content
+					}
+				case "og:site_name":
+					p.SiteName = content
+				case "og:url":
+					if u, err := base.Parse(content); err == nil && CheckURL(u) == nil {
+						p.URL = u.String()
+					}
+				case "description":
+					description = content
+				}
+			}
+		}
+	}
+}
+
+func metaAttrs(z *html.Tokenizer) (key, content string) {
+	for {
+		k, v, more := z.TagAttr()
+		switch string(k) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(string(v))
+			}
+		case "content":
+			content = strings.TrimSpace(string(v))
+		}
+		if !more {
+			return key, content
+		}
+	}
+}
+
+func finish(p Preview, title, description string, base *url.URL) Preview {
+	if p.Title == "" {
+		p.Title = title
+	}
+	if p.Description == "" {
+		p.Description = description
+	}
+	if p.SiteName == "" {
+		p.SiteName = base.Hostname()
+	}
+
+	// Images are loaded by clients, so only web URLs are kept
+	if p.ImageURL != "" {
+		u, err := base.Parse(p.ImageURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			p.ImageURL = ""
+		} else {
+			p.ImageURL = u.String()
+		}
+	}
+
+	return p
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = n
+	}
+	return networks
+}
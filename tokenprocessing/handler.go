@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gin-gonic/gin"
 
 	db "github.com/mikeydub/go-gallery/db/gen/coredb"
@@ -17,22 +18,27 @@ import (
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/persist/postgres"
 	"github.com/mikeydub/go-gallery/service/redis"
+	"github.com/mikeydub/go-gallery/service/rpc"
+	"github.com/mikeydub/go-gallery/service/rpc/ipfs"
 	sentryutil "github.com/mikeydub/go-gallery/service/sentry"
+	"github.com/mikeydub/go-gallery/service/spam"
 	"github.com/mikeydub/go-gallery/service/task"
 	"github.com/mikeydub/go-gallery/service/throttle"
 	"github.com/mikeydub/go-gallery/service/tokenmanage"
+	"github.com/mikeydub/go-gallery/service/traits"
 )
 
-func handlersInitServer(ctx context.Context, router *gin.Engine, tp *tokenProcessor, mc *multichain.Provider, repos *postgres.Repositories, throttler *throttle.Locker, taskClient *task.Client, tokenManageCache *redis.Cache) *gin.Engine {
+func handlersInitServer(ctx context.Context, router *gin.Engine, tp *tokenProcessor, mc *multichain.Provider, repos *postgres.Repositories, ethClient *ethclient.Client, throttler *throttle.Locker, taskClient *task.Client, tokenManageCache *redis.Cache) *gin.Engine {
 	// Handles retries and token state
 	fastRetry := limiters.NewKeyRateLimiter(ctx, tokenManageCache, "tickFast", 1, 30*time.Second)
 	slowRetry := limiters.NewKeyRateLimiter(ctx, tokenManageCache, "tickSlow", 1, 5*time.Minute)
 	mintRetry := limiters.NewKeyRateLimiter(ctx, tokenManageCache, "tickMint", 1, 10*time.Second)
 
-	refreshManager := tokenmanage.New(ctx, taskClient, tokenManageCache, tickTokenSyncF(ctx, fastRetry, slowRetry, mintRetry))
-	syncManager := tokenmanage.NewWithRetries(ctx, taskClient, tokenManageCache, maxRetriesForTokenSync, tickTokenSyncF(ctx, fastRetry, slowRetry, mintRetry))
+	refreshManager := tokenmanage.New(ctx, taskClient, tokenManageCache, mc.Queries, tickTokenSyncF(ctx, fastRetry, slowRetry, mintRetry))
+	syncManager := tokenmanage.NewWithRetries(ctx, taskClient, tokenManageCache, mc.Queries, maxRetriesForTokenSync, tickTokenSyncF(ctx, fastRetry, slowRetry, mintRetry))
 	highlightProvider := highlight.NewProvider(http.DefaultClient)
-	mintManager := tokenmanage.New(ctx, taskClient, tokenManageCache, tickTokenF(ctx, mintRetry))
+	mintManager := tokenmanage.New(ctx, taskClient, tokenManageCache, mc.Queries, tickTokenF(ctx, mintRetry))
+	refreshScheduler := tokenmanage.NewRefreshScheduler(mc.Queries, rpc.NewEVMClients(ethClient), &tokenmanage.TokenProcessingSubmitter{TaskClient: taskClient, Registry: syncManager.Registry})
 
 	mediaGroup := router.Group("/media")
 	mediaGroup.POST("/process", func(c *gin.Context) {
@@ -45,6 +51,9 @@ func handlersInitServer(ctx context.Context, router *gin.Engine, tp *tokenProces
 	mediaGroup.POST("/tokenmanage/process/token", processMediaForTokenManaged(tp, mc.Queries, taskClient, syncManager))
 	mediaGroup.POST("/process/post-preflight", processPostPreflight(tp, mc, repos.UserRepository, taskClient, syncManager))
 	mediaGroup.POST("/process/highlight-mint-claim", processHighlightMintClaim(mc, highlightProvider, tp, mintManager, taskClient, 20))
+	mediaGroup.POST("/refresh/schedule", scheduleMetadataRefreshes(refreshScheduler))
+	mediaGroup.GET("/ipfs/gateways", ipfsGatewayStats(ipfs.DefaultFetcher()))
+	mediaGroup.POST("/ipfs/pin/gallery", pinGalleryMedia(mc.Queries, ipfs.NewPinService(repos, mc.Queries, ipfs.NewPinner(http.DefaultClient))))
 
 	authOpts := middleware.BasicAuthOptionBuilder{}
 
@@ -55,6 +64,11 @@ func handlersInitServer(ctx context.Context, router *gin.Engine, tp *tokenProces
 
 	contractsGroup := router.Group("/contracts")
 	contractsGroup.POST("/detect-spam", detectSpamContracts(mc.Queries))
+	contractsGroup.POST("/score-spam", scoreSpamContracts(spam.NewScorer(mc.Queries)))
+	contractsGroup.POST("/enrich", enrichContracts(mc.Enricher))
+
+	communitiesGroup := router.Group("/communities")
+	communitiesGroup.POST("/index-traits", indexCommunityTraits(traits.NewIndexer(repos, mc.Queries)))
 
 	return router
 }
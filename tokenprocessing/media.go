@@ -93,9 +93,11 @@ type cachePipelineMetadata struct {
 	DetermineMediaTypeWithReader *persist.PipelineStepStatus
 	AnimationGzip                *persist.PipelineStepStatus
 	SVGRasterize                 *persist.PipelineStepStatus
+	SVGSanitize                  *persist.PipelineStepStatus
 	StoreGCP                     *persist.PipelineStepStatus
 	ThumbnailGCP                 *persist.PipelineStepStatus
 	LiveRenderGCP                *persist.PipelineStepStatus
+	HTMLSnapshot                 *persist.PipelineStepStatus
 }
 
 func createRawMedia(pCtx context.Context, tids persist.TokenIdentifiers, mediaType persist.MediaType, tokenBucket, animURL, imgURL string, objects []cachedMediaObject) persist.Media {
@@ -202,6 +204,10 @@ func createMediaFromCachedObjects(ctx context.Context, tokenBucket string, objec
 		result.ProfileImageURL = persist.NullString(profileImageObject.storageURL(tokenBucket))
 	}
 
+	if svgObject, ok := objects[objectTypeSVG]; ok && svgObject.SVGSanitization != nil {
+		result.SVGSanitization = svgObject.SVGSanitization
+	}
+
 	var err error
 	switch result.MediaType {
 	case persist.MediaTypeSVG:
@@ -266,7 +272,7 @@ func getSvgDimensions(ctx context.Context, url string) (persist.Dimensions, erro
 			return persist.Dimensions{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 		}
 
-		_, err = io.Copy(buf, resp.Body)
+		_, err = io.Copy(buf, io.LimitReader(resp.Body, maxSVGSize))
 		if err != nil {
 			return persist.Dimensions{}, err
 		}
@@ -412,6 +418,8 @@ func findImageAndAnimationURLs(ctx context.Context, metadata persist.TokenMetada
 	if err != nil {
 		persist.FailStep(&pMeta.MediaURLsRetrieval)
 	}
+	persist.AnnotateStep(ctx, "image_url", string(imgURL))
+	persist.AnnotateStep(ctx, "animation_url", string(vURL))
 	return imgURL, vURL, err
 }
 
@@ -490,6 +498,7 @@ const (
 	objectTypeLiveRender
 	objectTypeSVG
 	objectTypeProfileImage
+	objectTypeOriginalSVG
 )
 
 func (o objectType) String() string {
@@ -506,6 +515,8 @@ func (o objectType) String() string {
 		return "svg"
 	case objectTypeProfileImage:
 		return "pfp"
+	case objectTypeOriginalSVG:
+		return "svg-original"
 	case objectTypeUnknown:
 		return "unknown"
 	default:
@@ -518,6 +529,7 @@ var nonOverridableObjectTypes = map[objectType]bool{
 	objectTypeThumbnail:    true,
 	objectTypeLiveRender:   true,
 	objectTypeProfileImage: true,
+	objectTypeOriginalSVG:  true,
 }
 
 // mediaTypeToObjectTypeLookup are default mappings from media type to object type
@@ -550,6 +562,8 @@ type cachedMediaObject struct {
 	ContentType     string
 	ContentLength   *int64
 	ObjectType      objectType
+	// SVGSanitization is set on SVG objects that were run through the sanitizer
+	SVGSanitization *persist.SVGSanitization
 }
 
 func (m cachedMediaObject) fileName() string {
@@ -576,6 +590,7 @@ func cacheRawMedia(ctx context.Context, reader *util.FileHeaderReader, tids pers
 		ContentLength:   contentLength,
 		ObjectType:      mediaTypeToObjectType(mediaType, oType),
 	}
+	persist.AnnotateStep(ctx, "object", object.fileName())
 
 	err := persistToStorage(ctx, client, reader, bucket, object, map[string]string{
 		"originalURL": truncateString(ogURL, 100),
@@ -642,23 +657,12 @@ func cacheRasterizedSVG(ctx context.Context, svgURL string, tids persist.TokenId
 	traceCallback, ctx := persist.TrackStepStatus(ctx, subMeta.SVGRasterize, "SVGRasterize")
 	defer traceCallback()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/rasterize?url=%s", env.GetString("RASTERIZER_URL"), svgURL), nil)
+	req, err := newRasterizerRequest(ctx, "rasterize", svgURL, tids)
 	if err != nil {
 		persist.FailStep(subMeta.SVGRasterize)
 		return nil, err
 	}
 
-	idToken, _ := metadata.Get(fmt.Sprintf("instance/service-accounts/default/identity?audience=%s", env.GetString("RASTERIZER_URL")))
-	if idToken != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", idToken))
-	}
-
-	q := req.URL.Query()
-	q.Add("chain", strconv.Itoa(int(tids.Chain)))
-	q.Add("address", tids.ContractAddress.String())
-	q.Add("tokenId", tids.TokenID.Base10String())
-	req.URL.RawQuery = q.Encode()
-
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		persist.FailStep(subMeta.SVGRasterize)
@@ -763,6 +767,144 @@ func cacheRasterizedSVG(ctx context.Context, svgURL string, tids persist.TokenId
 	return objects, nil
 }
 
+// htmlSnapshotRenderTimeout is how long the rasterizer waits for a token to signal that it has rendered before snapshotting it anyway
+const htmlSnapshotRenderTimeout = 15 * time.Second
+
+type snapshotResponse struct {
+	PNG string  `json:"png"`
+	MP4 *string `json:"mp4"`
+}
+
+func newRasterizerRequest(ctx context.Context, endpoint, mediaURL string, tids persist.TokenIdentifiers) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s?url=%s", env.GetString("RASTERIZER_URL"), endpoint, mediaURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, _ := metadata.Get(fmt.Sprintf("instance/service-accounts/default/identity?audience=%s", env.GetString("RASTERIZER_URL")))
+	if idToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", idToken))
+	}
+
+	q := req.URL.Query()
+	q.Add("chain", strconv.Itoa(int(tids.Chain)))
+	q.Add("address", tids.ContractAddress.String())
+	q.Add("tokenId", tids.TokenID.Base10String())
+	req.URL.RawQuery = q.Encode()
+
+	return req, nil
+}
+
+// cacheHTMLSnapshot renders a HTML token with the rasterizer and caches a still of the rendered page as a thumbnail and, if the page is animated,
+// a short video loop as a live render. The still is skipped if withImage is false, e.g. when the metadata already provided an image.
+func cacheHTMLSnapshot(ctx context.Context, htmlURL string, tids persist.TokenIdentifiers, bucket string, withImage bool, httpClient *http.Client, client *storage.Client, subMeta *cachePipelineMetadata) ([]cachedMediaObject, error) {
+	traceCallback, ctx := persist.TrackStepStatus(ctx, subMeta.HTMLSnapshot, "HTMLSnapshot")
+	defer traceCallback()
+
+	req, err := newRasterizerRequest(ctx, "snapshot", htmlURL, tids)
+	if err != nil {
+		persist.FailStep(subMeta.HTMLSnapshot)
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("renderTimeout", strconv.FormatInt(htmlSnapshotRenderTimeout.Milliseconds(), 10))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		persist.FailStep(subMeta.HTMLSnapshot)
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		persist.FailStep(subMeta.HTMLSnapshot)
+		bs, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("rasterizer returned non-200 status code: %d (%s)", resp.StatusCode, string(bs))
+	}
+
+	var snapshotResp snapshotResponse
+	if err := json.NewDecoder(resp.Body).Decode(&snapshotResp); err != nil {
+		persist.FailStep(subMeta.HTMLSnapshot)
+		return nil, err
+	}
+
+	objects := make([]cachedMediaObject, 0, 2)
+
+	if withImage {
+		data, err := base64.StdEncoding.DecodeString(snapshotResp.PNG)
+		if err != nil {
+			persist.FailStep(subMeta.HTMLSnapshot)
+			return nil, fmt.Errorf("could not decode base64 data: %s", err)
+		}
+
+		pngObject := cachedMediaObject{
+			MediaType:       persist.MediaTypeImage,
+			ContentType:     "image/png",
+			TokenID:         tids.TokenID,
+			ContractAddress: tids.ContractAddress,
+			Chain:           tids.Chain,
+			ContentLength:   util.ToPointer(int64(len(data))),
+			ObjectType:      mediaTypeToObjectType(persist.MediaTypeImage, objectTypeThumbnail),
+		}
+
+		if err := cacheBytes(ctx, client, bucket, pngObject, data, htmlURL); err != nil {
+			persist.FailStep(subMeta.HTMLSnapshot)
+			return nil, err
+		}
+
+		objects = append(objects, pngObject)
+	}
+
+	if snapshotResp.MP4 != nil {
+		data, err := base64.StdEncoding.DecodeString(*snapshotResp.MP4)
+		if err != nil {
+			persist.FailStep(subMeta.HTMLSnapshot)
+			return nil, fmt.Errorf("could not decode base64 data: %s", err)
+		}
+
+		mp4Object := cachedMediaObject{
+			MediaType:       persist.MediaTypeVideo,
+			ContentType:     "video/mp4",
+			TokenID:         tids.TokenID,
+			ContractAddress: tids.ContractAddress,
+			Chain:           tids.Chain,
+			ContentLength:   util.ToPointer(int64(len(data))),
+			ObjectType:      mediaTypeToObjectType(persist.MediaTypeVideo, objectTypeLiveRender),
+		}
+
+		if err := cacheBytes(ctx, client, bucket, mp4Object, data, htmlURL); err != nil {
+			persist.FailStep(subMeta.HTMLSnapshot)
+			return nil, err
+		}
+
+		objects = append(objects, mp4Object)
+	}
+
+	return objects, nil
+}
+
+// cacheBytes writes data to storage as the object
+func cacheBytes(ctx context.Context, client *storage.Client, bucket string, object cachedMediaObject, data []byte, ogURL string) error {
+	sw := newObjectWriter(ctx, client, bucket, object.fileName(), object.ContentLength,
+		objAttrsOpts.WithContentType(object.ContentType),
+		objAttrsOpts.WithCustomMetadata(map[string]string{"originalURL": truncateString(ogURL, 100), "mediaType": object.MediaType.String()}),
+	)
+
+	if _, err := sw.Write(data); err != nil {
+		return fmt.Errorf("could not write to bucket %s for %s: %s", bucket, object.fileName(), err)
+	}
+
+	if err := sw.Close(); err != nil {
+		return err
+	}
+
+	purgeIfExists(ctx, bucket, object.fileName(), client)
+	return nil
+}
+
 func thumbnailAndCache(ctx context.Context, tids persist.TokenIdentifiers, videoURL, bucket string, client *storage.Client, subMeta *cachePipelineMetadata) (cachedMediaObject, error) {
 	traceCallback, ctx := persist.TrackStepStatus(ctx, subMeta.ThumbnailGCP, "ThumbnailGCP")
 	defer traceCallback()
@@ -847,6 +989,7 @@ func createLiveRenderAndCache(ctx context.Context, tids persist.TokenIdentifiers
 func readerFromURL(ctx context.Context, mediaURL string, mediaType persist.MediaType, ipfsClient *shell.Shell, arweaveClient *goar.Client, subMeta *cachePipelineMetadata) (*util.FileHeaderReader, persist.MediaType, error) {
 	traceCallback, ctx := persist.TrackStepStatus(ctx, subMeta.ReaderRetrieval, "ReaderRetrieval")
 	defer traceCallback()
+	persist.AnnotateStep(ctx, "url", mediaURL)
 
 	reader, mediaType, err := rpc.GetDataFromURIAsReader(ctx, persist.TokenURI(mediaURL), mediaType, ipfsClient, arweaveClient, util.MB, time.Minute, true)
 	if err != nil {
@@ -874,6 +1017,7 @@ func cacheObjectsFromURL(pCtx context.Context, tids persist.TokenIdentifiers, me
 		if err != nil {
 			persist.FailStep(subMeta.ContentHeaderValueRetrieval)
 		}
+		persist.AnnotateStep(pCtx, "media_type", mediaType.String())
 		pCtx = logger.NewContextWithFields(pCtx, logrus.Fields{
 			"predictedMediaType":   mediaType,
 			"predictedContentType": contentType,
@@ -950,6 +1094,10 @@ func cacheObjectsFromURL(pCtx context.Context, tids persist.TokenIdentifiers, me
 		return []cachedMediaObject{obj}, nil
 	}
 
+	if mediaType == persist.MediaTypeSVG {
+		return cacheSVG(pCtx, reader, tids, contentType, oType, bucket, mediaURL, httpClient, storageClient, subMeta)
+	}
+
 	timeBeforeCache := time.Now()
 	obj, err := cacheRawMedia(pCtx, reader, tids, mediaType, contentLength, contentType, oType, bucket, mediaURL, storageClient, subMeta)
 	if err != nil {
@@ -974,16 +1122,6 @@ func cacheObjectsFromURL(pCtx context.Context, tids persist.TokenIdentifiers, me
 			result = append(result, liveObj)
 		}
 
-	} else if mediaType == persist.MediaTypeSVG {
-		timeBeforeCache := time.Now()
-		obj, err := cacheRasterizedSVG(pCtx, obj.storageURL(bucket), tids, bucket, mediaURL, httpClient, storageClient, subMeta)
-		if err != nil {
-			logger.For(pCtx).Errorf("could not cache svg rasterization: %s", err)
-			// still return the original object as svg
-			return result, nil
-		}
-		logger.For(pCtx).Infof("cached animation for %s in %s", tids, time.Since(timeBeforeCache))
-		return append(result, obj...), nil
 	}
 
 	return result, nil
@@ -0,0 +1,137 @@
+package tokenprocessing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/moderation"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/service/rpc"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+// videoFrameOffsets are the timestamps of the frames that are classified when moderating a video
+var videoFrameOffsets = []string{"00:00:00.000", "00:00:02.000", "00:00:05.000"}
+
+// maxMarkupScanSize is the most bytes of an SVG or HTML document that are scanned
+const maxMarkupScanSize = 5 * util.MB
+
+// moderateMedia scores the media's images and video frames with the classifier and scans SVG and HTML media for scripts.
+// Moderation never fails the pipeline: if a check can't be run, the remaining checks still decide the label.
+func (tpj *tokenProcessingJob) moderateMedia(ctx context.Context, m persist.Media) persist.Media {
+	if !m.IsServable() {
+		return m
+	}
+
+	traceCallback, ctx := persist.TrackStepStatus(ctx, &tpj.pipelineMetadata.Moderation, "Moderation")
+	defer traceCallback()
+
+	var (
+		score    float64
+		findings []moderation.Finding
+	)
+
+	classify := func(f func() (float64, error)) {
+		s, err := f()
+		if err != nil {
+			logger.For(ctx).Warnf("failed to classify media: %s", err)
+			persist.FailStep(&tpj.pipelineMetadata.Moderation)
+			return
+		}
+		if s > score {
+			score = s
+		}
+	}
+
+	switch m.MediaType {
+	case persist.MediaTypeImage, persist.MediaTypeGIF:
+		if tpj.tp.classifier != nil {
+			classify(func() (float64, error) { return tpj.classifyURL(ctx, m.MediaURL.String()) })
+		}
+	case persist.MediaTypeVideo:
+		if tpj.tp.classifier != nil {
+			for _, offset := range videoFrameOffsets {
+				classify(func() (float64, error) { return tpj.classifyVideoFrame(ctx, m.MediaURL.String(), offset) })
+			}
+		}
+	case persist.MediaTypeSVG, persist.MediaTypeHTML:
+		content, err := tpj.readMarkup(ctx, m.MediaURL.String())
+		if err != nil {
+			logger.For(ctx).Warnf("failed to read markup for moderation: %s", err)
+			persist.FailStep(&tpj.pipelineMetadata.Moderation)
+		} else {
+			findings = moderation.ScanMarkup(content)
+		}
+	}
+
+	// The thumbnail is also what's shown for media types that can't be classified directly, e.g. a snapshot of an HTML token
+	if tpj.tp.classifier != nil && m.ThumbnailURL != "" && m.ThumbnailURL != m.MediaURL {
+		classify(func() (float64, error) { return tpj.classifyURL(ctx, m.ThumbnailURL.String()) })
+	}
+
+	result := moderation.Label(score, findings)
+	if result.IsFlagged() {
+		logger.For(ctx).Infof("media labeled as %s: %v", result.Label, result.Reasons)
+	}
+
+	m.Moderation = &result
+	return m
+}
+
+func (tpj *tokenProcessingJob) classifyURL(ctx context.Context, mediaURL string) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := tpj.tp.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, util.ErrHTTP{URL: mediaURL, Status: resp.StatusCode}
+	}
+
+	return tpj.tp.classifier.Classify(ctx, resp.Body, resp.Header.Get("Content-Type"))
+}
+
+func (tpj *tokenProcessingJob) classifyVideoFrame(ctx context.Context, videoURL, offset string) (float64, error) {
+	frame := new(bytes.Buffer)
+	if err := videoFrameToWriter(ctx, videoURL, offset, frame); err != nil {
+		return 0, err
+	}
+	// The video is shorter than the offset
+	if frame.Len() == 0 {
+		return 0, nil
+	}
+	return tpj.tp.classifier.Classify(ctx, frame, "image/jpeg")
+}
+
+func (tpj *tokenProcessingJob) readMarkup(ctx context.Context, mediaURL string) ([]byte, error) {
+	reader, _, err := rpc.GetDataFromURIAsReader(ctx, persist.TokenURI(mediaURL), "", tpj.tp.ipfsClient, tpj.tp.arweaveClient, util.MB, time.Minute, true)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, maxMarkupScanSize))
+}
+
+func videoFrameToWriter(ctx context.Context, url, offset string, writer io.Writer) error {
+	c := exec.CommandContext(ctx, "ffmpeg", "-hide_banner", "-loglevel", "error", "-ss", offset, "-i", url, "-vframes", "1", "-f", "mjpeg", "pipe:1")
+	errBuf := new(bytes.Buffer)
+	c.Stderr = errBuf
+	c.Stdout = writer
+	err := c.Run()
+	if _, ok := isExitErr(err); ok {
+		return errors.New(errBuf.String())
+	}
+	return err
+}
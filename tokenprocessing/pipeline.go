@@ -15,6 +15,7 @@ import (
 	"github.com/mikeydub/go-gallery/platform"
 	"github.com/mikeydub/go-gallery/service/logger"
 	"github.com/mikeydub/go-gallery/service/media"
+	"github.com/mikeydub/go-gallery/service/moderation"
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/rpc"
 	"github.com/mikeydub/go-gallery/service/tokenmanage"
@@ -29,9 +30,10 @@ type tokenProcessor struct {
 	arweaveClient  *goar.Client
 	stg            *storage.Client
 	tokenBucket    string
+	classifier     moderation.Classifier
 }
 
-func NewTokenProcessor(queries *db.Queries, httpClient *http.Client, metadataFinder *MetadataFinder, ipfsClient *shell.Shell, arweaveClient *goar.Client, stg *storage.Client, tokenBucket string) *tokenProcessor {
+func NewTokenProcessor(queries *db.Queries, httpClient *http.Client, metadataFinder *MetadataFinder, ipfsClient *shell.Shell, arweaveClient *goar.Client, stg *storage.Client, tokenBucket string, classifier moderation.Classifier) *tokenProcessor {
 	return &tokenProcessor{
 		queries:        queries,
 		metadataFinder: metadataFinder,
@@ -40,6 +42,7 @@ func NewTokenProcessor(queries *db.Queries, httpClient *http.Client, metadataFin
 		arweaveClient:  arweaveClient,
 		stg:            stg,
 		tokenBucket:    tokenBucket,
+		classifier:     classifier,
 	}
 }
 
@@ -50,6 +53,8 @@ type tokenProcessingJob struct {
 	contract         persist.ContractIdentifiers
 	cause            persist.ProcessingCause
 	pipelineMetadata *persist.PipelineMetadata
+	// tracer records the timing and outcome of each step of the run. The summary is saved with the media that the run produced.
+	tracer *persist.PipelineTracer
 	// profileImageKey is an optional key in the metadata that the pipeline should also process as a profile image.
 	// The pipeline only looks at the root level of the metadata for the key and will also not fail if the key is missing
 	// or if processing media for the key fails.
@@ -179,6 +184,7 @@ func wrapWithBadTokenErr(err error) error {
 
 func (tpj *tokenProcessingJob) run(ctx context.Context) (persist.Media, persist.TokenMetadata, error) {
 	ctx = logger.NewContextWithFields(ctx, logrus.Fields{"runID": tpj.id})
+	ctx = persist.NewContextWithPipelineTracer(ctx, tpj.tracer)
 	logger.For(ctx).Infof("starting pipeline for token=%s", tpj.token)
 
 	var (
@@ -225,6 +231,7 @@ func (tpj *tokenProcessingJob) run(ctx context.Context) (persist.Media, persist.
 		tpj.requireImage && imgURL != "",
 		tpj.requireFxHashSigned,
 	)
+	tokenMedia = tpj.moderateMedia(ctx, tokenMedia)
 	return tokenMedia, metadata, err
 }
 
@@ -269,9 +276,11 @@ func (tpj *tokenProcessingJob) cacheMediaFromOriginalURLs(ctx context.Context, i
 		DetermineMediaTypeWithReader: &tpj.pipelineMetadata.ImageDetermineMediaTypeWithReader,
 		AnimationGzip:                &tpj.pipelineMetadata.ImageAnimationGzip,
 		SVGRasterize:                 &tpj.pipelineMetadata.ImageSVGRasterize,
+		SVGSanitize:                  &tpj.pipelineMetadata.ImageSVGSanitize,
 		StoreGCP:                     &tpj.pipelineMetadata.ImageStoreGCP,
 		ThumbnailGCP:                 &tpj.pipelineMetadata.ImageThumbnailGCP,
 		LiveRenderGCP:                &tpj.pipelineMetadata.ImageLiveRenderGCP,
+		HTMLSnapshot:                 &tpj.pipelineMetadata.ImageHTMLSnapshot,
 	}
 	pfpRunMetadata := &cachePipelineMetadata{
 		ContentHeaderValueRetrieval:  &tpj.pipelineMetadata.ProfileImageContentHeaderValueRetrieval,
@@ -279,9 +288,11 @@ func (tpj *tokenProcessingJob) cacheMediaFromOriginalURLs(ctx context.Context, i
 		DetermineMediaTypeWithReader: &tpj.pipelineMetadata.ProfileImageDetermineMediaTypeWithReader,
 		AnimationGzip:                &tpj.pipelineMetadata.ProfileImageAnimationGzip,
 		SVGRasterize:                 &tpj.pipelineMetadata.ProfileImageSVGRasterize,
+		SVGSanitize:                  &tpj.pipelineMetadata.ProfileImageSVGSanitize,
 		StoreGCP:                     &tpj.pipelineMetadata.ProfileImageStoreGCP,
 		ThumbnailGCP:                 &tpj.pipelineMetadata.ProfileImageThumbnailGCP,
 		LiveRenderGCP:                &tpj.pipelineMetadata.ProfileImageLiveRenderGCP,
+		HTMLSnapshot:                 &tpj.pipelineMetadata.ProfileImageHTMLSnapshot,
 	}
 	animRunMetadata := &cachePipelineMetadata{
 		ContentHeaderValueRetrieval:  &tpj.pipelineMetadata.AnimationContentHeaderValueRetrieval,
@@ -289,9 +300,11 @@ func (tpj *tokenProcessingJob) cacheMediaFromOriginalURLs(ctx context.Context, i
 		DetermineMediaTypeWithReader: &tpj.pipelineMetadata.AnimationDetermineMediaTypeWithReader,
 		AnimationGzip:                &tpj.pipelineMetadata.AnimationAnimationGzip,
 		SVGRasterize:                 &tpj.pipelineMetadata.AnimationSVGRasterize,
+		SVGSanitize:                  &tpj.pipelineMetadata.AnimationSVGSanitize,
 		StoreGCP:                     &tpj.pipelineMetadata.AnimationStoreGCP,
 		ThumbnailGCP:                 &tpj.pipelineMetadata.AnimationThumbnailGCP,
 		LiveRenderGCP:                &tpj.pipelineMetadata.AnimationLiveRenderGCP,
+		HTMLSnapshot:                 &tpj.pipelineMetadata.AnimationHTMLSnapshot,
 	}
 	return tpj.cacheMediaSources(ctx, imgURL, pfpURL, animURL, imgRunMetadata, pfpRunMetadata, animRunMetadata)
 }
@@ -307,9 +320,11 @@ func (tpj *tokenProcessingJob) cacheMediaFromPlaceholder(ctx context.Context) (i
 		DetermineMediaTypeWithReader: &tpj.pipelineMetadata.AlternateImageDetermineMediaTypeWithReader,
 		AnimationGzip:                &tpj.pipelineMetadata.AlternateImageAnimationGzip,
 		SVGRasterize:                 &tpj.pipelineMetadata.AlternateImageSVGRasterize,
+		SVGSanitize:                  &tpj.pipelineMetadata.AlternateImageSVGSanitize,
 		StoreGCP:                     &tpj.pipelineMetadata.AlternateImageStoreGCP,
 		ThumbnailGCP:                 &tpj.pipelineMetadata.AlternateImageThumbnailGCP,
 		LiveRenderGCP:                &tpj.pipelineMetadata.AlternateImageLiveRenderGCP,
+		HTMLSnapshot:                 &tpj.pipelineMetadata.AlternateImageHTMLSnapshot,
 	}
 
 	imgResult, _, animResult = tpj.cacheMediaSources(ctx, media.ImageURL(tpj.placeHolderImageURL), "", "", imgRunMetadata, nil, nil)
@@ -350,9 +365,34 @@ func (tpj *tokenProcessingJob) cacheMediaSources(
 		animResult = <-animCh
 	}
 
+	// HTML isn't cached, so render it instead to have something to preview. A still is only
+	// kept if the metadata didn't provide an image.
+	if isHTMLResult(animResult) {
+		_, hasImage := findFirstImageObject(imgResult.cachedObjects)
+		animResult = tpj.snapshotHTML(ctx, animResult, !hasImage, animRunMetadata)
+	} else if isHTMLResult(imgResult) {
+		imgResult = tpj.snapshotHTML(ctx, imgResult, true, imgRunMetadata)
+	}
+
 	return imgResult, pfpResult, animResult
 }
 
+func isHTMLResult(result cacheResult) bool {
+	notCacheableErr, ok := result.err.(errNotCacheable)
+	return ok && notCacheableErr.MediaType == persist.MediaTypeHTML
+}
+
+func (tpj *tokenProcessingJob) snapshotHTML(ctx context.Context, result cacheResult, withImage bool, subMeta *cachePipelineMetadata) cacheResult {
+	htmlURL := result.err.(errNotCacheable).URL
+	objects, err := cacheHTMLSnapshot(ctx, htmlURL, tpj.token, tpj.tp.tokenBucket, withImage, tpj.tp.httpClient, tpj.tp.stg, subMeta)
+	if err != nil {
+		logger.For(ctx).Errorf("could not snapshot html for %s: %s", tpj.token, err)
+		return result
+	}
+	result.cachedObjects = append(result.cachedObjects, objects...)
+	return result
+}
+
 func (tpj *tokenProcessingJob) cacheMediaFromURLs(ctx context.Context, imgURL, pfpURL media.ImageURL, animURL media.AnimationURL, metadata persist.TokenMetadata, requireImg, requireSigned bool) (m persist.Media, err error) {
 	ctx = logger.NewContextWithFields(ctx, logrus.Fields{
 		"imgURL":        imgURL,
@@ -367,6 +407,14 @@ func (tpj *tokenProcessingJob) cacheMediaFromURLs(ctx context.Context, imgURL, p
 
 	imgResult, pfpResult, animResult := tpj.cacheMediaFromOriginalURLs(ctx, imgURL, pfpURL, animURL)
 
+	// Keep why a source couldn't be cached, e.g. to know why an animation fell back to its image
+	if imgResult.err != nil {
+		persist.AnnotateStep(ctx, "image_error", imgResult.err.Error())
+	}
+	if animResult.err != nil {
+		persist.AnnotateStep(ctx, "animation_error", animResult.err.Error())
+	}
+
 	if (!requireImg && animResult.IsSuccess()) || imgResult.IsSuccess() {
 		err = tpj.createErrFromResults(animResult, imgResult, metadata, requireImg, requireSigned)
 		return createMediaFromResults(ctx, tpj, animResult, imgResult, pfpResult), err
@@ -375,6 +423,7 @@ func (tpj *tokenProcessingJob) cacheMediaFromURLs(ctx context.Context, imgURL, p
 	// If there is a placeholder URL available, use that instead.
 	placeHolderImgResult, placeHolderAnimResult := tpj.cacheMediaFromPlaceholder(ctx)
 	if !imgResult.IsSuccess() && placeHolderImgResult.IsSuccess() {
+		persist.AnnotateStep(ctx, "fallback", "placeholder image")
 		imgResult = placeHolderImgResult
 	}
 	if !animResult.IsSuccess() && placeHolderAnimResult.IsSuccess() {
@@ -453,6 +502,7 @@ func (tpj *tokenProcessingJob) saveResult(ctx context.Context, media persist.Med
 		PipelineMetadata: *tpj.pipelineMetadata,
 		ProcessingCause:  tpj.cause,
 		ProcessorVersion: "",
+		PipelineTrace:    tpj.tracer.Trace(),
 		RetiringMediaID:  persist.GenerateID(),
 		Chain:            tpj.token.Chain,
 		ContractAddress:  tpj.contract.ContractAddress,
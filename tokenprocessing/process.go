@@ -26,10 +26,13 @@ import (
 	"github.com/mikeydub/go-gallery/service/multichain/operation"
 	"github.com/mikeydub/go-gallery/service/persist"
 	"github.com/mikeydub/go-gallery/service/persist/postgres"
+	"github.com/mikeydub/go-gallery/service/rpc/ipfs"
 	sentryutil "github.com/mikeydub/go-gallery/service/sentry"
+	"github.com/mikeydub/go-gallery/service/spam"
 	"github.com/mikeydub/go-gallery/service/task"
 	"github.com/mikeydub/go-gallery/service/throttle"
 	"github.com/mikeydub/go-gallery/service/tokenmanage"
+	"github.com/mikeydub/go-gallery/service/traits"
 	"github.com/mikeydub/go-gallery/util"
 	"github.com/mikeydub/go-gallery/util/retry"
 )
@@ -69,6 +72,10 @@ func processBatch(tp *tokenProcessor, queries *db.Queries, taskClient *task.Clie
 				}
 
 				ctx := sentryutil.NewSentryHubContext(reqCtx)
+				if input.RefreshMetadata {
+					_, err = runManagedPipeline(ctx, tp, tm, td, c, persist.ProcessingCauseRefresh, 0, PipelineOpts.WithRefreshMetadata())
+					return err
+				}
 				_, err = runManagedPipeline(ctx, tp, tm, td, c, persist.ProcessingCauseSync, 0)
 				return err
 			})
@@ -305,6 +312,84 @@ func processOwnersForOpenseaTokens(mc *multichain.Provider, queries *db.Queries)
 	}
 }
 
+type gatewayStatResponse struct {
+	Source      string  `json:"source"`
+	Successes   int64   `json:"successes"`
+	Failures    int64   `json:"failures"`
+	Mismatches  int64   `json:"mismatches"`
+	SuccessRate float64 `json:"success_rate"`
+}
+
+// scheduleMetadataRefreshes enqueues metadata refreshes for tokens that have announced metadata updates and for contracts whose refresh
+// policy is due. It's called on a schedule.
+func scheduleMetadataRefreshes(scheduler *tokenmanage.RefreshScheduler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		summary, err := scheduler.Run(c)
+		if err != nil {
+			util.ErrResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+		for _, l := range summary.Logs {
+			logger.For(c).Infof("scheduled metadata refreshes from logs: chain=%d; blocks=%d-%d; updates=%d; tokens=%d", l.Chain, l.FromBlock, l.ToBlock, l.MetadataUpdates, l.Tokens)
+		}
+		logger.For(c).Infof("scheduled metadata refreshes: policies=%d; tokens=%d", summary.ScheduledRefresh, summary.Tokens)
+		c.JSON(http.StatusOK, summary)
+	}
+}
+
+// ipfsGatewayStats returns how often each IPFS source has returned valid content since the server started
+func ipfsGatewayStats(f *ipfs.Fetcher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stats := util.MapWithoutError(f.Stats(), func(s ipfs.GatewayStat) gatewayStatResponse {
+			return gatewayStatResponse{
+				Source:      s.Source,
+				Successes:   s.Successes,
+				Failures:    s.Failures,
+				Mismatches:  s.Mismatches,
+				SuccessRate: s.SuccessRate(),
+			}
+		})
+		c.JSON(http.StatusOK, stats)
+	}
+}
+
+// pinGalleryMedia pins the IPFS media of the tokens in a gallery's visible collections
+func pinGalleryMedia(queries *db.Queries, pins *ipfs.PinService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input task.IPFSPinGalleryMessage
+		if err := c.ShouldBindJSON(&input); err != nil {
+			// Remove from queue if bad message
+			util.ErrResponse(c, http.StatusOK, err)
+			return
+		}
+
+		tds, err := queries.GetPinnableTokenDefinitionsByGalleryID(c, input.GalleryID)
+		if err != nil {
+			util.ErrResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		cids := make([]string, 0, len(tds))
+		for _, td := range tds {
+			imgURL, animURL, _ := media.FindMediaURLsChain(td.Metadata, td.Chain)
+			for _, u := range []string{string(imgURL), string(animURL)} {
+				if cid, ok := ipfs.CIDFromURL(u); ok {
+					cids = append(cids, cid)
+				}
+			}
+		}
+
+		logger.For(c).Infof("pinning %d cids for gallery %s", len(cids), input.GalleryID)
+
+		if err := pins.PinGallery(c, input.GalleryID, cids); err != nil {
+			util.ErrResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, util.SuccessResponse{Success: true})
+	}
+}
+
 // detectSpamContracts refreshes the alchemy_spam_contracts table with marked contracts from Alchemy
 func detectSpamContracts(queries *db.Queries) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -387,6 +472,49 @@ func detectSpamContracts(queries *db.Queries) gin.HandlerFunc {
 	}
 }
 
+// scoreSpamContracts scores the contracts that haven't been scored recently. It's called on a schedule.
+func scoreSpamContracts(scorer *spam.Scorer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scored, err := scorer.ScoreStale(c, 500)
+		if err != nil {
+			util.ErrResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+		logger.For(c).Infof("scored %d contracts for spam", scored)
+		c.JSON(http.StatusOK, util.SuccessResponse{Success: true})
+	}
+}
+
+func enrichContracts(enricher *multichain.ContractEnricher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input task.ContractEnrichmentMessage
+		if err := c.ShouldBindJSON(&input); err != nil {
+			// Remove from queue if bad message
+			util.ErrResponse(c, http.StatusOK, err)
+			return
+		}
+
+		if err := enricher.Refresh(c, input.Chain, input.Contracts); err != nil {
+			util.ErrResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, util.SuccessResponse{Success: true})
+	}
+}
+
+func indexCommunityTraits(indexer *traits.Indexer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		indexed, err := indexer.IndexStale(c, 100)
+		if err != nil {
+			util.ErrResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+		logger.For(c).Infof("indexed traits of %d communities", indexed)
+		c.JSON(http.StatusOK, util.SuccessResponse{Success: true})
+	}
+}
+
 func processWalletRemoval(queries *db.Queries) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var input task.TokenProcessingWalletRemovalMessage
@@ -802,6 +930,7 @@ func runManagedPipeline(ctx context.Context, tp *tokenProcessor, tm *tokenmanage
 		contract:         cID,
 		cause:            cause,
 		pipelineMetadata: new(persist.PipelineMetadata),
+		tracer:           new(persist.PipelineTracer),
 	}
 
 	for _, opt := range runOpts {
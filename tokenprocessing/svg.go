@@ -0,0 +1,442 @@
+package tokenprocessing
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/persist"
+	"github.com/mikeydub/go-gallery/util"
+)
+
+// maxSVGSize is the largest SVG that will be read into memory to be sanitized
+const maxSVGSize = 20 * util.MB
+
+var errSVGTooLarge = fmt.Errorf("svg is larger than %d bytes", maxSVGSize)
+
+// svgRemovedElements are elements that are dropped along with everything inside of them
+var svgRemovedElements = map[string]bool{
+	"script":   true,
+	"iframe":   true,
+	"frame":    true,
+	"frameset": true,
+	"object":   true,
+	"embed":    true,
+	"applet":   true,
+	"base":     true,
+	"link":     true,
+	"meta":     true,
+	"handler":  true,
+	"listener": true,
+}
+
+// svgAnimationElements are elements that can change the value of another attribute, e.g. to swap in a javascript: URL
+var svgAnimationElements = map[string]bool{
+	"animate":       true,
+	"animatemotion": true,
+	"set":           true,
+}
+
+// svgReferenceAttributes are attributes that load or navigate to another resource
+var svgReferenceAttributes = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"data":       true,
+	"codebase":   true,
+}
+
+var (
+	cssImportPattern = regexp.MustCompile(`(?i)@import\s+[^;]*;?`)
+	cssURLPattern    = regexp.MustCompile(`(?i)url\(\s*(?:"([^"]*)"|'([^']*)'|([^)]*))\s*\)`)
+	// svgEntityPattern matches the internal general entities of a document type declaration. Parameter entities and
+	// external entities don't match, so they're never expanded.
+	svgEntityPattern    = regexp.MustCompile(`<!ENTITY\s+([A-Za-z_:][\w.:-]*)\s+(?:"([^"]*)"|'([^']*)')\s*>`)
+	svgEntityRefPattern = regexp.MustCompile(`&(#x[0-9a-fA-F]+|#[0-9]+|[A-Za-z_:][\w.:-]*);`)
+)
+
+// maxSVGEntitySize is the longest that an entity can be once the entities it references are expanded, which stops
+// entities that reference each other from expanding exponentially
+const maxSVGEntitySize = 64 * util.KB
+
+var (
+	svgTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	svgAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;")
+)
+
+// cacheSVG stores the SVG as it was found in the metadata alongside a sanitized copy. The sanitized copy is what's served
+// unless the sanitizer didn't need to remove anything, in which case the original is served as is. The rasterized preview
+// is made from the original because the rasterizer renders in a sandbox, which keeps previews of scripted art intact.
+func cacheSVG(ctx context.Context, reader io.Reader, tids persist.TokenIdentifiers, contentType string, oType objectType, bucket, ogURL string, httpClient *http.Client, client *storage.Client, subMeta *cachePipelineMetadata) ([]cachedMediaObject, error) {
+	original, err := io.ReadAll(io.LimitReader(reader, maxSVGSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(original) > maxSVGSize {
+		return nil, errSVGTooLarge
+	}
+
+	originalObj, err := cacheRawMedia(ctx, util.NewFileHeaderReader(bytes.NewReader(original), util.MB), tids, persist.MediaTypeSVG, util.ToPointer(int64(len(original))), contentType, objectTypeOriginalSVG, bucket, ogURL, client, subMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	sanitization, served, err := sanitizeSVGWithStep(ctx, original, subMeta)
+	if err != nil {
+		// The sanitizer only fails on SVGs that aren't well-formed, which browsers refuse to render anyway
+		return nil, errInvalidMedia{URL: ogURL, err: err}
+	}
+	sanitization.OriginalURL = persist.NullString(originalObj.storageURL(bucket))
+
+	obj, err := cacheRawMedia(ctx, util.NewFileHeaderReader(bytes.NewReader(served), util.MB), tids, persist.MediaTypeSVG, util.ToPointer(int64(len(served))), "image/svg+xml", oType, bucket, ogURL, client, subMeta)
+	if err != nil {
+		return nil, err
+	}
+	obj.SVGSanitization = &sanitization
+
+	result := []cachedMediaObject{obj, originalObj}
+
+	timeBeforeCache := time.Now()
+	rasterized, err := cacheRasterizedSVG(ctx, originalObj.storageURL(bucket), tids, bucket, ogURL, httpClient, client, subMeta)
+	if err != nil {
+		logger.For(ctx).Errorf("could not cache svg rasterization: %s", err)
+		// still return the svg objects
+		return result, nil
+	}
+	logger.For(ctx).Infof("cached rasterized svg for %s in %s", tids, time.Since(timeBeforeCache))
+
+	return append(result, rasterized...), nil
+}
+
+func sanitizeSVGWithStep(ctx context.Context, original []byte, subMeta *cachePipelineMetadata) (persist.SVGSanitization, []byte, error) {
+	traceCallback, ctx := persist.TrackStepStatus(ctx, subMeta.SVGSanitize, "SVGSanitize")
+	defer traceCallback()
+
+	sanitized, removed, err := sanitizeSVG(original)
+	if err != nil {
+		persist.FailStep(subMeta.SVGSanitize)
+		return persist.SVGSanitization{}, nil, err
+	}
+
+	if len(removed) == 0 {
+		return persist.SVGSanitization{Served: persist.SVGVariantOriginal}, original, nil
+	}
+
+	logger.For(ctx).Infof("removed %v from svg", removed)
+	return persist.SVGSanitization{Served: persist.SVGVariantSanitized, Removed: removed}, sanitized, nil
+}
+
+// sanitizeSVG rewrites an SVG without scripts, event handlers, external references, or document type declarations. Everything
+// else, including comments and the original casing of names, is written back as it was found so that the art renders the same.
+// It returns the content that was removed in a stable order.
+func sanitizeSVG(original []byte) ([]byte, []string, error) {
+	d := xml.NewDecoder(bytes.NewReader(original))
+	d.Strict = true
+
+	var (
+		out     bytes.Buffer
+		removed = make(map[string]bool)
+		// skipDepth is the depth inside of an element that is being removed
+		skipDepth int
+		// openTag is true when the last start tag hasn't been closed yet, so that an empty element can be written as self-closing
+		openTag bool
+		// elements are the names of the elements that are currently open
+		elements []string
+	)
+
+	closeTag := func() {
+		if openTag {
+			out.WriteString(">")
+			openTag = false
+		}
+	}
+
+	for {
+		tok, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if out.Len() > maxSVGSize {
+			return nil, nil, errSVGTooLarge
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if skipDepth > 0 {
+				skipDepth++
+				continue
+			}
+			name := strings.ToLower(t.Name.Local)
+			if svgRemovedElements[name] || (svgAnimationElements[name] && animatesReference(t)) {
+				removed["element:"+name] = true
+				skipDepth = 1
+				continue
+			}
+			closeTag()
+			out.WriteString("<" + qualifiedName(t.Name))
+			for _, attr := range t.Attr {
+				value, ok := sanitizeSVGAttr(attr, removed)
+				if !ok {
+					continue
+				}
+				out.WriteString(" " + qualifiedName(attr.Name) + `="` + svgAttrEscaper.Replace(value) + `"`)
+			}
+			openTag = true
+			elements = append(elements, name)
+		case xml.EndElement:
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			if openTag {
+				out.WriteString("/>")
+				openTag = false
+			} else {
+				out.WriteString("</" + qualifiedName(t.Name) + ">")
+			}
+			if len(elements) > 0 {
+				elements = elements[:len(elements)-1]
+			}
+		case xml.CharData:
+			if skipDepth > 0 {
+				continue
+			}
+			closeTag()
+			text := string(t)
+			if len(elements) > 0 && elements[len(elements)-1] == "style" {
+				text = sanitizeCSS(text, removed)
+			}
+			out.WriteString(svgTextEscaper.Replace(text))
+		case xml.Comment:
+			if skipDepth > 0 {
+				continue
+			}
+			closeTag()
+			out.WriteString("<!--" + string(t) + "-->")
+		case xml.ProcInst:
+			if skipDepth > 0 {
+				continue
+			}
+			if t.Target != "xml" {
+				removed["instruction:"+t.Target] = true
+				continue
+			}
+			closeTag()
+			out.WriteString("<?xml " + string(t.Inst) + "?>")
+		case xml.Directive:
+			// Document type declarations are how external entities get into a document, so they're dropped. Editors like
+			// Illustrator declare internal entities for namespaces and reference them throughout the document, so those are
+			// expanded where they're used instead.
+			removed["doctype"] = true
+			if entities := svgInternalEntities(t); len(entities) > 0 {
+				// The decoder expands every reference to an entity in one go, so the rest of the document is measured
+				// with its references expanded before any of them are
+				if svgExpandedSize(original[d.InputOffset():], entities) > maxSVGSize {
+					return nil, nil, errSVGTooLarge
+				}
+				d.Entity = entities
+			}
+		}
+	}
+
+	reasons := make([]string, 0, len(removed))
+	for r := range removed {
+		reasons = append(reasons, r)
+	}
+	sort.Strings(reasons)
+
+	return out.Bytes(), reasons, nil
+}
+
+// sanitizeSVGAttr returns the attribute's value to write, or false if the attribute should be dropped
+func sanitizeSVGAttr(attr xml.Attr, removed map[string]bool) (string, bool) {
+	name := strings.ToLower(attr.Name.Local)
+
+	if strings.HasPrefix(name, "on") {
+		removed["attribute:"+name] = true
+		return "", false
+	}
+
+	if svgReferenceAttributes[name] && !isSafeSVGReference(attr.Value) {
+		removed["reference:"+name] = true
+		return "", false
+	}
+
+	if name == "style" || strings.Contains(strings.ToLower(attr.Value), "url(") {
+		return sanitizeCSS(attr.Value, removed), true
+	}
+
+	return attr.Value, true
+}
+
+// animatesReference returns true if an animation element targets a reference attribute or sets a script URL
+func animatesReference(t xml.StartElement) bool {
+	for _, attr := range t.Attr {
+		name := strings.ToLower(attr.Name.Local)
+		value := strings.ToLower(strings.TrimSpace(attr.Value))
+		if name == "attributename" && (svgReferenceAttributes[strings.TrimPrefix(value, "xlink:")]) {
+			return true
+		}
+		if (name == "to" || name == "from" || name == "values" || name == "by") && strings.Contains(value, "javascript:") {
+			return true
+		}
+	}
+	return false
+}
+
+// isSafeSVGReference returns true if the reference points to somewhere in the same document or is an embedded image or font
+func isSafeSVGReference(ref string) bool {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	return ref == "" ||
+		strings.HasPrefix(ref, "#") ||
+		strings.HasPrefix(ref, "data:image/") ||
+		strings.HasPrefix(ref, "data:font/") ||
+		strings.HasPrefix(ref, "data:application/font")
+}
+
+// sanitizeCSS removes imports and url() references to anything outside of the document
+func sanitizeCSS(css string, removed map[string]bool) string {
+	css = cssImportPattern.ReplaceAllStringFunc(css, func(string) string {
+		removed["style:import"] = true
+		return ""
+	})
+	return cssURLPattern.ReplaceAllStringFunc(css, func(match string) string {
+		groups := cssURLPattern.FindStringSubmatch(match)
+		ref := groups[1] + groups[2] + groups[3]
+		if isSafeSVGReference(ref) && strings.TrimSpace(ref) != "" {
+			return match
+		}
+		removed["style:url"] = true
+		return "none"
+	})
+}
+
+// svgInternalEntities returns the internal general entities declared by a document type declaration, with any entities
+// that they reference expanded. Entities that reference undeclared entities or that expand past maxSVGEntitySize are left
+// out, which makes documents that use them fail to parse.
+func svgInternalEntities(directive xml.Directive) map[string]string {
+	start := bytes.IndexByte(directive, '[')
+	end := bytes.LastIndexByte(directive, ']')
+	if start == -1 || end < start {
+		return nil
+	}
+
+	declared := make(map[string]string)
+	for _, m := range svgEntityPattern.FindAllSubmatch(directive[start+1:end], -1) {
+		name := string(m[1])
+		// The first declaration of an entity is binding
+		if _, ok := declared[name]; !ok {
+			declared[name] = string(m[2]) + string(m[3])
+		}
+	}
+
+	entities := make(map[string]string, len(declared))
+	for name := range declared {
+		if value, ok := expandSVGEntity(name, declared, map[string]bool{}); ok {
+			entities[name] = value
+		}
+	}
+	return entities
+}
+
+// svgExpandedSize returns how long content is once its references to entities are expanded. It stops counting once the
+// content is longer than maxSVGSize.
+func svgExpandedSize(content []byte, entities map[string]string) int {
+	size := len(content)
+	for pos := 0; size <= maxSVGSize; {
+		loc := svgEntityRefPattern.FindSubmatchIndex(content[pos:])
+		if loc == nil {
+			break
+		}
+		if value, ok := entities[string(content[pos+loc[2]:pos+loc[3]])]; ok {
+			size += len(value) - (loc[1] - loc[0])
+		}
+		pos += loc[1]
+	}
+	return size
+}
+
+func expandSVGEntity(name string, declared map[string]string, expanding map[string]bool) (string, bool) {
+	value, ok := declared[name]
+	if !ok || expanding[name] {
+		return "", false
+	}
+	expanding[name] = true
+	defer delete(expanding, name)
+
+	var out strings.Builder
+	last := 0
+	for _, loc := range svgEntityRefPattern.FindAllStringSubmatchIndex(value, -1) {
+		out.WriteString(value[last:loc[0]])
+		last = loc[1]
+		ref := value[loc[2]:loc[3]]
+		if predefined, ok := xmlPredefinedEntities[ref]; ok {
+			out.WriteString(predefined)
+			continue
+		}
+		if strings.HasPrefix(ref, "#") {
+			r, err := parseCharRef(ref[1:])
+			if err != nil {
+				return "", false
+			}
+			out.WriteRune(r)
+			continue
+		}
+		expanded, ok := expandSVGEntity(ref, declared, expanding)
+		if !ok {
+			return "", false
+		}
+		out.WriteString(expanded)
+		if out.Len() > maxSVGEntitySize {
+			return "", false
+		}
+	}
+	out.WriteString(value[last:])
+
+	if out.Len() > maxSVGEntitySize {
+		return "", false
+	}
+	return out.String(), true
+}
+
+func parseCharRef(ref string) (rune, error) {
+	var (
+		n   uint64
+		err error
+	)
+	if strings.HasPrefix(ref, "x") {
+		n, err = strconv.ParseUint(ref[1:], 16, 32)
+	} else {
+		n, err = strconv.ParseUint(ref, 10, 32)
+	}
+	if err != nil || !utf8.ValidRune(rune(n)) {
+		return 0, fmt.Errorf("invalid character reference &#%s;", ref)
+	}
+	return rune(n), nil
+}
+
+var xmlPredefinedEntities = map[string]string{"lt": "<", "gt": ">", "amp": "&", "apos": "'", "quot": `"`}
+
+func qualifiedName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
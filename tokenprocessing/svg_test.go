@@ -0,0 +1,141 @@
+package tokenprocessing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeSVG(t *testing.T) {
+	tests := []struct {
+		title    string
+		svg      string
+		expected string
+		removed  []string
+	}{
+		{
+			title:    "keeps clean svgs as is",
+			svg:      `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10"><!-- art --><rect fill="url(#g)" width="10" height="10"/></svg>`,
+			expected: `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10"><!-- art --><rect fill="url(#g)" width="10" height="10"/></svg>`,
+		},
+		{
+			title:    "removes scripts and their content",
+			svg:      `<svg><script type="text/javascript"><![CDATA[alert(1)]]></script><circle r="1"/></svg>`,
+			expected: `<svg><circle r="1"/></svg>`,
+			removed:  []string{"element:script"},
+		},
+		{
+			title:    "removes event handlers regardless of case",
+			svg:      `<svg onload="alert(1)"><rect OnClick="alert(2)" width="1"/></svg>`,
+			expected: `<svg><rect width="1"/></svg>`,
+			removed:  []string{"attribute:onclick", "attribute:onload"},
+		},
+		{
+			title:    "removes javascript urls",
+			svg:      `<svg><a href=" JavaScript:alert(1)"><text>x</text></a></svg>`,
+			expected: `<svg><a><text>x</text></a></svg>`,
+			removed:  []string{"reference:href"},
+		},
+		{
+			title:    "removes external xlink references and keeps internal and embedded ones",
+			svg:      `<svg xmlns:xlink="http://www.w3.org/1999/xlink"><image xlink:href="https://example.com/a.png"/><use xlink:href="#shape"/><image xlink:href="data:image/png;base64,AAAA"/></svg>`,
+			expected: `<svg xmlns:xlink="http://www.w3.org/1999/xlink"><image/><use xlink:href="#shape"/><image xlink:href="data:image/png;base64,AAAA"/></svg>`,
+			removed:  []string{"reference:href"},
+		},
+		{
+			title:    "removes css imports and external urls",
+			svg:      `<svg><style>@import url("https://example.com/a.css"); rect { fill: url('https://example.com/a.png'); stroke: url(#g) }</style><rect style="background: url(https://example.com/b.png)"/></svg>`,
+			expected: `<svg><style> rect { fill: none; stroke: url(#g) }</style><rect style="background: none"/></svg>`,
+			removed:  []string{"style:import", "style:url"},
+		},
+		{
+			title:    "removes animations of references",
+			svg:      `<svg><a><animate attributeName="href" to="javascript:alert(1)"/><set attributeName="xlink:href" to="https://example.com"/><animate attributeName="opacity" from="0" to="1"/></a></svg>`,
+			expected: `<svg><a><animate attributeName="opacity" from="0" to="1"/></a></svg>`,
+			removed:  []string{"element:animate", "element:set"},
+		},
+		{
+			title:    "removes animations that set javascript urls",
+			svg:      `<svg><set attributeName="fill" to="javascript:alert(1)"/></svg>`,
+			expected: `<svg/>`,
+			removed:  []string{"element:set"},
+		},
+		{
+			title: "expands internal entities and removes the doctype",
+			svg: `<?xml version="1.0"?>
+<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd" [
+	<!ENTITY ns_svg "http://www.w3.org/2000/svg">
+	<!ENTITY ns_xlink "http://www.w3.org/1999/xlink">
+	<!ENTITY label "&copy_sign; &amp; more">
+	<!ENTITY copy_sign "&#169;">
+]><svg xmlns="&ns_svg;" xmlns:xlink="&ns_xlink;"><text>&label;</text></svg>`,
+			expected: `<?xml version="1.0"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><text>© &amp; more</text></svg>`,
+			removed: []string{"doctype"},
+		},
+		{
+			title:    "doesn't expand external entities",
+			svg:      `<!DOCTYPE svg [<!ENTITY xxe SYSTEM "file:///etc/passwd">]><svg><text>&xxe;</text></svg>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			sanitized, removed, err := sanitizeSVG([]byte(tt.svg))
+			if tt.expected == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(sanitized))
+			if tt.removed == nil {
+				assert.Empty(t, removed)
+			} else {
+				assert.Equal(t, tt.removed, removed)
+			}
+		})
+	}
+}
+
+func TestSVGInternalEntities(t *testing.T) {
+	t.Run("doesn't expand entities that reference themselves", func(t *testing.T) {
+		entities := svgInternalEntities([]byte(`DOCTYPE svg [<!ENTITY a "&b;"><!ENTITY b "&a;"><!ENTITY c "ok">]`))
+		assert.Equal(t, map[string]string{"c": "ok"}, entities)
+	})
+
+	t.Run("doesn't expand entities past the size limit", func(t *testing.T) {
+		entities := svgInternalEntities([]byte(`DOCTYPE svg [
+			<!ENTITY a "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa">
+			<!ENTITY b "&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;&a;">
+			<!ENTITY c "&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;&b;">
+		]`))
+		assert.Contains(t, entities, "b")
+		assert.NotContains(t, entities, "c")
+	})
+
+	t.Run("ignores parameter entities", func(t *testing.T) {
+		entities := svgInternalEntities([]byte(`DOCTYPE svg [<!ENTITY % p "x"><!ENTITY q "y">]`))
+		assert.Equal(t, map[string]string{"q": "y"}, entities)
+	})
+}
+
+func TestSanitizeSVGEntityExpansion(t *testing.T) {
+	entity := strings.Repeat("a", maxSVGEntitySize)
+
+	t.Run("rejects documents that expand past the size limit", func(t *testing.T) {
+		refs := strings.Repeat("&b;", maxSVGSize/maxSVGEntitySize+1)
+		svg := `<!DOCTYPE svg [<!ENTITY b "` + entity + `">]><svg><text>` + refs + `</text></svg>`
+		_, _, err := sanitizeSVG([]byte(svg))
+		assert.ErrorIs(t, err, errSVGTooLarge)
+	})
+
+	t.Run("expands documents that stay under the size limit", func(t *testing.T) {
+		svg := `<!DOCTYPE svg [<!ENTITY b "` + entity + `">]><svg><text>&b;&b;</text></svg>`
+		sanitized, _, err := sanitizeSVG([]byte(svg))
+		require.NoError(t, err)
+		assert.Equal(t, `<svg><text>`+entity+entity+`</text></svg>`, string(sanitized))
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/mikeydub/go-gallery/service/auth"
 	"github.com/mikeydub/go-gallery/service/farcaster"
 	"github.com/mikeydub/go-gallery/service/logger"
+	"github.com/mikeydub/go-gallery/service/moderation"
 	"github.com/mikeydub/go-gallery/service/multichain"
 	"github.com/mikeydub/go-gallery/service/notifications"
 	"github.com/mikeydub/go-gallery/service/persist"
@@ -80,9 +81,9 @@ func CoreInitServer(ctx context.Context, clients *server.Clients, mc *multichain
 		(*t).DisableKeepAlives = true
 	}
 
-	tp := NewTokenProcessor(clients.Queries, http.DefaultClient, &metadataFetcher, clients.IPFSClient, clients.ArweaveClient, clients.StorageClient, env.GetString("GCLOUD_TOKEN_CONTENT_BUCKET"))
+	tp := NewTokenProcessor(clients.Queries, http.DefaultClient, &metadataFetcher, clients.IPFSClient, clients.ArweaveClient, clients.StorageClient, env.GetString("GCLOUD_TOKEN_CONTENT_BUCKET"), moderation.NewLocalClassifier(env.GetString("MODERATION_CLASSIFIER_URL"), http.DefaultClient))
 
-	return handlersInitServer(ctx, router, tp, mc, clients.Repos, t, clients.TaskClient, redis.NewCache(redis.TokenManageCache))
+	return handlersInitServer(ctx, router, tp, mc, clients.Repos, clients.EthClient, t, clients.TaskClient, redis.NewCache(redis.TokenManageCache))
 }
 
 func setDefaults() {
@@ -108,6 +109,11 @@ func setDefaults() {
 	viper.SetDefault("ALCHEMY_OPTIMISM_API_URL", "")
 	viper.SetDefault("ALCHEMY_POLYGON_API_URL", "")
 	viper.SetDefault("ALCHEMY_BASE_SEPOLIA_API_URL", "")
+	viper.SetDefault("ARBITRUM_RPC_URL", "")
+	viper.SetDefault("BASE_RPC_URL", "")
+	viper.SetDefault("OPTIMISM_RPC_URL", "")
+	viper.SetDefault("POLYGON_RPC_URL", "")
+	viper.SetDefault("ZORA_RPC_URL", "")
 	viper.SetDefault("POAP_API_KEY", "")
 	viper.SetDefault("POAP_AUTH_TOKEN", "")
 	viper.SetDefault("TOKEN_PROCESSING_URL", "http://localhost:6500")
@@ -120,6 +126,7 @@ func setDefaults() {
 	viper.SetDefault("PUBSUB_SUB_NEW_NOTIFICATIONS", "dev-new-notifications-sub")
 	viper.SetDefault("PUBSUB_SUB_UPDATED_NOTIFICATIONS", "dev-updated-notifications-sub")
 	viper.SetDefault("RASTERIZER_URL", "http://localhost:3000")
+	viper.SetDefault("MODERATION_CLASSIFIER_URL", "")
 	viper.SetDefault("TEZOS_API_URL", "https://api.tzkt.io")
 	viper.SetDefault("ALCHEMY_WEBHOOK_SECRET_ARBITRUM", "")
 	viper.SetDefault("ALCHEMY_WEBHOOK_SECRET_ETH", "")
@@ -0,0 +1,197 @@
+package util
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxDataURISize is the most bytes a compressed data URI is allowed to expand to
+const maxDataURISize = 64 * MB
+
+var (
+	errNotDataURI      = errors.New("not a data uri")
+	errDataURITooLarge = fmt.Errorf("data uri expands to more than %d bytes", maxDataURISize)
+	gzipMagic          = []byte{0x1f, 0x8b}
+	percentEncoding    = regexp.MustCompile(`%[0-9a-fA-F]{2}`)
+	scriptTag          = regexp.MustCompile(`(?is)<script\b([^>]*)>\s*</script>`)
+	scriptTypeAttr     = regexp.MustCompile(`(?i)\btype\s*=\s*["']?([^"'\s>]+)`)
+	scriptSrcAttr      = regexp.MustCompile(`(?i)\bsrc\s*=\s*["']?(data:[^"'\s>]+)`)
+	// A '+' in a base64 payload is sometimes decoded to a space, and long payloads are sometimes wrapped across lines
+	base64PayloadCleaner = strings.NewReplacer(" ", "+", "\n", "", "\r", "", "\t", "")
+)
+
+// DataURI is the decoded content of a data URI
+type DataURI struct {
+	// MediaType is the lowercased media type of the content, defaulting to text/plain when the URI doesn't have one
+	MediaType string
+	// Params are the parameters of the media type, e.g. charset. Flags like base64 and utf8 are stored with an empty value.
+	Params map[string]string
+	Data   []byte
+}
+
+// IsDataURI returns true if the URI is a data URI
+func IsDataURI(uri string) bool {
+	uri = strings.TrimSpace(uri)
+	return len(uri) >= 5 && strings.EqualFold(uri[:5], "data:")
+}
+
+// ParseDataURIHeader returns the media type of a data URI and whether its payload is base64 encoded
+func ParseDataURIHeader(uri string) (mediaType string, isBase64 bool, err error) {
+	mediaType, params, _, err := splitDataURI(uri)
+	if err != nil {
+		return "", false, err
+	}
+	_, isBase64 = params["base64"]
+	return mediaType, isBase64, nil
+}
+
+// DecodeDataURI decodes any of the data URI variants that are returned by token contracts: base64 with any of the standard
+// or URL alphabets, percent-encoded or plain utf8 text, and gzip compressed payloads. HTML documents that load gzipped
+// scripts from data URIs, like those assembled by scripty, have the scripts inlined so that they render without the
+// gunzip helper.
+func DecodeDataURI(uri string) (DataURI, error) {
+	mediaType, params, payload, err := splitDataURI(uri)
+	if err != nil {
+		return DataURI{}, err
+	}
+
+	var data []byte
+
+	if _, ok := params["base64"]; ok {
+		if percentEncoding.MatchString(payload) {
+			payload = unescapePercent(payload)
+		}
+		data, err = Base64Decode(base64PayloadCleaner.Replace(payload), base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding)
+		if err != nil {
+			return DataURI{}, fmt.Errorf("error decoding base64 data uri: %w", err)
+		}
+	} else {
+		data = []byte(unescapePercent(payload))
+	}
+
+	data = RemoveBOM(data)
+
+	_, gzipParam := params["gzip"]
+	if gzipParam || params["content-encoding"] == "gzip" || bytes.HasPrefix(data, gzipMagic) {
+		unzipped, err := gunzip(data)
+		if err != nil && (gzipParam || params["content-encoding"] == "gzip") {
+			return DataURI{}, fmt.Errorf("error decompressing data uri: %w", err)
+		}
+		// Content that only happens to start with the magic bytes is left as is
+		if err == nil {
+			data = RemoveBOM(unzipped)
+		}
+	}
+
+	if mediaType == "text/html" {
+		data = inlineGzipScripts(data)
+	}
+
+	return DataURI{MediaType: mediaType, Params: params, Data: data}, nil
+}
+
+// splitDataURI splits a data URI into its normalized media type, parameters, and undecoded payload
+func splitDataURI(uri string) (string, map[string]string, string, error) {
+	uri = strings.TrimSpace(uri)
+	if !IsDataURI(uri) {
+		return "", nil, "", errNotDataURI
+	}
+
+	uri = uri[5:]
+	comma := strings.IndexByte(uri, ',')
+	if comma == -1 {
+		// Some contracts percent-encode the entire URI, including the separator
+		unescaped, err := url.PathUnescape(uri)
+		if err != nil || strings.IndexByte(unescaped, ',') == -1 {
+			return "", nil, "", fmt.Errorf("data uri is missing a ',' separator")
+		}
+		uri = unescaped
+		comma = strings.IndexByte(uri, ',')
+	}
+
+	header, payload := uri[:comma], uri[comma+1:]
+	if percentEncoding.MatchString(header) {
+		header = unescapePercent(header)
+	}
+
+	parts := strings.Split(header, ";")
+
+	mediaType := strings.ToLower(strings.TrimSpace(parts[0]))
+	// A '+' is sometimes decoded to a space by the time the URI reaches us, e.g. image/svg xml
+	mediaType = strings.ReplaceAll(mediaType, " ", "+")
+	if mediaType == "" {
+		mediaType = "text/plain"
+	}
+
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			params[k] = strings.ToLower(strings.Trim(strings.TrimSpace(v), `"`))
+		}
+	}
+
+	return mediaType, params, payload, nil
+}
+
+// unescapePercent decodes the valid percent-encoded sequences of s. Unlike url.PathUnescape, a stray '%' like in an
+// SVG with width="100%" is kept as is instead of failing the whole payload.
+func unescapePercent(s string) string {
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		return unescaped
+	}
+	return percentEncoding.ReplaceAllStringFunc(s, func(match string) string {
+		unescaped, err := url.PathUnescape(match)
+		if err != nil {
+			return match
+		}
+		return unescaped
+	})
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	unzipped, err := io.ReadAll(io.LimitReader(r, maxDataURISize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(unzipped) > maxDataURISize {
+		return nil, errDataURITooLarge
+	}
+	return unzipped, nil
+}
+
+// inlineGzipScripts replaces scripts of type text/javascript+gzip that are loaded from data URIs with the decompressed script
+func inlineGzipScripts(html []byte) []byte {
+	return scriptTag.ReplaceAllFunc(html, func(tag []byte) []byte {
+		attrs := scriptTag.FindSubmatch(tag)[1]
+
+		scriptType := scriptTypeAttr.FindSubmatch(attrs)
+		if scriptType == nil || !strings.HasSuffix(strings.ToLower(string(scriptType[1])), "+gzip") {
+			return tag
+		}
+
+		src := scriptSrcAttr.FindSubmatch(attrs)
+		if src == nil {
+			return tag
+		}
+
+		script, err := DecodeDataURI(string(src[1]))
+		if err != nil {
+			return tag
+		}
+
+		return []byte("<script>" + string(script.Data) + "</script>")
+	})
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dataURITestCase struct {
+	description string
+	uri         string
+	mediaType   string
+	data        string
+}
+
+// dataURICorpus are the variants of data URIs that have been seen returned from tokenURI
+var dataURICorpus = []dataURITestCase{
+	{"base64 json", "data:application/json;base64,eyJuYW1lIjoiVG9rZW4gIzEifQ==", "application/json", `{"name":"Token #1"}`},
+	{"base64 json with charset", "data:application/json;charset=utf-8;base64,eyJuYW1lIjoiVG9rZW4gIzEifQ==", "application/json", `{"name":"Token #1"}`},
+	{"unpadded base64 json", "data:application/json;base64,eyJuYW1lIjoiVG9rZW4gIzEifQ", "application/json", `{"name":"Token #1"}`},
+	{"url-safe base64", "data:application/json;base64,eyJhIjoiPz8_In0=", "application/json", `{"a":"???"}`},
+	{"base64 with a '+' decoded to a space", "data:application/json;base64,eyJhIjoiPj4 In0=", "application/json", `{"a":">>>"}`},
+	{"base64 wrapped across lines", "data:application/json;base64,eyJuYW1lIjoi\nVG9rZW4gIzEifQ==", "application/json", `{"name":"Token #1"}`},
+	{"percent-encoded base64", "data:application/json;base64,eyJhIjoiPj4%2BIn0%3D", "application/json", `{"a":">>>"}`},
+	{"base64 with a byte order mark", "data:application/json;base64,77u/eyJuYW1lIjoiQk9NIn0=", "application/json", `{"name":"BOM"}`},
+	{"utf8 json", `data:application/json;utf8,{"name":"Token #1"}`, "application/json", `{"name":"Token #1"}`},
+	{"plain json", `data:application/json,{"name":"Token #1"}`, "application/json", `{"name":"Token #1"}`},
+	{"percent-encoded json", "data:application/json,%7B%22name%22%3A%22Token%20%231%22%7D", "application/json", `{"name":"Token #1"}`},
+	{"percent-encoded uri", "data:application/json%3Bbase64%2CeyJuYW1lIjoiVG9rZW4gIzEifQ%3D%3D", "application/json", `{"name":"Token #1"}`},
+	{"plain text json", `data:text/plain,{"name":"Token #1"}`, "text/plain", `{"name":"Token #1"}`},
+	{"uppercase scheme and media type", "DATA:Application/JSON;BASE64,eyJuYW1lIjoiVG9rZW4gIzEifQ==", "application/json", `{"name":"Token #1"}`},
+	{"surrounding whitespace", "  data:application/json;base64,eyJuYW1lIjoiVG9rZW4gIzEifQ==\n", "application/json", `{"name":"Token #1"}`},
+	{"no media type", "data:,Hello%2C%20World", "text/plain", "Hello, World"},
+	{"base64 svg", "data:image/svg+xml;base64,PHN2Zz48L3N2Zz4=", "image/svg+xml", "<svg></svg>"},
+	{"base64 svg with '+' decoded to a space", "data:image/svg xml;base64,PHN2Zz48L3N2Zz4=", "image/svg+xml", "<svg></svg>"},
+	{"utf8 svg", `data:image/svg+xml;utf8,<svg width="10"></svg>`, "image/svg+xml", `<svg width="10"></svg>`},
+	{"percent-encoded svg", "data:image/svg+xml,%3Csvg%20width%3D%2210%22%3E%3C%2Fsvg%3E", "image/svg+xml", `<svg width="10"></svg>`},
+	{"percent-encoded svg with a stray percent", `data:image/svg+xml,%3Csvg width="100%"%3E%3C/svg%3E`, "image/svg+xml", `<svg width="100%"></svg>`},
+	{"base64 html", "data:text/html;base64,PGh0bWw+PC9odG1sPg==", "text/html", "<html></html>"},
+	{"base64 html with charset", "data:text/html;charset=utf-8;base64,PGh0bWw+PC9odG1sPg==", "text/html", "<html></html>"},
+	{"percent-encoded html", "data:text/html,%3Chtml%3E%3C%2Fhtml%3E", "text/html", "<html></html>"},
+	{"gzip parameter", "data:application/json;gzip;base64,H4sIAAAAAAAC/6tWykvMTVWyUnKvyiwoSE1RqgUAnlQ8txIAAAA=", "application/json", `{"name":"Gzipped"}`},
+	{"gzip detected from content", "data:application/json;base64,H4sIAAAAAAAC/6tWykvMTVWyUnKvyiwoSE1RqgUAnlQ8txIAAAA=", "application/json", `{"name":"Gzipped"}`},
+	{
+		"html with a gzipped script",
+		`data:text/html,<html><body><script type="text/javascript+gzip" src="data:text/javascript;base64,H4sIAAAAAAAC/0vOzyvOz0nVy8lP11DKyFTSBACFQ1PiEQAAAA=="></script></body></html>`,
+		"text/html",
+		`<html><body><script>console.log("hi")</script></body></html>`,
+	},
+	{
+		"html with a plain script",
+		`data:text/html,<html><script src="https://example.com/p5.js"></script></html>`,
+		"text/html",
+		`<html><script src="https://example.com/p5.js"></script></html>`,
+	},
+}
+
+func TestDecodeDataURI_Corpus(t *testing.T) {
+	for _, tc := range dataURICorpus {
+		t.Run(tc.description, func(t *testing.T) {
+			decoded, err := DecodeDataURI(tc.uri)
+			require.NoError(t, err)
+			assert.Equal(t, tc.mediaType, decoded.MediaType)
+			assert.Equal(t, tc.data, string(decoded.Data))
+		})
+	}
+}
+
+func TestDecodeDataURI_Invalid(t *testing.T) {
+	for _, uri := range []string{
+		"https://example.com/1.json",
+		"data:application/json;base64",
+		"data:application/json;base64,!!!!",
+		"data:application/json;gzip;base64,eyJuYW1lIjoiVG9rZW4gIzEifQ==",
+	} {
+		_, err := DecodeDataURI(uri)
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestParseDataURIHeader(t *testing.T) {
+	mediaType, isBase64, err := ParseDataURIHeader("data:image/png;base64,iVBORw0KGgo=")
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", mediaType)
+	assert.True(t, isBase64)
+
+	mediaType, isBase64, err = ParseDataURIHeader(`data:text/html;charset=utf-8,<p>a;base64,b</p>`)
+	require.NoError(t, err)
+	assert.Equal(t, "text/html", mediaType)
+	assert.False(t, isBase64)
+}
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgtype"
@@ -674,6 +675,13 @@ func ToNullInt32(i *int) sql.NullInt32 {
 	return sql.NullInt32{Int32: int32(*i), Valid: true}
 }
 
+func ToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
 func ToPGJSONB[T any](v T) (pgtype.JSONB, error) {
 	marshalled, err := json.Marshal(v)
 	if err != nil {